@@ -20,6 +20,7 @@
 package answercmd
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"strings"
@@ -28,9 +29,12 @@ import (
 	"github.com/apache/incubator-answer/internal/cli"
 	"github.com/apache/incubator-answer/internal/install"
 	"github.com/apache/incubator-answer/internal/migrations"
+	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/apache/incubator-answer/pkg/storage"
 	"github.com/apache/incubator-answer/plugin"
 	"github.com/segmentfault/pacman/log"
 	"github.com/spf13/cobra"
+	"xorm.io/xorm"
 )
 
 var (
@@ -38,6 +42,12 @@ var (
 	dataDirPath string
 	// dumpDataPath dump data path
 	dumpDataPath string
+	// restoreFilePath the dump archive to restore
+	restoreFilePath string
+	// migrateDBFromConfig the config file of the source database
+	migrateDBFromConfig string
+	// migrateDBToConfig the config file of the target database
+	migrateDBToConfig string
 	// place to build new answer
 	buildDir string
 	// plugins needed to build in answer application
@@ -47,12 +57,28 @@ var (
 	// This config is used to upgrade the database from a specific version manually.
 	// If you want to upgrade the database to version 1.1.0, you can use `answer upgrade -f v1.1.0`.
 	upgradeVersion string
+	// upgradeStatus only list the applied and pending migrations
+	upgradeStatus bool
+	// upgradeDryRun only list the pending migrations and the schema changes
+	upgradeDryRun bool
 	// The fields that need to be set to the default value
 	configFields []string
 	// i18nSourcePath i18n from path
 	i18nSourcePath string
 	// i18nTargetPath i18n to path
 	i18nTargetPath string
+	// searchReindexReq the options of rebuilding search index
+	searchReindexReq = &schema.SearchReindexReq{}
+	// stackExchangeImportReq the options of importing Stack Exchange data dump
+	stackExchangeImportReq = &schema.StackExchangeImportReq{}
+	// contentExportReq the options of exporting contents
+	contentExportReq = &schema.ContentExportReq{}
+	// contentExportPath the directory of exported archive
+	contentExportPath string
+	// rankRecalculateReq the options of recalculating reputation
+	rankRecalculateReq = &schema.RankRecalculateReq{}
+	// storageMigrateReq the options of migrating uploaded files between storages
+	storageMigrateReq = &schema.StorageMigrateReq{}
 )
 
 func init() {
@@ -62,6 +88,15 @@ func init() {
 
 	dumpCmd.Flags().StringVarP(&dumpDataPath, "path", "p", "./", "dump data path, eg: -p ./dump/data/")
 
+	restoreCmd.Flags().StringVarP(&restoreFilePath, "file", "f", "", "dump archive to restore, eg: -f ./answer_dump_20240101120000.tar.gz")
+	_ = restoreCmd.MarkFlagRequired("file")
+
+	migrateDBCmd.Flags().StringVar(&migrateDBFromConfig, "from", "", "config file of the source database, eg: --from ./data/conf/config.yaml")
+
+	migrateDBCmd.Flags().StringVar(&migrateDBToConfig, "to", "", "config file of the target database, eg: --to ./new/conf/config.yaml")
+	_ = migrateDBCmd.MarkFlagRequired("from")
+	_ = migrateDBCmd.MarkFlagRequired("to")
+
 	buildCmd.Flags().StringSliceVarP(&buildWithPlugins, "with", "w", []string{}, "plugins needed to build")
 
 	buildCmd.Flags().StringVarP(&buildOutput, "output", "o", "", "build output path")
@@ -70,13 +105,59 @@ func init() {
 
 	upgradeCmd.Flags().StringVarP(&upgradeVersion, "from", "f", "", "upgrade from specific version, eg: -f v1.1.0")
 
+	upgradeCmd.Flags().BoolVar(&upgradeStatus, "status", false, "list the applied and pending migrations")
+
+	upgradeCmd.Flags().BoolVar(&upgradeDryRun, "dry-run", false, "list the pending migrations and the schema changes against the latest schema without changing anything")
+
 	configCmd.Flags().StringSliceVarP(&configFields, "with", "w", []string{}, "the fields that need to be set to the default value, eg: -w allow_password_login")
 
 	i18nCmd.Flags().StringVarP(&i18nSourcePath, "source", "s", "", "i18n source path, eg: -f ./i18n/source")
 
 	i18nCmd.Flags().StringVarP(&i18nTargetPath, "target", "t", "", "i18n target path, eg: -t ./i18n/target")
 
-	for _, cmd := range []*cobra.Command{initCmd, checkCmd, runCmd, dumpCmd, upgradeCmd, buildCmd, pluginCmd, configCmd, i18nCmd} {
+	searchReindexCmd.Flags().BoolVarP(&searchReindexReq.Incremental, "incremental", "i", false, "only sync the contents updated after the last finished reindex")
+
+	searchReindexCmd.Flags().BoolVar(&searchReindexReq.DryRun, "dry-run", false, "only count the contents that need to be synced")
+
+	searchReindexCmd.Flags().BoolVar(&searchReindexReq.Restart, "restart", false, "ignore the unfinished checkpoint and start over")
+
+	searchReindexCmd.Flags().IntVar(&searchReindexReq.PageSize, "page-size", 100, "the number of contents synced in each batch")
+
+	searchCmd.AddCommand(searchReindexCmd)
+
+	importStackExchangeCmd.Flags().StringVarP(&stackExchangeImportReq.Dir, "dir", "d", "", "the directory of the data dump, eg: -d ./dump/")
+	_ = importStackExchangeCmd.MarkFlagRequired("dir")
+
+	importCmd.AddCommand(importStackExchangeCmd)
+
+	exportCmd.Flags().StringVarP(&contentExportPath, "path", "p", "./", "the directory of exported archive, eg: -p ./export/")
+
+	exportCmd.Flags().BoolVar(&contentExportReq.IncludeDeleted, "include-deleted", false, "include the deleted questions and answers")
+
+	exportCmd.Flags().BoolVar(&contentExportReq.IncludePending, "include-pending", false, "include the questions and answers waiting for review")
+
+	exportCmd.Flags().BoolVar(&contentExportReq.IncludeHidden, "include-hidden", false, "include the hidden questions")
+
+	rankRecalculateCmd.Flags().BoolVar(&rankRecalculateReq.DryRun, "dry-run", false, "only report the differences without changing anything")
+
+	rankRecalculateCmd.Flags().IntVar(&rankRecalculateReq.BatchSize, "batch-size", schema.RankRecalculateDefaultBatchSize, "the number of users recalculated in each batch")
+
+	rankCmd.AddCommand(rankRecalculateCmd)
+
+	storageMigrateCmd.Flags().StringVar(&storageMigrateReq.From, "from", storage.TypeLocal, "the type of source storage: local, s3 or webdav")
+
+	storageMigrateCmd.Flags().StringVar(&storageMigrateReq.To, "to", "", "the type of target storage: local, s3 or webdav")
+	_ = storageMigrateCmd.MarkFlagRequired("to")
+
+	storageMigrateCmd.Flags().StringVar(&storageMigrateReq.FromURLPrefix, "from-url-prefix", "", "the url prefix of files in the source storage, eg: https://example.com/uploads")
+
+	storageMigrateCmd.Flags().StringVar(&storageMigrateReq.ToURLPrefix, "to-url-prefix", "", "the url prefix of files in the target storage, eg: https://cdn.example.com/answer")
+
+	storageMigrateCmd.Flags().BoolVar(&storageMigrateReq.DryRun, "dry-run", false, "only count the files and contents that need to be changed")
+
+	storageCmd.AddCommand(storageMigrateCmd)
+
+	for _, cmd := range []*cobra.Command{initCmd, checkCmd, runCmd, dumpCmd, restoreCmd, migrateDBCmd, upgradeCmd, buildCmd, pluginCmd, configCmd, i18nCmd, searchCmd, importCmd, exportCmd, rankCmd, storageCmd} {
 		rootCmd.AddCommand(cmd)
 	}
 }
@@ -139,7 +220,8 @@ To run answer, use:
 	upgradeCmd = &cobra.Command{
 		Use:   "upgrade",
 		Short: "upgrade Answer version",
-		Long:  `upgrade Answer version`,
+		Long: `Upgrade Answer version. The migrations run in transaction if the database supports it,
+and the applied migrations are rolled back if the upgrade fails.`,
 		Run: func(_ *cobra.Command, _ []string) {
 			log.SetLogger(log.NewStdLogger(os.Stdout))
 			cli.FormatAllPath(dataDirPath)
@@ -149,6 +231,18 @@ To run answer, use:
 				fmt.Println("read config failed: ", err.Error())
 				return
 			}
+			if upgradeStatus {
+				if err = migrations.MigrateStatus(c.Data.Database); err != nil {
+					fmt.Println("get migration status failed: ", err.Error())
+				}
+				return
+			}
+			if upgradeDryRun {
+				if err = migrations.MigrateDryRun(c.Data.Database, upgradeVersion); err != nil {
+					fmt.Println("migrate dry run failed: ", err.Error())
+				}
+				return
+			}
 			if err = migrations.Migrate(c.Debug, c.Data.Database, c.Data.Cache, upgradeVersion); err != nil {
 				fmt.Println("migrate failed: ", err.Error())
 				return
@@ -170,12 +264,66 @@ To run answer, use:
 				fmt.Println("read config failed: ", err.Error())
 				return
 			}
-			err = cli.DumpAllData(c.Data.Database, dumpDataPath)
+			archivePath, err := cli.DumpAllData(c.Data.Database, dumpDataPath, migrations.GetTables())
 			if err != nil {
 				fmt.Println("dump failed: ", err.Error())
 				return
 			}
-			fmt.Println("Answer backed up the data successfully.")
+			fmt.Println("Answer backed up the data successfully: ", archivePath)
+		},
+	}
+
+	// restoreCmd represents the restore command
+	restoreCmd = &cobra.Command{
+		Use:   "restore",
+		Short: "restore data from a dump archive",
+		Long:  `Restore the database, upload files and config file from an archive created by the dump command into an empty database`,
+		Run: func(_ *cobra.Command, _ []string) {
+			fmt.Println("Answer is restoring data")
+			cli.FormatAllPath(dataDirPath)
+			c, err := conf.ReadConfig(cli.GetConfigFilePath())
+			if err != nil {
+				fmt.Println("read config failed: ", err.Error())
+				return
+			}
+			err = cli.RestoreAllData(c.Data.Database, restoreFilePath, migrations.GetTables(), migrations.ExpectedVersion(),
+				func(x *xorm.Engine) error {
+					return migrations.InitSchema(context.Background(), x)
+				})
+			if err != nil {
+				fmt.Println("restore failed: ", err.Error())
+				return
+			}
+			fmt.Println("Answer restored the data successfully.")
+		},
+	}
+
+	// migrateDBCmd represents the migrate-db command
+	migrateDBCmd = &cobra.Command{
+		Use:   "migrate-db",
+		Short: "copy all data to another database",
+		Long:  `Copy all tables from the database in --from config to the empty database in --to config, eg: from SQLite to MySQL`,
+		Run: func(_ *cobra.Command, _ []string) {
+			fromConf, err := conf.ReadConfig(migrateDBFromConfig)
+			if err != nil {
+				fmt.Println("read source config failed: ", err.Error())
+				return
+			}
+			toConf, err := conf.ReadConfig(migrateDBToConfig)
+			if err != nil {
+				fmt.Println("read target config failed: ", err.Error())
+				return
+			}
+			fmt.Printf("Answer is copying data from %s to %s\n", fromConf.Data.Database.Driver, toConf.Data.Database.Driver)
+			err = cli.MigrateDatabase(fromConf.Data.Database, toConf.Data.Database, migrations.GetTables(), migrations.ExpectedVersion(),
+				func(x *xorm.Engine) error {
+					return migrations.InitSchema(context.Background(), x)
+				})
+			if err != nil {
+				fmt.Println("migrate database failed: ", err.Error())
+				return
+			}
+			fmt.Println("Answer copied the data successfully, please update the database config and restart.")
 		},
 	}
 
@@ -280,6 +428,142 @@ To run answer, use:
 		},
 	}
 
+	// searchCmd manage the index of search plugin
+	searchCmd = &cobra.Command{
+		Use:   "search",
+		Short: "manage the index of search plugin",
+		Long:  `Manage the index of the active search plugin`,
+	}
+
+	// searchReindexCmd rebuild the index of search plugin
+	searchReindexCmd = &cobra.Command{
+		Use:   "reindex",
+		Short: "rebuild the index of search plugin",
+		Long: `Sync all questions and answers to the active search plugin. The progress is saved after every batch,
+an interrupted reindex will be resumed next time unless --restart is set.`,
+		Run: func(_ *cobra.Command, _ []string) {
+			log.SetLogger(log.NewStdLogger(os.Stdout))
+			cli.FormatAllPath(dataDirPath)
+			c, err := conf.ReadConfig(cli.GetConfigFilePath())
+			if err != nil {
+				fmt.Println("read config failed: ", err.Error())
+				return
+			}
+			if err = searchReindex(c.Data.Database, c.Data.Cache, searchReindexReq); err != nil {
+				fmt.Println("search reindex failed: ", err.Error())
+				return
+			}
+		},
+	}
+
+	// rankCmd manage the reputation of users
+	rankCmd = &cobra.Command{
+		Use:   "rank",
+		Short: "manage the reputation of users",
+		Long:  `Manage the reputation of users`,
+	}
+
+	// rankRecalculateCmd recalculate the reputation of users
+	rankRecalculateCmd = &cobra.Command{
+		Use:   "recalculate",
+		Short: "recalculate the reputation of users",
+		Long: `Recalculate the reputation of all users from their activities under the current rank rules.
+The differences are applied batch by batch and an audit log is written for every changed user.`,
+		Run: func(_ *cobra.Command, _ []string) {
+			log.SetLogger(log.NewStdLogger(os.Stdout))
+			cli.FormatAllPath(dataDirPath)
+			c, err := conf.ReadConfig(cli.GetConfigFilePath())
+			if err != nil {
+				fmt.Println("read config failed: ", err.Error())
+				return
+			}
+			if err = rankRecalculate(c.Data.Database, c.Data.Cache, rankRecalculateReq); err != nil {
+				fmt.Println("rank recalculate failed: ", err.Error())
+				return
+			}
+		},
+	}
+
+	// storageCmd manage the storage of uploaded files
+	storageCmd = &cobra.Command{
+		Use:   "storage",
+		Short: "manage the storage of uploaded files",
+		Long:  `Manage the storage of uploaded files`,
+	}
+
+	// storageMigrateCmd migrate the uploaded files between storages
+	storageMigrateCmd = &cobra.Command{
+		Use:   "migrate",
+		Short: "migrate the uploaded files between storages",
+		Long: `Copy all uploaded files from the source storage to the target storage, then rewrite their urls
+in questions, answers, comments, revisions, avatars and branding. The storages are read from service_config.storage
+of the config file, the files already in the target storage are skipped.`,
+		Run: func(_ *cobra.Command, _ []string) {
+			log.SetLogger(log.NewStdLogger(os.Stdout))
+			cli.FormatAllPath(dataDirPath)
+			c, err := conf.ReadConfig(cli.GetConfigFilePath())
+			if err != nil {
+				fmt.Println("read config failed: ", err.Error())
+				return
+			}
+			if err = storageMigrate(c.Data.Database, c.Data.Cache, c.ServiceConfig, storageMigrateReq); err != nil {
+				fmt.Println("storage migrate failed: ", err.Error())
+				return
+			}
+		},
+	}
+
+	// importCmd import data from other sites
+	importCmd = &cobra.Command{
+		Use:   "import",
+		Short: "import data from other sites",
+		Long:  `Import data from other sites`,
+	}
+
+	// importStackExchangeCmd import the Stack Exchange data dump
+	importStackExchangeCmd = &cobra.Command{
+		Use:   "stackexchange",
+		Short: "import the Stack Exchange data dump",
+		Long: `Import users, tags, questions, answers, comments, revisions and votes from the xml files of a Stack Exchange
+or Stack Overflow for Teams data dump. The imported objects are skipped, so an interrupted import can be run again.`,
+		Run: func(_ *cobra.Command, _ []string) {
+			log.SetLogger(log.NewStdLogger(os.Stdout))
+			cli.FormatAllPath(dataDirPath)
+			c, err := conf.ReadConfig(cli.GetConfigFilePath())
+			if err != nil {
+				fmt.Println("read config failed: ", err.Error())
+				return
+			}
+			if err = importStackExchange(c.Data.Database, c.Data.Cache, stackExchangeImportReq); err != nil {
+				fmt.Println("import failed: ", err.Error())
+				return
+			}
+		},
+	}
+
+	// exportCmd export all questions to an archive
+	exportCmd = &cobra.Command{
+		Use:   "export",
+		Short: "export questions to json and markdown files",
+		Long: `Export every question with its answers, comments, tags, votes and revision history to an archive,
+each question is written as one json document and one markdown file. The password of users is never exported.`,
+		Run: func(_ *cobra.Command, _ []string) {
+			log.SetLogger(log.NewStdLogger(os.Stdout))
+			cli.FormatAllPath(dataDirPath)
+			c, err := conf.ReadConfig(cli.GetConfigFilePath())
+			if err != nil {
+				fmt.Println("read config failed: ", err.Error())
+				return
+			}
+			archivePath, err := exportContent(c.Data.Database, c.Data.Cache, contentExportReq, contentExportPath)
+			if err != nil {
+				fmt.Println("export failed: ", err.Error())
+				return
+			}
+			fmt.Println("export successfully: ", archivePath)
+		},
+	}
+
 	// i18nCmd used to merge i18n files
 	i18nCmd = &cobra.Command{
 		Use:   "i18n",
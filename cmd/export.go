@@ -0,0 +1,75 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package answercmd
+
+import (
+	"context"
+
+	"github.com/apache/incubator-answer/internal/base/data"
+	"github.com/apache/incubator-answer/internal/repo/activity_common"
+	"github.com/apache/incubator-answer/internal/repo/answer"
+	"github.com/apache/incubator-answer/internal/repo/comment"
+	"github.com/apache/incubator-answer/internal/repo/config"
+	"github.com/apache/incubator-answer/internal/repo/question"
+	"github.com/apache/incubator-answer/internal/repo/rank"
+	"github.com/apache/incubator-answer/internal/repo/revision"
+	"github.com/apache/incubator-answer/internal/repo/tag"
+	"github.com/apache/incubator-answer/internal/repo/tag_common"
+	"github.com/apache/incubator-answer/internal/repo/unique"
+	"github.com/apache/incubator-answer/internal/repo/user"
+	"github.com/apache/incubator-answer/internal/schema"
+	answercommon "github.com/apache/incubator-answer/internal/service/answer_common"
+	configService "github.com/apache/incubator-answer/internal/service/config"
+	"github.com/apache/incubator-answer/internal/service/content_export"
+)
+
+// exportContent export all questions to an archive in the output directory
+func exportContent(dbConf *data.Database, cacheConf *data.CacheConf, req *schema.ContentExportReq, outputDir string) (
+	archivePath string, err error) {
+	db, err := data.NewDB(false, dbConf)
+	if err != nil {
+		return "", err
+	}
+	cache, cacheCleanup, err := data.NewCache(cacheConf)
+	if err != nil {
+		return "", err
+	}
+	defer cacheCleanup()
+	dataData, dataCleanup, err := data.NewData(db, cache)
+	if err != nil {
+		return "", err
+	}
+	defer dataCleanup()
+
+	uniqueIDRepo := unique.NewUniqueIDRepo(dataData)
+	cs := configService.NewConfigService(config.NewConfigRepo(dataData))
+	answerRepo := answer.NewAnswerRepo(dataData, uniqueIDRepo,
+		rank.NewUserRankRepo(dataData, cs), activity_common.NewActivityRepo(dataData, uniqueIDRepo, cs))
+	ce := content_export.NewContentExporter(
+		question.NewQuestionRepo(dataData, uniqueIDRepo),
+		answercommon.NewAnswerCommon(answerRepo),
+		comment.NewCommentRepo(dataData, uniqueIDRepo),
+		tag_common.NewTagCommonRepo(dataData, uniqueIDRepo),
+		tag.NewTagRelRepo(dataData, uniqueIDRepo),
+		revision.NewRevisionRepo(dataData, uniqueIDRepo),
+		user.NewUserRepo(dataData),
+	)
+	return ce.Export(context.Background(), req, outputDir)
+}
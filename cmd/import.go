@@ -0,0 +1,66 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package answercmd
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/apache/incubator-answer/internal/base/data"
+	"github.com/apache/incubator-answer/internal/repo/config"
+	importerRepo "github.com/apache/incubator-answer/internal/repo/importer"
+	"github.com/apache/incubator-answer/internal/repo/unique"
+	"github.com/apache/incubator-answer/internal/schema"
+	configService "github.com/apache/incubator-answer/internal/service/config"
+	"github.com/apache/incubator-answer/internal/service/importer"
+)
+
+// importStackExchange import the Stack Exchange data dump in the directory
+func importStackExchange(dbConf *data.Database, cacheConf *data.CacheConf, req *schema.StackExchangeImportReq) error {
+	db, err := data.NewDB(false, dbConf)
+	if err != nil {
+		return err
+	}
+	cache, cacheCleanup, err := data.NewCache(cacheConf)
+	if err != nil {
+		return err
+	}
+	defer cacheCleanup()
+	dataData, dataCleanup, err := data.NewData(db, cache)
+	if err != nil {
+		return err
+	}
+	defer dataCleanup()
+
+	si := importer.NewStackExchangeImporter(
+		importerRepo.NewImportRepo(dataData),
+		unique.NewUniqueIDRepo(dataData),
+		configService.NewConfigService(config.NewConfigRepo(dataData)),
+	)
+	result, err := si.Import(context.Background(), req)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("imported %d users, %d tags, %d questions, %d answers, %d comments, %d revisions, %d votes, skipped %d\n",
+		result.Users, result.Tags, result.Questions, result.Answers, result.Comments,
+		result.Revisions, result.Votes, result.Skipped)
+	fmt.Println("please run `answer search reindex` if a search plugin is enabled")
+	return nil
+}
@@ -0,0 +1,78 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package answercmd
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/apache/incubator-answer/internal/base/data"
+	"github.com/apache/incubator-answer/internal/repo/config"
+	"github.com/apache/incubator-answer/internal/repo/plugin_config"
+	"github.com/apache/incubator-answer/internal/repo/rank"
+	"github.com/apache/incubator-answer/internal/schema"
+	configService "github.com/apache/incubator-answer/internal/service/config"
+	"github.com/apache/incubator-answer/internal/service/plugin_common"
+	rankService "github.com/apache/incubator-answer/internal/service/rank"
+)
+
+// rankRecalculate recalculate the reputation of all users from their activities
+func rankRecalculate(dbConf *data.Database, cacheConf *data.CacheConf, req *schema.RankRecalculateReq) error {
+	db, err := data.NewDB(false, dbConf)
+	if err != nil {
+		return err
+	}
+	cache, cacheCleanup, err := data.NewCache(cacheConf)
+	if err != nil {
+		return err
+	}
+	defer cacheCleanup()
+	dataData, dataCleanup, err := data.NewData(db, cache)
+	if err != nil {
+		return err
+	}
+	defer dataCleanup()
+
+	cs := configService.NewConfigService(config.NewConfigRepo(dataData))
+	// load the status of plugins, the reputation can't be recalculated if it is managed by a plugin
+	_ = plugin_common.NewPluginCommonService(
+		plugin_config.NewPluginConfigRepo(dataData),
+		plugin_config.NewPluginUserConfigRepo(dataData),
+		cs,
+		dataData,
+	)
+	rs := rankService.NewRankRecalculateService(rank.NewRankRecalculateRepo(dataData), cs)
+
+	// the operator of the audit log is the system
+	req.UserID = "0"
+	resp, err := rs.Recalculate(context.Background(), req, func(diff *schema.RankRecalculateDiff) {
+		fmt.Printf("user %s (%s): %d -> %d, %d activities changed\n",
+			diff.Username, diff.UserID, diff.OldRank, diff.NewRank, diff.ChangedActivities)
+	})
+	if err != nil {
+		return err
+	}
+	if req.DryRun {
+		fmt.Printf("rank recalculate checked %d users, %d users need to be changed\n", resp.Users, resp.Changed)
+		return nil
+	}
+	fmt.Printf("rank recalculate done, checked %d users, applied %d/%d changes\n", resp.Users, resp.Applied, resp.Changed)
+	return nil
+}
@@ -0,0 +1,79 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package answercmd
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/apache/incubator-answer/internal/base/data"
+	"github.com/apache/incubator-answer/internal/repo/config"
+	"github.com/apache/incubator-answer/internal/repo/plugin_config"
+	"github.com/apache/incubator-answer/internal/repo/search_sync"
+	"github.com/apache/incubator-answer/internal/schema"
+	configService "github.com/apache/incubator-answer/internal/service/config"
+	"github.com/apache/incubator-answer/internal/service/plugin_common"
+	searchSyncService "github.com/apache/incubator-answer/internal/service/search_sync"
+)
+
+// searchReindex rebuild the index of the active search plugin
+func searchReindex(dbConf *data.Database, cacheConf *data.CacheConf, req *schema.SearchReindexReq) error {
+	db, err := data.NewDB(false, dbConf)
+	if err != nil {
+		return err
+	}
+	cache, cacheCleanup, err := data.NewCache(cacheConf)
+	if err != nil {
+		return err
+	}
+	defer cacheCleanup()
+	dataData, dataCleanup, err := data.NewData(db, cache)
+	if err != nil {
+		return err
+	}
+	defer dataCleanup()
+
+	// load the status and config of plugins, so the search plugin can connect to its engine
+	_ = plugin_common.NewPluginCommonService(
+		plugin_config.NewPluginConfigRepo(dataData),
+		plugin_config.NewPluginUserConfigRepo(dataData),
+		configService.NewConfigService(config.NewConfigRepo(dataData)),
+		dataData,
+	)
+	ss := searchSyncService.NewSearchSyncService(
+		search_sync.NewSearchSyncRepo(dataData),
+		search_sync.NewSearchSyncCheckpointRepo(dataData),
+	)
+
+	resp, err := ss.Reindex(context.Background(), req, func(progress *schema.SearchReindexProgress) {
+		fmt.Printf("[%s] %s page %d, synced %d/%d\n", progress.PluginSlugName, progress.ObjectType,
+			progress.Page, progress.Synced, progress.QuestionTotal+progress.AnswerTotal)
+	})
+	if err != nil {
+		return err
+	}
+	if req.DryRun {
+		fmt.Printf("[%s] %s reindex needs to sync %d questions and %d answers\n",
+			resp.PluginSlugName, resp.Mode, resp.QuestionTotal, resp.AnswerTotal)
+		return nil
+	}
+	fmt.Printf("[%s] %s reindex done, synced %d contents\n", resp.PluginSlugName, resp.Mode, resp.Synced)
+	return nil
+}
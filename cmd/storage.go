@@ -0,0 +1,78 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package answercmd
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/apache/incubator-answer/internal/base/data"
+	"github.com/apache/incubator-answer/internal/repo/site_info"
+	uploaderRepo "github.com/apache/incubator-answer/internal/repo/uploader"
+	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/apache/incubator-answer/internal/service/service_config"
+	"github.com/apache/incubator-answer/internal/service/siteinfo_common"
+	"github.com/apache/incubator-answer/internal/service/uploader"
+	"github.com/apache/incubator-answer/pkg/storage"
+)
+
+// storageMigrate copy the uploaded files between storages and rewrite their urls in contents
+func storageMigrate(dbConf *data.Database, cacheConf *data.CacheConf, serviceConf *service_config.ServiceConfig,
+	req *schema.StorageMigrateReq) error {
+	db, err := data.NewDB(false, dbConf)
+	if err != nil {
+		return err
+	}
+	cache, cacheCleanup, err := data.NewCache(cacheConf)
+	if err != nil {
+		return err
+	}
+	defer cacheCleanup()
+	dataData, dataCleanup, err := data.NewData(db, cache)
+	if err != nil {
+		return err
+	}
+	defer dataCleanup()
+
+	ss := uploader.NewStorageMigrateService(
+		serviceConf,
+		siteinfo_common.NewSiteInfoCommonService(site_info.NewSiteInfo(dataData)),
+		uploaderRepo.NewUploadedURLRepo(dataData),
+	)
+	resp, err := ss.Migrate(context.Background(), req, func(obj *storage.Object, copied bool) {
+		if copied {
+			fmt.Printf("copy %s (%d bytes)\n", obj.Key, obj.Size)
+		} else {
+			fmt.Printf("skip %s, it is already in %s storage\n", obj.Key, req.To)
+		}
+	})
+	if err != nil {
+		return err
+	}
+	if req.DryRun {
+		fmt.Printf("storage migrate needs to copy %d/%d files and rewrite %d rows from %s to %s\n",
+			resp.Copied, resp.Files, resp.Rewritten, resp.OldURLPrefix, resp.NewURLPrefix)
+		return nil
+	}
+	fmt.Printf("storage migrate done, copied %d/%d files, rewrote %d rows from %s to %s\n",
+		resp.Copied, resp.Files, resp.Rewritten, resp.OldURLPrefix, resp.NewURLPrefix)
+	fmt.Printf("set service_config.storage.type to %s in config file and restart the server to use it\n", req.To)
+	return nil
+}
@@ -19,15 +19,21 @@ import (
 	"github.com/apache/incubator-answer/internal/repo/activity"
 	"github.com/apache/incubator-answer/internal/repo/activity_common"
 	"github.com/apache/incubator-answer/internal/repo/answer"
+	"github.com/apache/incubator-answer/internal/repo/api_token"
+	"github.com/apache/incubator-answer/internal/repo/attachment"
 	"github.com/apache/incubator-answer/internal/repo/auth"
+	"github.com/apache/incubator-answer/internal/repo/badge"
+	"github.com/apache/incubator-answer/internal/repo/bounty"
 	"github.com/apache/incubator-answer/internal/repo/captcha"
 	"github.com/apache/incubator-answer/internal/repo/collection"
 	"github.com/apache/incubator-answer/internal/repo/comment"
 	"github.com/apache/incubator-answer/internal/repo/config"
 	"github.com/apache/incubator-answer/internal/repo/export"
+	"github.com/apache/incubator-answer/internal/repo/importer"
+	"github.com/apache/incubator-answer/internal/repo/job_queue"
 	"github.com/apache/incubator-answer/internal/repo/limit"
 	"github.com/apache/incubator-answer/internal/repo/meta"
-	notification2 "github.com/apache/incubator-answer/internal/repo/notification"
+	"github.com/apache/incubator-answer/internal/repo/notification"
 	"github.com/apache/incubator-answer/internal/repo/plugin_config"
 	"github.com/apache/incubator-answer/internal/repo/question"
 	"github.com/apache/incubator-answer/internal/repo/rank"
@@ -37,33 +43,46 @@ import (
 	"github.com/apache/incubator-answer/internal/repo/revision"
 	"github.com/apache/incubator-answer/internal/repo/role"
 	"github.com/apache/incubator-answer/internal/repo/search_common"
+	"github.com/apache/incubator-answer/internal/repo/search_sync"
 	"github.com/apache/incubator-answer/internal/repo/site_info"
 	"github.com/apache/incubator-answer/internal/repo/tag"
 	"github.com/apache/incubator-answer/internal/repo/tag_common"
 	"github.com/apache/incubator-answer/internal/repo/unique"
+	"github.com/apache/incubator-answer/internal/repo/uploader"
 	"github.com/apache/incubator-answer/internal/repo/user"
+	"github.com/apache/incubator-answer/internal/repo/user_data"
 	"github.com/apache/incubator-answer/internal/repo/user_external_login"
 	"github.com/apache/incubator-answer/internal/repo/user_notification_config"
+	"github.com/apache/incubator-answer/internal/repo/webhook"
 	"github.com/apache/incubator-answer/internal/router"
 	"github.com/apache/incubator-answer/internal/service/action"
 	activity2 "github.com/apache/incubator-answer/internal/service/activity"
 	activity_common2 "github.com/apache/incubator-answer/internal/service/activity_common"
 	"github.com/apache/incubator-answer/internal/service/activity_queue"
 	"github.com/apache/incubator-answer/internal/service/answer_common"
+	api_token2 "github.com/apache/incubator-answer/internal/service/api_token"
+	attachment2 "github.com/apache/incubator-answer/internal/service/attachment"
 	auth2 "github.com/apache/incubator-answer/internal/service/auth"
+	badge2 "github.com/apache/incubator-answer/internal/service/badge"
+	"github.com/apache/incubator-answer/internal/service/badge_queue"
+	bounty2 "github.com/apache/incubator-answer/internal/service/bounty"
 	collection2 "github.com/apache/incubator-answer/internal/service/collection"
 	"github.com/apache/incubator-answer/internal/service/collection_common"
 	comment2 "github.com/apache/incubator-answer/internal/service/comment"
 	"github.com/apache/incubator-answer/internal/service/comment_common"
 	config2 "github.com/apache/incubator-answer/internal/service/config"
 	"github.com/apache/incubator-answer/internal/service/content"
+	"github.com/apache/incubator-answer/internal/service/content_export"
 	"github.com/apache/incubator-answer/internal/service/dashboard"
 	export2 "github.com/apache/incubator-answer/internal/service/export"
 	"github.com/apache/incubator-answer/internal/service/follow"
+	importer2 "github.com/apache/incubator-answer/internal/service/importer"
+	"github.com/apache/incubator-answer/internal/service/inbound_mail"
+	job_queue2 "github.com/apache/incubator-answer/internal/service/job_queue"
 	meta2 "github.com/apache/incubator-answer/internal/service/meta"
 	"github.com/apache/incubator-answer/internal/service/meta_common"
 	"github.com/apache/incubator-answer/internal/service/notice_queue"
-	"github.com/apache/incubator-answer/internal/service/notification"
+	notification2 "github.com/apache/incubator-answer/internal/service/notification"
 	"github.com/apache/incubator-answer/internal/service/notification_common"
 	"github.com/apache/incubator-answer/internal/service/object_info"
 	"github.com/apache/incubator-answer/internal/service/plugin_common"
@@ -76,16 +95,20 @@ import (
 	"github.com/apache/incubator-answer/internal/service/revision_common"
 	role2 "github.com/apache/incubator-answer/internal/service/role"
 	"github.com/apache/incubator-answer/internal/service/search_parser"
+	search_sync2 "github.com/apache/incubator-answer/internal/service/search_sync"
 	"github.com/apache/incubator-answer/internal/service/service_config"
 	"github.com/apache/incubator-answer/internal/service/siteinfo"
 	"github.com/apache/incubator-answer/internal/service/siteinfo_common"
 	tag2 "github.com/apache/incubator-answer/internal/service/tag"
 	tag_common2 "github.com/apache/incubator-answer/internal/service/tag_common"
-	"github.com/apache/incubator-answer/internal/service/uploader"
+	uploader2 "github.com/apache/incubator-answer/internal/service/uploader"
 	"github.com/apache/incubator-answer/internal/service/user_admin"
 	"github.com/apache/incubator-answer/internal/service/user_common"
+	user_data2 "github.com/apache/incubator-answer/internal/service/user_data"
 	user_external_login2 "github.com/apache/incubator-answer/internal/service/user_external_login"
 	user_notification_config2 "github.com/apache/incubator-answer/internal/service/user_notification_config"
+	webhook2 "github.com/apache/incubator-answer/internal/service/webhook"
+	"github.com/apache/incubator-answer/internal/service/webhook_queue"
 	"github.com/segmentfault/pacman"
 	"github.com/segmentfault/pacman/log"
 )
@@ -94,11 +117,6 @@ import (
 
 // initApplication init application.
 func initApplication(debug bool, serverConf *conf.Server, dbConf *data.Database, cacheConf *data.CacheConf, i18nConf *translator.I18n, swaggerConf *router.SwaggerConfig, serviceConf *service_config.ServiceConfig, uiConf *server.UI, logConf log.Logger) (*pacman.Application, func(), error) {
-	staticRouter := router.NewStaticRouter(serviceConf)
-	i18nTranslator, err := translator.NewTranslator(i18nConf)
-	if err != nil {
-		return nil, nil, err
-	}
 	engine, err := data.NewDB(debug, dbConf)
 	if err != nil {
 		return nil, nil, err
@@ -114,6 +132,18 @@ func initApplication(debug bool, serverConf *conf.Server, dbConf *data.Database,
 	}
 	siteInfoRepo := site_info.NewSiteInfo(dataData)
 	siteInfoCommonService := siteinfo_common.NewSiteInfoCommonService(siteInfoRepo)
+	attachmentRepo := attachment.NewAttachmentRepo(dataData)
+	attachmentService := attachment2.NewAttachmentService(attachmentRepo)
+	uploadedURLRepo := uploader.NewUploadedURLRepo(dataData)
+	uploaderService := uploader2.NewUploaderService(serviceConf, siteInfoCommonService, attachmentService, uploadedURLRepo)
+	uploadController := controller.NewUploadController(uploaderService)
+	staticRouter := router.NewStaticRouter(serviceConf, uploadController)
+	i18nTranslator, err := translator.NewTranslator(i18nConf)
+	if err != nil {
+		cleanup2()
+		cleanup()
+		return nil, nil, err
+	}
 	langController := controller.NewLangController(i18nTranslator, siteInfoCommonService)
 	authRepo := auth.NewAuthRepo(dataData)
 	authService := auth2.NewAuthService(authRepo)
@@ -125,7 +155,10 @@ func initApplication(debug bool, serverConf *conf.Server, dbConf *data.Database,
 	userRankRepo := rank.NewUserRankRepo(dataData, configService)
 	userActiveActivityRepo := activity.NewUserActiveActivityRepo(dataData, activityRepo, userRankRepo, configService)
 	emailRepo := export.NewEmailRepo(dataData)
-	emailService := export2.NewEmailService(configService, emailRepo, siteInfoCommonService)
+	emailDeliveryRepo := export.NewEmailDeliveryRepo(dataData)
+	jobQueueRepo := job_queue.NewJobQueueRepo(dataData)
+	jobQueueService := job_queue2.NewJobQueueService(jobQueueRepo)
+	emailService := export2.NewEmailService(configService, emailRepo, emailDeliveryRepo, siteInfoCommonService, jobQueueService, serviceConf)
 	userRoleRelRepo := role.NewUserRoleRelRepo(dataData)
 	roleRepo := role.NewRoleRepo(dataData)
 	roleService := role2.NewRoleService(roleRepo)
@@ -143,8 +176,8 @@ func initApplication(debug bool, serverConf *conf.Server, dbConf *data.Database,
 	tagRelRepo := tag.NewTagRelRepo(dataData, uniqueIDRepo)
 	tagRepo := tag.NewTagRepo(dataData, uniqueIDRepo)
 	revisionRepo := revision.NewRevisionRepo(dataData, uniqueIDRepo)
-	revisionService := revision_common.NewRevisionService(revisionRepo, userRepo)
-	activityQueueService := activity_queue.NewActivityQueueService()
+	revisionService := revision_common.NewRevisionService(revisionRepo, userRepo, attachmentService)
+	activityQueueService := activity_queue.NewActivityQueueService(jobQueueService)
 	tagCommonService := tag_common2.NewTagCommonService(tagCommonRepo, tagRelRepo, tagRepo, revisionService, siteInfoCommonService, activityQueueService)
 	collectionRepo := collection.NewCollectionRepo(dataData, uniqueIDRepo)
 	collectionCommon := collectioncommon.NewCollectionCommon(collectionRepo)
@@ -152,15 +185,19 @@ func initApplication(debug bool, serverConf *conf.Server, dbConf *data.Database,
 	metaRepo := meta.NewMetaRepo(dataData)
 	metaCommonService := metacommon.NewMetaCommonService(metaRepo)
 	questionCommon := questioncommon.NewQuestionCommon(questionRepo, answerRepo, voteRepo, followRepo, tagCommonService, userCommon, collectionCommon, answerCommon, metaCommonService, configService, activityQueueService, revisionRepo, dataData)
-	userService := content.NewUserService(userRepo, userActiveActivityRepo, activityRepo, emailService, authService, siteInfoCommonService, userRoleRelService, userCommon, userExternalLoginService, userNotificationConfigRepo, userNotificationConfigService, questionCommon)
+	notificationMuteRepo := user_notification_config.NewNotificationMuteRepo(dataData)
+	badgeRepo := badge.NewBadgeRepo(dataData)
+	notificationQueueService := notice_queue.NewNotificationQueueService(jobQueueService)
+	badgeQueueService := badge_queue.NewBadgeQueueService(jobQueueService)
+	badgeService := badge2.NewBadgeService(badgeRepo, userRepo, tagCommonRepo, notificationQueueService, badgeQueueService)
+	userService := content.NewUserService(userRepo, userActiveActivityRepo, activityRepo, emailService, authService, siteInfoCommonService, userRoleRelService, userCommon, userExternalLoginService, userNotificationConfigRepo, userNotificationConfigService, questionCommon, notificationMuteRepo, badgeService)
 	captchaRepo := captcha.NewCaptchaRepo(dataData)
 	captchaService := action.NewCaptchaService(captchaRepo)
 	userController := controller.NewUserController(authService, userService, captchaService, emailService, siteInfoCommonService, userNotificationConfigService)
 	commentRepo := comment.NewCommentRepo(dataData, uniqueIDRepo)
 	commentCommonRepo := comment.NewCommentCommonRepo(dataData, uniqueIDRepo)
 	objService := object_info.NewObjService(answerRepo, questionRepo, commentCommonRepo, tagCommonRepo, tagCommonService)
-	notificationQueueService := notice_queue.NewNotificationQueueService()
-	externalNotificationQueueService := notice_queue.NewNewQuestionNotificationQueueService()
+	externalNotificationQueueService := notice_queue.NewNewQuestionNotificationQueueService(jobQueueService)
 	commentService := comment2.NewCommentService(commentRepo, commentCommonRepo, userCommon, objService, voteRepo, emailService, userRepo, notificationQueueService, externalNotificationQueueService, activityQueueService)
 	rolePowerRelRepo := role.NewRolePowerRelRepo(dataData)
 	rolePowerRelService := role2.NewRolePowerRelService(rolePowerRelRepo, userRoleRelService)
@@ -170,19 +207,25 @@ func initApplication(debug bool, serverConf *conf.Server, dbConf *data.Database,
 	commentController := controller.NewCommentController(commentService, rankService, captchaService, rateLimitMiddleware)
 	reportRepo := report.NewReportRepo(dataData, uniqueIDRepo)
 	answerActivityRepo := activity.NewAnswerActivityRepo(dataData, activityRepo, userRankRepo, notificationQueueService)
-	answerActivityService := activity2.NewAnswerActivityService(answerActivityRepo, configService)
-	externalNotificationService := notification.NewExternalNotificationService(dataData, userNotificationConfigRepo, followRepo, emailService, userRepo, externalNotificationQueueService, userExternalLoginRepo, siteInfoCommonService)
+	webhookQueueService := webhook_queue.NewWebhookQueueService(jobQueueService)
+	answerActivityService := activity2.NewAnswerActivityService(answerActivityRepo, configService, webhookQueueService, badgeQueueService)
+	notificationDigestRepo := notification.NewNotificationDigestRepo(dataData)
+	externalNotificationService := notification2.NewExternalNotificationService(dataData, userNotificationConfigRepo, followRepo, emailService, userRepo, externalNotificationQueueService, userExternalLoginRepo, siteInfoCommonService, notificationDigestRepo, notificationMuteRepo, tagCommonService)
 	reviewRepo := review.NewReviewRepo(dataData)
-	reviewService := review2.NewReviewService(reviewRepo, objService, userCommon, userRepo, questionRepo, answerRepo, userRoleRelService, externalNotificationQueueService, tagCommonService, questionCommon, notificationQueueService, siteInfoCommonService)
-	questionService := content.NewQuestionService(questionRepo, answerRepo, tagCommonService, questionCommon, userCommon, userRepo, userRoleRelService, revisionService, metaCommonService, collectionCommon, answerActivityService, emailService, notificationQueueService, externalNotificationQueueService, activityQueueService, siteInfoCommonService, externalNotificationService, reviewService, configService)
+	reviewService := review2.NewReviewService(reviewRepo, objService, userCommon, userRepo, questionRepo, answerRepo, userRoleRelService, externalNotificationQueueService, tagCommonService, questionCommon, notificationQueueService, siteInfoCommonService, webhookQueueService)
+	bountyRepo := bounty.NewBountyRepo(dataData)
+	bountyActivityRepo := activity.NewBountyActivityRepo(dataData, userRankRepo, configService)
+	bountyService := bounty2.NewBountyService(bountyRepo, bountyActivityRepo, questionRepo, answerRepo, userCommon, notificationQueueService)
+	questionService := content.NewQuestionService(questionRepo, answerRepo, tagCommonService, questionCommon, userCommon, userRepo, userRoleRelService, revisionService, metaCommonService, collectionCommon, answerActivityService, emailService, notificationQueueService, externalNotificationQueueService, activityQueueService, siteInfoCommonService, externalNotificationService, reviewService, configService, bountyService)
 	answerService := content.NewAnswerService(answerRepo, questionRepo, questionCommon, userCommon, collectionCommon, userRepo, revisionService, answerActivityService, answerCommon, voteRepo, emailService, userRoleRelService, notificationQueueService, externalNotificationQueueService, activityQueueService, reviewService)
 	reportHandle := report_handle.NewReportHandle(questionService, answerService, commentService)
-	reportService := report2.NewReportService(reportRepo, objService, userCommon, answerRepo, questionRepo, commentCommonRepo, reportHandle, configService)
+	reportService := report2.NewReportService(reportRepo, objService, userCommon, answerRepo, questionRepo, commentCommonRepo, reportHandle, configService, webhookQueueService, userRoleRelService, serviceConf)
 	reportController := controller.NewReportController(reportService, rankService, captchaService)
 	contentVoteRepo := activity.NewVoteRepo(dataData, activityRepo, userRankRepo, notificationQueueService)
-	voteService := content.NewVoteService(contentVoteRepo, configService, questionRepo, answerRepo, commentCommonRepo, objService)
+	voteService := content.NewVoteService(contentVoteRepo, configService, questionRepo, answerRepo, commentCommonRepo, objService, webhookQueueService, badgeQueueService)
 	voteController := controller.NewVoteController(voteService, rankService, captchaService)
-	tagService := tag2.NewTagService(tagRepo, tagCommonService, revisionService, followRepo, siteInfoCommonService, activityQueueService)
+	tagGroupRepo := tag.NewTagGroupRepo(dataData)
+	tagService := tag2.NewTagService(tagRepo, tagCommonService, revisionService, followRepo, siteInfoCommonService, activityQueueService, tagGroupRepo)
 	tagController := controller.NewTagController(tagService, tagCommonService, rankService)
 	followFollowRepo := activity.NewFollowRepo(dataData, uniqueIDRepo, activityRepo)
 	followService := follow.NewFollowService(followFollowRepo, followRepo, tagCommonRepo)
@@ -197,12 +240,14 @@ func initApplication(debug bool, serverConf *conf.Server, dbConf *data.Database,
 	searchService := content.NewSearchService(searchParser, searchRepo)
 	searchController := controller.NewSearchController(searchService, captchaService)
 	reviewActivityRepo := activity.NewReviewActivityRepo(dataData, activityRepo, userRankRepo, configService)
-	contentRevisionService := content.NewRevisionService(revisionRepo, userCommon, questionCommon, answerService, objService, questionRepo, answerRepo, tagRepo, tagCommonService, notificationQueueService, activityQueueService, reportRepo, reviewService, reviewActivityRepo)
+	contentRevisionService := content.NewRevisionService(revisionRepo, userCommon, questionCommon, answerService, objService, questionRepo, answerRepo, tagRepo, tagCommonService, tagGroupRepo, notificationQueueService, activityQueueService, reportRepo, reviewService, reviewActivityRepo, revisionService)
 	revisionController := controller.NewRevisionController(contentRevisionService, rankService)
 	rankController := controller.NewRankController(rankService)
 	userAdminRepo := user.NewUserAdminRepo(dataData, authRepo)
 	userAdminService := user_admin.NewUserAdminService(userAdminRepo, userRoleRelService, authService, userCommon, userActiveActivityRepo, siteInfoCommonService, emailService, questionRepo, answerRepo, commentCommonRepo)
-	userAdminController := controller_admin.NewUserAdminController(userAdminService)
+	userDataRepo := user_data.NewUserDataRepo(dataData)
+	userDataService := user_data2.NewUserDataService(userDataRepo, userRepo, configService, authService)
+	userAdminController := controller_admin.NewUserAdminController(userAdminService, userDataService)
 	reasonRepo := reason.NewReasonRepo(configService)
 	reasonService := reason2.NewReasonService(reasonRepo)
 	reasonController := controller.NewReasonController(reasonService)
@@ -210,16 +255,14 @@ func initApplication(debug bool, serverConf *conf.Server, dbConf *data.Database,
 	siteInfoService := siteinfo.NewSiteInfoService(siteInfoRepo, siteInfoCommonService, emailService, tagCommonService, configService, questionCommon)
 	siteInfoController := controller_admin.NewSiteInfoController(siteInfoService)
 	controllerSiteInfoController := controller.NewSiteInfoController(siteInfoCommonService)
-	notificationRepo := notification2.NewNotificationRepo(dataData)
+	notificationRepo := notification.NewNotificationRepo(dataData)
 	notificationCommon := notificationcommon.NewNotificationCommon(dataData, notificationRepo, userCommon, activityRepo, followRepo, objService, notificationQueueService, userExternalLoginRepo, siteInfoCommonService)
-	notificationService := notification.NewNotificationService(dataData, notificationRepo, notificationCommon, revisionService, userRepo, reportRepo, reviewService)
+	notificationService := notification2.NewNotificationService(dataData, notificationRepo, notificationCommon, revisionService, userRepo, reportRepo, reviewService)
 	notificationController := controller.NewNotificationController(notificationService, rankService)
 	dashboardService := dashboard.NewDashboardService(questionRepo, answerRepo, commentCommonRepo, voteRepo, userRepo, reportRepo, configService, siteInfoCommonService, serviceConf, reviewService, revisionRepo, dataData)
 	dashboardController := controller.NewDashboardController(dashboardService)
-	uploaderService := uploader.NewUploaderService(serviceConf, siteInfoCommonService)
-	uploadController := controller.NewUploadController(uploaderService)
 	activityActivityRepo := activity.NewActivityRepo(dataData, configService)
-	activityCommon := activity_common2.NewActivityCommon(activityRepo, activityQueueService)
+	activityCommon := activity_common2.NewActivityCommon(activityRepo, activityQueueService, webhookQueueService, badgeQueueService)
 	commentCommonService := comment_common.NewCommentCommonService(commentCommonRepo)
 	activityService := activity2.NewActivityService(activityActivityRepo, userCommon, activityCommon, tagCommonService, objService, commentCommonService, revisionService, metaCommonService, configService)
 	activityController := controller.NewActivityController(activityService)
@@ -233,10 +276,38 @@ func initApplication(debug bool, serverConf *conf.Server, dbConf *data.Database,
 	reviewController := controller.NewReviewController(reviewService, rankService, captchaService)
 	metaService := meta2.NewMetaService(metaCommonService, userCommon, answerRepo, questionRepo)
 	metaController := controller.NewMetaController(metaService)
-	answerAPIRouter := router.NewAnswerAPIRouter(langController, userController, commentController, reportController, voteController, tagController, followController, collectionController, questionController, answerController, searchController, revisionController, rankController, userAdminController, reasonController, themeController, siteInfoController, controllerSiteInfoController, notificationController, dashboardController, uploadController, activityController, roleController, pluginController, permissionController, userPluginController, reviewController, metaController)
+	jobQueueController := controller_admin.NewJobQueueController(jobQueueService)
+	webhookRepo := webhook.NewWebhookRepo(dataData)
+	webhookService := webhook2.NewWebhookService(webhookRepo, jobQueueService, webhookQueueService, objService, siteInfoCommonService)
+	webhookController := controller_admin.NewWebhookController(webhookService)
+	apiTokenRepo := api_token.NewAPITokenRepo(dataData)
+	apiTokenService := api_token2.NewAPITokenService(apiTokenRepo, userRepo, userRoleRelService)
+	apiTokenController := controller.NewAPITokenController(apiTokenService)
+	searchSyncRepo := search_sync.NewSearchSyncRepo(dataData)
+	searchSyncCheckpointRepo := search_sync.NewSearchSyncCheckpointRepo(dataData)
+	searchSyncService := search_sync2.NewSearchSyncService(searchSyncRepo, searchSyncCheckpointRepo)
+	searchSyncController := controller_admin.NewSearchSyncController(searchSyncService)
+	importRepo := importer.NewImportRepo(dataData)
+	stackExchangeImporter := importer2.NewStackExchangeImporter(importRepo, uniqueIDRepo, configService)
+	importerService := importer2.NewImporterService(stackExchangeImporter, jobQueueService)
+	importController := controller_admin.NewImportController(importerService)
+	contentExporter := content_export.NewContentExporter(questionRepo, answerCommon, commentRepo, tagCommonRepo, tagRelRepo, revisionRepo, userRepo)
+	contentExportService := content_export.NewContentExportService(contentExporter, jobQueueService, serviceConf)
+	contentExportController := controller_admin.NewContentExportController(contentExportService)
+	userDataController := controller.NewUserDataController(userDataService)
+	emailDeliveryController := controller_admin.NewEmailDeliveryController(emailService)
+	inboundMailService := inbound_mail.NewInboundMailService(serviceConf, emailService, userRepo, userRoleRelService, rankService, captchaService, commentService, answerService, siteInfoCommonService)
+	inboundMailController := controller.NewInboundMailController(inboundMailService)
+	bountyController := controller.NewBountyController(bountyService)
+	badgeController := controller.NewBadgeController(badgeService)
+	controller_adminBadgeController := controller_admin.NewBadgeController(badgeService)
+	rankRecalculateRepo := rank.NewRankRecalculateRepo(dataData)
+	rankRecalculateService := rank2.NewRankRecalculateService(rankRecalculateRepo, configService)
+	rankRecalculateController := controller_admin.NewRankRecalculateController(rankRecalculateService)
+	answerAPIRouter := router.NewAnswerAPIRouter(langController, userController, commentController, reportController, voteController, tagController, followController, collectionController, questionController, answerController, searchController, revisionController, rankController, userAdminController, reasonController, themeController, siteInfoController, controllerSiteInfoController, notificationController, dashboardController, uploadController, activityController, roleController, pluginController, permissionController, userPluginController, reviewController, metaController, jobQueueController, webhookController, apiTokenController, searchSyncController, importController, contentExportController, userDataController, emailDeliveryController, inboundMailController, bountyController, badgeController, controller_adminBadgeController, rankRecalculateController)
 	swaggerRouter := router.NewSwaggerRouter(swaggerConf)
 	uiRouter := router.NewUIRouter(controllerSiteInfoController, siteInfoCommonService)
-	authUserMiddleware := middleware.NewAuthUserMiddleware(authService, siteInfoCommonService)
+	authUserMiddleware := middleware.NewAuthUserMiddleware(authService, siteInfoCommonService, apiTokenService)
 	avatarMiddleware := middleware.NewAvatarMiddleware(serviceConf, uploaderService)
 	shortIDMiddleware := middleware.NewShortIDMiddleware(siteInfoCommonService)
 	templateRenderController := templaterender.NewTemplateRenderController(questionService, userService, tagService, answerService, commentService, siteInfoCommonService, questionRepo)
@@ -249,7 +320,7 @@ func initApplication(debug bool, serverConf *conf.Server, dbConf *data.Database,
 	embedController := controller.NewEmbedController()
 	pluginAPIRouter := router.NewPluginAPIRouter(connectorController, userCenterController, captchaController, embedController)
 	ginEngine := server.NewHTTPServer(debug, staticRouter, answerAPIRouter, swaggerRouter, uiRouter, authUserMiddleware, avatarMiddleware, shortIDMiddleware, templateRouter, pluginAPIRouter, uiConf)
-	scheduledTaskManager := cron.NewScheduledTaskManager(siteInfoCommonService, questionService)
+	scheduledTaskManager := cron.NewScheduledTaskManager(siteInfoCommonService, questionService, externalNotificationService, inboundMailService, bountyService, badgeService, uploaderService, emailService)
 	application := newApplication(serverConf, ginEngine, scheduledTaskManager)
 	return application, func() {
 		cleanup2()
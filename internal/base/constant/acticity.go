@@ -56,6 +56,9 @@ const (
 	ActQuestionUnPin     ActivityTypeKey = "question.unpin"
 	ActQuestionHide      ActivityTypeKey = "question.hide"
 	ActQuestionShow      ActivityTypeKey = "question.show"
+
+	ActQuestionBountyOffered ActivityTypeKey = "question.bounty_offered"
+	ActQuestionBountyExpired ActivityTypeKey = "question.bounty_expired"
 )
 
 const (
@@ -68,6 +71,8 @@ const (
 	ActAnswerRollback  ActivityTypeKey = "answer.rollback"
 	ActAnswerDeleted   ActivityTypeKey = "answer.deleted"
 	ActAnswerUndeleted ActivityTypeKey = "answer.undeleted"
+
+	ActAnswerBountyAwarded ActivityTypeKey = "answer.bounty_awarded"
 )
 
 const (
@@ -56,6 +56,10 @@ const (
 	NotificationYourCommentWasDeleted = "notification.action.your_comment_was_deleted"
 	// NotificationInvitedYouToAnswer invited you to answer
 	NotificationInvitedYouToAnswer = "notification.action.invited_you_to_answer"
+	// NotificationEarnedBadge you earned a badge
+	NotificationEarnedBadge = "notification.action.earned_badge"
+	// NotificationBountyAwarded awarded the bounty to your answer
+	NotificationBountyAwarded = "notification.action.bounty_awarded"
 )
 
 type NotificationChannelKey string
@@ -71,6 +75,25 @@ const (
 	EmailChannel NotificationChannelKey = "email"
 )
 
+// NotificationFrequency how often the notifications of a source are sent by a channel
+type NotificationFrequency string
+
+const (
+	NotificationFrequencyImmediate NotificationFrequency = "immediate"
+	NotificationFrequencyHourly    NotificationFrequency = "hourly"
+	NotificationFrequencyDaily     NotificationFrequency = "daily"
+	NotificationFrequencyWeekly    NotificationFrequency = "weekly"
+)
+
+var (
+	// NotificationDigestFrequencies the frequencies that collect notifications into a digest email
+	NotificationDigestFrequencies = []NotificationFrequency{
+		NotificationFrequencyHourly,
+		NotificationFrequencyDaily,
+		NotificationFrequencyWeekly,
+	}
+)
+
 var (
 	NotificationMsgTypeMapping = map[string]int{
 		NotificationUpdateQuestion:         1,
@@ -91,5 +114,6 @@ var (
 		NotificationYourAnswerWasDeleted:   1,
 		NotificationYourCommentWasDeleted:  1,
 		NotificationInvitedYouToAnswer:     3,
+		NotificationBountyAwarded:          1,
 	}
 )
@@ -27,6 +27,7 @@ const (
 	CollectionObjectType = "collection"
 	CommentObjectType    = "comment"
 	ReportObjectType     = "report"
+	BadgeObjectType      = "badge"
 )
 
 var (
@@ -35,6 +35,8 @@ const (
 	ReportOperationDeletePost   = "delete_post"
 	ReportOperationUnlistPost   = "unlist_post"
 	ReportOperationIgnoreReport = "ignore_report"
+	// ReportOperationApproveReport accept the report without changing the post
+	ReportOperationApproveReport = "approve_report"
 )
 
 const (
@@ -47,4 +47,6 @@ const (
 
 const (
 	EmailConfigKey = "email.config"
+	// EmailUnsubscribeSecretKey signs the one-click unsubscribe token, it is generated at install or upgrade
+	EmailUnsubscribeSecretKey = "email.unsubscribe_secret"
 )
@@ -0,0 +1,76 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package constant
+
+const (
+	WebhookEventPing ActivityTypeKey = "ping"
+)
+
+const (
+	WebhookEventReportCreated ActivityTypeKey = "report.created"
+	WebhookEventReportHandled ActivityTypeKey = "report.handled"
+	WebhookEventReportIgnored ActivityTypeKey = "report.ignored"
+)
+
+const (
+	WebhookEventReviewPending  ActivityTypeKey = "review.pending"
+	WebhookEventReviewApproved ActivityTypeKey = "review.approved"
+	WebhookEventReviewRejected ActivityTypeKey = "review.rejected"
+)
+
+// WebhookEvents all events that webhook can subscribe
+var WebhookEvents = []ActivityTypeKey{
+	ActQuestionAsked,
+	ActQuestionClosed,
+	ActQuestionReopened,
+	ActQuestionAnswered,
+	ActQuestionCommented,
+	ActQuestionAccept,
+	ActQuestionUpvote,
+	ActQuestionDownVote,
+	ActQuestionEdited,
+	ActQuestionRollback,
+	ActQuestionDeleted,
+	ActQuestionUndeleted,
+	ActQuestionPin,
+	ActQuestionUnPin,
+	ActQuestionHide,
+	ActQuestionShow,
+	ActAnswerAnswered,
+	ActAnswerCommented,
+	ActAnswerAccept,
+	ActAnswerUpvote,
+	ActAnswerDownVote,
+	ActAnswerEdited,
+	ActAnswerRollback,
+	ActAnswerDeleted,
+	ActAnswerUndeleted,
+	ActTagCreated,
+	ActTagEdited,
+	ActTagRollback,
+	ActTagDeleted,
+	ActTagUndeleted,
+	WebhookEventReportCreated,
+	WebhookEventReportHandled,
+	WebhookEventReportIgnored,
+	WebhookEventReviewPending,
+	WebhookEventReviewApproved,
+	WebhookEventReviewRejected,
+}
@@ -23,26 +23,51 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/apache/incubator-answer/internal/base/constant"
+	"github.com/apache/incubator-answer/internal/service/badge"
+	"github.com/apache/incubator-answer/internal/service/bounty"
 	"github.com/apache/incubator-answer/internal/service/content"
+	"github.com/apache/incubator-answer/internal/service/export"
+	"github.com/apache/incubator-answer/internal/service/inbound_mail"
+	"github.com/apache/incubator-answer/internal/service/notification"
 	"github.com/apache/incubator-answer/internal/service/siteinfo_common"
+	"github.com/apache/incubator-answer/internal/service/uploader"
 	"github.com/robfig/cron/v3"
 	"github.com/segmentfault/pacman/log"
 )
 
 // ScheduledTaskManager scheduled task manager
 type ScheduledTaskManager struct {
-	siteInfoService siteinfo_common.SiteInfoCommonService
-	questionService *content.QuestionService
+	siteInfoService             siteinfo_common.SiteInfoCommonService
+	questionService             *content.QuestionService
+	externalNotificationService *notification.ExternalNotificationService
+	inboundMailService          *inbound_mail.InboundMailService
+	bountyService               *bounty.BountyService
+	badgeService                *badge.BadgeService
+	uploaderService             uploader.UploaderService
+	emailService                *export.EmailService
 }
 
 // NewScheduledTaskManager new scheduled task manager
 func NewScheduledTaskManager(
 	siteInfoService siteinfo_common.SiteInfoCommonService,
 	questionService *content.QuestionService,
+	externalNotificationService *notification.ExternalNotificationService,
+	inboundMailService *inbound_mail.InboundMailService,
+	bountyService *bounty.BountyService,
+	badgeService *badge.BadgeService,
+	uploaderService uploader.UploaderService,
+	emailService *export.EmailService,
 ) *ScheduledTaskManager {
 	manager := &ScheduledTaskManager{
-		siteInfoService: siteInfoService,
-		questionService: questionService,
+		siteInfoService:             siteInfoService,
+		questionService:             questionService,
+		externalNotificationService: externalNotificationService,
+		inboundMailService:          inboundMailService,
+		bountyService:               bountyService,
+		badgeService:                badgeService,
+		uploaderService:             uploaderService,
+		emailService:                emailService,
 	}
 	return manager
 }
@@ -69,5 +94,69 @@ func (s *ScheduledTaskManager) Run() {
 		log.Error(err)
 	}
 
+	digestSpecs := map[constant.NotificationFrequency]string{
+		constant.NotificationFrequencyHourly: "0 */1 * * *",
+		constant.NotificationFrequencyDaily:  "0 8 * * *",
+		constant.NotificationFrequencyWeekly: "0 8 * * 1",
+	}
+	for frequency, spec := range digestSpecs {
+		frequency := frequency
+		_, err = c.AddFunc(spec, func() {
+			ctx := context.Background()
+			fmt.Printf("%s notification digest cron execution\n", frequency)
+			s.externalNotificationService.SendDigestCron(ctx, frequency)
+		})
+		if err != nil {
+			log.Error(err)
+		}
+	}
+
+	// reply-by-email maildir, it does nothing if the maildir is not configured
+	_, err = c.AddFunc("*/1 * * * *", func() {
+		s.inboundMailService.PollMaildir(context.Background())
+	})
+	if err != nil {
+		log.Error(err)
+	}
+
+	_, err = c.AddFunc("*/10 * * * *", func() {
+		ctx := context.Background()
+		fmt.Println("expire bounty cron execution")
+		s.bountyService.ExpireBountyCron(ctx)
+	})
+	if err != nil {
+		log.Error(err)
+	}
+
+	// award the badges that are missed by the incremental evaluation, e.g. the badges of a newly enabled plugin
+	_, err = c.AddFunc("0 3 * * *", func() {
+		ctx := context.Background()
+		fmt.Println("badge back-fill cron execution")
+		s.badgeService.BackfillCron(ctx)
+	})
+	if err != nil {
+		log.Error(err)
+	}
+
+	// delete the uploaded files not referenced by any content, it does nothing if the grace period is not configured
+	_, err = c.AddFunc("0 4 * * *", func() {
+		ctx := context.Background()
+		fmt.Println("orphan uploads cleanup cron execution")
+		s.uploaderService.CleanOrphanUploadsCron(ctx)
+	})
+	if err != nil {
+		log.Error(err)
+	}
+
+	// delete the email delivery logs that are done after the retention days
+	_, err = c.AddFunc("30 4 * * *", func() {
+		ctx := context.Background()
+		fmt.Println("email delivery logs purge cron execution")
+		s.emailService.PurgeDeliveriesCron(ctx)
+	})
+	if err != nil {
+		log.Error(err)
+	}
+
 	c.Start()
 }
@@ -24,6 +24,7 @@ import (
 	"strings"
 
 	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/apache/incubator-answer/internal/service/api_token"
 	"github.com/apache/incubator-answer/internal/service/role"
 	"github.com/apache/incubator-answer/internal/service/siteinfo_common"
 	"github.com/apache/incubator-answer/ui"
@@ -38,22 +39,51 @@ import (
 	"github.com/segmentfault/pacman/log"
 )
 
-var ctxUUIDKey = "ctxUuidKey"
+var (
+	ctxUUIDKey          = "ctxUuidKey"
+	ctxAPITokenScopeKey = "ctxAPITokenScopeKey"
+)
 
 // AuthUserMiddleware auth user middleware
 type AuthUserMiddleware struct {
 	authService           *auth.AuthService
 	siteInfoCommonService siteinfo_common.SiteInfoCommonService
+	apiTokenService       *api_token.APITokenService
 }
 
 // NewAuthUserMiddleware new auth user middleware
 func NewAuthUserMiddleware(
 	authService *auth.AuthService,
-	siteInfoCommonService siteinfo_common.SiteInfoCommonService) *AuthUserMiddleware {
+	siteInfoCommonService siteinfo_common.SiteInfoCommonService,
+	apiTokenService *api_token.APITokenService) *AuthUserMiddleware {
 	return &AuthUserMiddleware{
 		authService:           authService,
 		siteInfoCommonService: siteInfoCommonService,
+		apiTokenService:       apiTokenService,
+	}
+}
+
+// getUserCacheInfo get user info by session token or api token. If it is an api token, the scope will be set to context.
+func (am *AuthUserMiddleware) getUserCacheInfo(ctx *gin.Context, token string) (
+	userInfo *entity.UserCacheInfo, err error) {
+	if !api_token.IsAPIToken(token) {
+		return am.authService.GetUserCacheInfo(ctx, token)
+	}
+	userInfo, scope, err := am.apiTokenService.GetUserCacheInfo(ctx, token)
+	if err != nil || userInfo == nil {
+		return nil, err
+	}
+	ctx.Set(ctxAPITokenScopeKey, scope)
+	return userInfo, nil
+}
+
+// apiTokenScopeAllowed whether the api token used by current request is allowed to access, always true for session token
+func apiTokenScopeAllowed(ctx *gin.Context, adminAPI bool) bool {
+	scope, exist := ctx.Get(ctxAPITokenScopeKey)
+	if !exist {
+		return true
 	}
+	return api_token.ScopeAllowed(scope.(string), ctx.Request.Method, adminAPI)
 }
 
 // Auth get token and auth user, set user info to context if user is already login
@@ -64,12 +94,12 @@ func (am *AuthUserMiddleware) Auth() gin.HandlerFunc {
 			ctx.Next()
 			return
 		}
-		userInfo, err := am.authService.GetUserCacheInfo(ctx, token)
+		userInfo, err := am.getUserCacheInfo(ctx, token)
 		if err != nil {
 			ctx.Next()
 			return
 		}
-		if userInfo != nil {
+		if userInfo != nil && apiTokenScopeAllowed(ctx, false) {
 			ctx.Set(ctxUUIDKey, userInfo)
 		}
 		ctx.Next()
@@ -116,12 +146,17 @@ func (am *AuthUserMiddleware) MustAuthWithoutAccountAvailable() gin.HandlerFunc
 			ctx.Abort()
 			return
 		}
-		userInfo, err := am.authService.GetUserCacheInfo(ctx, token)
+		userInfo, err := am.getUserCacheInfo(ctx, token)
 		if err != nil || userInfo == nil {
 			handler.HandleResponse(ctx, errors.Unauthorized(reason.UnauthorizedError), nil)
 			ctx.Abort()
 			return
 		}
+		if !apiTokenScopeAllowed(ctx, false) {
+			handler.HandleResponse(ctx, errors.Forbidden(reason.APITokenScopeNotAllowed), nil)
+			ctx.Abort()
+			return
+		}
 		if userInfo.UserStatus == entity.UserStatusDeleted {
 			handler.HandleResponse(ctx, errors.Unauthorized(reason.UnauthorizedError), nil)
 			ctx.Abort()
@@ -141,12 +176,17 @@ func (am *AuthUserMiddleware) MustAuthAndAccountAvailable() gin.HandlerFunc {
 			ctx.Abort()
 			return
 		}
-		userInfo, err := am.authService.GetUserCacheInfo(ctx, token)
+		userInfo, err := am.getUserCacheInfo(ctx, token)
 		if err != nil || userInfo == nil {
 			handler.HandleResponse(ctx, errors.Unauthorized(reason.UnauthorizedError), nil)
 			ctx.Abort()
 			return
 		}
+		if !apiTokenScopeAllowed(ctx, false) {
+			handler.HandleResponse(ctx, errors.Forbidden(reason.APITokenScopeNotAllowed), nil)
+			ctx.Abort()
+			return
+		}
 		if userInfo.EmailStatus != entity.EmailStatusAvailable {
 			handler.HandleResponse(ctx, errors.Forbidden(reason.EmailNeedToBeVerified),
 				&schema.ForbiddenResp{Type: schema.ForbiddenReasonTypeInactive})
@@ -177,7 +217,13 @@ func (am *AuthUserMiddleware) AdminAuth() gin.HandlerFunc {
 			ctx.Abort()
 			return
 		}
-		userInfo, err := am.authService.GetAdminUserCacheInfo(ctx, token)
+		var userInfo *entity.UserCacheInfo
+		var err error
+		if api_token.IsAPIToken(token) {
+			userInfo, err = am.getAdminUserCacheInfoByAPIToken(ctx, token)
+		} else {
+			userInfo, err = am.authService.GetAdminUserCacheInfo(ctx, token)
+		}
 		if err != nil || userInfo == nil {
 			handler.HandleResponse(ctx, errors.Forbidden(reason.UnauthorizedError), nil)
 			ctx.Abort()
@@ -195,6 +241,19 @@ func (am *AuthUserMiddleware) AdminAuth() gin.HandlerFunc {
 	}
 }
 
+// getAdminUserCacheInfoByAPIToken only the api token with admin scope of an admin user can access admin api
+func (am *AuthUserMiddleware) getAdminUserCacheInfoByAPIToken(ctx *gin.Context, token string) (
+	userInfo *entity.UserCacheInfo, err error) {
+	userInfo, err = am.getUserCacheInfo(ctx, token)
+	if err != nil || userInfo == nil {
+		return nil, err
+	}
+	if userInfo.RoleID != role.RoleAdminID || !apiTokenScopeAllowed(ctx, true) {
+		return nil, nil
+	}
+	return userInfo, nil
+}
+
 func (am *AuthUserMiddleware) CheckPrivateMode() gin.HandlerFunc {
 	return func(ctx *gin.Context) {
 		resp, err := am.siteInfoCommonService.GetSiteLogin(ctx)
@@ -241,6 +300,12 @@ func GetIsAdminFromContext(ctx *gin.Context) (isAdmin bool) {
 	return userInfo.RoleID == role.RoleAdminID
 }
 
+// IsAPITokenAuth whether current request is authenticated by api token
+func IsAPITokenAuth(ctx *gin.Context) bool {
+	_, exist := ctx.Get(ctxAPITokenScopeKey)
+	return exist
+}
+
 // GetUserInfoFromContext get user info from context
 func GetUserInfoFromContext(ctx *gin.Context) (u *entity.UserCacheInfo) {
 	userInfo, exist := ctx.Get(ctxUUIDKey)
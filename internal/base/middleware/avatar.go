@@ -30,6 +30,7 @@ import (
 	"github.com/apache/incubator-answer/internal/service/service_config"
 	"github.com/apache/incubator-answer/internal/service/uploader"
 	"github.com/apache/incubator-answer/pkg/converter"
+	"github.com/apache/incubator-answer/plugin"
 	"github.com/gin-gonic/gin"
 	"github.com/segmentfault/pacman/log"
 )
@@ -63,9 +64,15 @@ func (am *AvatarMiddleware) AvatarThumb() gin.HandlerFunc {
 				if err != nil {
 					log.Error(err)
 					ctx.Abort()
+					return
 				}
 			}
 			avatarFile, err := os.ReadFile(filePath)
+			if os.IsNotExist(err) {
+				// the original avatar is not on local disk, it is served from the storage
+				ctx.Next()
+				return
+			}
 			if err != nil {
 				log.Error(err)
 				ctx.Abort()
@@ -79,14 +86,21 @@ func (am *AvatarMiddleware) AvatarThumb() gin.HandlerFunc {
 			ctx.Abort()
 			return
 
-		} else {
+		} else if strings.HasPrefix(uri, "/uploads/") {
 			urlInfo, err := url.Parse(uri)
 			if err != nil {
 				ctx.Next()
 				return
 			}
-			ext := strings.TrimPrefix(filepath.Ext(urlInfo.Path), ".")
-			ctx.Header("content-type", fmt.Sprintf("image/%s", ext))
+			ext := strings.ToLower(filepath.Ext(urlInfo.Path))
+			if plugin.DefaultFileTypeCheckMapping[plugin.UserPost][ext] ||
+				plugin.DefaultFileTypeCheckMapping[plugin.AdminBranding][ext] {
+				ctx.Header("content-type", fmt.Sprintf("image/%s", strings.TrimPrefix(ext, ".")))
+			} else {
+				// the files which are not images are always downloaded, never rendered by the browser
+				ctx.Header("Content-Disposition", "attachment")
+				ctx.Header("X-Content-Type-Options", "nosniff")
+			}
 		}
 		ctx.Next()
 	}
@@ -74,6 +74,9 @@ const (
 	TagCannotUpdate                  = "error.tag.cannot_update"
 	TagIsUsedCannotDelete            = "error.tag.is_used_cannot_delete"
 	TagAlreadyExist                  = "error.tag.already_exist"
+	TagParentInvalid                 = "error.tag.parent_invalid"
+	TagGroupNotFound                 = "error.tag.group_not_found"
+	TagGroupAlreadyExist             = "error.tag.group_already_exist"
 	RankFailToMeetTheCondition       = "error.rank.fail_to_meet_the_condition"
 	VoteRankFailToMeetTheCondition   = "error.rank.vote_fail_to_meet_the_condition"
 	NoEnoughRankToOperate            = "error.rank.no_enough_rank_to_operate"
@@ -81,6 +84,7 @@ const (
 	LangNotFound                     = "error.lang.not_found"
 	ReportHandleFailed               = "error.report.handle_failed"
 	ReportNotFound                   = "error.report.not_found"
+	ReportAssigneeInvalid            = "error.report.assignee_invalid"
 	ReadConfigFailed                 = "error.config.read_config_failed"
 	DatabaseConnectionFailed         = "error.database.connection_failed"
 	InstallCreateTableFailed         = "error.database.create_table_failed"
@@ -92,6 +96,8 @@ const (
 	RecommendTagEnter                = "error.tag.recommend_tag_enter"
 	RevisionReviewUnderway           = "error.revision.review_underway"
 	RevisionNoPermission             = "error.revision.no_permission"
+	RevisionNotFound                 = "error.revision.not_found"
+	RevisionRollbackNoChange         = "error.revision.rollback_no_change"
 	UserCannotUpdateYourRole         = "error.user.cannot_update_your_role"
 	TagCannotSetSynonymAsItself      = "error.tag.cannot_set_synonym_as_itself"
 	NotAllowedRegistration           = "error.user.not_allowed_registration"
@@ -106,6 +112,34 @@ const (
 	AddBulkUsersAmountError          = "error.user.add_bulk_users_amount_error"
 	InvalidURLError                  = "error.common.invalid_url"
 	MetaObjectNotFound               = "error.meta.object_not_found"
+	QueueJobNotFound                 = "error.queue_job.not_found"
+	QueueJobIsRunning                = "error.queue_job.is_running"
+	WebhookNotFound                  = "error.webhook.not_found"
+	WebhookEventUnsupported          = "error.webhook.event_unsupported"
+	APITokenNotFound                 = "error.api_token.not_found"
+	APITokenTooMany                  = "error.api_token.too_many"
+	APITokenScopeNotAllowed          = "error.api_token.scope_not_allowed"
+	SearchPluginNotFound             = "error.search.plugin_not_found"
+	SearchReindexIsRunning           = "error.search.reindex_is_running"
+	ImportSourceNotFound             = "error.import.source_not_found"
+	ImportFallbackUserNotFound       = "error.import.fallback_user_not_found"
+	ContentExportFileNotFound        = "error.export.file_not_found"
+	InboundMailDisabled              = "error.inbound_mail.disabled"
+	InboundMailInvalidMessage        = "error.inbound_mail.invalid_message"
+	InboundMailInvalidToken          = "error.inbound_mail.invalid_token"
+	InboundMailSenderNotAllowed      = "error.inbound_mail.sender_not_allowed"
+	InboundMailEmptyContent          = "error.inbound_mail.empty_content"
+	InboundMailCaptchaRequired       = "error.inbound_mail.captcha_required"
+	BountyNotFound                   = "error.bounty.not_found"
+	BountyAlreadyActive              = "error.bounty.already_active"
+	BountyAmountInvalid              = "error.bounty.amount_invalid"
+	BountyRankNotEnough              = "error.bounty.rank_not_enough"
+	BountyQuestionNotOpen            = "error.bounty.question_not_open"
+	BountyCannotAwardOwnAnswer       = "error.bounty.cannot_award_own_answer"
+	BadgeBackfillIsRunning           = "error.badge.backfill_is_running"
+	RankRecalculateIsRunning         = "error.rank.recalculate_is_running"
+	RankRecalculateAgentEnabled      = "error.rank.recalculate_agent_enabled"
+	UploadFileTooLarge               = "error.upload.file_too_large"
 )
 
 // user external login reasons
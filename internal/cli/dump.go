@@ -20,25 +20,229 @@
 package cli
 
 import (
+	"archive/tar"
+	"bufio"
+	"compress/gzip"
+	"encoding/json"
 	"fmt"
+	"io"
+	"os"
+	"path"
 	"path/filepath"
+	"reflect"
 	"time"
 
 	"github.com/apache/incubator-answer/internal/base/data"
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/pkg/dir"
+	"xorm.io/xorm"
 	"xorm.io/xorm/schemas"
 )
 
-// DumpAllData dump all database data to sql
-func DumpAllData(dataConf *data.Database, dumpDataPath string) error {
+const (
+	// DumpFormatVersion is the version of the dump archive layout
+	DumpFormatVersion = 1
+
+	dumpManifestName = "manifest.json"
+	dumpDataDir      = "data"
+	dumpUploadsDir   = "uploads"
+	dumpConfigDir    = "conf"
+)
+
+// DumpManifest describes the content of a dump archive
+type DumpManifest struct {
+	FormatVersion int          `json:"format_version"`
+	DBVersion     int64        `json:"db_version"`
+	Driver        string       `json:"driver"`
+	CreatedAt     time.Time    `json:"created_at"`
+	Tables        []*DumpTable `json:"tables"`
+}
+
+// DumpTable the table data in dump archive
+type DumpTable struct {
+	Name string `json:"name"`
+	Rows int64  `json:"rows"`
+}
+
+// DumpAllData dump all database data, upload files and config file into a tar.gz archive.
+// Every table is saved as a JSONL file whose lines are rows keyed by column name, so it can be restored into any driver.
+func DumpAllData(dataConf *data.Database, dumpDataPath string, tables []interface{}) (archivePath string, err error) {
 	db, err := data.NewDB(false, dataConf)
 	if err != nil {
-		return err
+		return "", err
 	}
 	defer db.Close()
 	if err = db.Ping(); err != nil {
+		return "", err
+	}
+
+	currentVersion := &entity.Version{ID: 1}
+	exist, err := db.Get(currentVersion)
+	if err != nil {
+		return "", fmt.Errorf("get db version failed: %v", err)
+	}
+	if !exist {
+		return "", fmt.Errorf("db version not found, the database is not installed")
+	}
+
+	tempDir, err := os.MkdirTemp("", "answer_dump_")
+	if err != nil {
+		return "", err
+	}
+	defer os.RemoveAll(tempDir)
+
+	manifest := &DumpManifest{
+		FormatVersion: DumpFormatVersion,
+		DBVersion:     currentVersion.VersionNumber,
+		Driver:        string(db.Dialect().URI().DBType),
+		CreatedAt:     time.Now().UTC(),
+	}
+	for _, bean := range tables {
+		table, err := dumpTable(db, bean, tempDir)
+		if err != nil {
+			return "", err
+		}
+		manifest.Tables = append(manifest.Tables, table)
+	}
+
+	if err = dir.CreateDirIfNotExist(dumpDataPath); err != nil {
+		return "", err
+	}
+	archivePath = filepath.Join(dumpDataPath, fmt.Sprintf("answer_dump_%s.tar.gz", time.Now().Format("20060102150405")))
+	if err = writeDumpArchive(archivePath, manifest, tempDir); err != nil {
+		_ = os.Remove(archivePath)
+		return "", err
+	}
+	return archivePath, nil
+}
+
+// dumpTable writes all rows of the table into a JSONL file in the dir
+func dumpTable(x *xorm.Engine, bean interface{}, dir string) (*DumpTable, error) {
+	table, err := x.TableInfo(bean)
+	if err != nil {
+		return nil, err
+	}
+	file, err := os.Create(filepath.Join(dir, table.Name+".jsonl"))
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	writer := bufio.NewWriter(file)
+	encoder := json.NewEncoder(writer)
+	encoder.SetEscapeHTML(false)
+	dumpTable := &DumpTable{Name: table.Name}
+	err = walkTableRows(x, bean, tableBatchSize, func(rows reflect.Value) error {
+		for i := 0; i < rows.Len(); i++ {
+			if err := encoder.Encode(rowToColumnMap(table, rows.Index(i))); err != nil {
+				return err
+			}
+			dumpTable.Rows++
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return dumpTable, writer.Flush()
+}
+
+// rowToColumnMap converts the row struct to a map keyed by column name
+func rowToColumnMap(table *schemas.Table, row reflect.Value) map[string]interface{} {
+	row = reflect.Indirect(row)
+	columns := make(map[string]interface{}, len(table.Columns()))
+	for _, col := range table.Columns() {
+		columns[col.Name] = row.FieldByIndex(col.FieldIndex).Interface()
+	}
+	return columns
+}
+
+func writeDumpArchive(archivePath string, manifest *DumpManifest, tableDataDir string) (err error) {
+	file, err := os.Create(archivePath)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	gzipWriter := gzip.NewWriter(file)
+	tarWriter := tar.NewWriter(gzipWriter)
+
+	manifestContent, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err = writeArchiveContent(tarWriter, dumpManifestName, manifestContent); err != nil {
+		return err
+	}
+	for _, table := range manifest.Tables {
+		err = writeArchiveFile(tarWriter, path.Join(dumpDataDir, table.Name+".jsonl"),
+			filepath.Join(tableDataDir, table.Name+".jsonl"))
+		if err != nil {
+			return err
+		}
+	}
+	if err = writeArchiveDir(tarWriter, dumpUploadsDir, UploadFilePath); err != nil {
+		return err
+	}
+	if err = writeArchiveFile(tarWriter, path.Join(dumpConfigDir, DefaultConfigFileName), GetConfigFilePath()); err != nil {
+		return err
+	}
+
+	if err = tarWriter.Close(); err != nil {
+		return err
+	}
+	if err = gzipWriter.Close(); err != nil {
+		return err
+	}
+	return file.Close()
+}
+
+func writeArchiveContent(tarWriter *tar.Writer, name string, content []byte) error {
+	header := &tar.Header{Name: name, Mode: 0o644, Size: int64(len(content)), ModTime: time.Now()}
+	if err := tarWriter.WriteHeader(header); err != nil {
+		return err
+	}
+	_, err := tarWriter.Write(content)
+	return err
+}
+
+func writeArchiveFile(tarWriter *tar.Writer, name, filePath string) error {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	info, err := file.Stat()
+	if err != nil {
+		return err
+	}
+	header, err := tar.FileInfoHeader(info, "")
+	if err != nil {
 		return err
 	}
+	header.Name = name
+	if err = tarWriter.WriteHeader(header); err != nil {
+		return err
+	}
+	_, err = io.Copy(tarWriter, file)
+	return err
+}
 
-	name := filepath.Join(dumpDataPath, fmt.Sprintf("answer_dump_data_%s.sql", time.Now().Format("2006-01-02")))
-	return db.DumpAllToFile(name, schemas.MYSQL)
+// writeArchiveDir writes all regular files in the dir into archive with the prefix, do nothing if the dir does not exist
+func writeArchiveDir(tarWriter *tar.Writer, prefix, dirPath string) error {
+	if !dir.CheckDirExist(dirPath) {
+		return nil
+	}
+	return filepath.Walk(dirPath, func(filePath string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if !info.Mode().IsRegular() {
+			return nil
+		}
+		relPath, err := filepath.Rel(dirPath, filePath)
+		if err != nil {
+			return err
+		}
+		return writeArchiveFile(tarWriter, path.Join(prefix, filepath.ToSlash(relPath)), filePath)
+	})
 }
@@ -0,0 +1,147 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package cli
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/apache/incubator-answer/internal/base/data"
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"xorm.io/xorm"
+)
+
+const testDBVersion = 38
+
+var testTables = []interface{}{&entity.Version{}, &entity.Config{}, &entity.Tag{}}
+
+func syncTestTables(x *xorm.Engine) error {
+	return x.Sync(testTables...)
+}
+
+// newTestSourceDB creates a sqlite database with some rows, the ids are not continuous so that they can be checked
+func newTestSourceDB(t *testing.T) *data.Database {
+	conf := &data.Database{Driver: "sqlite3", Connection: filepath.Join(t.TempDir(), "source.db")}
+	db, err := data.NewDB(false, conf)
+	require.NoError(t, err)
+	defer db.Close()
+	require.NoError(t, syncTestTables(db))
+	_, err = db.Insert(
+		&entity.Version{ID: 1, VersionNumber: testDBVersion},
+		&entity.Config{ID: 3, Key: "question.asked", Value: "0"},
+		&entity.Config{ID: 7, Key: "answer.accepted", Value: "15"},
+		&entity.Tag{ID: "10010000000000001", SlugName: "go", DisplayName: "Go", OriginalText: "the **go**", ParsedText: "<p>the <strong>go</strong></p>"},
+		&entity.Tag{ID: "10010000000000005", SlugName: "sql", DisplayName: "SQL", OriginalText: "", ParsedText: ""},
+	)
+	require.NoError(t, err)
+	return conf
+}
+
+func newTestDataDir(t *testing.T) {
+	dataDir := t.TempDir()
+	ConfigFileDir = filepath.Join(dataDir, "conf")
+	UploadFilePath = filepath.Join(dataDir, "uploads")
+	require.NoError(t, os.MkdirAll(ConfigFileDir, 0o755))
+	require.NoError(t, os.MkdirAll(filepath.Join(UploadFilePath, "post"), 0o755))
+	require.NoError(t, os.WriteFile(GetConfigFilePath(), []byte("server: {}\n"), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(UploadFilePath, "post", "a.png"), []byte("png"), 0o644))
+}
+
+func TestDumpAndRestore(t *testing.T) {
+	newTestDataDir(t)
+	sourceConf := newTestSourceDB(t)
+	archivePath, err := DumpAllData(sourceConf, t.TempDir(), testTables)
+	require.NoError(t, err)
+
+	// the archive of another db version is refused
+	targetConf := &data.Database{Driver: "sqlite3", Connection: filepath.Join(t.TempDir(), "target.db")}
+	err = RestoreAllData(targetConf, archivePath, testTables, testDBVersion+1, syncTestTables)
+	assert.ErrorContains(t, err, "does not match")
+
+	// restore to a new site
+	newTestDataDir(t)
+	require.NoError(t, RestoreAllData(targetConf, archivePath, testTables, testDBVersion, syncTestTables))
+	source, err := data.NewDB(false, sourceConf)
+	require.NoError(t, err)
+	defer source.Close()
+	target, err := data.NewDB(false, targetConf)
+	require.NoError(t, err)
+	defer target.Close()
+	require.NoError(t, verifyRowCounts(source, target, testTables))
+
+	version := &entity.Version{ID: 1}
+	_, err = target.Get(version)
+	require.NoError(t, err)
+	assert.Equal(t, int64(testDBVersion), version.VersionNumber)
+	tag := &entity.Tag{ID: "10010000000000001"}
+	exist, err := target.Get(tag)
+	require.NoError(t, err)
+	assert.True(t, exist)
+	assert.Equal(t, "<p>the <strong>go</strong></p>", tag.ParsedText)
+
+	upload, err := os.ReadFile(filepath.Join(UploadFilePath, "post", "a.png"))
+	require.NoError(t, err)
+	assert.Equal(t, "png", string(upload))
+	assert.FileExists(t, filepath.Join(ConfigFileDir, RestoredConfigFileName))
+
+	// the database is not empty now
+	err = RestoreAllData(targetConf, archivePath, testTables, testDBVersion, syncTestTables)
+	assert.ErrorContains(t, err, "not empty")
+}
+
+func TestRestoreRejectsPathTraversal(t *testing.T) {
+	newTestDataDir(t)
+	archivePath := filepath.Join(t.TempDir(), "evil.tar.gz")
+	file, err := os.Create(archivePath)
+	require.NoError(t, err)
+	gzipWriter := gzip.NewWriter(file)
+	tarWriter := tar.NewWriter(gzipWriter)
+	require.NoError(t, writeArchiveContent(tarWriter, dumpManifestName,
+		[]byte(`{"format_version":1,"db_version":38,"tables":[]}`)))
+	require.NoError(t, writeArchiveContent(tarWriter, "uploads/../../evil.txt", []byte("evil")))
+	require.NoError(t, tarWriter.Close())
+	require.NoError(t, gzipWriter.Close())
+	require.NoError(t, file.Close())
+
+	targetConf := &data.Database{Driver: "sqlite3", Connection: filepath.Join(t.TempDir(), "target.db")}
+	err = RestoreAllData(targetConf, archivePath, testTables, testDBVersion, syncTestTables)
+	assert.ErrorContains(t, err, "invalid file path")
+	assert.NoFileExists(t, filepath.Join(filepath.Dir(UploadFilePath), "evil.txt"))
+}
+
+func TestSafeJoin(t *testing.T) {
+	base := filepath.Join(os.TempDir(), "uploads")
+	target, err := safeJoin(base, "post/a.png")
+	assert.NoError(t, err)
+	assert.Equal(t, filepath.Join(base, "post", "a.png"), target)
+	for _, name := range []string{"../a.png", "post/../../a.png", ".."} {
+		_, err = safeJoin(base, name)
+		assert.Error(t, err, name)
+	}
+	// the absolute path is joined under the base dir
+	target, err = safeJoin(base, "/etc/passwd")
+	assert.NoError(t, err)
+	assert.Equal(t, filepath.Join(base, "etc", "passwd"), target)
+}
@@ -0,0 +1,104 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package cli
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/apache/incubator-answer/internal/base/data"
+	"github.com/apache/incubator-answer/internal/entity"
+	"xorm.io/xorm"
+)
+
+// MigrateDatabase copies all the tables from the source database to an empty target database.
+// The IDs, snowflake uniqid and sequences are kept, so the site can run on the target database directly.
+func MigrateDatabase(fromConf, toConf *data.Database, tables []interface{},
+	expectedDBVersion int64, initSchema func(x *xorm.Engine) error) (err error) {
+	source, err := data.NewDB(false, fromConf)
+	if err != nil {
+		return fmt.Errorf("connect source database failed: %v", err)
+	}
+	defer source.Close()
+	target, err := data.NewDB(false, toConf)
+	if err != nil {
+		return fmt.Errorf("connect target database failed: %v", err)
+	}
+	defer target.Close()
+
+	currentVersion := &entity.Version{ID: 1}
+	exist, err := source.Get(currentVersion)
+	if err != nil {
+		return fmt.Errorf("get source db version failed: %v", err)
+	}
+	if !exist {
+		return fmt.Errorf("db version not found, the source database is not installed")
+	}
+	if currentVersion.VersionNumber != expectedDBVersion {
+		return fmt.Errorf("source db version %d does not match the expected db version %d, please upgrade it first",
+			currentVersion.VersionNumber, expectedDBVersion)
+	}
+
+	if err = checkDatabaseEmpty(target, tables); err != nil {
+		return err
+	}
+	if err = initSchema(target); err != nil {
+		return err
+	}
+
+	for _, bean := range tables {
+		tableName := source.TableName(bean)
+		var copied int64
+		err = walkTableRows(source, bean, tableBatchSize, func(rows reflect.Value) error {
+			if err := insertTableRows(target, rows); err != nil {
+				return err
+			}
+			copied += int64(rows.Len())
+			return nil
+		})
+		if err != nil {
+			return fmt.Errorf("copy table %s failed: %v", tableName, err)
+		}
+		fmt.Printf("[migrate-db] table %s: %d rows\n", tableName, copied)
+	}
+	if err = resetSequences(target, tables); err != nil {
+		return err
+	}
+	return verifyRowCounts(source, target, tables)
+}
+
+// verifyRowCounts checks every table has the same row count in both databases
+func verifyRowCounts(source, target *xorm.Engine, tables []interface{}) error {
+	for _, bean := range tables {
+		sourceCount, err := source.Count(newBean(bean).Interface())
+		if err != nil {
+			return err
+		}
+		targetCount, err := target.Count(newBean(bean).Interface())
+		if err != nil {
+			return err
+		}
+		if sourceCount != targetCount {
+			return fmt.Errorf("table %s has %d rows in source database, but %d rows in target database",
+				source.TableName(bean), sourceCount, targetCount)
+		}
+	}
+	return nil
+}
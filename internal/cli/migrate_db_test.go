@@ -0,0 +1,86 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package cli
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/apache/incubator-answer/internal/base/data"
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMigrateDatabase(t *testing.T) {
+	sourceConf := newTestSourceDB(t)
+	targetConf := &data.Database{Driver: "sqlite3", Connection: filepath.Join(t.TempDir(), "target.db")}
+
+	err := MigrateDatabase(sourceConf, targetConf, testTables, testDBVersion+1, syncTestTables)
+	assert.ErrorContains(t, err, "please upgrade it first")
+
+	require.NoError(t, MigrateDatabase(sourceConf, targetConf, testTables, testDBVersion, syncTestTables))
+	target, err := data.NewDB(false, targetConf)
+	require.NoError(t, err)
+	defer target.Close()
+
+	// the ids are preserved
+	configs := make([]*entity.Config, 0)
+	require.NoError(t, target.Asc("id").Find(&configs))
+	require.Len(t, configs, 2)
+	assert.Equal(t, 3, configs[0].ID)
+	assert.Equal(t, "question.asked", configs[0].Key)
+	assert.Equal(t, 7, configs[1].ID)
+	tag := &entity.Tag{ID: "10010000000000005"}
+	exist, err := target.Get(tag)
+	require.NoError(t, err)
+	assert.True(t, exist)
+	assert.Equal(t, "sql", tag.SlugName)
+
+	// the new rows continue after the copied ids
+	newConfig := &entity.Config{Key: "answer.voted_up", Value: "10"}
+	_, err = target.Insert(newConfig)
+	require.NoError(t, err)
+	assert.Greater(t, newConfig.ID, 7)
+
+	// the target is not empty any more
+	err = MigrateDatabase(sourceConf, targetConf, testTables, testDBVersion, syncTestTables)
+	assert.ErrorContains(t, err, "not empty")
+}
+
+func TestVerifyRowCounts(t *testing.T) {
+	sourceConf := newTestSourceDB(t)
+	source, err := data.NewDB(false, sourceConf)
+	require.NoError(t, err)
+	defer source.Close()
+	target, err := data.NewDB(false, &data.Database{Driver: "sqlite3", Connection: filepath.Join(t.TempDir(), "target.db")})
+	require.NoError(t, err)
+	defer target.Close()
+	require.NoError(t, syncTestTables(target))
+
+	err = verifyRowCounts(source, target, testTables)
+	assert.ErrorContains(t, err, "has 1 rows in source database, but 0 rows in target database")
+
+	_, err = target.Insert(&entity.Version{ID: 1, VersionNumber: testDBVersion},
+		&entity.Config{ID: 3, Key: "a"}, &entity.Config{ID: 7, Key: "b"},
+		&entity.Tag{ID: "1", SlugName: "a"}, &entity.Tag{ID: "2", SlugName: "b"})
+	require.NoError(t, err)
+	assert.NoError(t, verifyRowCounts(source, target, testTables))
+}
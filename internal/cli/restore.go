@@ -0,0 +1,235 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package cli
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"reflect"
+	"strings"
+
+	"github.com/apache/incubator-answer/internal/base/data"
+	"github.com/apache/incubator-answer/pkg/dir"
+	"xorm.io/xorm"
+	"xorm.io/xorm/schemas"
+)
+
+// RestoredConfigFileName the config file in dump archive is restored as this name,
+// so that the config of the current site will not be overwritten.
+const RestoredConfigFileName = "config.restore.yaml"
+
+// RestoreAllData restore the dump archive into an empty database and the upload directory.
+// The db version of the archive must be the same as the expected version of the current binary,
+// initSchema is used to create all tables before inserting rows.
+func RestoreAllData(dataConf *data.Database, archivePath string, tables []interface{},
+	expectedDBVersion int64, initSchema func(x *xorm.Engine) error) (err error) {
+	file, err := os.Open(archivePath)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	gzipReader, err := gzip.NewReader(file)
+	if err != nil {
+		return fmt.Errorf("read archive failed: %v", err)
+	}
+	defer gzipReader.Close()
+	tarReader := tar.NewReader(gzipReader)
+
+	manifest, err := readDumpManifest(tarReader)
+	if err != nil {
+		return err
+	}
+	if manifest.FormatVersion > DumpFormatVersion {
+		return fmt.Errorf("archive format version %d is not supported, please upgrade answer", manifest.FormatVersion)
+	}
+	if manifest.DBVersion != expectedDBVersion {
+		return fmt.Errorf("archive db version %d does not match the expected db version %d, "+
+			"please restore it with the answer version which created it and then upgrade",
+			manifest.DBVersion, expectedDBVersion)
+	}
+
+	db, err := data.NewDB(false, dataConf)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+	if err = db.Ping(); err != nil {
+		return err
+	}
+	if err = checkDatabaseEmpty(db, tables); err != nil {
+		return err
+	}
+	if err = initSchema(db); err != nil {
+		return err
+	}
+
+	beans := make(map[string]interface{}, len(tables))
+	for _, bean := range tables {
+		beans[db.TableName(bean)] = bean
+	}
+	restoredRows := make(map[string]int64, len(manifest.Tables))
+	for {
+		header, err := tarReader.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("read archive failed: %v", err)
+		}
+		if header.Typeflag != tar.TypeReg {
+			continue
+		}
+		switch {
+		case strings.HasPrefix(header.Name, dumpDataDir+"/"):
+			tableName := strings.TrimSuffix(path.Base(header.Name), ".jsonl")
+			bean, ok := beans[tableName]
+			if !ok {
+				fmt.Printf("[restore] skip unknown table %s\n", tableName)
+				continue
+			}
+			rows, err := restoreTable(db, bean, tarReader)
+			if err != nil {
+				return fmt.Errorf("restore table %s failed: %v", tableName, err)
+			}
+			restoredRows[tableName] = rows
+			fmt.Printf("[restore] table %s: %d rows\n", tableName, rows)
+		case strings.HasPrefix(header.Name, dumpUploadsDir+"/"):
+			target, err := safeJoin(UploadFilePath, strings.TrimPrefix(header.Name, dumpUploadsDir+"/"))
+			if err != nil {
+				return err
+			}
+			if err = extractArchiveFile(tarReader, target, header.FileInfo().Mode()); err != nil {
+				return err
+			}
+		case header.Name == path.Join(dumpConfigDir, DefaultConfigFileName):
+			target := filepath.Join(ConfigFileDir, RestoredConfigFileName)
+			if err = extractArchiveFile(tarReader, target, 0o644); err != nil {
+				return err
+			}
+			fmt.Printf("[restore] config file is restored to %s, please review it before using\n", target)
+		}
+	}
+
+	for _, table := range manifest.Tables {
+		if _, ok := beans[table.Name]; !ok {
+			continue
+		}
+		if restoredRows[table.Name] != table.Rows {
+			return fmt.Errorf("table %s restored %d rows, but %d rows are expected",
+				table.Name, restoredRows[table.Name], table.Rows)
+		}
+	}
+	return resetSequences(db, tables)
+}
+
+func readDumpManifest(tarReader *tar.Reader) (*DumpManifest, error) {
+	header, err := tarReader.Next()
+	if err != nil {
+		return nil, fmt.Errorf("read archive failed: %v", err)
+	}
+	if header.Name != dumpManifestName {
+		return nil, fmt.Errorf("%s not found at the beginning of archive", dumpManifestName)
+	}
+	manifest := &DumpManifest{}
+	if err = json.NewDecoder(tarReader).Decode(manifest); err != nil {
+		return nil, fmt.Errorf("parse %s failed: %v", dumpManifestName, err)
+	}
+	return manifest, nil
+}
+
+// restoreTable inserts all rows in JSONL reader into the table of bean
+func restoreTable(x *xorm.Engine, bean interface{}, reader io.Reader) (count int64, err error) {
+	table, err := x.TableInfo(bean)
+	if err != nil {
+		return 0, err
+	}
+	decoder := json.NewDecoder(reader)
+	rows := newBeanSlice(bean).Elem()
+	for decoder.More() {
+		columns := make(map[string]json.RawMessage)
+		if err = decoder.Decode(&columns); err != nil {
+			return count, err
+		}
+		row, err := columnMapToRow(table, bean, columns)
+		if err != nil {
+			return count, err
+		}
+		rows = reflect.Append(rows, row)
+		if rows.Len() >= tableBatchSize {
+			if err = insertTableRows(x, rows); err != nil {
+				return count, err
+			}
+			count += int64(rows.Len())
+			rows = rows.Slice(0, 0)
+		}
+	}
+	if err = insertTableRows(x, rows); err != nil {
+		return count, err
+	}
+	return count + int64(rows.Len()), nil
+}
+
+// columnMapToRow converts the map keyed by column name to a new row of bean,
+// the columns which are not in the map keep the zero value.
+func columnMapToRow(table *schemas.Table, bean interface{}, columns map[string]json.RawMessage) (reflect.Value, error) {
+	row := newBean(bean)
+	for _, col := range table.Columns() {
+		value, ok := columns[col.Name]
+		if !ok || string(value) == "null" {
+			continue
+		}
+		field := row.Elem().FieldByIndex(col.FieldIndex)
+		if err := json.Unmarshal(value, field.Addr().Interface()); err != nil {
+			return row, fmt.Errorf("parse column %s failed: %v", col.Name, err)
+		}
+	}
+	return row, nil
+}
+
+// safeJoin joins the name to the base dir and makes sure the result is still in the base dir
+func safeJoin(baseDir, name string) (string, error) {
+	target := filepath.Join(baseDir, filepath.FromSlash(name))
+	relPath, err := filepath.Rel(baseDir, target)
+	if err != nil || relPath == ".." || strings.HasPrefix(relPath, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("invalid file path in archive: %s", name)
+	}
+	return target, nil
+}
+
+func extractArchiveFile(reader io.Reader, target string, mode os.FileMode) error {
+	if err := dir.CreateDirIfNotExist(filepath.Dir(target)); err != nil {
+		return err
+	}
+	file, err := os.OpenFile(target, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, mode.Perm())
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	if _, err = io.Copy(file, reader); err != nil {
+		return err
+	}
+	return file.Close()
+}
@@ -0,0 +1,124 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package cli
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"xorm.io/xorm"
+	"xorm.io/xorm/schemas"
+)
+
+// tableBatchSize is the number of rows read or written in one batch when copying table data
+const tableBatchSize = 500
+
+// newBeanSlice returns a pointer to an empty slice whose element type is the same as bean
+func newBeanSlice(bean interface{}) reflect.Value {
+	sliceType := reflect.SliceOf(reflect.TypeOf(bean))
+	slice := reflect.New(sliceType)
+	slice.Elem().Set(reflect.MakeSlice(sliceType, 0, tableBatchSize))
+	return slice
+}
+
+// newBean returns a new zero value pointer of bean
+func newBean(bean interface{}) reflect.Value {
+	return reflect.New(reflect.TypeOf(bean).Elem())
+}
+
+// walkTableRows reads all rows of the table of bean in primary key order,
+// fn is called with a slice of rows for each batch.
+func walkTableRows(x *xorm.Engine, bean interface{}, batchSize int, fn func(rows reflect.Value) error) error {
+	table, err := x.TableInfo(bean)
+	if err != nil {
+		return err
+	}
+	for offset := 0; ; offset += batchSize {
+		rows := newBeanSlice(bean)
+		session := x.Table(table.Name)
+		for _, pk := range table.PrimaryKeys {
+			session.Asc(pk)
+		}
+		err = session.Limit(batchSize, offset).Find(rows.Interface())
+		if err != nil {
+			return fmt.Errorf("read table %s failed: %v", table.Name, err)
+		}
+		if rows.Elem().Len() == 0 {
+			return nil
+		}
+		if err = fn(rows.Elem()); err != nil {
+			return err
+		}
+		if rows.Elem().Len() < batchSize {
+			return nil
+		}
+	}
+}
+
+// insertTableRows inserts rows as they are, the created and updated time will not be changed.
+func insertTableRows(x *xorm.Engine, rows reflect.Value) error {
+	if rows.Len() == 0 {
+		return nil
+	}
+	_, err := x.NoAutoTime().Insert(rows.Interface())
+	return err
+}
+
+// resetSequences makes the sequences of auto increment columns continue from the max value in tables.
+// Only PostgreSQL needs it, MySQL and SQLite compute the next value from the table data.
+func resetSequences(x *xorm.Engine, beans []interface{}) error {
+	if x.Dialect().URI().DBType != schemas.POSTGRES {
+		return nil
+	}
+	for _, bean := range beans {
+		table, err := x.TableInfo(bean)
+		if err != nil {
+			return err
+		}
+		if len(table.AutoIncrement) == 0 {
+			continue
+		}
+		sql := fmt.Sprintf(`SELECT setval(pg_get_serial_sequence('"%s"', '%s'), COALESCE(MAX("%s"), 0) + 1, false) FROM "%s"`,
+			table.Name, table.AutoIncrement, table.AutoIncrement, table.Name)
+		if _, err = x.Exec(sql); err != nil {
+			return fmt.Errorf("reset sequence of table %s failed: %v", table.Name, err)
+		}
+	}
+	return nil
+}
+
+// checkDatabaseEmpty returns an error if any table of beans already exists in database
+func checkDatabaseEmpty(x *xorm.Engine, beans []interface{}) error {
+	var existed []string
+	for _, bean := range beans {
+		exist, err := x.IsTableExist(bean)
+		if err != nil {
+			return err
+		}
+		if exist {
+			existed = append(existed, x.TableName(bean))
+		}
+	}
+	if len(existed) > 0 {
+		return fmt.Errorf("target database is not empty, tables already exist: %s", strings.Join(existed, ", "))
+	}
+	return nil
+}
@@ -0,0 +1,101 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+package controller
+
+import (
+	"github.com/apache/incubator-answer/internal/base/handler"
+	"github.com/apache/incubator-answer/internal/base/middleware"
+	"github.com/apache/incubator-answer/internal/base/reason"
+	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/apache/incubator-answer/internal/service/api_token"
+	"github.com/gin-gonic/gin"
+	"github.com/segmentfault/pacman/errors"
+)
+
+// APITokenController api token controller
+type APITokenController struct {
+	apiTokenService *api_token.APITokenService
+}
+
+// NewAPITokenController new controller
+func NewAPITokenController(apiTokenService *api_token.APITokenService) *APITokenController {
+	return &APITokenController{apiTokenService: apiTokenService}
+}
+
+// GetAPITokenList get api tokens of current user
+// @Summary get api tokens of current user
+// @Description get api tokens of current user, the plain token is not returned
+// @Tags User
+// @Security ApiKeyAuth
+// @Produce json
+// @Success 200 {object} handler.RespBody{data=[]schema.GetAPITokenResp}
+// @Router /answer/api/v1/user/api-tokens [get]
+func (ac *APITokenController) GetAPITokenList(ctx *gin.Context) {
+	userID := middleware.GetLoginUserIDFromContext(ctx)
+	resp, err := ac.apiTokenService.GetAPITokenList(ctx, userID)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// CreateAPIToken create api token
+// @Summary create api token
+// @Description create api token, the plain token is only returned once
+// @Tags User
+// @Security ApiKeyAuth
+// @Accept json
+// @Produce json
+// @Param data body schema.CreateAPITokenReq true "api token"
+// @Success 200 {object} handler.RespBody{data=schema.CreateAPITokenResp}
+// @Router /answer/api/v1/user/api-token [post]
+func (ac *APITokenController) CreateAPIToken(ctx *gin.Context) {
+	// api tokens can not be used to create other api tokens
+	if middleware.IsAPITokenAuth(ctx) {
+		handler.HandleResponse(ctx, errors.Forbidden(reason.APITokenScopeNotAllowed), nil)
+		return
+	}
+	req := &schema.CreateAPITokenReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	req.UserID = middleware.GetLoginUserIDFromContext(ctx)
+	req.IsAdmin = middleware.GetIsAdminFromContext(ctx)
+
+	resp, err := ac.apiTokenService.CreateAPIToken(ctx, req)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// RevokeAPIToken revoke api token
+// @Summary revoke api token
+// @Description revoke api token
+// @Tags User
+// @Security ApiKeyAuth
+// @Accept json
+// @Produce json
+// @Param data body schema.RevokeAPITokenReq true "api token"
+// @Success 200 {object} handler.RespBody
+// @Router /answer/api/v1/user/api-token [delete]
+func (ac *APITokenController) RevokeAPIToken(ctx *gin.Context) {
+	req := &schema.RevokeAPITokenReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	req.UserID = middleware.GetLoginUserIDFromContext(ctx)
+
+	err := ac.apiTokenService.RevokeAPIToken(ctx, req)
+	handler.HandleResponse(ctx, err, nil)
+}
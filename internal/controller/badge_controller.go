@@ -0,0 +1,48 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package controller
+
+import (
+	"github.com/apache/incubator-answer/internal/base/handler"
+	"github.com/apache/incubator-answer/internal/service/badge"
+	"github.com/gin-gonic/gin"
+)
+
+// BadgeController badge controller
+type BadgeController struct {
+	badgeService *badge.BadgeService
+}
+
+// NewBadgeController new controller
+func NewBadgeController(badgeService *badge.BadgeService) *BadgeController {
+	return &BadgeController{badgeService: badgeService}
+}
+
+// GetBadgeList get all badges
+// @Summary get all badges
+// @Description get all badges, including the badges provided by the enabled plugins
+// @Tags Badge
+// @Produce json
+// @Success 200 {object} handler.RespBody{data=[]schema.BadgeInfo}
+// @Router /answer/api/v1/badges [get]
+func (bc *BadgeController) GetBadgeList(ctx *gin.Context) {
+	resp, err := bc.badgeService.GetBadgeList(ctx)
+	handler.HandleResponse(ctx, err, resp)
+}
@@ -0,0 +1,98 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package controller
+
+import (
+	"github.com/apache/incubator-answer/internal/base/handler"
+	"github.com/apache/incubator-answer/internal/base/middleware"
+	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/apache/incubator-answer/internal/service/bounty"
+	"github.com/gin-gonic/gin"
+)
+
+// BountyController question bounty controller
+type BountyController struct {
+	bountyService *bounty.BountyService
+}
+
+// NewBountyController new controller
+func NewBountyController(bountyService *bounty.BountyService) *BountyController {
+	return &BountyController{bountyService: bountyService}
+}
+
+// GetQuestionBounty get question bounty
+// @Summary get the active bounty and the bounty history of the question
+// @Description get the active bounty and the bounty history of the question
+// @Tags Bounty
+// @Produce json
+// @Param question_id query string true "question id"
+// @Success 200 {object} handler.RespBody{data=schema.GetQuestionBountyResp}
+// @Router /answer/api/v1/question/bounty [get]
+func (bc *BountyController) GetQuestionBounty(ctx *gin.Context) {
+	req := &schema.GetQuestionBountyReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+
+	resp, err := bc.bountyService.GetQuestionBounty(ctx, req)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// OfferBounty offer bounty
+// @Summary offer a bounty on the question
+// @Description the amount is deducted from the user's reputation, the bounty expires after 7 days
+// @Tags Bounty
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param data body schema.OfferBountyReq true "bounty"
+// @Success 200 {object} handler.RespBody
+// @Router /answer/api/v1/question/bounty [post]
+func (bc *BountyController) OfferBounty(ctx *gin.Context) {
+	req := &schema.OfferBountyReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	req.UserID = middleware.GetLoginUserIDFromContext(ctx)
+
+	err := bc.bountyService.OfferBounty(ctx, req)
+	handler.HandleResponse(ctx, err, nil)
+}
+
+// AwardBounty award bounty
+// @Summary award the active bounty of the question to an answer
+// @Description only the user who offered the bounty can award it
+// @Tags Bounty
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param data body schema.AwardBountyReq true "award"
+// @Success 200 {object} handler.RespBody
+// @Router /answer/api/v1/question/bounty/award [put]
+func (bc *BountyController) AwardBounty(ctx *gin.Context) {
+	req := &schema.AwardBountyReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	req.UserID = middleware.GetLoginUserIDFromContext(ctx)
+
+	err := bc.bountyService.AwardBounty(ctx, req)
+	handler.HandleResponse(ctx, err, nil)
+}
@@ -51,4 +51,9 @@ var ProviderSetController = wire.NewSet(
 	NewCaptchaController,
 	NewMetaController,
 	NewEmbedController,
+	NewAPITokenController,
+	NewUserDataController,
+	NewInboundMailController,
+	NewBountyController,
+	NewBadgeController,
 )
@@ -0,0 +1,62 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package controller
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/apache/incubator-answer/internal/base/handler"
+	"github.com/apache/incubator-answer/internal/base/reason"
+	"github.com/apache/incubator-answer/internal/service/inbound_mail"
+	"github.com/gin-gonic/gin"
+	"github.com/segmentfault/pacman/errors"
+)
+
+// InboundMailController inbound mail controller
+type InboundMailController struct {
+	inboundMailService *inbound_mail.InboundMailService
+}
+
+// NewInboundMailController new controller
+func NewInboundMailController(inboundMailService *inbound_mail.InboundMailService) *InboundMailController {
+	return &InboundMailController{inboundMailService: inboundMailService}
+}
+
+// ReceiveMail receive the raw mail piped from the MTA and post the reply
+// @Summary receive the raw mail piped from the MTA
+// @Description the reply to the notification email is posted as answer or comment, the request must have the
+// @Description inbound mail secret as bearer token, the body is the raw RFC 822 message
+// @Tags InboundMail
+// @Accept message/rfc822
+// @Produce json
+// @Success 200 {object} handler.RespBody
+// @Router /answer/api/v1/inbound/mail [post]
+func (ic *InboundMailController) ReceiveMail(ctx *gin.Context) {
+	secret := strings.TrimPrefix(ctx.GetHeader("Authorization"), "Bearer ")
+	if !ic.inboundMailService.VerifySecret(secret) {
+		handler.HandleResponse(ctx, errors.Unauthorized(reason.UnauthorizedError), nil)
+		return
+	}
+
+	ctx.Request.Body = http.MaxBytesReader(ctx.Writer, ctx.Request.Body, ic.inboundMailService.MaxMessageSize())
+	err := ic.inboundMailService.HandleMessage(ctx, ctx.Request.Body)
+	handler.HandleResponse(ctx, err, nil)
+}
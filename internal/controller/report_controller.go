@@ -154,3 +154,85 @@ func (rc *ReportController) ReviewReport(ctx *gin.Context) {
 	err := rc.reportService.ReviewReport(ctx, req)
 	handler.HandleResponse(ctx, err, nil)
 }
+
+// GetReportCasePage get report case page
+// @Summary get the queue of the pending flags grouped by post
+// @Description get the queue of the pending flags grouped by post, with the aging of each case
+// @Tags Report
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param page query int false "page"
+// @Param page_size query int false "page size"
+// @Param sort query string false "sort" Enums(newest, oldest, most_reported)
+// @Param assignee query string false "empty means all, me, unassigned or the user id"
+// @Success 200 {object} handler.RespBody{data=pager.PageModel{list=[]schema.GetReportListPageResp}}
+// @Router /answer/api/v1/report/case/page [get]
+func (rc *ReportController) GetReportCasePage(ctx *gin.Context) {
+	req := &schema.GetReportCasePageReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	if !middleware.GetUserIsAdminModerator(ctx) {
+		handler.HandleResponse(ctx, errors.Forbidden(reason.ForbiddenError), nil)
+		return
+	}
+	req.UserID = middleware.GetLoginUserIDFromContext(ctx)
+
+	resp, err := rc.reportService.GetReportCasePage(ctx, req)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// AssignReportCase assign report case
+// @Summary assign the pending flags of the post to an admin or moderator
+// @Description assign the pending flags of the post to an admin or moderator, empty assignee means unassign
+// @Tags Report
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param data body schema.AssignReportCaseReq true "assign"
+// @Success 200 {object} handler.RespBody
+// @Router /answer/api/v1/report/case/assign [put]
+func (rc *ReportController) AssignReportCase(ctx *gin.Context) {
+	req := &schema.AssignReportCaseReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	if !middleware.GetUserIsAdminModerator(ctx) {
+		handler.HandleResponse(ctx, errors.Forbidden(reason.ForbiddenError), nil)
+		return
+	}
+	req.ObjectID = uid.DeShortID(req.ObjectID)
+	req.UserID = middleware.GetLoginUserIDFromContext(ctx)
+
+	err := rc.reportService.AssignReportCase(ctx, req)
+	handler.HandleResponse(ctx, err, nil)
+}
+
+// BulkReviewReportCase bulk review report case
+// @Summary approve or ignore the pending flags of the posts in bulk
+// @Description approve or ignore the pending flags of the posts in bulk, the posts are not changed
+// @Tags Report
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param data body schema.BulkReviewReportCaseReq true "review"
+// @Success 200 {object} handler.RespBody{data=schema.BulkReviewReportCaseResp}
+// @Router /answer/api/v1/report/case/review/bulk [put]
+func (rc *ReportController) BulkReviewReportCase(ctx *gin.Context) {
+	req := &schema.BulkReviewReportCaseReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	if !middleware.GetUserIsAdminModerator(ctx) {
+		handler.HandleResponse(ctx, errors.Forbidden(reason.ForbiddenError), nil)
+		return
+	}
+	for i, objectID := range req.ObjectIDs {
+		req.ObjectIDs[i] = uid.DeShortID(objectID)
+	}
+	req.UserID = middleware.GetLoginUserIDFromContext(ctx)
+
+	resp, err := rc.reportService.BulkReviewReportCase(ctx, req)
+	handler.HandleResponse(ctx, err, resp)
+}
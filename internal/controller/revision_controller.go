@@ -192,6 +192,76 @@ func (rc *RevisionController) CheckCanUpdateRevision(ctx *gin.Context) {
 	handler.HandleResponse(ctx, err, resp)
 }
 
+// GetRevisionDiff godoc
+// @Summary get revision diff
+// @Description get the line and word diff of title, tags and content between two revisions of an object
+// @Tags Revision
+// @Produce json
+// @Param object_id query string true "object id"
+// @Param from query string true "old revision id"
+// @Param to query string true "new revision id"
+// @Success 200 {object} handler.RespBody{data=schema.GetRevisionDiffResp}
+// @Router /answer/api/v1/revisions/diff [get]
+func (rc *RevisionController) GetRevisionDiff(ctx *gin.Context) {
+	req := &schema.GetRevisionDiffReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+
+	resp, err := rc.revisionListService.GetRevisionDiff(ctx, req)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// RollbackRevision godoc
+// @Summary rollback revision
+// @Description roll the object back to an old revision, the old content is saved as a new revision
+// @Tags Revision
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param data body schema.RollbackRevisionReq true "rollback"
+// @Success 200 {object} handler.RespBody{data=schema.RollbackRevisionResp}
+// @Router /answer/api/v1/revisions/rollback [put]
+func (rc *RevisionController) RollbackRevision(ctx *gin.Context) {
+	req := &schema.RollbackRevisionReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	req.UserID = middleware.GetLoginUserIDFromContext(ctx)
+
+	// rollback is applied directly, so the user must be able to edit without review
+	var actions []string
+	objectID := uid.DeShortID(req.ObjectID)
+	objectTypeStr, _ := obj.GetObjectTypeStrByObjectID(objectID)
+	switch objectTypeStr {
+	case constant.QuestionObjectType:
+		actions = []string{permission.QuestionEdit, permission.QuestionEditWithoutReview}
+	case constant.AnswerObjectType:
+		actions = []string{permission.AnswerEdit, permission.AnswerEditWithoutReview}
+	case constant.TagObjectType:
+		actions = []string{permission.TagEdit, permission.TagEditWithoutReview}
+	default:
+		handler.HandleResponse(ctx, errors.BadRequest(reason.ObjectNotFound), nil)
+		return
+	}
+	canList, err := rc.rankService.CheckOperationPermissions(ctx, req.UserID, actions)
+	if err != nil {
+		handler.HandleResponse(ctx, err, nil)
+		return
+	}
+	can := canList[0] && canList[1]
+	if objectTypeStr != constant.TagObjectType && rc.rankService.CheckOperationObjectOwner(ctx, req.UserID, objectID) {
+		can = true
+	}
+	if !can {
+		handler.HandleResponse(ctx, errors.Forbidden(reason.RankFailToMeetTheCondition), nil)
+		return
+	}
+
+	resp, err := rc.revisionListService.RollbackRevision(ctx, req)
+	handler.HandleResponse(ctx, err, resp)
+}
+
 // GetReviewingType get reviewing type
 // @Summary get reviewing type
 // @Description get reviewing type
@@ -341,3 +341,109 @@ func (tc *TagController) UpdateTagSynonym(ctx *gin.Context) {
 	err = tc.tagService.UpdateTagSynonym(ctx, req)
 	handler.HandleResponse(ctx, err, nil)
 }
+
+// GetTagTree get tag tree
+// @Summary get the tag trees grouped by tag group
+// @Description get the tag trees grouped by tag group, only the tags which have parent, children or group are included
+// @Tags Tag
+// @Produce json
+// @Success 200 {object} handler.RespBody{data=schema.GetTagTreeResp}
+// @Router /answer/api/v1/tags/tree [get]
+func (tc *TagController) GetTagTree(ctx *gin.Context) {
+	resp, err := tc.tagService.GetTagTree(ctx)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// AdminGetTagGroupList get tag group list
+// @Summary get tag group list
+// @Description get tag group list
+// @Security ApiKeyAuth
+// @Tags admin
+// @Produce json
+// @Success 200 {object} handler.RespBody{data=[]schema.TagGroupResp}
+// @Router /answer/admin/api/tag/groups [get]
+func (tc *TagController) AdminGetTagGroupList(ctx *gin.Context) {
+	resp, err := tc.tagService.GetTagGroupList(ctx)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// AdminAddTagGroup add tag group
+// @Summary add tag group
+// @Description add tag group
+// @Security ApiKeyAuth
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param data body schema.AddTagGroupReq true "tag group"
+// @Success 200 {object} handler.RespBody{data=schema.TagGroupResp}
+// @Router /answer/admin/api/tag/group [post]
+func (tc *TagController) AdminAddTagGroup(ctx *gin.Context) {
+	req := &schema.AddTagGroupReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+
+	resp, err := tc.tagService.AddTagGroup(ctx, req)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// AdminUpdateTagGroup update tag group
+// @Summary update tag group
+// @Description update tag group
+// @Security ApiKeyAuth
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param data body schema.UpdateTagGroupReq true "tag group"
+// @Success 200 {object} handler.RespBody
+// @Router /answer/admin/api/tag/group [put]
+func (tc *TagController) AdminUpdateTagGroup(ctx *gin.Context) {
+	req := &schema.UpdateTagGroupReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+
+	err := tc.tagService.UpdateTagGroup(ctx, req)
+	handler.HandleResponse(ctx, err, nil)
+}
+
+// AdminRemoveTagGroup remove tag group
+// @Summary remove tag group, the tags in this group are kept
+// @Description remove tag group, the tags in this group are kept
+// @Security ApiKeyAuth
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param data body schema.RemoveTagGroupReq true "tag group"
+// @Success 200 {object} handler.RespBody
+// @Router /answer/admin/api/tag/group [delete]
+func (tc *TagController) AdminRemoveTagGroup(ctx *gin.Context) {
+	req := &schema.RemoveTagGroupReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+
+	err := tc.tagService.RemoveTagGroup(ctx, req)
+	handler.HandleResponse(ctx, err, nil)
+}
+
+// AdminUpdateTagStructure update tag structure
+// @Summary move the tag to another parent tag or tag group
+// @Description move the tag to another parent tag or tag group, the change is recorded in the tag revisions
+// @Security ApiKeyAuth
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param data body schema.UpdateTagStructureReq true "tag structure"
+// @Success 200 {object} handler.RespBody
+// @Router /answer/admin/api/tag/structure [put]
+func (tc *TagController) AdminUpdateTagStructure(ctx *gin.Context) {
+	req := &schema.UpdateTagStructureReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	req.UserID = middleware.GetLoginUserIDFromContext(ctx)
+
+	err := tc.tagService.UpdateTagStructure(ctx, req)
+	handler.HandleResponse(ctx, err, nil)
+}
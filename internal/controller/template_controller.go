@@ -39,6 +39,7 @@ import (
 	"github.com/apache/incubator-answer/internal/service/siteinfo_common"
 	"github.com/apache/incubator-answer/pkg/checker"
 	"github.com/apache/incubator-answer/pkg/converter"
+	"github.com/apache/incubator-answer/pkg/feed"
 	"github.com/apache/incubator-answer/pkg/htmltext"
 	"github.com/apache/incubator-answer/pkg/obj"
 	"github.com/apache/incubator-answer/pkg/uid"
@@ -595,6 +596,139 @@ func (tc *TemplateController) SitemapPage(ctx *gin.Context) {
 	}
 }
 
+// QuestionFeed the feed of newest questions
+func (tc *TemplateController) QuestionFeed(ctx *gin.Context) {
+	if tc.checkPrivateMode(ctx) {
+		tc.Page404(ctx)
+		return
+	}
+	req := &schema.QuestionPageReq{
+		Page:      1,
+		PageSize:  constant.FeedMaxSize,
+		OrderCond: schema.QuestionOrderCondNewest,
+	}
+	questionList, _, err := tc.templateRenderController.Index(ctx, req)
+	if err != nil {
+		tc.Page404(ctx)
+		return
+	}
+	siteInfo := tc.SiteInfo(ctx)
+	tc.feed(ctx, &feed.Feed{
+		Title:       fmt.Sprintf("%s - %s", translator.Tr(handler.GetLang(ctx), constant.QuestionsTitleTrKey), siteInfo.General.Name),
+		Description: siteInfo.General.Description,
+		Link:        fmt.Sprintf("%s/questions", siteInfo.General.SiteUrl),
+		SelfLink:    fmt.Sprintf("%s/questions/feed", siteInfo.General.SiteUrl),
+		Items:       templaterender.QuestionListFeedItems(siteInfo, questionList),
+	})
+}
+
+// TagFeed the feed of newest questions with the tag
+func (tc *TemplateController) TagFeed(ctx *gin.Context) {
+	if tc.checkPrivateMode(ctx) {
+		tc.Page404(ctx)
+		return
+	}
+	req := &schema.GetTamplateTagInfoReq{
+		Name:     ctx.Param("tag"),
+		Page:     1,
+		PageSize: constant.FeedMaxSize,
+	}
+	tagInfo, questionList, _, err := tc.templateRenderController.TagInfo(ctx, req)
+	if err != nil {
+		tc.Page404(ctx)
+		return
+	}
+	siteInfo := tc.SiteInfo(ctx)
+	tc.feed(ctx, &feed.Feed{
+		Title: fmt.Sprintf("'%s' %s - %s", tagInfo.DisplayName,
+			translator.Tr(handler.GetLang(ctx), constant.QuestionsTitleTrKey), siteInfo.General.Name),
+		Description: htmltext.FetchExcerpt(tagInfo.ParsedText, "...", 240),
+		Link:        fmt.Sprintf("%s/tags/%s", siteInfo.General.SiteUrl, tagInfo.SlugName),
+		SelfLink:    fmt.Sprintf("%s/tags/%s/feed", siteInfo.General.SiteUrl, tagInfo.SlugName),
+		Items:       templaterender.QuestionListFeedItems(siteInfo, questionList),
+	})
+}
+
+// UserFeed the feed of newest questions and answers of the user
+func (tc *TemplateController) UserFeed(ctx *gin.Context) {
+	if tc.checkPrivateMode(ctx) {
+		tc.Page404(ctx)
+		return
+	}
+	req := &schema.GetOtherUserInfoByUsernameReq{Username: ctx.Param("username")}
+	userInfo, err := tc.templateRenderController.UserInfo(ctx, req)
+	if err != nil {
+		tc.Page404(ctx)
+		return
+	}
+	siteInfo := tc.SiteInfo(ctx)
+	items, err := tc.templateRenderController.UserFeedItems(ctx, siteInfo, userInfo)
+	if err != nil {
+		log.Error(err)
+		tc.Page404(ctx)
+		return
+	}
+	tc.feed(ctx, &feed.Feed{
+		Title:    fmt.Sprintf("%s - %s", userInfo.Username, siteInfo.General.Name),
+		Link:     fmt.Sprintf("%s/users/%s", siteInfo.General.SiteUrl, userInfo.Username),
+		SelfLink: fmt.Sprintf("%s/users/%s/feed", siteInfo.General.SiteUrl, userInfo.Username),
+		Items:    items,
+	})
+}
+
+// QuestionInfoFeed the feed of the question with its answers and comments
+func (tc *TemplateController) QuestionInfoFeed(ctx *gin.Context) {
+	if tc.checkPrivateMode(ctx) {
+		tc.Page404(ctx)
+		return
+	}
+	detail, err := tc.templateRenderController.QuestionDetail(ctx, ctx.Param("id"))
+	if err != nil {
+		tc.Page404(ctx)
+		return
+	}
+	siteInfo := tc.SiteInfo(ctx)
+	items, err := tc.templateRenderController.QuestionThreadFeedItems(ctx, siteInfo, detail)
+	if err != nil {
+		log.Error(err)
+		tc.Page404(ctx)
+		return
+	}
+	tc.feed(ctx, &feed.Feed{
+		Title:       fmt.Sprintf("%s - %s", detail.Title, siteInfo.General.Name),
+		Description: detail.Description,
+		Link:        templaterender.FeedQuestionURL(siteInfo, detail.ID, detail.Title),
+		SelfLink:    fmt.Sprintf("%s/feeds/questions/%s", siteInfo.General.SiteUrl, detail.ID),
+		Items:       items,
+	})
+}
+
+// feed render the feed as Atom, or RSS if the format is rss, the unchanged feed is not sent again
+func (tc *TemplateController) feed(ctx *gin.Context, f *feed.Feed) {
+	format := ctx.Query("format")
+	if format == feed.FormatRSS {
+		f.SelfLink = fmt.Sprintf("%s?format=%s", f.SelfLink, feed.FormatRSS)
+	}
+	content, contentType, err := f.Render(format)
+	if err != nil {
+		log.Error(err)
+		tc.Page404(ctx)
+		return
+	}
+	etag := feed.ETag(content)
+	lastModified := f.LastModified()
+	ctx.Header("ETag", etag)
+	if !lastModified.IsZero() {
+		ctx.Header("Last-Modified", lastModified.Format(http.TimeFormat))
+	}
+	ctx.Header("Cache-Control", fmt.Sprintf("public, max-age=%d", int(constant.FeedCacheMaxAge.Seconds())))
+	if feed.NotModified(ctx.Request.Header, etag, lastModified) {
+		ctx.Status(http.StatusNotModified)
+		return
+	}
+	ctx.Data(http.StatusOK, contentType, content)
+}
+
 func (tc *TemplateController) checkPrivateMode(ctx *gin.Context) bool {
 	resp, err := tc.siteInfoService.GetSiteLogin(ctx)
 	if err != nil {
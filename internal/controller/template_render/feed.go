@@ -0,0 +1,180 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package templaterender
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/apache/incubator-answer/internal/base/constant"
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/apache/incubator-answer/pkg/feed"
+	"github.com/apache/incubator-answer/pkg/htmltext"
+)
+
+// FeedQuestionURL the url of question under the permalink style of site
+func FeedQuestionURL(siteInfo *schema.TemplateSiteInfoResp, questionID, title string) string {
+	url := fmt.Sprintf("%s/questions/%s", siteInfo.General.SiteUrl, questionID)
+	if siteInfo.SiteSeo.Permalink == constant.PermalinkQuestionIDAndTitle ||
+		siteInfo.SiteSeo.Permalink == constant.PermalinkQuestionIDAndTitleByShortID {
+		url = fmt.Sprintf("%s/%s", url, htmltext.UrlTitle(title))
+	}
+	return url
+}
+
+// QuestionListFeedItems convert the question list to feed items
+func QuestionListFeedItems(siteInfo *schema.TemplateSiteInfoResp, questionList []*schema.QuestionPageResp) []*feed.Item {
+	items := make([]*feed.Item, 0, len(questionList))
+	for _, question := range questionList {
+		item := &feed.Item{
+			Title:     question.Title,
+			Link:      FeedQuestionURL(siteInfo, question.ID, question.Title),
+			Content:   question.Description,
+			Published: time.Unix(question.CreatedAt, 0),
+		}
+		// the operator is the author only if nobody has edited or answered the question
+		if question.OperationType == schema.QuestionPageRespOperationTypeAsked && question.Operator != nil {
+			item.Author = question.Operator.DisplayName
+		}
+		items = append(items, item)
+	}
+	return items
+}
+
+// UserFeedItems the newest questions and answers of the user
+func (t *TemplateRenderController) UserFeedItems(ctx context.Context, siteInfo *schema.TemplateSiteInfoResp,
+	userInfo *schema.GetOtherUserInfoByUsernameResp) (items []*feed.Item, err error) {
+	questionList, _, err := t.questionService.GetQuestionPage(ctx, &schema.QuestionPageReq{
+		Page:             1,
+		PageSize:         constant.FeedMaxSize,
+		OrderCond:        schema.QuestionOrderCondNewest,
+		UserIDBeSearched: userInfo.ID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	items = QuestionListFeedItems(siteInfo, questionList)
+	for _, item := range items {
+		item.Author = userInfo.DisplayName
+	}
+
+	answerPage, err := t.questionService.PersonalAnswerPage(ctx, &schema.PersonalAnswerPageReq{
+		Page:      1,
+		PageSize:  constant.FeedMaxSize,
+		OrderCond: schema.QuestionOrderCondNewest,
+		Username:  userInfo.Username,
+	})
+	if err != nil {
+		return nil, err
+	}
+	answerList, _ := answerPage.List.([]*schema.UserAnswerInfo)
+	for _, answer := range answerList {
+		items = append(items, &feed.Item{
+			Title: answer.QuestionInfo.Title,
+			Link: fmt.Sprintf("%s/%s",
+				FeedQuestionURL(siteInfo, answer.QuestionID, answer.QuestionInfo.Title), answer.AnswerID),
+			Author:    userInfo.DisplayName,
+			Published: time.Unix(int64(answer.CreateTime), 0),
+			Updated:   time.Unix(int64(answer.UpdateTime), 0),
+		})
+	}
+	return newestFeedItems(items), nil
+}
+
+// QuestionThreadFeedItems the question with its newest answers and comments
+func (t *TemplateRenderController) QuestionThreadFeedItems(ctx context.Context, siteInfo *schema.TemplateSiteInfoResp,
+	question *schema.QuestionInfoResp) (items []*feed.Item, err error) {
+	questionURL := FeedQuestionURL(siteInfo, question.ID, question.Title)
+	items = []*feed.Item{{
+		Title:     question.Title,
+		Link:      questionURL,
+		Author:    feedAuthor(question.UserInfo),
+		Content:   question.HTML,
+		Published: time.Unix(question.CreateTime, 0),
+		Updated:   time.Unix(question.QuestionUpdateTime, 0),
+	}}
+	objectURLs := map[string]string{question.ID: questionURL}
+
+	answerList, _, err := t.answerService.SearchList(ctx, &schema.AnswerListReq{
+		QuestionID: question.ID,
+		Order:      entity.AnswerSearchOrderByTime,
+		Page:       1,
+		PageSize:   constant.FeedMaxSize,
+	})
+	if err != nil {
+		return nil, err
+	}
+	for _, answer := range answerList {
+		answerURL := fmt.Sprintf("%s/%s", questionURL, answer.ID)
+		objectURLs[answer.ID] = answerURL
+		items = append(items, &feed.Item{
+			Title:     question.Title,
+			Link:      answerURL,
+			Author:    feedAuthor(answer.UserInfo),
+			Content:   answer.HTML,
+			Published: time.Unix(answer.CreateTime, 0),
+			Updated:   time.Unix(answer.UpdateTime, 0),
+		})
+	}
+
+	for objectID, objectURL := range objectURLs {
+		pageModel, err := t.commentService.GetCommentWithPage(ctx, &schema.GetCommentWithPageReq{
+			Page:      1,
+			PageSize:  constant.FeedMaxSize,
+			ObjectID:  objectID,
+			QueryCond: "created_at",
+		})
+		if err != nil {
+			return nil, err
+		}
+		commentList, _ := pageModel.List.([]*schema.GetCommentResp)
+		for _, comment := range commentList {
+			items = append(items, &feed.Item{
+				Title:     question.Title,
+				Link:      fmt.Sprintf("%s?commentId=%s", objectURL, comment.CommentID),
+				Author:    comment.UserDisplayName,
+				Content:   comment.ParsedText,
+				Published: time.Unix(comment.CreatedAt, 0),
+			})
+		}
+	}
+	return newestFeedItems(items), nil
+}
+
+// newestFeedItems sort the items by the newest first and keep the first page
+func newestFeedItems(items []*feed.Item) []*feed.Item {
+	sort.SliceStable(items, func(i, j int) bool {
+		return items[i].Published.After(items[j].Published)
+	})
+	if len(items) > constant.FeedMaxSize {
+		items = items[:constant.FeedMaxSize]
+	}
+	return items
+}
+
+func feedAuthor(userInfo *schema.UserBasicInfo) string {
+	if userInfo == nil {
+		return ""
+	}
+	return userInfo.DisplayName
+}
@@ -20,13 +20,20 @@
 package controller
 
 import (
+	"io"
+	"mime"
+	"net/http"
+	"path"
+
 	"github.com/apache/incubator-answer/internal/base/handler"
+	"github.com/apache/incubator-answer/internal/base/middleware"
 	"github.com/apache/incubator-answer/internal/base/reason"
 	"github.com/apache/incubator-answer/internal/schema"
 	"github.com/apache/incubator-answer/internal/service/uploader"
 	"github.com/apache/incubator-answer/pkg/converter"
 	"github.com/gin-gonic/gin"
 	"github.com/segmentfault/pacman/errors"
+	"github.com/segmentfault/pacman/log"
 )
 
 const (
@@ -36,6 +43,8 @@ const (
 	fileFromAvatar = "avatar"
 	// file is logo/icon images
 	fileFromBranding = "branding"
+	// file is attached to post, it is not an image
+	fileFromPostAttachment = "post_attachment"
 )
 
 // UploadController upload controller
@@ -56,7 +65,7 @@ func NewUploadController(uploaderService uploader.UploaderService) *UploadContro
 // @Tags Upload
 // @Accept multipart/form-data
 // @Security ApiKeyAuth
-// @Param source formData string true "identify the source of the file upload" Enums(post, avatar, branding)
+// @Param source formData string true "identify the source of the file upload" Enums(post, avatar, branding, post_attachment)
 // @Param file formData file true "file"
 // @Success 200 {object} handler.RespBody{data=string}
 // @Router /answer/api/v1/file [post]
@@ -74,6 +83,8 @@ func (uc *UploadController) UploadFile(ctx *gin.Context) {
 		url, err = uc.uploaderService.UploadPostFile(ctx)
 	case fileFromBranding:
 		url, err = uc.uploaderService.UploadBrandingFile(ctx)
+	case fileFromPostAttachment:
+		url, err = uc.uploaderService.UploadPostAttachment(ctx, middleware.GetLoginUserIDFromContext(ctx))
 	default:
 		handler.HandleResponse(ctx, errors.BadRequest(reason.UploadFileSourceUnsupported), nil)
 		return
@@ -85,6 +96,57 @@ func (uc *UploadController) UploadFile(ctx *gin.Context) {
 	handler.HandleResponse(ctx, err, url)
 }
 
+// GetUploadedFile serves the uploaded file which is not on local disk from the storage
+func (uc *UploadController) GetUploadedFile(ctx *gin.Context) {
+	reader, err := uc.uploaderService.OpenUploadedFile(ctx, ctx.Param("filepath"))
+	if err != nil {
+		if e, ok := err.(*errors.Error); ok && errors.IsNotFound(e) {
+			ctx.Status(http.StatusNotFound)
+		} else {
+			log.Error(err)
+			ctx.Status(http.StatusInternalServerError)
+		}
+		return
+	}
+	defer reader.Close()
+
+	contentType := mime.TypeByExtension(path.Ext(ctx.Param("filepath")))
+	if len(contentType) == 0 {
+		contentType = "application/octet-stream"
+	}
+	ctx.Header("Content-Type", contentType)
+	ctx.Status(http.StatusOK)
+	if ctx.Request.Method == http.MethodHead {
+		return
+	}
+	if _, err = io.Copy(ctx.Writer, reader); err != nil {
+		log.Error(err)
+	}
+}
+
+// DownloadAttachment download the attachment with its original file name
+func (uc *UploadController) DownloadAttachment(ctx *gin.Context) {
+	attachment, reader, err := uc.uploaderService.DownloadAttachment(ctx, ctx.Param("id"))
+	if err != nil {
+		if e, ok := err.(*errors.Error); ok && errors.IsNotFound(e) {
+			ctx.Status(http.StatusNotFound)
+		} else {
+			log.Error(err)
+			ctx.Status(http.StatusInternalServerError)
+		}
+		return
+	}
+	defer reader.Close()
+
+	disposition := mime.FormatMediaType("attachment", map[string]string{"filename": attachment.FileName})
+	if len(disposition) == 0 {
+		disposition = "attachment"
+	}
+	ctx.Header("Content-Disposition", disposition)
+	ctx.Header("X-Content-Type-Options", "nosniff")
+	ctx.DataFromReader(http.StatusOK, attachment.FileSize, attachment.MimeType, reader, nil)
+}
+
 // PostRender render post content
 // @Summary render post content
 // @Description render post content
@@ -690,6 +690,42 @@ func (uc *UserController) UserUnsubscribeNotification(ctx *gin.Context) {
 	handler.HandleResponse(ctx, err, nil)
 }
 
+// UserUnsubscribeNotificationOneClick one-click unsubscribe notification from the mail client
+// @Summary one-click unsubscribe notification from the mail client
+// @Description RFC 8058 one-click unsubscribe, the url is in the List-Unsubscribe header of the notification email
+// @Tags User
+// @Accept x-www-form-urlencoded
+// @Produce json
+// @Param token query string false "signed unsubscribe token"
+// @Param code query string false "unsubscribe code"
+// @Param List-Unsubscribe formData string false "One-Click"
+// @Success 200 {object} handler.RespBody{}
+// @Router /answer/api/v1/user/notification/unsubscribe/one-click [post]
+func (uc *UserController) UserUnsubscribeNotificationOneClick(ctx *gin.Context) {
+	req := &schema.UserUnsubscribeNotificationOneClickReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+
+	var content string
+	if len(req.Token) > 0 {
+		content = uc.emailService.VerifyUnsubscribeToken(ctx, req.Token)
+	} else {
+		content = uc.emailService.VerifyUrlExpired(ctx, req.Code)
+	}
+	if len(content) == 0 {
+		handler.HandleResponse(ctx, errors.Forbidden(reason.EmailVerifyURLExpired),
+			&schema.ForbiddenResp{Type: schema.ForbiddenReasonTypeURLExpired})
+		return
+	}
+
+	err := uc.userService.UserUnsubscribeNotification(ctx, &schema.UserUnsubscribeNotificationReq{
+		Code:    req.Code,
+		Content: content,
+	})
+	handler.HandleResponse(ctx, err, nil)
+}
+
 // SearchUserListByName godoc
 // @Summary SearchUserListByName
 // @Description SearchUserListByName
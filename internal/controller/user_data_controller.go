@@ -0,0 +1,66 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package controller
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/apache/incubator-answer/internal/base/handler"
+	"github.com/apache/incubator-answer/internal/base/middleware"
+	"github.com/apache/incubator-answer/internal/base/reason"
+	"github.com/apache/incubator-answer/internal/service/user_data"
+	"github.com/gin-gonic/gin"
+	"github.com/segmentfault/pacman/errors"
+)
+
+// UserDataController user data controller
+type UserDataController struct {
+	userDataService *user_data.UserDataService
+}
+
+// NewUserDataController new controller
+func NewUserDataController(userDataService *user_data.UserDataService) *UserDataController {
+	return &UserDataController{userDataService: userDataService}
+}
+
+// ExportUserData download the personal data of current user
+// @Summary download the personal data of current user
+// @Description download an archive of the profile, posts, comments, votes, collections, notifications and external logins of current user
+// @Tags User
+// @Security ApiKeyAuth
+// @Produce application/gzip
+// @Success 200 {file} file
+// @Router /answer/api/v1/user/data/export [get]
+func (uc *UserDataController) ExportUserData(ctx *gin.Context) {
+	// the personal data can only be exported by the user self, not by api tokens
+	if middleware.IsAPITokenAuth(ctx) {
+		handler.HandleResponse(ctx, errors.Forbidden(reason.APITokenScopeNotAllowed), nil)
+		return
+	}
+	userID := middleware.GetLoginUserIDFromContext(ctx)
+	fileName, content, err := uc.userDataService.ExportUserData(ctx, userID)
+	if err != nil {
+		handler.HandleResponse(ctx, err, nil)
+		return
+	}
+	ctx.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", fileName))
+	ctx.Data(http.StatusOK, "application/gzip", content)
+}
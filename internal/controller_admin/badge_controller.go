@@ -0,0 +1,62 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package controller_admin
+
+import (
+	"github.com/apache/incubator-answer/internal/base/handler"
+	"github.com/apache/incubator-answer/internal/service/badge"
+	"github.com/gin-gonic/gin"
+)
+
+// BadgeController badge controller
+type BadgeController struct {
+	badgeService *badge.BadgeService
+}
+
+// NewBadgeController new controller
+func NewBadgeController(badgeService *badge.BadgeService) *BadgeController {
+	return &BadgeController{badgeService: badgeService}
+}
+
+// StartBackfill evaluate the badges of all users
+// @Summary evaluate the badges of all users
+// @Description evaluate all badges of all users in background, the badges that users have earned are awarded
+// @Tags AdminBadge
+// @Security ApiKeyAuth
+// @Produce json
+// @Success 200 {object} handler.RespBody{data=schema.BadgeBackfillProgress}
+// @Router /answer/admin/api/badges/backfill [post]
+func (bc *BadgeController) StartBackfill(ctx *gin.Context) {
+	resp, err := bc.badgeService.StartBackfill(ctx)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// GetBackfillStatus get the progress of the badge back-fill
+// @Summary get the progress of the badge back-fill
+// @Description get the progress of the running badge back-fill, or the result of the last one
+// @Tags AdminBadge
+// @Security ApiKeyAuth
+// @Produce json
+// @Success 200 {object} handler.RespBody{data=schema.BadgeBackfillProgress}
+// @Router /answer/admin/api/badges/backfill [get]
+func (bc *BadgeController) GetBackfillStatus(ctx *gin.Context) {
+	resp := bc.badgeService.GetBackfillStatus(ctx)
+	handler.HandleResponse(ctx, nil, resp)
+}
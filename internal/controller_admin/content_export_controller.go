@@ -0,0 +1,93 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package controller_admin
+
+import (
+	"github.com/apache/incubator-answer/internal/base/handler"
+	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/apache/incubator-answer/internal/service/content_export"
+	"github.com/gin-gonic/gin"
+)
+
+// ContentExportController content export controller
+type ContentExportController struct {
+	contentExportService *content_export.ContentExportService
+}
+
+// NewContentExportController new controller
+func NewContentExportController(contentExportService *content_export.ContentExportService) *ContentExportController {
+	return &ContentExportController{contentExportService: contentExportService}
+}
+
+// StartContentExport export all questions to an archive
+// @Summary export all questions to an archive
+// @Description export every question with its answers, comments, tags and revisions as json and markdown files in a queue job
+// @Tags AdminExport
+// @Security ApiKeyAuth
+// @Accept json
+// @Produce json
+// @Param data body schema.ContentExportReq true "export"
+// @Success 200 {object} handler.RespBody
+// @Router /answer/admin/api/export/content [post]
+func (ec *ContentExportController) StartContentExport(ctx *gin.Context) {
+	req := &schema.ContentExportReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+
+	err := ec.contentExportService.StartContentExport(ctx, req)
+	handler.HandleResponse(ctx, err, nil)
+}
+
+// GetContentExportFiles get the exported archives
+// @Summary get the exported archives
+// @Description get the exported archives
+// @Tags AdminExport
+// @Security ApiKeyAuth
+// @Produce json
+// @Success 200 {object} handler.RespBody{data=[]schema.ContentExportFileResp}
+// @Router /answer/admin/api/export/content/files [get]
+func (ec *ContentExportController) GetContentExportFiles(ctx *gin.Context) {
+	resp, err := ec.contentExportService.GetContentExportFiles(ctx)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// DownloadContentExportFile download the exported archive
+// @Summary download the exported archive
+// @Description download the exported archive
+// @Tags AdminExport
+// @Security ApiKeyAuth
+// @Produce application/gzip
+// @Param file_name query string true "file name"
+// @Success 200 {file} file
+// @Router /answer/admin/api/export/content/download [get]
+func (ec *ContentExportController) DownloadContentExportFile(ctx *gin.Context) {
+	req := &schema.ContentExportDownloadReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+
+	filePath, err := ec.contentExportService.GetContentExportFilePath(ctx, req)
+	if err != nil {
+		handler.HandleResponse(ctx, err, nil)
+		return
+	}
+	ctx.FileAttachment(filePath, req.FileName)
+}
@@ -28,4 +28,12 @@ var ProviderSetController = wire.NewSet(
 	NewSiteInfoController,
 	NewRoleController,
 	NewPluginController,
+	NewJobQueueController,
+	NewWebhookController,
+	NewSearchSyncController,
+	NewImportController,
+	NewContentExportController,
+	NewEmailDeliveryController,
+	NewBadgeController,
+	NewRankRecalculateController,
 )
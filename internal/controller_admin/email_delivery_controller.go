@@ -0,0 +1,58 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package controller_admin
+
+import (
+	"github.com/apache/incubator-answer/internal/base/handler"
+	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/apache/incubator-answer/internal/service/export"
+	"github.com/gin-gonic/gin"
+)
+
+// EmailDeliveryController email delivery controller
+type EmailDeliveryController struct {
+	emailService *export.EmailService
+}
+
+// NewEmailDeliveryController new controller
+func NewEmailDeliveryController(emailService *export.EmailService) *EmailDeliveryController {
+	return &EmailDeliveryController{emailService: emailService}
+}
+
+// GetDeliveryPage get email delivery page
+// @Summary get email delivery page
+// @Description get the recent outgoing emails and their delivery status, the latest first
+// @Tags AdminEmail
+// @Security ApiKeyAuth
+// @Produce json
+// @Param page query int false "page"
+// @Param page_size query int false "page size"
+// @Param status query string false "status" Enums(queued, sent, failed, bounced)
+// @Success 200 {object} handler.RespBody{data=pager.PageModel{list=[]schema.GetEmailDeliveryResp}}
+// @Router /answer/admin/api/email/deliveries/page [get]
+func (ec *EmailDeliveryController) GetDeliveryPage(ctx *gin.Context) {
+	req := &schema.GetEmailDeliveryPageReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+
+	resp, err := ec.emailService.GetDeliveryPage(ctx, req)
+	handler.HandleResponse(ctx, err, resp)
+}
@@ -0,0 +1,60 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package controller_admin
+
+import (
+	"github.com/apache/incubator-answer/internal/base/handler"
+	"github.com/apache/incubator-answer/internal/base/middleware"
+	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/apache/incubator-answer/internal/service/importer"
+	"github.com/gin-gonic/gin"
+)
+
+// ImportController import controller
+type ImportController struct {
+	importerService *importer.ImporterService
+}
+
+// NewImportController new controller
+func NewImportController(importerService *importer.ImporterService) *ImportController {
+	return &ImportController{importerService: importerService}
+}
+
+// StartStackExchangeImport import the Stack Exchange data dump
+// @Summary import the Stack Exchange data dump
+// @Description import the Stack Exchange data dump in the directory of server as a queue job,
+// @Description the contents whose author is unknown are owned by the current admin
+// @Tags AdminImport
+// @Security ApiKeyAuth
+// @Accept json
+// @Produce json
+// @Param data body schema.StackExchangeImportReq true "import"
+// @Success 200 {object} handler.RespBody
+// @Router /answer/admin/api/import/stackexchange [post]
+func (ic *ImportController) StartStackExchangeImport(ctx *gin.Context) {
+	req := &schema.StackExchangeImportReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	req.UserID = middleware.GetLoginUserIDFromContext(ctx)
+
+	err := ic.importerService.StartStackExchangeImport(ctx, req)
+	handler.HandleResponse(ctx, err, nil)
+}
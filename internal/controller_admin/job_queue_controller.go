@@ -0,0 +1,99 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package controller_admin
+
+import (
+	"github.com/apache/incubator-answer/internal/base/handler"
+	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/apache/incubator-answer/internal/service/job_queue"
+	"github.com/gin-gonic/gin"
+)
+
+// JobQueueController job queue controller
+type JobQueueController struct {
+	jobQueueService *job_queue.JobQueueService
+}
+
+// NewJobQueueController new controller
+func NewJobQueueController(jobQueueService *job_queue.JobQueueService) *JobQueueController {
+	return &JobQueueController{jobQueueService: jobQueueService}
+}
+
+// GetJobPage get queue job page
+// @Summary get queue job page
+// @Description get queue job page, failed jobs are in dead status
+// @Tags AdminQueue
+// @Security ApiKeyAuth
+// @Produce json
+// @Param page query int false "page"
+// @Param page_size query int false "page size"
+// @Param queue_name query string false "queue name"
+// @Param status query string false "status" Enums(pending, running, dead)
+// @Success 200 {object} handler.RespBody{data=pager.PageModel{list=[]schema.GetQueueJobPageResp}}
+// @Router /answer/admin/api/queue/jobs/page [get]
+func (jc *JobQueueController) GetJobPage(ctx *gin.Context) {
+	req := &schema.GetQueueJobPageReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+
+	resp, err := jc.jobQueueService.GetJobPage(ctx, req)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// RetryJob retry queue job
+// @Summary retry queue job
+// @Description replay a queue job immediately and reset its attempts
+// @Tags AdminQueue
+// @Security ApiKeyAuth
+// @Accept json
+// @Produce json
+// @Param data body schema.RetryQueueJobReq true "job"
+// @Success 200 {object} handler.RespBody
+// @Router /answer/admin/api/queue/job/retry [put]
+func (jc *JobQueueController) RetryJob(ctx *gin.Context) {
+	req := &schema.RetryQueueJobReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+
+	err := jc.jobQueueService.RetryJob(ctx, req)
+	handler.HandleResponse(ctx, err, nil)
+}
+
+// RemoveJob remove queue job
+// @Summary remove queue job
+// @Description remove queue job
+// @Tags AdminQueue
+// @Security ApiKeyAuth
+// @Accept json
+// @Produce json
+// @Param data body schema.RemoveQueueJobReq true "job"
+// @Success 200 {object} handler.RespBody
+// @Router /answer/admin/api/queue/job [delete]
+func (jc *JobQueueController) RemoveJob(ctx *gin.Context) {
+	req := &schema.RemoveQueueJobReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+
+	err := jc.jobQueueService.RemoveJob(ctx, req)
+	handler.HandleResponse(ctx, err, nil)
+}
@@ -0,0 +1,71 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package controller_admin
+
+import (
+	"github.com/apache/incubator-answer/internal/base/handler"
+	"github.com/apache/incubator-answer/internal/base/middleware"
+	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/apache/incubator-answer/internal/service/rank"
+	"github.com/gin-gonic/gin"
+)
+
+// RankRecalculateController reputation recalculation controller
+type RankRecalculateController struct {
+	rankRecalculateService *rank.RankRecalculateService
+}
+
+// NewRankRecalculateController new controller
+func NewRankRecalculateController(rankRecalculateService *rank.RankRecalculateService) *RankRecalculateController {
+	return &RankRecalculateController{rankRecalculateService: rankRecalculateService}
+}
+
+// StartRecalculate recalculate the reputation of all users
+// @Summary recalculate the reputation of all users
+// @Description recalculate the reputation of all users from their activities under the current rank rules in background
+// @Tags AdminRank
+// @Security ApiKeyAuth
+// @Accept json
+// @Produce json
+// @Param data body schema.RankRecalculateReq true "recalculate options"
+// @Success 200 {object} handler.RespBody{data=schema.RankRecalculateProgress}
+// @Router /answer/admin/api/rank/recalculate [post]
+func (rc *RankRecalculateController) StartRecalculate(ctx *gin.Context) {
+	req := &schema.RankRecalculateReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	req.UserID = middleware.GetLoginUserIDFromContext(ctx)
+	resp, err := rc.rankRecalculateService.StartRecalculate(ctx, req)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// GetRecalculateStatus get the progress of the reputation recalculation
+// @Summary get the progress of the reputation recalculation
+// @Description get the progress and differences of the running recalculation, or the result of the last one
+// @Tags AdminRank
+// @Security ApiKeyAuth
+// @Produce json
+// @Success 200 {object} handler.RespBody{data=schema.RankRecalculateProgress}
+// @Router /answer/admin/api/rank/recalculate [get]
+func (rc *RankRecalculateController) GetRecalculateStatus(ctx *gin.Context) {
+	resp := rc.rankRecalculateService.GetRecalculateStatus(ctx)
+	handler.HandleResponse(ctx, nil, resp)
+}
@@ -0,0 +1,69 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+package controller_admin
+
+import (
+	"github.com/apache/incubator-answer/internal/base/handler"
+	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/apache/incubator-answer/internal/service/search_sync"
+	"github.com/gin-gonic/gin"
+)
+
+// SearchSyncController search sync controller
+type SearchSyncController struct {
+	searchSyncService *search_sync.SearchSyncService
+}
+
+// NewSearchSyncController new controller
+func NewSearchSyncController(searchSyncService *search_sync.SearchSyncService) *SearchSyncController {
+	return &SearchSyncController{searchSyncService: searchSyncService}
+}
+
+// StartReindex rebuild the index of search plugin
+// @Summary rebuild the index of search plugin
+// @Description rebuild the index of the active search plugin in background, dry run only returns the count of contents
+// @Tags AdminSearch
+// @Security ApiKeyAuth
+// @Accept json
+// @Produce json
+// @Param data body schema.SearchReindexReq true "reindex"
+// @Success 200 {object} handler.RespBody{data=schema.SearchReindexProgress}
+// @Router /answer/admin/api/search/reindex [post]
+func (sc *SearchSyncController) StartReindex(ctx *gin.Context) {
+	req := &schema.SearchReindexReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+
+	resp, err := sc.searchSyncService.StartReindex(ctx, req)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// GetReindexStatus get the progress of rebuilding search index
+// @Summary get the progress of rebuilding search index
+// @Description get the progress of rebuilding search index
+// @Tags AdminSearch
+// @Security ApiKeyAuth
+// @Produce json
+// @Success 200 {object} handler.RespBody{data=schema.SearchReindexProgress}
+// @Router /answer/admin/api/search/reindex [get]
+func (sc *SearchSyncController) GetReindexStatus(ctx *gin.Context) {
+	resp, err := sc.searchSyncService.GetReindexStatus(ctx)
+	handler.HandleResponse(ctx, err, resp)
+}
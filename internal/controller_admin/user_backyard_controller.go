@@ -26,6 +26,7 @@ import (
 	"github.com/apache/incubator-answer/internal/base/translator"
 	"github.com/apache/incubator-answer/internal/schema"
 	"github.com/apache/incubator-answer/internal/service/user_admin"
+	"github.com/apache/incubator-answer/internal/service/user_data"
 	"github.com/apache/incubator-answer/plugin"
 	"github.com/gin-gonic/gin"
 	"github.com/segmentfault/pacman/errors"
@@ -33,12 +34,19 @@ import (
 
 // UserAdminController user controller
 type UserAdminController struct {
-	userService *user_admin.UserAdminService
+	userService     *user_admin.UserAdminService
+	userDataService *user_data.UserDataService
 }
 
 // NewUserAdminController new controller
-func NewUserAdminController(userService *user_admin.UserAdminService) *UserAdminController {
-	return &UserAdminController{userService: userService}
+func NewUserAdminController(
+	userService *user_admin.UserAdminService,
+	userDataService *user_data.UserDataService,
+) *UserAdminController {
+	return &UserAdminController{
+		userService:     userService,
+		userDataService: userDataService,
+	}
 }
 
 // UpdateUserStatus update user
@@ -67,6 +75,32 @@ func (uc *UserAdminController) UpdateUserStatus(ctx *gin.Context) {
 	handler.HandleResponse(ctx, err, nil)
 }
 
+// EraseUser erase the personal data of user
+// @Summary erase the personal data of user
+// @Description erase the personal data of user, the posts are kept but the author is anonymized, an audit log is recorded
+// @Security ApiKeyAuth
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param data body schema.EraseUserReq true "user"
+// @Success 200 {object} handler.RespBody
+// @Router /answer/admin/api/user/erase [post]
+func (uc *UserAdminController) EraseUser(ctx *gin.Context) {
+	if u, ok := plugin.GetUserCenter(); ok && u.Description().UserStatusAgentEnabled {
+		handler.HandleResponse(ctx, errors.Forbidden(reason.ForbiddenError), nil)
+		return
+	}
+	req := &schema.EraseUserReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+
+	req.LoginUserID = middleware.GetLoginUserIDFromContext(ctx)
+
+	err := uc.userDataService.EraseUser(ctx, req)
+	handler.HandleResponse(ctx, err, nil)
+}
+
 // UpdateUserRole update user role
 // @Summary update user role
 // @Description update user role
@@ -0,0 +1,163 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package controller_admin
+
+import (
+	"github.com/apache/incubator-answer/internal/base/handler"
+	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/apache/incubator-answer/internal/service/webhook"
+	"github.com/gin-gonic/gin"
+)
+
+// WebhookController webhook controller
+type WebhookController struct {
+	webhookService *webhook.WebhookService
+}
+
+// NewWebhookController new controller
+func NewWebhookController(webhookService *webhook.WebhookService) *WebhookController {
+	return &WebhookController{webhookService: webhookService}
+}
+
+// GetWebhookList get webhook list
+// @Summary get webhook list
+// @Description get webhook list
+// @Tags AdminWebhook
+// @Security ApiKeyAuth
+// @Produce json
+// @Success 200 {object} handler.RespBody{data=[]schema.GetWebhookResp}
+// @Router /answer/admin/api/webhooks [get]
+func (wc *WebhookController) GetWebhookList(ctx *gin.Context) {
+	resp, err := wc.webhookService.GetWebhookList(ctx)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// AddWebhook add webhook
+// @Summary add webhook
+// @Description add webhook, the secret will be generated if it is empty
+// @Tags AdminWebhook
+// @Security ApiKeyAuth
+// @Accept json
+// @Produce json
+// @Param data body schema.AddWebhookReq true "webhook"
+// @Success 200 {object} handler.RespBody{data=schema.GetWebhookResp}
+// @Router /answer/admin/api/webhook [post]
+func (wc *WebhookController) AddWebhook(ctx *gin.Context) {
+	req := &schema.AddWebhookReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+
+	resp, err := wc.webhookService.AddWebhook(ctx, req)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// UpdateWebhook update webhook
+// @Summary update webhook
+// @Description update webhook
+// @Tags AdminWebhook
+// @Security ApiKeyAuth
+// @Accept json
+// @Produce json
+// @Param data body schema.UpdateWebhookReq true "webhook"
+// @Success 200 {object} handler.RespBody
+// @Router /answer/admin/api/webhook [put]
+func (wc *WebhookController) UpdateWebhook(ctx *gin.Context) {
+	req := &schema.UpdateWebhookReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+
+	err := wc.webhookService.UpdateWebhook(ctx, req)
+	handler.HandleResponse(ctx, err, nil)
+}
+
+// RemoveWebhook remove webhook
+// @Summary remove webhook
+// @Description remove webhook and its deliveries
+// @Tags AdminWebhook
+// @Security ApiKeyAuth
+// @Accept json
+// @Produce json
+// @Param data body schema.RemoveWebhookReq true "webhook"
+// @Success 200 {object} handler.RespBody
+// @Router /answer/admin/api/webhook [delete]
+func (wc *WebhookController) RemoveWebhook(ctx *gin.Context) {
+	req := &schema.RemoveWebhookReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+
+	err := wc.webhookService.RemoveWebhook(ctx, req)
+	handler.HandleResponse(ctx, err, nil)
+}
+
+// TestWebhook test webhook
+// @Summary test webhook
+// @Description send a ping event to the webhook and return the delivery result
+// @Tags AdminWebhook
+// @Security ApiKeyAuth
+// @Accept json
+// @Produce json
+// @Param data body schema.TestWebhookReq true "webhook"
+// @Success 200 {object} handler.RespBody{data=schema.GetWebhookDeliveryResp}
+// @Router /answer/admin/api/webhook/test [post]
+func (wc *WebhookController) TestWebhook(ctx *gin.Context) {
+	req := &schema.TestWebhookReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+
+	resp, err := wc.webhookService.TestWebhook(ctx, req)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// GetDeliveryPage get webhook delivery page
+// @Summary get webhook delivery page
+// @Description get webhook delivery page
+// @Tags AdminWebhook
+// @Security ApiKeyAuth
+// @Produce json
+// @Param page query int false "page"
+// @Param page_size query int false "page size"
+// @Param webhook_id query int false "webhook id"
+// @Success 200 {object} handler.RespBody{data=pager.PageModel{list=[]schema.GetWebhookDeliveryResp}}
+// @Router /answer/admin/api/webhook/deliveries/page [get]
+func (wc *WebhookController) GetDeliveryPage(ctx *gin.Context) {
+	req := &schema.GetWebhookDeliveryPageReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+
+	resp, err := wc.webhookService.GetDeliveryPage(ctx, req)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// GetEvents get all events that webhooks can subscribe to
+// @Summary get webhook events
+// @Description get all events that webhooks can subscribe to
+// @Tags AdminWebhook
+// @Security ApiKeyAuth
+// @Produce json
+// @Success 200 {object} handler.RespBody{data=[]string}
+// @Router /answer/admin/api/webhook/events [get]
+func (wc *WebhookController) GetEvents(ctx *gin.Context) {
+	handler.HandleResponse(ctx, nil, wc.webhookService.GetEvents())
+}
@@ -0,0 +1,46 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+package entity
+
+import "time"
+
+const (
+	APITokenScopeRead  = "read"
+	APITokenScopeWrite = "write"
+	APITokenScopeAdmin = "admin"
+)
+
+// APIToken personal access token for scripted clients, only the hash of the token is stored
+type APIToken struct {
+	ID          int64     `xorm:"not null pk autoincr BIGINT(20) id"`
+	CreatedAt   time.Time `xorm:"created TIMESTAMP created_at"`
+	UpdatedAt   time.Time `xorm:"updated TIMESTAMP updated_at"`
+	UserID      string    `xorm:"not null default 0 BIGINT(20) INDEX user_id"`
+	Name        string    `xorm:"not null default '' VARCHAR(100) name"`
+	TokenHash   string    `xorm:"not null default '' VARCHAR(64) UNIQUE token_hash"`
+	TokenPrefix string    `xorm:"not null default '' VARCHAR(16) token_prefix"`
+	Scope       string    `xorm:"not null default '' VARCHAR(16) scope"`
+	ExpiredAt   time.Time `xorm:"TIMESTAMP expired_at"`
+	LastUsedAt  time.Time `xorm:"TIMESTAMP last_used_at"`
+}
+
+// TableName api token table name
+func (APIToken) TableName() string {
+	return "api_token"
+}
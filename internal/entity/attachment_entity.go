@@ -0,0 +1,43 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package entity
+
+import "time"
+
+// Attachment the file attached to a post, it is downloaded by the random file id and the downloads are counted.
+// The object id is 0 until the attachment is used in a question or answer.
+type Attachment struct {
+	ID            int64     `xorm:"not null pk autoincr BIGINT(20) id"`
+	CreatedAt     time.Time `xorm:"created TIMESTAMP created_at"`
+	UpdatedAt     time.Time `xorm:"updated TIMESTAMP updated_at"`
+	FileID        string    `xorm:"not null default '' UNIQUE VARCHAR(32) file_id"`
+	UserID        string    `xorm:"not null default 0 index BIGINT(20) user_id"`
+	ObjectID      string    `xorm:"not null default 0 index BIGINT(20) object_id"`
+	FileName      string    `xorm:"not null default '' VARCHAR(255) file_name"`
+	FilePath      string    `xorm:"not null default '' VARCHAR(255) file_path"`
+	MimeType      string    `xorm:"not null default '' VARCHAR(128) mime_type"`
+	FileSize      int64     `xorm:"not null default 0 BIGINT(20) file_size"`
+	DownloadCount int64     `xorm:"not null default 0 BIGINT(20) download_count"`
+}
+
+// TableName attachment table name
+func (Attachment) TableName() string {
+	return "attachment"
+}
@@ -0,0 +1,44 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package entity
+
+import "time"
+
+const (
+	// AuditActionUserErase admin erased the personal data of user
+	AuditActionUserErase = "user.erase"
+	// AuditActionUserRankRecalculate the reputation of user was recalculated from the activities
+	AuditActionUserRankRecalculate = "user.rank_recalculate"
+)
+
+// AuditLog the record of sensitive operations of admin
+type AuditLog struct {
+	ID         int64     `xorm:"not null pk autoincr BIGINT(20) id"`
+	CreatedAt  time.Time `xorm:"created TIMESTAMP created_at"`
+	OperatorID string    `xorm:"not null default 0 BIGINT(20) INDEX operator_id"`
+	Action     string    `xorm:"not null default '' VARCHAR(100) action"`
+	ObjectID   string    `xorm:"not null default 0 BIGINT(20) INDEX object_id"`
+	Detail     string    `xorm:"TEXT detail"`
+}
+
+// TableName audit log table name
+func (AuditLog) TableName() string {
+	return "audit_log"
+}
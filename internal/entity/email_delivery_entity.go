@@ -0,0 +1,59 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package entity
+
+import "time"
+
+const (
+	EmailDeliveryStatusQueued  = 1
+	EmailDeliveryStatusSent    = 2
+	EmailDeliveryStatusFailed  = 3
+	EmailDeliveryStatusBounced = 4
+)
+
+var (
+	EmailDeliveryStatus = map[string]int{
+		"queued":  EmailDeliveryStatusQueued,
+		"sent":    EmailDeliveryStatusSent,
+		"failed":  EmailDeliveryStatusFailed,
+		"bounced": EmailDeliveryStatusBounced,
+	}
+)
+
+// EmailDelivery the outgoing email and its delivery result
+type EmailDelivery struct {
+	ID                 int64     `xorm:"not null pk autoincr BIGINT(20) id"`
+	CreatedAt          time.Time `xorm:"created TIMESTAMP created_at"`
+	UpdatedAt          time.Time `xorm:"updated TIMESTAMP updated_at"`
+	ToEmail            string    `xorm:"not null default '' VARCHAR(255) to_email"`
+	Subject            string    `xorm:"not null default '' VARCHAR(512) subject"`
+	Body               string    `xorm:"MEDIUMTEXT body"`
+	ListUnsubscribeURL string    `xorm:"not null default '' VARCHAR(1024) list_unsubscribe_url"`
+	ReplyTo            string    `xorm:"not null default '' VARCHAR(255) reply_to"`
+	Status             int       `xorm:"not null default 1 index INT(11) status"`
+	Attempts           int       `xorm:"not null default 0 INT(11) attempts"`
+	Error              string    `xorm:"TEXT error"`
+	SentAt             time.Time `xorm:"TIMESTAMP sent_at"`
+}
+
+// TableName email delivery table name
+func (EmailDelivery) TableName() string {
+	return "email_delivery"
+}
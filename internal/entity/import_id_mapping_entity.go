@@ -0,0 +1,42 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package entity
+
+import "time"
+
+const (
+	ImportSourceStackExchange = "stackexchange"
+)
+
+// ImportIDMapping the mapping from the id in the imported source to the id in answer,
+// it is used to skip the imported objects and redirect the old urls.
+type ImportIDMapping struct {
+	ID         int64     `xorm:"not null pk autoincr BIGINT(20) id"`
+	CreatedAt  time.Time `xorm:"created TIMESTAMP created_at"`
+	Source     string    `xorm:"not null default '' VARCHAR(50) UNIQUE(source_object_old_id) source"`
+	ObjectType string    `xorm:"not null default '' VARCHAR(50) UNIQUE(source_object_old_id) object_type"`
+	OldID      string    `xorm:"not null default '' VARCHAR(100) UNIQUE(source_object_old_id) old_id"`
+	NewID      string    `xorm:"not null default 0 BIGINT(20) INDEX new_id"`
+}
+
+// TableName import id mapping table name
+func (ImportIDMapping) TableName() string {
+	return "import_id_mapping"
+}
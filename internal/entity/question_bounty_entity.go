@@ -0,0 +1,49 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package entity
+
+import "time"
+
+const (
+	QuestionBountyStatusActive  = 1
+	QuestionBountyStatusAwarded = 2
+	QuestionBountyStatusExpired = 3
+)
+
+// QuestionBounty reputation offered on a question, it is deducted from the offering user when offered
+// and granted to the author of the awarded answer
+type QuestionBounty struct {
+	ID              int64     `xorm:"not null pk autoincr BIGINT(20) id"`
+	CreatedAt       time.Time `xorm:"created TIMESTAMP created_at"`
+	UpdatedAt       time.Time `xorm:"updated TIMESTAMP updated_at"`
+	QuestionID      string    `xorm:"not null default 0 index BIGINT(20) question_id"`
+	UserID          string    `xorm:"not null default 0 BIGINT(20) user_id"`
+	Amount          int       `xorm:"not null default 0 INT(11) amount"`
+	Status          int       `xorm:"not null default 1 index TINYINT(4) status"`
+	ExpiresAt       time.Time `xorm:"TIMESTAMP expires_at"`
+	AwardedAnswerID string    `xorm:"not null default 0 BIGINT(20) awarded_answer_id"`
+	AwardedUserID   string    `xorm:"not null default 0 BIGINT(20) awarded_user_id"`
+	AwardedAt       time.Time `xorm:"TIMESTAMP awarded_at"`
+}
+
+// TableName question bounty table name
+func (QuestionBounty) TableName() string {
+	return "question_bounty"
+}
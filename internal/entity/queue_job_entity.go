@@ -0,0 +1,57 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package entity
+
+import "time"
+
+const (
+	QueueJobStatusPending = 1
+	QueueJobStatusRunning = 2
+	QueueJobStatusDead    = 3
+)
+
+var (
+	QueueJobStatus = map[string]int{
+		"pending": QueueJobStatusPending,
+		"running": QueueJobStatusRunning,
+		"dead":    QueueJobStatusDead,
+	}
+)
+
+// QueueJob persisted queue job
+type QueueJob struct {
+	ID          int64     `xorm:"not null pk autoincr BIGINT(20) id"`
+	CreatedAt   time.Time `xorm:"created TIMESTAMP created_at"`
+	UpdatedAt   time.Time `xorm:"updated TIMESTAMP updated_at"`
+	QueueName   string    `xorm:"not null default '' index VARCHAR(64) queue_name"`
+	Payload     string    `xorm:"not null MEDIUMTEXT payload"`
+	Status      int       `xorm:"not null default 1 index INT(11) status"`
+	Attempts    int       `xorm:"not null default 0 INT(11) attempts"`
+	MaxAttempts int       `xorm:"not null default 0 INT(11) max_attempts"`
+	LastError   string    `xorm:"TEXT last_error"`
+	NextRunAt   time.Time `xorm:"TIMESTAMP next_run_at"`
+	// ClaimedAt when the running job was claimed by a worker, the claim expires after the lease timeout
+	ClaimedAt time.Time `xorm:"TIMESTAMP claimed_at"`
+}
+
+// TableName queue job table name
+func (QueueJob) TableName() string {
+	return "queue_job"
+}
@@ -50,9 +50,21 @@ type Report struct {
 	FlaggedType    int       `xorm:"not null default 0 INT(11) flagged_type"`
 	FlaggedContent string    `xorm:"TEXT flagged_content"`
 	Status         int       `xorm:"not null default 1 INT(11) status"`
+	AssigneeUserID string    `xorm:"not null default 0 BIGINT(20) assignee_user_id"`
+	HandledUserID  string    `xorm:"not null default 0 BIGINT(20) handled_user_id"`
+	HandledAt      time.Time `xorm:"TIMESTAMP handled_at"`
 }
 
 // TableName report table name
 func (Report) TableName() string {
 	return "report"
 }
+
+// ReportCaseStat the pending reports of the same object are reviewed as one case
+type ReportCaseStat struct {
+	ObjectID       string `xorm:"object_id"`
+	ReportCount    int    `xorm:"report_count"`
+	FirstReportID  string `xorm:"first_report_id"`
+	LastReportID   string `xorm:"last_report_id"`
+	AssigneeUserID string `xorm:"assignee_user_id"`
+}
@@ -0,0 +1,47 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+package entity
+
+import "time"
+
+const (
+	SearchSyncModeFull        = "full"
+	SearchSyncModeIncremental = "incremental"
+)
+
+// SearchSyncCheckpoint the progress of rebuilding the index of search plugin, one row for each plugin
+type SearchSyncCheckpoint struct {
+	ID             int64     `xorm:"not null pk autoincr BIGINT(20) id"`
+	CreatedAt      time.Time `xorm:"created TIMESTAMP created_at"`
+	UpdatedAt      time.Time `xorm:"updated TIMESTAMP updated_at"`
+	PluginSlugName string    `xorm:"not null default '' VARCHAR(100) UNIQUE plugin_slug_name"`
+	Mode           string    `xorm:"not null default '' VARCHAR(16) mode"`
+	ObjectType     string    `xorm:"not null default '' VARCHAR(16) object_type"`
+	Page           int       `xorm:"not null default 0 INT(11) page"`
+	Synced         int64     `xorm:"not null default 0 BIGINT(20) synced"`
+	Since          time.Time `xorm:"TIMESTAMP since"`
+	StartedAt      time.Time `xorm:"TIMESTAMP started_at"`
+	FinishedAt     time.Time `xorm:"TIMESTAMP finished_at"`
+	LastSyncedAt   time.Time `xorm:"TIMESTAMP last_synced_at"`
+}
+
+// TableName search sync checkpoint table name
+func (SearchSyncCheckpoint) TableName() string {
+	return "search_sync_checkpoint"
+}
@@ -49,6 +49,8 @@ type Tag struct {
 	Reserved        bool      `xorm:"not null default false BOOL reserved"`
 	RevisionID      string    `xorm:"not null default 0 BIGINT(20) revision_id"`
 	UserID          string    `xorm:"not null default 0 BIGINT(20) user_id"`
+	ParentTagID     int64     `xorm:"not null default 0 BIGINT(20) INDEX parent_tag_id"`
+	TagGroupID      int64     `xorm:"not null default 0 BIGINT(20) tag_group_id"`
 }
 
 // TableName tag table name
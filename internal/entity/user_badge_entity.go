@@ -0,0 +1,50 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package entity
+
+import "time"
+
+const (
+	BadgeLevelBronze = 1
+	BadgeLevelSilver = 2
+	BadgeLevelGold   = 3
+)
+
+// UserBadge the badge awarded to the user. Some badges are awarded once for every object, e.g. the tag badges,
+// the other badges are awarded once and their object id is 0.
+type UserBadge struct {
+	ID        int64     `xorm:"not null pk autoincr BIGINT(20) id"`
+	CreatedAt time.Time `xorm:"created TIMESTAMP created_at"`
+	UserID    string    `xorm:"not null default 0 UNIQUE(user_badge) BIGINT(20) user_id"`
+	BadgeName string    `xorm:"not null default '' UNIQUE(user_badge) VARCHAR(100) badge_name"`
+	ObjectID  string    `xorm:"not null default 0 UNIQUE(user_badge) BIGINT(20) object_id"`
+	Level     int       `xorm:"not null default 1 TINYINT(4) level"`
+}
+
+// TableName user badge table name
+func (UserBadge) TableName() string {
+	return "user_badge"
+}
+
+// UserBadgeTagStat the amount of the upvoted answers of the user in a tag
+type UserBadgeTagStat struct {
+	TagID        string `xorm:"tag_id"`
+	AnswerAmount int64  `xorm:"answer_amount"`
+}
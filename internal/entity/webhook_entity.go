@@ -0,0 +1,79 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package entity
+
+import "time"
+
+const (
+	WebhookStatusEnabled  = 1
+	WebhookStatusDisabled = 2
+)
+
+const (
+	WebhookDeliveryStatusPending = 1
+	WebhookDeliveryStatusSuccess = 2
+	WebhookDeliveryStatusFailed  = 3
+)
+
+var (
+	WebhookDeliveryStatus = map[int]string{
+		WebhookDeliveryStatusPending: "pending",
+		WebhookDeliveryStatusSuccess: "success",
+		WebhookDeliveryStatusFailed:  "failed",
+	}
+)
+
+// Webhook outgoing webhook endpoint
+type Webhook struct {
+	ID        int64     `xorm:"not null pk autoincr BIGINT(20) id"`
+	CreatedAt time.Time `xorm:"created TIMESTAMP created_at"`
+	UpdatedAt time.Time `xorm:"updated TIMESTAMP updated_at"`
+	Name      string    `xorm:"not null default '' VARCHAR(100) name"`
+	URL       string    `xorm:"not null default '' VARCHAR(512) url"`
+	Secret    string    `xorm:"not null default '' VARCHAR(128) secret"`
+	Events    string    `xorm:"not null TEXT events"`
+	Status    int       `xorm:"not null default 1 INT(11) status"`
+}
+
+// TableName webhook table name
+func (Webhook) TableName() string {
+	return "webhook"
+}
+
+// WebhookDelivery the delivery log of webhook
+type WebhookDelivery struct {
+	ID           int64     `xorm:"not null pk autoincr BIGINT(20) id"`
+	CreatedAt    time.Time `xorm:"created TIMESTAMP created_at"`
+	UpdatedAt    time.Time `xorm:"updated TIMESTAMP updated_at"`
+	WebhookID    int64     `xorm:"not null default 0 index BIGINT(20) webhook_id"`
+	Event        string    `xorm:"not null default '' VARCHAR(64) event"`
+	Payload      string    `xorm:"not null MEDIUMTEXT payload"`
+	Status       int       `xorm:"not null default 1 INT(11) status"`
+	Attempts     int       `xorm:"not null default 0 INT(11) attempts"`
+	ResponseCode int       `xorm:"not null default 0 INT(11) response_code"`
+	ResponseBody string    `xorm:"TEXT response_body"`
+	Error        string    `xorm:"TEXT error"`
+	Duration     int64     `xorm:"not null default 0 BIGINT(20) duration"`
+}
+
+// TableName webhook delivery table name
+func (WebhookDelivery) TableName() string {
+	return "webhook_delivery"
+}
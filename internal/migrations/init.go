@@ -62,6 +62,7 @@ type InitNeedUserInputData struct {
 func (m *Mentor) InitDB() error {
 	m.do("check table exist", m.checkTableExist)
 	m.do("sync table", m.syncTable)
+	m.do("init full-text search index", m.initFullTextSearchIndex)
 	m.do("init version table", m.initVersionTable)
 	m.do("init admin user", m.initAdminUser)
 	m.do("init config", m.initConfig)
@@ -103,6 +104,12 @@ func (m *Mentor) syncTable() {
 	m.err = m.engine.Context(m.ctx).Sync(tables...)
 }
 
+func (m *Mentor) initFullTextSearchIndex() {
+	session := m.engine.NewSession()
+	defer session.Close()
+	m.err = addFullTextSearchIndex(m.ctx, session)
+}
+
 func (m *Mentor) initVersionTable() {
 	_, m.err = m.engine.Context(m.ctx).Insert(&entity.Version{ID: 1, VersionNumber: ExpectedVersion()})
 }
@@ -124,6 +131,10 @@ func (m *Mentor) initAdminUser() {
 
 func (m *Mentor) initConfig() {
 	_, m.err = m.engine.Context(m.ctx).Insert(defaultConfigTable)
+	if m.err != nil {
+		return
+	}
+	_, m.err = m.engine.Context(m.ctx).Insert(newEmailUnsubscribeSecretConfig())
 }
 
 func (m *Mentor) initDefaultRankPrivileges() {
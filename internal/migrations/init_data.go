@@ -69,6 +69,20 @@ var (
 		&entity.UserNotificationConfig{},
 		&entity.PluginUserConfig{},
 		&entity.Review{},
+		&entity.QueueJob{},
+		&entity.Webhook{},
+		&entity.WebhookDelivery{},
+		&entity.APIToken{},
+		&entity.SearchSyncCheckpoint{},
+		&entity.ImportIDMapping{},
+		&entity.AuditLog{},
+		&entity.NotificationDigest{},
+		&entity.NotificationMute{},
+		&entity.EmailDelivery{},
+		&entity.TagGroup{},
+		&entity.QuestionBounty{},
+		&entity.UserBadge{},
+		&entity.Attachment{},
 	}
 
 	roles = []*entity.Role{
@@ -343,5 +357,8 @@ var (
 		{ID: 128, Key: "rank.answer.undeleted", Value: `-1`},
 		{ID: 129, Key: "rank.question.undeleted", Value: `-1`},
 		{ID: 130, Key: "rank.tag.undeleted", Value: `-1`},
+		{ID: 131, Key: "question.bounty_offered", Value: `0`},
+		{ID: 132, Key: "question.bounty_expired", Value: `0`},
+		{ID: 133, Key: "answer.bounty_awarded", Value: `0`},
 	}
 )
@@ -22,26 +22,37 @@ package migrations
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/apache/incubator-answer/internal/base/data"
 	"github.com/apache/incubator-answer/internal/entity"
 	"xorm.io/xorm"
+	"xorm.io/xorm/schemas"
 )
 
 const minDBVersion = 0
 
-// Migration describes on migration from lower version to high version
+// Migration describes on migration from lower version to high version.
+// Migrate runs on the session that the runner creates for it, the session is in a transaction
+// if the database supports transactional DDL, so the migration must not begin or commit a transaction by itself.
 type Migration interface {
 	Version() string
 	Description() string
-	Migrate(ctx context.Context, x *xorm.Engine) error
+	Migrate(ctx context.Context, x *xorm.Session) error
 	ShouldCleanCache() bool
 }
 
+// RollbackMigration describes the migration that can be reverted,
+// Rollback reverts the changes of Migrate and should succeed even if Migrate is partially done.
+type RollbackMigration interface {
+	Migration
+	Rollback(ctx context.Context, x *xorm.Session) error
+}
+
 type migration struct {
 	version          string
 	description      string
-	migrate          func(ctx context.Context, x *xorm.Engine) error
+	migrate          func(ctx context.Context, x *xorm.Session) error
 	shouldCleanCache bool
 }
 
@@ -56,7 +67,7 @@ func (m *migration) Description() string {
 }
 
 // Migrate executes the migration
-func (m *migration) Migrate(ctx context.Context, x *xorm.Engine) error {
+func (m *migration) Migrate(ctx context.Context, x *xorm.Session) error {
 	return m.migrate(ctx, x)
 }
 
@@ -65,13 +76,32 @@ func (m *migration) ShouldCleanCache() bool {
 	return m.shouldCleanCache
 }
 
+type rollbackMigration struct {
+	*migration
+	rollback func(ctx context.Context, x *xorm.Session) error
+}
+
+// Rollback reverts the migration
+func (m *rollbackMigration) Rollback(ctx context.Context, x *xorm.Session) error {
+	return m.rollback(ctx, x)
+}
+
 // NewMigration creates a new migration
-func NewMigration(version, desc string, fn func(ctx context.Context, x *xorm.Engine) error, shouldCleanCache bool) Migration {
+func NewMigration(version, desc string, fn func(ctx context.Context, x *xorm.Session) error, shouldCleanCache bool) Migration {
 	return &migration{version: version, description: desc, migrate: fn, shouldCleanCache: shouldCleanCache}
 }
 
+// NewMigrationWithRollback creates a new migration that can be reverted
+func NewMigrationWithRollback(version, desc string, fn, rollback func(ctx context.Context, x *xorm.Session) error,
+	shouldCleanCache bool) Migration {
+	return &rollbackMigration{
+		migration: &migration{version: version, description: desc, migrate: fn, shouldCleanCache: shouldCleanCache},
+		rollback:  rollback,
+	}
+}
+
 // Use noopMigration when there is a migration that has been no-oped
-var noopMigration = func(_ context.Context, _ *xorm.Engine) error { return nil }
+var noopMigration = func(_ context.Context, _ *xorm.Session) error { return nil }
 
 var migrations = []Migration{
 	// 0->1
@@ -97,12 +127,48 @@ var migrations = []Migration{
 	NewMigration("v1.2.5", "add notification plugin and theme config", addNotificationPluginAndThemeConfig, true),
 	NewMigration("v1.3.0", "add review", addReview, false),
 	NewMigration("v1.3.6", "add hot score to question table", addQuestionHotScore, true),
+	NewMigration("v1.3.7", "add queue job table", addQueueJob, false),
+	NewMigration("v1.3.8", "add webhook", addWebhook, false),
+	NewMigration("v1.3.9", "add api token", addAPIToken, false),
+	NewMigration("v1.4.0", "add full-text search index", addFullTextSearchIndex, false),
+	NewMigration("v1.4.1", "add search sync checkpoint", addSearchSyncCheckpoint, false),
+	NewMigration("v1.4.2", "add import id mapping", addImportIDMapping, false),
+	NewMigration("v1.4.3", "add audit log", addAuditLog, false),
+	NewMigration("v1.4.4", "add notification digest", addNotificationDigest, false),
+	NewMigration("v1.4.5", "add notification mute", addNotificationMute, false),
+	NewMigration("v1.4.6", "add email delivery", addEmailDelivery, false),
+	NewMigration("v1.4.7", "add reply to of email delivery", addEmailDeliveryReplyTo, false),
+	NewMigration("v1.4.8", "add report assignee and handler", addReportTriage, false),
+	NewMigration("v1.4.9", "add tag hierarchy and tag group", addTagHierarchy, false),
+	NewMigrationWithRollback("v1.4.10", "add question bounty", addQuestionBounty, removeQuestionBounty, true),
+	NewMigrationWithRollback("v1.4.11", "add user badge", addUserBadge, removeUserBadge, false),
+	NewMigrationWithRollback("v1.4.12", "add attachment", addAttachment, removeAttachment, false),
+	NewMigration("v1.4.13", "add claimed at of queue job", addQueueJobClaimedAt, false),
+	NewMigration("v1.4.14", "add email unsubscribe secret", addEmailUnsubscribeSecret, false),
 }
 
 func GetMigrations() []Migration {
 	return migrations
 }
 
+// GetTables returns all the entities whose tables are managed by answer
+func GetTables() []interface{} {
+	return tables
+}
+
+// InitSchema creates all the tables and full-text search indexes of the latest version
+func InitSchema(ctx context.Context, x *xorm.Engine) error {
+	if err := x.Context(ctx).Sync(tables...); err != nil {
+		return fmt.Errorf("sync table failed: %v", err)
+	}
+	session := x.NewSession()
+	defer session.Close()
+	if err := addFullTextSearchIndex(ctx, session); err != nil {
+		return fmt.Errorf("init full-text search index failed: %v", err)
+	}
+	return nil
+}
+
 // GetCurrentDBVersion returns the current db version
 func GetCurrentDBVersion(engine *xorm.Engine) (int64, error) {
 	if err := engine.Sync(new(entity.Version)); err != nil {
@@ -129,7 +195,9 @@ func ExpectedVersion() int64 {
 	return int64(minDBVersion + len(migrations))
 }
 
-// Migrate database to current version
+// Migrate database to current version.
+// If a migration fails, the migrations applied in this upgrade are reverted in reverse order as long as
+// they can be rolled back, and the version row is set to the last version that is still applied.
 func Migrate(debug bool, dbConf *data.Database, cacheConf *data.CacheConf, upgradeToSpecificVersion string) error {
 	cache, cacheCleanup, err := data.NewCache(cacheConf)
 	if err != nil {
@@ -146,40 +214,157 @@ func Migrate(debug bool, dbConf *data.Database, cacheConf *data.CacheConf, upgra
 	if err != nil {
 		return err
 	}
-	expectedVersion := ExpectedVersion()
-	if len(upgradeToSpecificVersion) > 0 {
-		fmt.Printf("[migrate] user set upgrade to version: %s\n", upgradeToSpecificVersion)
-		for i, m := range migrations {
-			if m.Version() == upgradeToSpecificVersion {
-				currentDBVersion = int64(i)
-				break
+	fromVersion := startVersion(currentDBVersion, upgradeToSpecificVersion)
+	err = migrate(context.Background(), engine, currentDBVersion, fromVersion)
+	if cache != nil {
+		for _, m := range migrations[min64(fromVersion, ExpectedVersion()):] {
+			if !m.ShouldCleanCache() {
+				continue
+			}
+			if err := cache.Flush(context.Background()); err != nil {
+				fmt.Printf("[migrate] flush cache failed: %s\n", err.Error())
 			}
+			break
+		}
+		cacheCleanup()
+	}
+	return err
+}
+
+// startVersion the db version that the upgrade starts from
+func startVersion(currentDBVersion int64, upgradeToSpecificVersion string) int64 {
+	if len(upgradeToSpecificVersion) == 0 {
+		return currentDBVersion
+	}
+	fmt.Printf("[migrate] user set upgrade to version: %s\n", upgradeToSpecificVersion)
+	for i, m := range migrations {
+		if m.Version() == upgradeToSpecificVersion {
+			return int64(i)
 		}
 	}
+	return currentDBVersion
+}
+
+func migrate(ctx context.Context, engine *xorm.Engine, currentDBVersion, fromVersion int64) error {
+	expectedVersion := ExpectedVersion()
+	useTx := supportTransactionalDDL(engine)
+	if useTx && engine.DB().Stats().MaxOpenConnections == 1 {
+		// xorm loads the info of the existing tables by the engine instead of the session when syncing,
+		// so one more connection is required while the transaction holds the only one
+		engine.SetMaxOpenConns(2)
+	}
+	if fromVersion < expectedVersion {
+		fmt.Printf("[migrate] %d migrations to apply, run in transaction: %t\n", expectedVersion-fromVersion, useTx)
+	}
 
-	for currentDBVersion < expectedVersion {
+	for version := fromVersion; version < expectedVersion; version++ {
 		fmt.Printf("[migrate] current db version is %d, try to migrate version %d, latest version is %d\n",
-			currentDBVersion, currentDBVersion+1, expectedVersion)
-		migrationFunc := migrations[currentDBVersion]
-		fmt.Printf("[migrate] try to migrate Answer version %s, description: %s\n", migrationFunc.Version(), migrationFunc.Description())
-		if err := migrationFunc.Migrate(context.Background(), engine); err != nil {
-			fmt.Printf("[migrate] migrate to db version %d failed: %s\n", currentDBVersion+1, err.Error())
+			version, version+1, expectedVersion)
+		m := migrations[version]
+		fmt.Printf("[migrate] try to migrate Answer version %s, description: %s\n", m.Version(), m.Description())
+		startAt := time.Now()
+		err := runInSession(ctx, engine, useTx, func(session *xorm.Session) error {
+			if err := m.Migrate(ctx, session); err != nil {
+				return err
+			}
+			return setDBVersion(ctx, session, version+1)
+		})
+		if err != nil {
+			fmt.Printf("[migrate] migrate to db version %d failed: %s\n", version+1, err.Error())
+			// the changes of the failed migration are not reverted if the database does not support transactional DDL
+			if rm, ok := m.(RollbackMigration); ok && !useTx {
+				rbErr := runInSession(ctx, engine, useTx, func(session *xorm.Session) error {
+					return rm.Rollback(ctx, session)
+				})
+				if rbErr != nil {
+					fmt.Printf("[migrate] revert the failed version %s failed: %s\n", m.Version(), rbErr.Error())
+					return err
+				}
+			}
+			rollback(ctx, engine, useTx, version, max64(currentDBVersion, fromVersion))
 			return err
 		}
-		if migrationFunc.ShouldCleanCache() {
-			if err := cache.Flush(context.Background()); err != nil {
-				fmt.Printf("[migrate] flush cache failed: %s\n", err.Error())
+		fmt.Printf("[migrate] migrate to db version %d success, cost %s\n", version+1, time.Since(startAt))
+	}
+	return nil
+}
+
+// rollback reverts the applied migrations from fromVersion down to toVersion,
+// it stops at the first migration that can not be rolled back.
+func rollback(ctx context.Context, engine *xorm.Engine, useTx bool, fromVersion, toVersion int64) {
+	for version := fromVersion; version > toVersion; version-- {
+		m := migrations[version-1]
+		rm, ok := m.(RollbackMigration)
+		if !ok {
+			fmt.Printf("[migrate] version %s can not be rolled back, db version stays at %d\n", m.Version(), version)
+			return
+		}
+		fmt.Printf("[migrate] try to roll back Answer version %s\n", m.Version())
+		err := runInSession(ctx, engine, useTx, func(session *xorm.Session) error {
+			if err := rm.Rollback(ctx, session); err != nil {
+				return err
 			}
+			return setDBVersion(ctx, session, version-1)
+		})
+		if err != nil {
+			fmt.Printf("[migrate] roll back version %s failed, db version stays at %d: %s\n",
+				m.Version(), version, err.Error())
+			return
 		}
-		fmt.Printf("[migrate] migrate to db version %d success\n", currentDBVersion+1)
-		if _, err := engine.Update(&entity.Version{ID: 1, VersionNumber: currentDBVersion + 1}); err != nil {
-			fmt.Printf("[migrate] migrate to db version %d, update failed: %s", currentDBVersion+1, err.Error())
-			return err
+		fmt.Printf("[migrate] roll back to db version %d success\n", version-1)
+	}
+}
+
+// supportTransactionalDDL MySQL commits the schema changes implicitly, so the migration can't be reverted by transaction
+func supportTransactionalDDL(engine *xorm.Engine) bool {
+	switch engine.Dialect().URI().DBType {
+	case schemas.SQLITE, schemas.POSTGRES:
+		return true
+	default:
+		return false
+	}
+}
+
+// runInSession run fn with a new session, the session is in a transaction if useTx is true
+func runInSession(ctx context.Context, engine *xorm.Engine, useTx bool, fn func(session *xorm.Session) error) error {
+	session := engine.NewSession().Context(ctx)
+	defer session.Close()
+	if !useTx {
+		return fn(session)
+	}
+	if err := session.Begin(); err != nil {
+		return fmt.Errorf("begin transaction failed: %w", err)
+	}
+	if err := fn(session); err != nil {
+		if rbErr := session.Rollback(); rbErr != nil {
+			fmt.Printf("[migrate] roll back transaction failed: %s\n", rbErr.Error())
 		}
-		currentDBVersion++
+		return err
 	}
-	if cache != nil {
-		cacheCleanup()
+	if err := session.Commit(); err != nil {
+		return fmt.Errorf("commit transaction failed: %w", err)
+	}
+	return nil
+}
+
+func setDBVersion(ctx context.Context, session *xorm.Session, version int64) error {
+	_, err := session.Context(ctx).ID(1).Cols("version_number").Update(&entity.Version{VersionNumber: version})
+	if err != nil {
+		return fmt.Errorf("update db version to %d failed: %w", version, err)
 	}
 	return nil
 }
+
+func min64(a, b int64) int64 {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+func max64(a, b int64) int64 {
+	if a > b {
+		return a
+	}
+	return b
+}
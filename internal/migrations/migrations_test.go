@@ -0,0 +1,195 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package migrations
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/apache/incubator-answer/internal/base/data"
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"xorm.io/xorm"
+)
+
+type testMigrationTable struct {
+	ID   int    `xorm:"not null pk autoincr INT(11) id"`
+	Name string `xorm:"VARCHAR(100) name"`
+}
+
+func newTestEngine(t *testing.T) *xorm.Engine {
+	engine, err := data.NewDB(false, &data.Database{
+		Driver:     "sqlite3",
+		Connection: filepath.Join(t.TempDir(), "answer.db"),
+	})
+	assert.NoError(t, err)
+	t.Cleanup(func() { _ = engine.Close() })
+	return engine
+}
+
+func useTestMigrations(t *testing.T, testMigrations []Migration) {
+	origin := migrations
+	migrations = testMigrations
+	t.Cleanup(func() { migrations = origin })
+}
+
+func syncTestTable(name string) func(ctx context.Context, x *xorm.Session) error {
+	return func(ctx context.Context, x *xorm.Session) error {
+		return x.Context(ctx).Table(name).Sync(new(testMigrationTable))
+	}
+}
+
+func dropTestTable(name string) func(ctx context.Context, x *xorm.Session) error {
+	return func(ctx context.Context, x *xorm.Session) error {
+		return x.Context(ctx).DropTable(name)
+	}
+}
+
+func TestMigrate_RollbackOnFailure(t *testing.T) {
+	engine := newTestEngine(t)
+	testMigrations := []Migration{
+		NewMigration("v0.0.1", "first", syncTestTable("t1"), false),
+		NewMigrationWithRollback("v0.0.2", "second", syncTestTable("t2"), dropTestTable("t2"), false),
+		NewMigrationWithRollback("v0.0.3", "third", syncTestTable("t3"), dropTestTable("t3"), false),
+		NewMigration("v0.0.4", "failed", func(ctx context.Context, x *xorm.Session) error {
+			if err := syncTestTable("t4")(ctx, x); err != nil {
+				return err
+			}
+			return fmt.Errorf("failed")
+		}, false),
+	}
+	ctx := context.Background()
+	_, err := GetCurrentDBVersion(engine)
+	assert.NoError(t, err)
+	useTestMigrations(t, testMigrations[:1])
+	assert.NoError(t, migrate(ctx, engine, 0, 0))
+	useTestMigrations(t, testMigrations)
+	assert.Error(t, migrate(ctx, engine, 1, 1))
+	// the failed migration is reverted by transaction, the applied ones by rollback
+	for name, exist := range map[string]bool{"t1": true, "t2": false, "t3": false, "t4": false} {
+		actual, err := engine.IsTableExist(name)
+		assert.NoError(t, err)
+		assert.Equal(t, exist, actual, name)
+	}
+	version, err := readDBVersion(engine)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), version)
+}
+
+func TestMigrate_RollbackStopsAtIrreversibleMigration(t *testing.T) {
+	engine := newTestEngine(t)
+	useTestMigrations(t, []Migration{
+		NewMigrationWithRollback("v0.0.1", "first", syncTestTable("t1"), dropTestTable("t1"), false),
+		NewMigration("v0.0.2", "second", syncTestTable("t2"), false),
+		NewMigrationWithRollback("v0.0.3", "third", syncTestTable("t3"), dropTestTable("t3"), false),
+		NewMigration("v0.0.4", "failed", func(ctx context.Context, x *xorm.Session) error {
+			return fmt.Errorf("failed")
+		}, false),
+	})
+	ctx := context.Background()
+	_, err := GetCurrentDBVersion(engine)
+	assert.NoError(t, err)
+	assert.Error(t, migrate(ctx, engine, 0, 0))
+	version, err := readDBVersion(engine)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), version)
+	exist, err := engine.IsTableExist("t3")
+	assert.NoError(t, err)
+	assert.False(t, exist)
+}
+
+func TestMigrate_SyncExistingTableInTransaction(t *testing.T) {
+	engine := newTestEngine(t)
+	useTestMigrations(t, []Migration{
+		NewMigration("v0.0.1", "first", syncTestTable("t1"), false),
+		NewMigration("v0.0.2", "sync again", syncTestTable("t1"), false),
+		NewMigration("v0.0.3", "failed", func(ctx context.Context, x *xorm.Session) error {
+			if _, err := x.Context(ctx).Table("t1").Insert(&testMigrationTable{Name: "name"}); err != nil {
+				return err
+			}
+			return fmt.Errorf("failed")
+		}, false),
+	})
+	ctx := context.Background()
+	_, err := GetCurrentDBVersion(engine)
+	assert.NoError(t, err)
+	assert.Error(t, migrate(ctx, engine, 0, 0))
+	version, err := readDBVersion(engine)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), version)
+	count, err := engine.Table("t1").Count()
+	assert.NoError(t, err)
+	assert.Equal(t, int64(0), count)
+}
+
+func TestMigrate_UpgradeFromSQLiteV22(t *testing.T) {
+	engine := newTestEngine(t)
+	schema, err := os.ReadFile(filepath.Join("testdata", "sqlite_v22.sql"))
+	require.NoError(t, err)
+	for _, statement := range strings.Split(string(schema), ";\n") {
+		if strings.Contains(statement, "CREATE") || strings.Contains(statement, "INSERT") {
+			_, err = engine.Exec(statement)
+			require.NoError(t, err)
+		}
+	}
+	_, err = engine.Exec("INSERT INTO `tag` (`id`, `slug_name`, `display_name`, `original_text`, `parsed_text`) " +
+		"VALUES (1, 'go', 'Go', '', '')")
+	require.NoError(t, err)
+
+	currentDBVersion, err := GetCurrentDBVersion(engine)
+	require.NoError(t, err)
+	assert.Equal(t, int64(22), currentDBVersion)
+	assert.NoError(t, migrate(context.Background(), engine, currentDBVersion, currentDBVersion))
+	version, err := readDBVersion(engine)
+	assert.NoError(t, err)
+	assert.Equal(t, ExpectedVersion(), version)
+
+	changes, err := SchemaChanges(engine)
+	assert.NoError(t, err)
+	assert.Empty(t, changes)
+	tag := &entity.Tag{}
+	exist, err := engine.ID(1).Get(tag)
+	assert.NoError(t, err)
+	assert.True(t, exist)
+	assert.Equal(t, int64(0), tag.ParentTagID)
+}
+
+func TestSchemaChanges(t *testing.T) {
+	engine := newTestEngine(t)
+	version, err := readDBVersion(engine)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(0), version)
+
+	assert.NoError(t, engine.Sync(new(entity.Version), new(entity.Config)))
+	changes, err := SchemaChanges(engine)
+	assert.NoError(t, err)
+	assert.Contains(t, changes, "create table user_badge")
+	assert.NotContains(t, changes, "create table config")
+
+	assert.NoError(t, InitSchema(context.Background(), engine))
+	changes, err = SchemaChanges(engine)
+	assert.NoError(t, err)
+	assert.Empty(t, changes)
+}
@@ -0,0 +1,172 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package migrations
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/apache/incubator-answer/internal/base/data"
+	"github.com/apache/incubator-answer/internal/entity"
+	"xorm.io/xorm"
+	"xorm.io/xorm/schemas"
+)
+
+// MigrateStatus print the applied and pending migrations
+func MigrateStatus(dbConf *data.Database) error {
+	engine, err := data.NewDB(false, dbConf)
+	if err != nil {
+		fmt.Println("new database failed: ", err.Error())
+		return err
+	}
+	defer engine.Close()
+
+	currentDBVersion, err := readDBVersion(engine)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("[status] current db version is %d, latest version is %d\n", currentDBVersion, ExpectedVersion())
+	for i, m := range migrations {
+		status := "applied"
+		if int64(i) >= currentDBVersion {
+			status = "pending"
+		}
+		rollbackable := ""
+		if _, ok := m.(RollbackMigration); ok {
+			rollbackable = " (rollback)"
+		}
+		fmt.Printf("[status] %3d %-15s %-8s %s%s\n", i+1, m.Version(), status, m.Description(), rollbackable)
+	}
+	return nil
+}
+
+// MigrateDryRun print the pending migrations and the schema changes of the upgrade, nothing is changed.
+// The migrations are not executed, so the schema changes are the difference between the database and
+// the schema of the latest version, whatever version the upgrade starts from, and the data changes are not listed.
+func MigrateDryRun(dbConf *data.Database, upgradeToSpecificVersion string) error {
+	engine, err := data.NewDB(false, dbConf)
+	if err != nil {
+		fmt.Println("new database failed: ", err.Error())
+		return err
+	}
+	defer engine.Close()
+
+	currentDBVersion, err := readDBVersion(engine)
+	if err != nil {
+		return err
+	}
+	fromVersion := startVersion(currentDBVersion, upgradeToSpecificVersion)
+	expectedVersion := ExpectedVersion()
+	if fromVersion >= expectedVersion {
+		fmt.Printf("[dry-run] db version %d is the latest, nothing to migrate\n", currentDBVersion)
+		return nil
+	}
+	fmt.Printf("[dry-run] current db version is %d, %d migrations to apply, run in transaction: %t\n",
+		currentDBVersion, expectedVersion-fromVersion, supportTransactionalDDL(engine))
+	if fromVersion != currentDBVersion {
+		fmt.Printf("[dry-run] upgrade starts from db version %d\n", fromVersion)
+	}
+	for version := fromVersion; version < expectedVersion; version++ {
+		m := migrations[version]
+		fmt.Printf("[dry-run] migrate to db version %d, Answer version %s, description: %s\n",
+			version+1, m.Version(), m.Description())
+	}
+
+	changes, err := SchemaChanges(engine)
+	if err != nil {
+		return err
+	}
+	fmt.Println("[dry-run] schema changes are diffed against the latest schema, " +
+		"the data changes of the migrations above are not listed")
+	if len(changes) == 0 {
+		fmt.Println("[dry-run] no schema changes")
+		return nil
+	}
+	for _, change := range changes {
+		fmt.Printf("[dry-run] schema change: %s\n", change)
+	}
+	return nil
+}
+
+// SchemaChanges the changes that make the schema of database match the tables of the latest version.
+// Only the missing tables, columns and indexes are listed, because the upgrade never drops them.
+func SchemaChanges(engine *xorm.Engine) (changes []string, err error) {
+	dbTables, err := engine.DBMetas()
+	if err != nil {
+		return nil, fmt.Errorf("get database tables failed: %w", err)
+	}
+	dbTableMapping := make(map[string]*schemas.Table, len(dbTables))
+	for _, table := range dbTables {
+		dbTableMapping[strings.ToLower(table.Name)] = table
+	}
+
+	for _, bean := range tables {
+		table, err := engine.TableInfo(bean)
+		if err != nil {
+			return nil, fmt.Errorf("parse table failed: %w", err)
+		}
+		dbTable, ok := dbTableMapping[strings.ToLower(table.Name)]
+		if !ok {
+			changes = append(changes, fmt.Sprintf("create table %s", table.Name))
+			continue
+		}
+		for _, col := range table.Columns() {
+			if dbTable.GetColumn(col.Name) == nil {
+				changes = append(changes, fmt.Sprintf("add column %s.%s %s",
+					table.Name, col.Name, engine.Dialect().SQLType(col)))
+			}
+		}
+		indexNames := make([]string, 0, len(table.Indexes))
+		for name := range table.Indexes {
+			indexNames = append(indexNames, name)
+		}
+		sort.Strings(indexNames)
+		for _, name := range indexNames {
+			if _, ok := dbTable.Indexes[name]; ok {
+				continue
+			}
+			index := table.Indexes[name]
+			kind := "index"
+			if index.Type == schemas.UniqueType {
+				kind = "unique index"
+			}
+			changes = append(changes, fmt.Sprintf("add %s %s on %s (%s)",
+				kind, index.XName(table.Name), table.Name, strings.Join(index.Cols, ", ")))
+		}
+	}
+	return changes, nil
+}
+
+// readDBVersion read the db version without creating the version table
+func readDBVersion(engine *xorm.Engine) (int64, error) {
+	exist, err := engine.IsTableExist(new(entity.Version))
+	if err != nil {
+		return -1, fmt.Errorf("check version table failed: %v", err)
+	}
+	if !exist {
+		return 0, nil
+	}
+	currentVersion := &entity.Version{ID: 1}
+	if _, err = engine.Get(currentVersion); err != nil {
+		return -1, fmt.Errorf("get first version failed: %v", err)
+	}
+	return currentVersion.VersionNumber, nil
+}
@@ -0,0 +1,69 @@
+--
+-- Licensed to the Apache Software Foundation (ASF) under one
+-- or more contributor license agreements.  See the NOTICE file
+-- distributed with this work for additional information
+-- regarding copyright ownership.  The ASF licenses this file
+-- to you under the Apache License, Version 2.0 (the
+-- "License"); you may not use this file except in compliance
+-- with the License.  You may obtain a copy of the License at
+--
+--   http://www.apache.org/licenses/LICENSE-2.0
+--
+-- Unless required by applicable law or agreed to in writing,
+-- software distributed under the License is distributed on an
+-- "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+-- KIND, either express or implied.  See the License for the
+-- specific language governing permissions and limitations
+-- under the License.
+
+-- the schema of the database installed by Answer v1.3.6 (db version 22) before the tag hierarchy and bounty migrations
+CREATE TABLE `activity` (`id` INTEGER PRIMARY KEY AUTOINCREMENT NOT NULL, `created_at` DATETIME NULL, `updated_at` DATETIME NULL, `cancelled_at` DATETIME NULL, `user_id` INTEGER NOT NULL, `trigger_user_id` INTEGER DEFAULT 0 NOT NULL, `object_id` INTEGER DEFAULT 0 NOT NULL, `original_object_id` INTEGER DEFAULT 0 NOT NULL, `activity_type` INTEGER NOT NULL, `cancelled` INTEGER DEFAULT 0 NOT NULL, `rank` INTEGER DEFAULT 0 NOT NULL, `has_rank` INTEGER DEFAULT 0 NOT NULL, `revision_id` INTEGER DEFAULT 0 NOT NULL);
+CREATE INDEX `IDX_activity_trigger_user_id` ON `activity` (`trigger_user_id`);
+CREATE INDEX `IDX_activity_object_id` ON `activity` (`object_id`);
+CREATE INDEX `IDX_activity_user_id` ON `activity` (`user_id`);
+CREATE TABLE `answer` (`id` INTEGER PRIMARY KEY AUTOINCREMENT NOT NULL, `created_at` DATETIME DEFAULT CURRENT_TIMESTAMP NOT NULL, `updated_at` DATETIME NULL, `question_id` INTEGER DEFAULT 0 NOT NULL, `user_id` INTEGER DEFAULT 0 NOT NULL, `last_edit_user_id` INTEGER DEFAULT 0 NOT NULL, `original_text` TEXT NOT NULL, `parsed_text` TEXT NOT NULL, `status` INTEGER DEFAULT 1 NOT NULL, `adopted` INTEGER DEFAULT 1 NOT NULL, `comment_count` INTEGER DEFAULT 0 NOT NULL, `vote_count` INTEGER DEFAULT 0 NOT NULL, `revision_id` INTEGER DEFAULT 0 NOT NULL);
+CREATE INDEX `IDX_answer_user_id` ON `answer` (`user_id`);
+CREATE TABLE `collection` (`id` INTEGER PRIMARY KEY DEFAULT 0 NOT NULL, `created_at` DATETIME DEFAULT CURRENT_TIMESTAMP NOT NULL, `updated_at` DATETIME DEFAULT CURRENT_TIMESTAMP NOT NULL, `user_id` INTEGER DEFAULT 0 NOT NULL, `object_id` INTEGER DEFAULT 0 NOT NULL, `user_collection_group_id` INTEGER DEFAULT 0 NOT NULL);
+CREATE INDEX `IDX_collection_user_id` ON `collection` (`user_id`);
+CREATE TABLE `collection_group` (`id` INTEGER PRIMARY KEY AUTOINCREMENT NOT NULL, `created_at` DATETIME DEFAULT CURRENT_TIMESTAMP NOT NULL, `updated_at` DATETIME DEFAULT CURRENT_TIMESTAMP NOT NULL, `user_id` INTEGER DEFAULT 0 NOT NULL, `name` TEXT DEFAULT '' NOT NULL, `default_group` INTEGER DEFAULT 1 NOT NULL);
+CREATE INDEX `IDX_collection_group_user_id` ON `collection_group` (`user_id`);
+CREATE TABLE `comment` (`id` INTEGER PRIMARY KEY AUTOINCREMENT NOT NULL, `created_at` DATETIME NULL, `updated_at` DATETIME NULL, `user_id` INTEGER DEFAULT 0 NOT NULL, `reply_user_id` INTEGER NULL, `reply_comment_id` INTEGER NULL, `object_id` INTEGER DEFAULT 0 NOT NULL, `question_id` INTEGER DEFAULT 0 NOT NULL, `vote_count` INTEGER DEFAULT 0 NOT NULL, `status` INTEGER DEFAULT 0 NOT NULL, `original_text` TEXT NOT NULL, `parsed_text` TEXT NOT NULL);
+CREATE INDEX `IDX_comment_object_id` ON `comment` (`object_id`);
+CREATE TABLE `config` (`id` INTEGER PRIMARY KEY AUTOINCREMENT NOT NULL, `key` TEXT NULL, `value` TEXT NULL);
+CREATE UNIQUE INDEX `UQE_config_key` ON `config` (`key`);
+CREATE TABLE `meta` (`id` INTEGER PRIMARY KEY AUTOINCREMENT NOT NULL, `created_at` DATETIME DEFAULT CURRENT_TIMESTAMP NOT NULL, `updated_at` DATETIME DEFAULT CURRENT_TIMESTAMP NOT NULL, `object_id` INTEGER DEFAULT 0 NOT NULL, `key` TEXT NOT NULL, `value` TEXT NOT NULL);
+CREATE INDEX `IDX_meta_object_id` ON `meta` (`object_id`);
+CREATE TABLE `notification` (`id` INTEGER PRIMARY KEY AUTOINCREMENT NOT NULL, `created_at` DATETIME NULL, `updated_at` DATETIME NULL, `user_id` INTEGER DEFAULT 0 NOT NULL, `object_id` INTEGER DEFAULT 0 NOT NULL, `content` TEXT NOT NULL, `type` INTEGER DEFAULT 0 NOT NULL, `msg_type` INTEGER DEFAULT 0 NOT NULL, `is_read` INTEGER DEFAULT 1 NOT NULL, `status` INTEGER DEFAULT 1 NOT NULL);
+CREATE INDEX `IDX_notification_user_id` ON `notification` (`user_id`);
+CREATE INDEX `IDX_notification_object_id` ON `notification` (`object_id`);
+CREATE TABLE `question` (`id` INTEGER PRIMARY KEY NOT NULL, `created_at` DATETIME DEFAULT CURRENT_TIMESTAMP NOT NULL, `updated_at` DATETIME NULL, `user_id` INTEGER DEFAULT 0 NOT NULL, `invite_user_id` TEXT NULL, `last_edit_user_id` INTEGER DEFAULT 0 NOT NULL, `title` TEXT DEFAULT '' NOT NULL, `original_text` TEXT NOT NULL, `parsed_text` TEXT NOT NULL, `pin` INTEGER DEFAULT 1 NOT NULL, `show` INTEGER DEFAULT 1 NOT NULL, `status` INTEGER DEFAULT 1 NOT NULL, `view_count` INTEGER DEFAULT 0 NOT NULL, `unique_view_count` INTEGER DEFAULT 0 NOT NULL, `vote_count` INTEGER DEFAULT 0 NOT NULL, `answer_count` INTEGER DEFAULT 0 NOT NULL, `hot_score` INTEGER DEFAULT 0 NOT NULL, `collection_count` INTEGER DEFAULT 0 NOT NULL, `follow_count` INTEGER DEFAULT 0 NOT NULL, `accepted_answer_id` INTEGER DEFAULT 0 NOT NULL, `last_answer_id` INTEGER DEFAULT 0 NOT NULL, `post_update_time` DATETIME NULL, `revision_id` INTEGER DEFAULT 0 NOT NULL);
+CREATE INDEX `IDX_question_user_id` ON `question` (`user_id`);
+CREATE TABLE `report` (`id` INTEGER PRIMARY KEY AUTOINCREMENT NOT NULL, `created_at` DATETIME NULL, `updated_at` DATETIME NULL, `user_id` INTEGER NOT NULL, `object_id` INTEGER NOT NULL, `reported_user_id` INTEGER DEFAULT 0 NOT NULL, `object_type` INTEGER DEFAULT 0 NOT NULL, `report_type` INTEGER DEFAULT 0 NOT NULL, `content` TEXT NOT NULL, `flagged_type` INTEGER DEFAULT 0 NOT NULL, `flagged_content` TEXT NULL, `status` INTEGER DEFAULT 1 NOT NULL);
+CREATE TABLE `revision` (`id` INTEGER PRIMARY KEY AUTOINCREMENT NOT NULL, `created_at` DATETIME NULL, `updated_at` DATETIME NULL, `user_id` INTEGER DEFAULT 0 NOT NULL, `object_type` INTEGER DEFAULT 0 NOT NULL, `object_id` INTEGER DEFAULT 0 NOT NULL, `title` TEXT DEFAULT '' NOT NULL, `content` TEXT NOT NULL, `log` TEXT NULL, `status` INTEGER DEFAULT 1 NOT NULL, `review_user_id` INTEGER DEFAULT 0 NOT NULL);
+CREATE INDEX `IDX_revision_object_id` ON `revision` (`object_id`);
+CREATE TABLE `site_info` (`id` INTEGER PRIMARY KEY AUTOINCREMENT NOT NULL, `created_at` DATETIME NULL, `updated_at` DATETIME NULL, `type` TEXT NOT NULL, `content` TEXT NOT NULL, `status` INTEGER DEFAULT 1 NOT NULL);
+CREATE TABLE `tag` (`id` INTEGER PRIMARY KEY NOT NULL, `created_at` DATETIME NULL, `updated_at` DATETIME NULL, `main_tag_id` INTEGER DEFAULT 0 NOT NULL, `main_tag_slug_name` TEXT DEFAULT '' NOT NULL, `slug_name` TEXT DEFAULT '' NOT NULL, `display_name` TEXT DEFAULT '' NOT NULL, `original_text` TEXT NOT NULL, `parsed_text` TEXT NOT NULL, `follow_count` INTEGER DEFAULT 0 NOT NULL, `question_count` INTEGER DEFAULT 0 NOT NULL, `status` INTEGER DEFAULT 1 NOT NULL, `recommend` INTEGER DEFAULT 0 NOT NULL, `reserved` INTEGER DEFAULT 0 NOT NULL, `revision_id` INTEGER DEFAULT 0 NOT NULL, `user_id` INTEGER DEFAULT 0 NOT NULL);
+CREATE UNIQUE INDEX `UQE_tag_slug_name` ON `tag` (`slug_name`);
+CREATE TABLE `tag_rel` (`id` INTEGER PRIMARY KEY AUTOINCREMENT NOT NULL, `created_at` DATETIME NULL, `updated_at` DATETIME NULL, `object_id` INTEGER NOT NULL, `tag_id` INTEGER NOT NULL, `status` INTEGER DEFAULT 1 NOT NULL);
+CREATE UNIQUE INDEX `UQE_tag_rel_s` ON `tag_rel` (`object_id`,`tag_id`);
+CREATE INDEX `IDX_tag_rel_object_id` ON `tag_rel` (`object_id`);
+CREATE INDEX `IDX_tag_rel_tag_id` ON `tag_rel` (`tag_id`);
+CREATE TABLE `uniqid` (`id` INTEGER PRIMARY KEY AUTOINCREMENT NOT NULL, `uniqid_type` INTEGER DEFAULT 0 NOT NULL);
+CREATE TABLE `user` (`id` INTEGER PRIMARY KEY AUTOINCREMENT NOT NULL, `created_at` DATETIME NULL, `updated_at` DATETIME NULL, `suspended_at` DATETIME NULL, `deleted_at` DATETIME NULL, `last_login_date` DATETIME NULL, `username` TEXT DEFAULT '' NOT NULL, `pass` TEXT DEFAULT '' NOT NULL, `e_mail` TEXT NOT NULL, `mail_status` INTEGER DEFAULT 2 NOT NULL, `notice_status` INTEGER DEFAULT 2 NOT NULL, `follow_count` INTEGER DEFAULT 0 NOT NULL, `answer_count` INTEGER DEFAULT 0 NOT NULL, `question_count` INTEGER DEFAULT 0 NOT NULL, `rank` INTEGER DEFAULT 0 NOT NULL, `status` INTEGER DEFAULT 1 NOT NULL, `authority_group` INTEGER DEFAULT 1 NOT NULL, `display_name` TEXT DEFAULT '' NOT NULL, `avatar` TEXT DEFAULT '' NOT NULL, `mobile` TEXT NOT NULL, `bio` TEXT NOT NULL, `bio_html` TEXT NOT NULL, `website` TEXT DEFAULT '' NOT NULL, `location` TEXT DEFAULT '' NOT NULL, `ip_info` TEXT DEFAULT '' NOT NULL, `is_admin` INTEGER DEFAULT 0 NOT NULL, `language` TEXT DEFAULT '' NOT NULL, `color_scheme` TEXT DEFAULT '' NOT NULL);
+CREATE UNIQUE INDEX `UQE_user_username` ON `user` (`username`);
+CREATE TABLE `version` (`id` INTEGER PRIMARY KEY AUTOINCREMENT NOT NULL, `version_number` INTEGER DEFAULT 0 NOT NULL);
+CREATE TABLE `role` (`id` INTEGER PRIMARY KEY AUTOINCREMENT NOT NULL, `created_at` DATETIME NULL, `updated_at` DATETIME NULL, `name` TEXT DEFAULT '' NOT NULL, `description` TEXT DEFAULT '' NOT NULL);
+CREATE TABLE `role_power_rel` (`id` INTEGER PRIMARY KEY AUTOINCREMENT NOT NULL, `created_at` DATETIME NULL, `updated_at` DATETIME NULL, `role_id` INTEGER DEFAULT 0 NOT NULL, `power_type` TEXT DEFAULT '' NOT NULL);
+CREATE TABLE `power` (`id` INTEGER PRIMARY KEY AUTOINCREMENT NOT NULL, `created_at` DATETIME NULL, `updated_at` DATETIME NULL, `name` TEXT DEFAULT '' NOT NULL, `power_type` TEXT DEFAULT '' NOT NULL, `description` TEXT DEFAULT '' NOT NULL);
+CREATE TABLE `user_role_rel` (`id` INTEGER PRIMARY KEY AUTOINCREMENT NOT NULL, `created_at` DATETIME NULL, `updated_at` DATETIME NULL, `user_id` INTEGER DEFAULT 0 NOT NULL, `role_id` INTEGER DEFAULT 0 NOT NULL);
+CREATE TABLE `plugin_config` (`id` INTEGER PRIMARY KEY AUTOINCREMENT NOT NULL, `plugin_slug_name` TEXT NULL, `value` TEXT NULL);
+CREATE UNIQUE INDEX `UQE_plugin_config_plugin_slug_name` ON `plugin_config` (`plugin_slug_name`);
+CREATE TABLE `user_external_login` (`id` INTEGER PRIMARY KEY AUTOINCREMENT NOT NULL, `created_at` DATETIME NULL, `updated_at` DATETIME NULL, `user_id` INTEGER DEFAULT 0 NOT NULL, `provider` TEXT DEFAULT '' NOT NULL, `external_id` TEXT DEFAULT '' NOT NULL, `meta_info` TEXT NULL);
+CREATE TABLE `user_notification_config` (`id` INTEGER PRIMARY KEY AUTOINCREMENT NOT NULL, `created_at` DATETIME NULL, `updated_at` DATETIME NULL, `user_id` INTEGER DEFAULT 0 NOT NULL, `source` TEXT DEFAULT '' NOT NULL, `channels` TEXT NOT NULL, `enabled` INTEGER DEFAULT 0 NOT NULL);
+CREATE UNIQUE INDEX `UQE_user_notification_config_uk_us` ON `user_notification_config` (`user_id`,`source`);
+CREATE INDEX `IDX_user_notification_config_user_id` ON `user_notification_config` (`user_id`);
+CREATE INDEX `IDX_user_notification_config_source` ON `user_notification_config` (`source`);
+CREATE TABLE `plugin_user_config` (`id` INTEGER PRIMARY KEY AUTOINCREMENT NOT NULL, `user_id` INTEGER DEFAULT 0 NOT NULL, `plugin_slug_name` TEXT NULL, `value` TEXT NULL);
+CREATE UNIQUE INDEX `UQE_plugin_user_config_uk_up` ON `plugin_user_config` (`user_id`,`plugin_slug_name`);
+CREATE TABLE `review` (`id` INTEGER PRIMARY KEY AUTOINCREMENT NOT NULL, `created_at` DATETIME NULL, `updated_at` DATETIME NULL, `user_id` INTEGER NOT NULL, `object_id` INTEGER NOT NULL, `object_type` INTEGER DEFAULT 0 NOT NULL, `reviewer_user_id` INTEGER DEFAULT 0 NOT NULL, `submitter` TEXT DEFAULT '' NOT NULL, `reason` TEXT NOT NULL, `status` INTEGER DEFAULT 0 NOT NULL);
+INSERT INTO `version` (`id`, `version_number`) VALUES (1, 22);
@@ -24,7 +24,7 @@ import (
 	"xorm.io/xorm"
 )
 
-func addUserLanguage(ctx context.Context, x *xorm.Engine) error {
+func addUserLanguage(ctx context.Context, x *xorm.Session) error {
 	type User struct {
 		ID       string `xorm:"not null pk autoincr BIGINT(20) id"`
 		Username string `xorm:"not null default '' VARCHAR(50) UNIQUE username"`
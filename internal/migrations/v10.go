@@ -31,7 +31,7 @@ import (
 	"xorm.io/xorm"
 )
 
-func addLoginLimitations(ctx context.Context, x *xorm.Engine) error {
+func addLoginLimitations(ctx context.Context, x *xorm.Session) error {
 	loginSiteInfo := &entity.SiteInfo{
 		Type: constant.SiteTypeLogin,
 	}
@@ -28,7 +28,7 @@ import (
 	"xorm.io/xorm"
 )
 
-func updateRolePinAndHideFeatures(ctx context.Context, x *xorm.Engine) error {
+func updateRolePinAndHideFeatures(ctx context.Context, x *xorm.Session) error {
 	defaultConfigTable := []*entity.Config{
 		{ID: 119, Key: "question.pin", Value: `0`},
 		{ID: 120, Key: "question.unpin", Value: `0`},
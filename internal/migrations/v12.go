@@ -57,7 +57,7 @@ func (QuestionPostTime) TableName() string {
 	return "question"
 }
 
-func updateQuestionPostTime(ctx context.Context, x *xorm.Engine) error {
+func updateQuestionPostTime(ctx context.Context, x *xorm.Session) error {
 	questionList := make([]QuestionPostTime, 0)
 	err := x.Context(ctx).Find(&questionList, &entity.Question{})
 	if err != nil {
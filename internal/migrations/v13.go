@@ -34,8 +34,8 @@ import (
 	"xorm.io/xorm"
 )
 
-func updateCount(ctx context.Context, x *xorm.Engine) error {
-	fns := []func(ctx context.Context, x *xorm.Engine) error{
+func updateCount(ctx context.Context, x *xorm.Session) error {
+	fns := []func(ctx context.Context, x *xorm.Session) error{
 		inviteAnswer,
 		addPrivilegeForInviteSomeoneToAnswer,
 		addGravatarBaseURL,
@@ -53,7 +53,7 @@ func updateCount(ctx context.Context, x *xorm.Engine) error {
 	return nil
 }
 
-func addGravatarBaseURL(ctx context.Context, x *xorm.Engine) error {
+func addGravatarBaseURL(ctx context.Context, x *xorm.Session) error {
 	usersSiteInfo := &entity.SiteInfo{
 		Type: constant.SiteTypeUsers,
 	}
@@ -76,7 +76,7 @@ func addGravatarBaseURL(ctx context.Context, x *xorm.Engine) error {
 	return nil
 }
 
-func addPrivilegeForInviteSomeoneToAnswer(ctx context.Context, x *xorm.Engine) error {
+func addPrivilegeForInviteSomeoneToAnswer(ctx context.Context, x *xorm.Session) error {
 	// add rank for invite to answer
 	powers := []*entity.Power{
 		{ID: 38, Name: "invite someone to answer", PowerType: permission.AnswerInviteSomeoneToAnswer, Description: "invite someone to answer"},
@@ -134,7 +134,7 @@ func addPrivilegeForInviteSomeoneToAnswer(ctx context.Context, x *xorm.Engine) e
 	return nil
 }
 
-func updateQuestionCount(ctx context.Context, x *xorm.Engine) error {
+func updateQuestionCount(ctx context.Context, x *xorm.Session) error {
 	//question answer count
 	answers := make([]AnswerV13, 0)
 	err := x.Context(ctx).Find(&answers, &AnswerV13{Status: entity.AnswerStatusAvailable})
@@ -170,7 +170,7 @@ func updateQuestionCount(ctx context.Context, x *xorm.Engine) error {
 }
 
 // updateTagCount update tag count
-func updateTagCount(ctx context.Context, x *xorm.Engine) error {
+func updateTagCount(ctx context.Context, x *xorm.Session) error {
 	tagRelList := make([]entity.TagRel, 0)
 	err := x.Context(ctx).Find(&tagRelList, &entity.TagRel{})
 	if err != nil {
@@ -255,7 +255,7 @@ func updateTagCount(ctx context.Context, x *xorm.Engine) error {
 }
 
 // updateUserQuestionCount update user question count
-func updateUserQuestionCount(ctx context.Context, x *xorm.Engine) error {
+func updateUserQuestionCount(ctx context.Context, x *xorm.Session) error {
 	questionList := make([]QuestionV13, 0)
 	err := x.Context(ctx).Where(builder.Lt{"status": entity.QuestionStatusDeleted}).Find(&questionList, &QuestionV13{})
 	if err != nil {
@@ -307,7 +307,7 @@ func (AnswerV13) TableName() string {
 }
 
 // updateUserAnswerCount update user answer count
-func updateUserAnswerCount(ctx context.Context, x *xorm.Engine) error {
+func updateUserAnswerCount(ctx context.Context, x *xorm.Session) error {
 	answers := make([]AnswerV13, 0)
 	err := x.Context(ctx).Find(&answers, &AnswerV13{Status: entity.AnswerStatusAvailable})
 	if err != nil {
@@ -375,7 +375,7 @@ func (QuestionV13) TableName() string {
 	return "question"
 }
 
-func inviteAnswer(ctx context.Context, x *xorm.Engine) error {
+func inviteAnswer(ctx context.Context, x *xorm.Session) error {
 	err := x.Context(ctx).Sync(new(QuestionV13))
 	if err != nil {
 		return err
@@ -384,7 +384,7 @@ func inviteAnswer(ctx context.Context, x *xorm.Engine) error {
 }
 
 // inBoxData Classify messages
-func inBoxData(ctx context.Context, x *xorm.Engine) error {
+func inBoxData(ctx context.Context, x *xorm.Session) error {
 	type Notification struct {
 		ID        string    `xorm:"not null pk autoincr BIGINT(20) id"`
 		CreatedAt time.Time `xorm:"created TIMESTAMP created_at"`
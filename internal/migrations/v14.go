@@ -27,9 +27,9 @@ import (
 	"xorm.io/xorm"
 )
 
-func updateTheLengthOfRevisionContent(ctx context.Context, x *xorm.Engine) (err error) {
+func updateTheLengthOfRevisionContent(ctx context.Context, x *xorm.Session) (err error) {
 	sess := x.Context(ctx)
-	if x.Dialect().URI().DBType == schemas.MYSQL {
+	if x.Engine().Dialect().URI().DBType == schemas.MYSQL {
 		_, err = sess.Exec("ALTER TABLE `revision` CHANGE `content` `content` MEDIUMTEXT NOT NULL;")
 	}
 	return err
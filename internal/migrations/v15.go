@@ -25,6 +25,6 @@ import (
 	"xorm.io/xorm"
 )
 
-func addNoticeConfig(ctx context.Context, x *xorm.Engine) error {
+func addNoticeConfig(ctx context.Context, x *xorm.Session) error {
 	return x.Context(ctx).Sync(new(entity.UserNotificationConfig))
 }
@@ -27,7 +27,7 @@ import (
 	"xorm.io/xorm"
 )
 
-func setDefaultUserNotificationConfig(ctx context.Context, x *xorm.Engine) error {
+func setDefaultUserNotificationConfig(ctx context.Context, x *xorm.Session) error {
 	userIDs := make([]string, 0)
 	err := x.Context(ctx).Table("user").Select("id").Find(&userIDs)
 	if err != nil {
@@ -28,7 +28,7 @@ import (
 	"xorm.io/xorm"
 )
 
-func addRecoverPermission(ctx context.Context, x *xorm.Engine) error {
+func addRecoverPermission(ctx context.Context, x *xorm.Session) error {
 	powers := []*entity.Power{
 		{ID: 39, Name: "recover answer", PowerType: permission.AnswerUnDelete, Description: "recover deleted answer"},
 		{ID: 40, Name: "recover question", PowerType: permission.QuestionUnDelete, Description: "recover deleted question"},
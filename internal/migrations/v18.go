@@ -29,7 +29,7 @@ import (
 	"xorm.io/xorm"
 )
 
-func addPasswordLoginControl(ctx context.Context, x *xorm.Engine) error {
+func addPasswordLoginControl(ctx context.Context, x *xorm.Session) error {
 	loginSiteInfo := &entity.SiteInfo{
 		Type: constant.SiteTypeLogin,
 	}
@@ -25,7 +25,7 @@ import (
 	"xorm.io/xorm"
 )
 
-func addNotificationPluginAndThemeConfig(ctx context.Context, x *xorm.Engine) error {
+func addNotificationPluginAndThemeConfig(ctx context.Context, x *xorm.Session) error {
 	type User struct {
 		ID          string `xorm:"not null pk autoincr BIGINT(20) id"`
 		ColorScheme string `xorm:"not null default '' VARCHAR(100) color_scheme"`
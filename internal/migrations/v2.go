@@ -24,7 +24,7 @@ import (
 	"xorm.io/xorm"
 )
 
-func addTagRecommendedAndReserved(ctx context.Context, x *xorm.Engine) error {
+func addTagRecommendedAndReserved(ctx context.Context, x *xorm.Session) error {
 	type Tag struct {
 		ID        string `xorm:"not null pk comment('tag_id') BIGINT(20) id"`
 		SlugName  string `xorm:"not null default '' unique VARCHAR(35) slug_name"`
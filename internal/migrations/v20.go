@@ -28,7 +28,7 @@ import (
 	"xorm.io/xorm"
 )
 
-func addReview(ctx context.Context, x *xorm.Engine) error {
+func addReview(ctx context.Context, x *xorm.Session) error {
 	c := &entity.Config{Key: "reason.not_clarity", Value: `{"name":"needs details or clarity","description":"This question currently includes multiple questions in one. It should focus on one problem only."}`}
 	if _, err := x.Context(ctx).Update(c, &entity.Config{Key: "reason.not_clarity"}); err != nil {
 		log.Errorf("update %+v config failed: %s", c, err)
@@ -24,7 +24,7 @@ import (
 	"xorm.io/xorm"
 )
 
-func addQuestionHotScore(ctx context.Context, x *xorm.Engine) error {
+func addQuestionHotScore(ctx context.Context, x *xorm.Session) error {
 	type Question struct {
 		HotScore int `xorm:"not null default 0 INT(11) hot_score"`
 	}
@@ -0,0 +1,126 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package migrations
+
+import (
+	"context"
+	"fmt"
+
+	"xorm.io/xorm"
+	"xorm.io/xorm/schemas"
+)
+
+// addFullTextSearchIndex create the native full-text search indexes used by the built-in search,
+// the expressions must be the same as the ones in repo/search_common/fulltext.go
+func addFullTextSearchIndex(ctx context.Context, x *xorm.Session) (err error) {
+	switch x.Engine().Dialect().URI().DBType {
+	case schemas.MYSQL:
+		return addMySQLFullTextIndex(ctx, x)
+	case schemas.POSTGRES:
+		return addPostgresFullTextIndex(ctx, x)
+	case schemas.SQLITE:
+		return addSQLiteFullTextIndex(ctx, x)
+	}
+	return nil
+}
+
+func addMySQLFullTextIndex(ctx context.Context, x *xorm.Session) (err error) {
+	indexes := []struct {
+		table, name, columns string
+	}{
+		{"question", "FT_question_title_original_text", "`title`, `original_text`"},
+		{"answer", "FT_answer_original_text", "`original_text`"},
+	}
+	for _, index := range indexes {
+		count, err := x.Context(ctx).SQL("SELECT COUNT(*) FROM information_schema.statistics "+
+			"WHERE table_schema = DATABASE() AND table_name = ? AND index_name = ?", index.table, index.name).Count()
+		if err != nil {
+			return err
+		}
+		if count > 0 {
+			continue
+		}
+		_, err = x.Context(ctx).Exec(fmt.Sprintf("ALTER TABLE `%s` ADD FULLTEXT INDEX `%s` (%s)",
+			index.table, index.name, index.columns))
+		if err != nil {
+			return fmt.Errorf("add full-text index of %s failed: %w", index.table, err)
+		}
+	}
+	return nil
+}
+
+func addPostgresFullTextIndex(ctx context.Context, x *xorm.Session) (err error) {
+	_, err = x.Context(ctx).Exec(`CREATE INDEX IF NOT EXISTS "FT_question_title_original_text" ON "question" ` +
+		`USING GIN (to_tsvector('english', "title" || ' ' || "original_text"))`)
+	if err != nil {
+		return fmt.Errorf("add full-text index of question failed: %w", err)
+	}
+	_, err = x.Context(ctx).Exec(`CREATE INDEX IF NOT EXISTS "FT_answer_original_text" ON "answer" ` +
+		`USING GIN (to_tsvector('english', "original_text"))`)
+	if err != nil {
+		return fmt.Errorf("add full-text index of answer failed: %w", err)
+	}
+	return nil
+}
+
+// addSQLiteFullTextIndex the FTS5 tables are kept in sync with content tables by triggers
+func addSQLiteFullTextIndex(ctx context.Context, x *xorm.Session) (err error) {
+	tables := []struct {
+		name    string
+		columns []string
+	}{
+		{"question", []string{"title", "original_text"}},
+		{"answer", []string{"original_text"}},
+	}
+	for _, table := range tables {
+		var columns, newValues, setValues string
+		for i, column := range table.columns {
+			if i > 0 {
+				columns += ", "
+				newValues += ", "
+				setValues += ", "
+			}
+			columns += column
+			newValues += "new." + column
+			setValues += column + " = new." + column
+		}
+		statements := []string{
+			fmt.Sprintf(`CREATE VIRTUAL TABLE IF NOT EXISTS %s_fts USING fts5(%s, tokenize = 'porter unicode61')`,
+				table.name, columns),
+			fmt.Sprintf(`CREATE TRIGGER IF NOT EXISTS %s_fts_ai AFTER INSERT ON %s BEGIN
+  INSERT INTO %s_fts(rowid, %s) VALUES (new.id, %s);
+END`, table.name, table.name, table.name, columns, newValues),
+			fmt.Sprintf(`CREATE TRIGGER IF NOT EXISTS %s_fts_ad AFTER DELETE ON %s BEGIN
+  DELETE FROM %s_fts WHERE rowid = old.id;
+END`, table.name, table.name, table.name),
+			fmt.Sprintf(`CREATE TRIGGER IF NOT EXISTS %s_fts_au AFTER UPDATE OF %s ON %s BEGIN
+  UPDATE %s_fts SET %s WHERE rowid = old.id;
+END`, table.name, columns, table.name, table.name, setValues),
+			fmt.Sprintf(`INSERT INTO %s_fts(rowid, %s) SELECT id, %s FROM %s WHERE id NOT IN (SELECT rowid FROM %s_fts)`,
+				table.name, columns, columns, table.name, table.name),
+		}
+		for _, statement := range statements {
+			if _, err = x.Context(ctx).Exec(statement); err != nil {
+				return fmt.Errorf("add full-text index of %s failed: %w", table.name, err)
+			}
+		}
+	}
+	return nil
+}
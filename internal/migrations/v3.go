@@ -30,8 +30,8 @@ import (
 	"xorm.io/xorm/schemas"
 )
 
-func addActivityTimeline(ctx context.Context, x *xorm.Engine) (err error) {
-	switch x.Dialect().URI().DBType {
+func addActivityTimeline(ctx context.Context, x *xorm.Session) (err error) {
+	switch x.Engine().Dialect().URI().DBType {
 	case schemas.MYSQL:
 		_, err = x.Context(ctx).Exec("ALTER TABLE `answer` CHANGE `updated_at` `updated_at` TIMESTAMP NULL DEFAULT NULL")
 		if err != nil {
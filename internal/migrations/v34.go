@@ -0,0 +1,76 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package migrations
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/apache/incubator-answer/internal/entity"
+	"xorm.io/xorm"
+	"xorm.io/xorm/core"
+	"xorm.io/xorm/schemas"
+)
+
+// addTagHierarchy the tag table is not synced, because xorm tries to modify its primary key on SQLite
+// and SQLite does not support "ALTER TABLE ... MODIFY COLUMN", so the new columns and index are added one by one.
+func addTagHierarchy(ctx context.Context, x *xorm.Session) (err error) {
+	if err = x.Context(ctx).Sync(new(entity.TagGroup)); err != nil {
+		return err
+	}
+	tagTable, err := x.Engine().TableInfo(new(entity.Tag))
+	if err != nil {
+		return err
+	}
+	dialect := x.Engine().Dialect()
+	_, columns, err := dialect.GetColumns(sessionQueryer(x), ctx, tagTable.Name)
+	if err != nil {
+		return fmt.Errorf("get columns of tag table failed: %w", err)
+	}
+	for _, name := range []string{"parent_tag_id", "tag_group_id"} {
+		if _, ok := columns[name]; ok {
+			continue
+		}
+		if _, err = x.Context(ctx).Exec(dialect.AddColumnSQL(tagTable.Name, tagTable.GetColumn(name))); err != nil {
+			return fmt.Errorf("add column %s to tag table failed: %w", name, err)
+		}
+	}
+
+	indexes, err := dialect.GetIndexes(sessionQueryer(x), ctx, tagTable.Name)
+	if err != nil {
+		return fmt.Errorf("get indexes of tag table failed: %w", err)
+	}
+	if _, ok := indexes["parent_tag_id"]; !ok {
+		index := schemas.NewIndex("parent_tag_id", schemas.IndexType)
+		index.AddColumn("parent_tag_id")
+		if _, err = x.Context(ctx).Exec(dialect.CreateIndexSQL(tagTable.Name, index)); err != nil {
+			return fmt.Errorf("add index of parent_tag_id to tag table failed: %w", err)
+		}
+	}
+	return nil
+}
+
+// sessionQueryer the queries run in the transaction of session if it has began one
+func sessionQueryer(x *xorm.Session) core.Queryer {
+	if tx := x.Tx(); tx != nil {
+		return tx
+	}
+	return x.DB()
+}
@@ -0,0 +1,65 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package migrations
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/segmentfault/pacman/log"
+	"xorm.io/xorm"
+)
+
+func addQuestionBounty(ctx context.Context, x *xorm.Session) error {
+	defaultConfigTable := []*entity.Config{
+		{ID: 131, Key: "question.bounty_offered", Value: `0`},
+		{ID: 132, Key: "question.bounty_expired", Value: `0`},
+		{ID: 133, Key: "answer.bounty_awarded", Value: `0`},
+	}
+	for _, c := range defaultConfigTable {
+		exist, err := x.Context(ctx).Get(&entity.Config{ID: c.ID})
+		if err != nil {
+			return fmt.Errorf("get config failed: %w", err)
+		}
+		if exist {
+			if _, err = x.Context(ctx).Update(c, &entity.Config{ID: c.ID}); err != nil {
+				log.Errorf("update %+v config failed: %s", c, err)
+				return fmt.Errorf("update config failed: %w", err)
+			}
+			continue
+		}
+		if _, err = x.Context(ctx).Insert(&entity.Config{ID: c.ID, Key: c.Key, Value: c.Value}); err != nil {
+			log.Errorf("insert %+v config failed: %s", c, err)
+			return fmt.Errorf("add config failed: %w", err)
+		}
+	}
+	return x.Context(ctx).Sync(new(entity.QuestionBounty))
+}
+
+func removeQuestionBounty(ctx context.Context, x *xorm.Session) error {
+	if err := x.Context(ctx).DropTable(new(entity.QuestionBounty)); err != nil {
+		return fmt.Errorf("drop question bounty table failed: %w", err)
+	}
+	if _, err := x.Context(ctx).In("id", 131, 132, 133).Delete(&entity.Config{}); err != nil {
+		return fmt.Errorf("remove config failed: %w", err)
+	}
+	return nil
+}
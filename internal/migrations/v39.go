@@ -0,0 +1,52 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package migrations
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+
+	"github.com/apache/incubator-answer/internal/base/constant"
+	"github.com/apache/incubator-answer/internal/entity"
+	"xorm.io/xorm"
+)
+
+// newEmailUnsubscribeSecretConfig the random secret of site which signs the one-click unsubscribe tokens
+func newEmailUnsubscribeSecretConfig() *entity.Config {
+	secret := make([]byte, 32)
+	_, _ = rand.Read(secret)
+	return &entity.Config{ID: 134, Key: constant.EmailUnsubscribeSecretKey, Value: hex.EncodeToString(secret)}
+}
+
+func addEmailUnsubscribeSecret(ctx context.Context, x *xorm.Session) error {
+	exist, err := x.Context(ctx).Exist(&entity.Config{Key: constant.EmailUnsubscribeSecretKey})
+	if err != nil {
+		return fmt.Errorf("check email unsubscribe secret failed: %w", err)
+	}
+	if exist {
+		return nil
+	}
+	if _, err = x.Context(ctx).Insert(newEmailUnsubscribeSecretConfig()); err != nil {
+		return fmt.Errorf("add email unsubscribe secret failed: %w", err)
+	}
+	return nil
+}
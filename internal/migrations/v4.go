@@ -29,7 +29,7 @@ import (
 	"xorm.io/xorm"
 )
 
-func addRoleFeatures(ctx context.Context, x *xorm.Engine) error {
+func addRoleFeatures(ctx context.Context, x *xorm.Session) error {
 	err := x.Context(ctx).Sync(new(entity.Role), new(entity.RolePowerRel), new(entity.Power), new(entity.UserRoleRel))
 	if err != nil {
 		return err
@@ -28,7 +28,7 @@ import (
 	"xorm.io/xorm"
 )
 
-func addThemeAndPrivateMode(ctx context.Context, x *xorm.Engine) error {
+func addThemeAndPrivateMode(ctx context.Context, x *xorm.Session) error {
 	loginConfig := map[string]bool{
 		"allow_new_registrations": true,
 		"login_required":          false,
@@ -28,7 +28,7 @@ import (
 	"xorm.io/xorm"
 )
 
-func addNewAnswerNotification(ctx context.Context, x *xorm.Engine) error {
+func addNewAnswerNotification(ctx context.Context, x *xorm.Session) error {
 	cond := &entity.Config{Key: "email.config"}
 	exists, err := x.Context(ctx).Get(cond)
 	if err != nil {
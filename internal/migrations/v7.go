@@ -28,7 +28,7 @@ import (
 	"xorm.io/xorm"
 )
 
-func addPlugin(ctx context.Context, x *xorm.Engine) error {
+func addPlugin(ctx context.Context, x *xorm.Session) error {
 	defaultConfigTable := []*entity.Config{
 		{ID: 118, Key: "plugin.status", Value: `{}`},
 	}
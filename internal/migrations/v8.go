@@ -30,7 +30,7 @@ import (
 	"xorm.io/xorm"
 )
 
-func addRolePinAndHideFeatures(ctx context.Context, x *xorm.Engine) error {
+func addRolePinAndHideFeatures(ctx context.Context, x *xorm.Session) error {
 	powers := []*entity.Power{
 		{ID: 34, Name: "question pin", PowerType: permission.QuestionPin, Description: "top the question"},
 		{ID: 35, Name: "question hide", PowerType: permission.QuestionHide, Description: "hide  the question"},
@@ -28,7 +28,7 @@ import (
 	"xorm.io/xorm"
 )
 
-func updateAcceptAnswerRank(ctx context.Context, x *xorm.Engine) error {
+func updateAcceptAnswerRank(ctx context.Context, x *xorm.Session) error {
 	c := &entity.Config{ID: 44, Key: "rank.answer.accept", Value: `-1`}
 	if _, err := x.Context(ctx).Update(c, &entity.Config{ID: 44, Key: "rank.answer.accept"}); err != nil {
 		log.Errorf("update %+v config failed: %s", c, err)
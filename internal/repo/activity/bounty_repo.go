@@ -0,0 +1,207 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package activity
+
+import (
+	"context"
+	stderrors "errors"
+	"time"
+
+	"github.com/apache/incubator-answer/internal/base/constant"
+	"github.com/apache/incubator-answer/internal/base/data"
+	"github.com/apache/incubator-answer/internal/base/reason"
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/service/activity"
+	"github.com/apache/incubator-answer/internal/service/config"
+	"github.com/apache/incubator-answer/internal/service/rank"
+	"github.com/apache/incubator-answer/pkg/converter"
+	"github.com/segmentfault/pacman/errors"
+	"xorm.io/builder"
+	"xorm.io/xorm"
+)
+
+// BountyActivityRepo question bounty
+type BountyActivityRepo struct {
+	data          *data.Data
+	userRankRepo  rank.UserRankRepo
+	configService *config.ConfigService
+}
+
+// NewBountyActivityRepo new repository
+func NewBountyActivityRepo(
+	data *data.Data,
+	userRankRepo rank.UserRankRepo,
+	configService *config.ConfigService,
+) activity.BountyActivityRepo {
+	return &BountyActivityRepo{
+		data:          data,
+		userRankRepo:  userRankRepo,
+		configService: configService,
+	}
+}
+
+// OfferBounty save the bounty and deduct the amount from the offering user's rank
+func (br *BountyActivityRepo) OfferBounty(ctx context.Context, bounty *entity.QuestionBounty) (err error) {
+	cfg, err := br.configService.GetConfigByKey(ctx, string(constant.ActQuestionBountyOffered))
+	if err != nil {
+		return err
+	}
+	_, err = br.data.DB.Transaction(func(session *xorm.Session) (result any, err error) {
+		session = session.Context(ctx)
+
+		// lock the question row first, so the concurrent offers on the same question are serialized
+		// and only one of them can pass the active bounty check below
+		exist, err := session.ID(bounty.QuestionID).Cols("id").ForUpdate().Get(&entity.Question{})
+		if err != nil {
+			return nil, err
+		}
+		if !exist {
+			return nil, errors.BadRequest(reason.QuestionNotFound)
+		}
+
+		user := &entity.User{}
+		exist, err = session.ID(bounty.UserID).ForUpdate().Get(user)
+		if err != nil {
+			return nil, err
+		}
+		if !exist {
+			return nil, errors.BadRequest(reason.UserNotFound)
+		}
+		// the user must keep at least 1 reputation after offering
+		if user.Rank <= bounty.Amount {
+			return nil, errors.BadRequest(reason.BountyRankNotEnough)
+		}
+
+		exist, err = session.Where(builder.Eq{"question_id": bounty.QuestionID}).
+			And(builder.Eq{"status": entity.QuestionBountyStatusActive}).
+			Exist(&entity.QuestionBounty{})
+		if err != nil {
+			return nil, err
+		}
+		if exist {
+			return nil, errors.BadRequest(reason.BountyAlreadyActive)
+		}
+
+		bounty.Status = entity.QuestionBountyStatusActive
+		if _, err = session.Insert(bounty); err != nil {
+			return nil, err
+		}
+		if err = br.userRankRepo.ChangeUserRank(ctx, session, user.ID, user.Rank, -bounty.Amount); err != nil {
+			return nil, err
+		}
+		_, err = session.Insert(&entity.Activity{
+			UserID:           bounty.UserID,
+			ObjectID:         bounty.QuestionID,
+			OriginalObjectID: bounty.QuestionID,
+			ActivityType:     cfg.ID,
+			Rank:             -bounty.Amount,
+			HasRank:          1,
+		})
+		return nil, err
+	})
+	return br.wrapError(err)
+}
+
+// AwardBounty mark the bounty as awarded and grant the amount to the author of the awarded answer
+func (br *BountyActivityRepo) AwardBounty(ctx context.Context, bounty *entity.QuestionBounty) (err error) {
+	cfg, err := br.configService.GetConfigByKey(ctx, string(constant.ActAnswerBountyAwarded))
+	if err != nil {
+		return err
+	}
+	_, err = br.data.DB.Transaction(func(session *xorm.Session) (result any, err error) {
+		session = session.Context(ctx)
+
+		bounty.Status = entity.QuestionBountyStatusAwarded
+		bounty.AwardedAt = time.Now()
+		affected, err := session.ID(bounty.ID).
+			And(builder.Eq{"status": entity.QuestionBountyStatusActive}).
+			Cols("status", "awarded_answer_id", "awarded_user_id", "awarded_at").
+			Update(bounty)
+		if err != nil {
+			return nil, err
+		}
+		if affected == 0 {
+			return nil, errors.BadRequest(reason.BountyNotFound)
+		}
+
+		user := &entity.User{}
+		exist, err := session.ID(bounty.AwardedUserID).ForUpdate().Get(user)
+		if err != nil {
+			return nil, err
+		}
+		if !exist {
+			return nil, errors.BadRequest(reason.UserNotFound)
+		}
+		if err = br.userRankRepo.ChangeUserRank(ctx, session, user.ID, user.Rank, bounty.Amount); err != nil {
+			return nil, err
+		}
+		_, err = session.Insert(&entity.Activity{
+			UserID:           bounty.AwardedUserID,
+			TriggerUserID:    converter.StringToInt64(bounty.UserID),
+			ObjectID:         bounty.AwardedAnswerID,
+			OriginalObjectID: bounty.AwardedAnswerID,
+			ActivityType:     cfg.ID,
+			Rank:             bounty.Amount,
+			HasRank:          1,
+		})
+		return nil, err
+	})
+	return br.wrapError(err)
+}
+
+// ExpireBounty mark the bounty as expired, the offered rank is not returned
+func (br *BountyActivityRepo) ExpireBounty(ctx context.Context, bounty *entity.QuestionBounty) (err error) {
+	cfg, err := br.configService.GetConfigByKey(ctx, string(constant.ActQuestionBountyExpired))
+	if err != nil {
+		return err
+	}
+	_, err = br.data.DB.Transaction(func(session *xorm.Session) (result any, err error) {
+		session = session.Context(ctx)
+
+		bounty.Status = entity.QuestionBountyStatusExpired
+		affected, err := session.ID(bounty.ID).
+			And(builder.Eq{"status": entity.QuestionBountyStatusActive}).
+			Cols("status").
+			Update(bounty)
+		if err != nil || affected == 0 {
+			return nil, err
+		}
+		_, err = session.Insert(&entity.Activity{
+			UserID:           bounty.UserID,
+			ObjectID:         bounty.QuestionID,
+			OriginalObjectID: bounty.QuestionID,
+			ActivityType:     cfg.ID,
+		})
+		return nil, err
+	})
+	return br.wrapError(err)
+}
+
+// wrapError keeps the business errors returned in the transaction
+func (br *BountyActivityRepo) wrapError(err error) error {
+	if err == nil {
+		return nil
+	}
+	var pacmanErr *errors.Error
+	if stderrors.As(err, &pacmanErr) {
+		return err
+	}
+	return errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+}
@@ -0,0 +1,103 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+package api_token
+
+import (
+	"context"
+	"time"
+
+	"github.com/apache/incubator-answer/internal/base/data"
+	"github.com/apache/incubator-answer/internal/base/reason"
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/service/api_token"
+	"github.com/segmentfault/pacman/errors"
+)
+
+// apiTokenRepo api token repository
+type apiTokenRepo struct {
+	data *data.Data
+}
+
+// NewAPITokenRepo new repository
+func NewAPITokenRepo(data *data.Data) api_token.APITokenRepo {
+	return &apiTokenRepo{
+		data: data,
+	}
+}
+
+// AddAPIToken add api token
+func (ar *apiTokenRepo) AddAPIToken(ctx context.Context, token *entity.APIToken) (err error) {
+	_, err = ar.data.DB.Context(ctx).Insert(token)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// RemoveAPIToken remove api token of user
+func (ar *apiTokenRepo) RemoveAPIToken(ctx context.Context, userID string, tokenID int64) (
+	affected int64, err error) {
+	affected, err = ar.data.DB.Context(ctx).Where("id = ? AND user_id = ?", tokenID, userID).
+		Delete(&entity.APIToken{})
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetAPITokenByHash get api token by token hash
+func (ar *apiTokenRepo) GetAPITokenByHash(ctx context.Context, tokenHash string) (
+	token *entity.APIToken, exist bool, err error) {
+	token = &entity.APIToken{}
+	exist, err = ar.data.DB.Context(ctx).Where("token_hash = ?", tokenHash).Get(token)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetAPITokenList get api tokens of user
+func (ar *apiTokenRepo) GetAPITokenList(ctx context.Context, userID string) (
+	tokens []*entity.APIToken, err error) {
+	tokens = make([]*entity.APIToken, 0)
+	err = ar.data.DB.Context(ctx).Where("user_id = ?", userID).Desc("id").Find(&tokens)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// CountAPIToken count api tokens of user
+func (ar *apiTokenRepo) CountAPIToken(ctx context.Context, userID string) (count int64, err error) {
+	count, err = ar.data.DB.Context(ctx).Where("user_id = ?", userID).Count(&entity.APIToken{})
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// UpdateLastUsedAt update the last used time of api token
+func (ar *apiTokenRepo) UpdateLastUsedAt(ctx context.Context, tokenID int64, lastUsedAt time.Time) (err error) {
+	_, err = ar.data.DB.Context(ctx).ID(tokenID).Cols("last_used_at").
+		Update(&entity.APIToken{LastUsedAt: lastUsedAt})
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
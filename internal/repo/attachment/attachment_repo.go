@@ -0,0 +1,105 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package attachment
+
+import (
+	"context"
+	"time"
+
+	"github.com/apache/incubator-answer/internal/base/data"
+	"github.com/apache/incubator-answer/internal/base/reason"
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/service/attachment"
+	"github.com/segmentfault/pacman/errors"
+	"xorm.io/builder"
+)
+
+// attachmentRepo attachment repository
+type attachmentRepo struct {
+	data *data.Data
+}
+
+// NewAttachmentRepo new repository
+func NewAttachmentRepo(data *data.Data) attachment.AttachmentRepo {
+	return &attachmentRepo{
+		data: data,
+	}
+}
+
+// AddAttachment add attachment
+func (ar *attachmentRepo) AddAttachment(ctx context.Context, attachment *entity.Attachment) (err error) {
+	_, err = ar.data.DB.Context(ctx).Insert(attachment)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetAttachmentByFileID get attachment by file id
+func (ar *attachmentRepo) GetAttachmentByFileID(ctx context.Context, fileID string) (
+	attachment *entity.Attachment, exist bool, err error) {
+	attachment = &entity.Attachment{}
+	exist, err = ar.data.DB.Context(ctx).Where(builder.Eq{"file_id": fileID}).Get(attachment)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// IncreaseDownloadCount increase the download count of attachment by 1
+func (ar *attachmentRepo) IncreaseDownloadCount(ctx context.Context, id int64) (err error) {
+	_, err = ar.data.DB.Context(ctx).ID(id).Incr("download_count", 1).NoAutoTime().Update(&entity.Attachment{})
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// LinkAttachments set the object id of the attachments which are not used in any object
+func (ar *attachmentRepo) LinkAttachments(ctx context.Context, objectID string, fileIDs []string) (err error) {
+	_, err = ar.data.DB.Context(ctx).Where(builder.In("file_id", fileIDs)).And(builder.Eq{"object_id": 0}).
+		Cols("object_id").Update(&entity.Attachment{ObjectID: objectID})
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetUnlinkedAttachments get the attachments not used in any object and created before the time,
+// the attachments are ordered by id and start after the last id
+func (ar *attachmentRepo) GetUnlinkedAttachments(ctx context.Context, createdBefore time.Time, lastID int64, limit int) (
+	attachments []*entity.Attachment, err error) {
+	attachments = make([]*entity.Attachment, 0)
+	err = ar.data.DB.Context(ctx).Where(builder.Eq{"object_id": 0}).And(builder.Lt{"created_at": createdBefore}).
+		And(builder.Gt{"id": lastID}).OrderBy("id").Limit(limit).Find(&attachments)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// RemoveUnlinkedAttachment remove the attachment if it is still not used in any object
+func (ar *attachmentRepo) RemoveUnlinkedAttachment(ctx context.Context, id int64) (removed bool, err error) {
+	affected, err := ar.data.DB.Context(ctx).ID(id).And(builder.Eq{"object_id": 0}).Delete(&entity.Attachment{})
+	if err != nil {
+		return false, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return affected > 0, nil
+}
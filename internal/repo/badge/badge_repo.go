@@ -0,0 +1,134 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package badge
+
+import (
+	"context"
+
+	"github.com/apache/incubator-answer/internal/base/data"
+	"github.com/apache/incubator-answer/internal/base/reason"
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/apache/incubator-answer/internal/service/badge"
+	"github.com/apache/incubator-answer/pkg/converter"
+	"github.com/segmentfault/pacman/errors"
+	"xorm.io/builder"
+)
+
+// badgeRepo user badge repository
+type badgeRepo struct {
+	data *data.Data
+}
+
+// NewBadgeRepo new repository
+func NewBadgeRepo(data *data.Data) badge.BadgeRepo {
+	return &badgeRepo{
+		data: data,
+	}
+}
+
+// AddUserBadge add the badge to the user, nothing is added if the user already has it
+func (br *badgeRepo) AddUserBadge(ctx context.Context, userBadge *entity.UserBadge) (added bool, err error) {
+	exist, err := br.data.DB.Context(ctx).Where(builder.Eq{"user_id": userBadge.UserID}).
+		And(builder.Eq{"badge_name": userBadge.BadgeName}).
+		And(builder.Eq{"object_id": userBadge.ObjectID}).Exist(&entity.UserBadge{})
+	if err != nil {
+		return false, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	if exist {
+		return false, nil
+	}
+	_, err = br.data.DB.Context(ctx).Insert(userBadge)
+	if err != nil {
+		return false, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return true, nil
+}
+
+// GetUserBadgeList get all badges of the user, the higher level first
+func (br *badgeRepo) GetUserBadgeList(ctx context.Context, userID string) (
+	badgeList []*entity.UserBadge, err error) {
+	badgeList = make([]*entity.UserBadge, 0)
+	err = br.data.DB.Context(ctx).Where(builder.Eq{"user_id": userID}).
+		Desc("level").Asc("id").Find(&badgeList)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetUserIDsAfter get the ids of the users whose id is greater than the last user id, in ascending order
+func (br *badgeRepo) GetUserIDsAfter(ctx context.Context, lastUserID string, limit int) (userIDs []string, err error) {
+	userList := make([]*entity.User, 0)
+	err = br.data.DB.Context(ctx).Cols("id").
+		Where(builder.Gt{"id": converter.StringToInt64(lastUserID)}).
+		And(builder.Neq{"status": entity.UserStatusDeleted}).
+		Asc("id").Limit(limit).Find(&userList)
+	if err != nil {
+		return nil, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	userIDs = make([]string, 0, len(userList))
+	for _, user := range userList {
+		userIDs = append(userIDs, user.ID)
+	}
+	return userIDs, nil
+}
+
+// CountAcceptedAnswer count the accepted answers of the user
+func (br *badgeRepo) CountAcceptedAnswer(ctx context.Context, userID string) (count int64, err error) {
+	count, err = br.data.DB.Context(ctx).Where(builder.Eq{"user_id": userID}).
+		And(builder.Eq{"adopted": schema.AnswerAcceptedEnable}).
+		And(builder.Eq{"status": entity.AnswerStatusAvailable}).Count(&entity.Answer{})
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetUpvotedAnswerTagStat get the amount of the upvoted answers of the user in every tag
+func (br *badgeRepo) GetUpvotedAnswerTagStat(ctx context.Context, userID string) (
+	tagStat []*entity.UserBadgeTagStat, err error) {
+	tagStat = make([]*entity.UserBadgeTagStat, 0)
+	session := br.data.DB.Context(ctx).Table("answer")
+	session.Select("tag_rel.tag_id AS tag_id, COUNT(*) AS answer_amount")
+	session.Join("INNER", "tag_rel", "tag_rel.object_id = answer.question_id")
+	session.Where(builder.Eq{"answer.user_id": userID}).
+		And(builder.Gt{"answer.vote_count": 0}).
+		And(builder.Eq{"answer.status": entity.AnswerStatusAvailable}).
+		And(builder.Eq{"tag_rel.status": entity.TagRelStatusAvailable})
+	session.GroupBy("tag_rel.tag_id")
+	err = session.Find(&tagStat)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// CountActiveDays count the days that the user has any activity done by himself
+func (br *badgeRepo) CountActiveDays(ctx context.Context, userID string) (count int64, err error) {
+	_, err = br.data.DB.Context(ctx).Table(entity.Activity{}.TableName()).
+		Where(builder.Eq{"user_id": userID}).
+		And(builder.Eq{"trigger_user_id": 0}.Or(builder.Eq{"trigger_user_id": converter.StringToInt64(userID)})).
+		Select("COUNT(DISTINCT DATE(created_at))").Get(&count)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
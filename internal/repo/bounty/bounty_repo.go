@@ -0,0 +1,94 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package bounty
+
+import (
+	"context"
+	"time"
+
+	"github.com/apache/incubator-answer/internal/base/data"
+	"github.com/apache/incubator-answer/internal/base/reason"
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/service/bounty"
+	"github.com/segmentfault/pacman/errors"
+	"xorm.io/builder"
+)
+
+// bountyRepo question bounty repository
+type bountyRepo struct {
+	data *data.Data
+}
+
+// NewBountyRepo new repository
+func NewBountyRepo(data *data.Data) bounty.BountyRepo {
+	return &bountyRepo{
+		data: data,
+	}
+}
+
+// GetActiveBounty get the active bounty of the question
+func (br *bountyRepo) GetActiveBounty(ctx context.Context, questionID string) (
+	questionBounty *entity.QuestionBounty, exist bool, err error) {
+	questionBounty = &entity.QuestionBounty{}
+	exist, err = br.data.DB.Context(ctx).Where(builder.Eq{"question_id": questionID}).
+		And(builder.Eq{"status": entity.QuestionBountyStatusActive}).Get(questionBounty)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetActiveBountyListByQuestionIDs get active bounties of the questions
+func (br *bountyRepo) GetActiveBountyListByQuestionIDs(ctx context.Context, questionIDs []string) (
+	bountyList []*entity.QuestionBounty, err error) {
+	bountyList = make([]*entity.QuestionBounty, 0)
+	if len(questionIDs) == 0 {
+		return bountyList, nil
+	}
+	err = br.data.DB.Context(ctx).In("question_id", questionIDs).
+		And(builder.Eq{"status": entity.QuestionBountyStatusActive}).Find(&bountyList)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetBountyListByQuestionID get all bounties of the question, the newest first
+func (br *bountyRepo) GetBountyListByQuestionID(ctx context.Context, questionID string) (
+	bountyList []*entity.QuestionBounty, err error) {
+	bountyList = make([]*entity.QuestionBounty, 0)
+	err = br.data.DB.Context(ctx).Where(builder.Eq{"question_id": questionID}).Desc("id").Find(&bountyList)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetExpiredBountyList get active bounties that expired before the deadline
+func (br *bountyRepo) GetExpiredBountyList(ctx context.Context, deadline time.Time, limit int) (
+	bountyList []*entity.QuestionBounty, err error) {
+	bountyList = make([]*entity.QuestionBounty, 0)
+	err = br.data.DB.Context(ctx).Where(builder.Eq{"status": entity.QuestionBountyStatusActive}).
+		And(builder.Lt{"expires_at": deadline}).Asc("id").Limit(limit).Find(&bountyList)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
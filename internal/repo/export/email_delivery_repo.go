@@ -0,0 +1,99 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package export
+
+import (
+	"context"
+	"time"
+
+	"github.com/apache/incubator-answer/internal/base/data"
+	"github.com/apache/incubator-answer/internal/base/pager"
+	"github.com/apache/incubator-answer/internal/base/reason"
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/service/export"
+	"github.com/segmentfault/pacman/errors"
+	"xorm.io/builder"
+)
+
+// emailDeliveryRepo email delivery repository
+type emailDeliveryRepo struct {
+	data *data.Data
+}
+
+// NewEmailDeliveryRepo new repository
+func NewEmailDeliveryRepo(data *data.Data) export.EmailDeliveryRepo {
+	return &emailDeliveryRepo{
+		data: data,
+	}
+}
+
+// AddDelivery add email delivery
+func (er *emailDeliveryRepo) AddDelivery(ctx context.Context, delivery *entity.EmailDelivery) (err error) {
+	_, err = er.data.DB.Context(ctx).Insert(delivery)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// UpdateDelivery update email delivery result
+func (er *emailDeliveryRepo) UpdateDelivery(ctx context.Context, delivery *entity.EmailDelivery) (err error) {
+	_, err = er.data.DB.Context(ctx).ID(delivery.ID).
+		Cols("body", "status", "attempts", "error", "sent_at").Update(delivery)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetDelivery get email delivery one
+func (er *emailDeliveryRepo) GetDelivery(ctx context.Context, deliveryID int64) (
+	delivery *entity.EmailDelivery, exist bool, err error) {
+	delivery = &entity.EmailDelivery{}
+	exist, err = er.data.DB.Context(ctx).ID(deliveryID).Get(delivery)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetDeliveryPage get email delivery page, the latest first
+func (er *emailDeliveryRepo) GetDeliveryPage(ctx context.Context, page, pageSize int, status int) (
+	deliveries []*entity.EmailDelivery, total int64, err error) {
+	session := er.data.DB.Context(ctx).Omit("body").Desc("id")
+	deliveries = make([]*entity.EmailDelivery, 0)
+	total, err = pager.Help(page, pageSize, &deliveries, &entity.EmailDelivery{Status: status}, session)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// RemoveDoneDeliveries remove the sent, bounced and failed email deliveries updated before the time
+func (er *emailDeliveryRepo) RemoveDoneDeliveries(ctx context.Context, updatedBefore time.Time) (
+	removed int64, err error) {
+	removed, err = er.data.DB.Context(ctx).
+		In("status", entity.EmailDeliveryStatusSent, entity.EmailDeliveryStatusBounced, entity.EmailDeliveryStatusFailed).
+		And(builder.Lt{"updated_at": updatedBefore}).Delete(&entity.EmailDelivery{})
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
@@ -0,0 +1,235 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package importer
+
+import (
+	"context"
+
+	"github.com/apache/incubator-answer/internal/base/data"
+	"github.com/apache/incubator-answer/internal/base/reason"
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/service/importer"
+	"github.com/apache/incubator-answer/internal/service/role"
+	"github.com/segmentfault/pacman/errors"
+	"xorm.io/builder"
+	"xorm.io/xorm"
+)
+
+// importRepo import repository
+type importRepo struct {
+	data *data.Data
+}
+
+// NewImportRepo new repository
+func NewImportRepo(data *data.Data) importer.ImportRepo {
+	return &importRepo{
+		data: data,
+	}
+}
+
+// GetIDMapping get the mapping from old id to new id of the imported objects
+func (ir *importRepo) GetIDMapping(ctx context.Context, source, objectType string) (
+	mapping map[string]string, err error) {
+	rows := make([]*entity.ImportIDMapping, 0)
+	err = ir.data.DB.Context(ctx).Where("source = ? AND object_type = ?", source, objectType).Find(&rows)
+	if err != nil {
+		return nil, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	mapping = make(map[string]string, len(rows))
+	for _, row := range rows {
+		mapping[row.OldID] = row.NewID
+	}
+	return mapping, nil
+}
+
+// GetUserByEmail get the user by email
+func (ir *importRepo) GetUserByEmail(ctx context.Context, email string) (user *entity.User, exist bool, err error) {
+	user = &entity.User{}
+	exist, err = ir.data.DB.Context(ctx).Where("e_mail = ?", email).Get(user)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// CheckUsernameExist check whether the username is used
+func (ir *importRepo) CheckUsernameExist(ctx context.Context, username string) (exist bool, err error) {
+	exist, err = ir.data.DB.Context(ctx).Where("username = ?", username).Exist(&entity.User{})
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetFirstAdminUserID get the id of the first admin user
+func (ir *importRepo) GetFirstAdminUserID(ctx context.Context) (userID string, exist bool, err error) {
+	rel := &entity.UserRoleRel{}
+	exist, err = ir.data.DB.Context(ctx).Where("role_id = ?", role.RoleAdminID).Asc("id").Get(rel)
+	if err != nil {
+		return "", false, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return rel.UserID, exist, nil
+}
+
+// GetTagsBySlugNames get the tags by slug names
+func (ir *importRepo) GetTagsBySlugNames(ctx context.Context, slugNames []string) (tags []*entity.Tag, err error) {
+	tags = make([]*entity.Tag, 0)
+	err = ir.data.DB.Context(ctx).In("slug_name", slugNames).Find(&tags)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// AddUser add the imported user and its id mapping
+func (ir *importRepo) AddUser(ctx context.Context, user *entity.User, mapping *entity.ImportIDMapping) (err error) {
+	_, err = ir.data.DB.Transaction(func(session *xorm.Session) (result any, err error) {
+		session = session.Context(ctx)
+		if _, err = session.NoAutoTime().Insert(user); err != nil {
+			return nil, err
+		}
+		mapping.NewID = user.ID
+		_, err = session.Insert(mapping)
+		return nil, err
+	})
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// AddQuestion add the imported question with its tags, revisions and id mapping,
+// the revision id of question is set to the last revision.
+func (ir *importRepo) AddQuestion(ctx context.Context, question *entity.Question, tagRels []*entity.TagRel,
+	revisions []*entity.Revision, mapping *entity.ImportIDMapping) (err error) {
+	_, err = ir.data.DB.Transaction(func(session *xorm.Session) (result any, err error) {
+		session = session.Context(ctx)
+		if question.RevisionID, err = addRevisions(session, revisions); err != nil {
+			return nil, err
+		}
+		if _, err = session.NoAutoTime().Insert(question); err != nil {
+			return nil, err
+		}
+		if len(tagRels) > 0 {
+			if _, err = session.NoAutoTime().Insert(tagRels); err != nil {
+				return nil, err
+			}
+		}
+		_, err = session.Insert(mapping)
+		return nil, err
+	})
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// AddAnswer add the imported answer with its revisions and id mapping
+func (ir *importRepo) AddAnswer(ctx context.Context, answer *entity.Answer,
+	revisions []*entity.Revision, mapping *entity.ImportIDMapping) (err error) {
+	_, err = ir.data.DB.Transaction(func(session *xorm.Session) (result any, err error) {
+		session = session.Context(ctx)
+		if answer.RevisionID, err = addRevisions(session, revisions); err != nil {
+			return nil, err
+		}
+		if _, err = session.NoAutoTime().Insert(answer); err != nil {
+			return nil, err
+		}
+		_, err = session.Insert(mapping)
+		return nil, err
+	})
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// addRevisions add revisions one by one and return the id of the last one
+func addRevisions(session *xorm.Session, revisions []*entity.Revision) (lastRevisionID string, err error) {
+	lastRevisionID = "0"
+	for _, revision := range revisions {
+		if _, err = session.NoAutoTime().Insert(revision); err != nil {
+			return "", err
+		}
+		lastRevisionID = revision.ID
+	}
+	return lastRevisionID, nil
+}
+
+// AddObjects add the imported objects and the id mapping in one transaction
+func (ir *importRepo) AddObjects(ctx context.Context, mapping *entity.ImportIDMapping, objects ...any) (err error) {
+	_, err = ir.data.DB.Transaction(func(session *xorm.Session) (result any, err error) {
+		session = session.Context(ctx)
+		for _, object := range objects {
+			if _, err = session.NoAutoTime().Insert(object); err != nil {
+				return nil, err
+			}
+		}
+		_, err = session.Insert(mapping)
+		return nil, err
+	})
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// UpdateTagQuestionCount update the question count of tags by the available questions
+func (ir *importRepo) UpdateTagQuestionCount(ctx context.Context, tagIDs []string) (err error) {
+	for _, tagID := range tagIDs {
+		count, err := ir.data.DB.Context(ctx).Table("tag_rel").
+			Join("INNER", "question", "question.id = tag_rel.object_id").
+			Where(builder.Eq{"tag_rel.tag_id": tagID}).
+			And(builder.Eq{"tag_rel.status": entity.TagRelStatusAvailable}).
+			And(builder.Lt{"question.status": entity.QuestionStatusDeleted}).
+			Count()
+		if err != nil {
+			return errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+		}
+		_, err = ir.data.DB.Context(ctx).ID(tagID).MustCols("question_count").
+			Update(&entity.Tag{QuestionCount: int(count)})
+		if err != nil {
+			return errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+		}
+	}
+	return nil
+}
+
+// UpdateUserContentCount update the question and answer count of users by the available contents
+func (ir *importRepo) UpdateUserContentCount(ctx context.Context, userIDs []string) (err error) {
+	for _, userID := range userIDs {
+		questionCount, err := ir.data.DB.Context(ctx).Where("user_id = ?", userID).
+			And(builder.Lt{"status": entity.QuestionStatusDeleted}).Count(&entity.Question{})
+		if err != nil {
+			return errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+		}
+		answerCount, err := ir.data.DB.Context(ctx).Where("user_id = ?", userID).
+			And(builder.Eq{"status": entity.AnswerStatusAvailable}).Count(&entity.Answer{})
+		if err != nil {
+			return errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+		}
+		_, err = ir.data.DB.Context(ctx).ID(userID).MustCols("question_count", "answer_count").
+			Update(&entity.User{QuestionCount: int(questionCount), AnswerCount: int(answerCount)})
+		if err != nil {
+			return errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+		}
+	}
+	return nil
+}
@@ -0,0 +1,130 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package job_queue
+
+import (
+	"context"
+	"time"
+
+	"github.com/apache/incubator-answer/internal/base/data"
+	"github.com/apache/incubator-answer/internal/base/pager"
+	"github.com/apache/incubator-answer/internal/base/reason"
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/service/job_queue"
+	"github.com/segmentfault/pacman/errors"
+)
+
+// jobQueueRepo job queue repository
+type jobQueueRepo struct {
+	data *data.Data
+}
+
+// NewJobQueueRepo new repository
+func NewJobQueueRepo(data *data.Data) job_queue.JobQueueRepo {
+	return &jobQueueRepo{
+		data: data,
+	}
+}
+
+// AddJob add job
+func (jr *jobQueueRepo) AddJob(ctx context.Context, job *entity.QueueJob) (err error) {
+	_, err = jr.data.DB.Context(ctx).Insert(job)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetJob get job one
+func (jr *jobQueueRepo) GetJob(ctx context.Context, jobID int64) (job *entity.QueueJob, exist bool, err error) {
+	job = &entity.QueueJob{}
+	exist, err = jr.data.DB.Context(ctx).ID(jobID).Get(job)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetDueJobs get pending jobs of the queue that should be run now, the oldest first
+func (jr *jobQueueRepo) GetDueJobs(ctx context.Context, queueName string, now time.Time, limit int) (
+	jobs []*entity.QueueJob, err error) {
+	jobs = make([]*entity.QueueJob, 0)
+	err = jr.data.DB.Context(ctx).
+		Where("queue_name = ? AND status = ? AND next_run_at <= ?", queueName, entity.QueueJobStatusPending, now).
+		Asc("id").Limit(limit).Find(&jobs)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetJobPage get job page
+func (jr *jobQueueRepo) GetJobPage(ctx context.Context, page, pageSize int, cond *entity.QueueJob) (
+	jobs []*entity.QueueJob, total int64, err error) {
+	session := jr.data.DB.Context(ctx).Desc("id")
+	jobs = make([]*entity.QueueJob, 0)
+	total, err = pager.Help(page, pageSize, &jobs, cond, session)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// ClaimJob mark the pending job as running, claimed is false if another worker got it first
+func (jr *jobQueueRepo) ClaimJob(ctx context.Context, jobID int64, now time.Time) (claimed bool, err error) {
+	affected, err := jr.data.DB.Context(ctx).ID(jobID).
+		Where("status = ?", entity.QueueJobStatusPending).
+		Cols("status", "claimed_at").Update(&entity.QueueJob{Status: entity.QueueJobStatusRunning, ClaimedAt: now})
+	if err != nil {
+		return false, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return affected > 0, nil
+}
+
+// UpdateJob update job
+func (jr *jobQueueRepo) UpdateJob(ctx context.Context, job *entity.QueueJob, cols ...string) (err error) {
+	_, err = jr.data.DB.Context(ctx).ID(job.ID).Cols(cols...).Update(job)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// RemoveJob remove job
+func (jr *jobQueueRepo) RemoveJob(ctx context.Context, jobID int64) (err error) {
+	_, err = jr.data.DB.Context(ctx).ID(jobID).Delete(&entity.QueueJob{})
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// ResetExpiredJobs put the running jobs of the queue claimed before the time back to pending
+func (jr *jobQueueRepo) ResetExpiredJobs(ctx context.Context, queueName string, claimedBefore time.Time) (
+	affected int64, err error) {
+	affected, err = jr.data.DB.Context(ctx).
+		Where("queue_name = ? AND status = ?", queueName, entity.QueueJobStatusRunning).
+		And("claimed_at IS NULL OR claimed_at < ?", claimedBefore).
+		Cols("status").Update(&entity.QueueJob{Status: entity.QueueJobStatusPending})
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
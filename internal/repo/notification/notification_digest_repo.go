@@ -0,0 +1,89 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package notification
+
+import (
+	"context"
+	"time"
+
+	"github.com/apache/incubator-answer/internal/base/data"
+	"github.com/apache/incubator-answer/internal/base/reason"
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/service/notification"
+	"github.com/segmentfault/pacman/errors"
+)
+
+// notificationDigestRepo notification digest repository
+type notificationDigestRepo struct {
+	data *data.Data
+}
+
+// NewNotificationDigestRepo new repository
+func NewNotificationDigestRepo(data *data.Data) notification.NotificationDigestRepo {
+	return &notificationDigestRepo{
+		data: data,
+	}
+}
+
+// AddDigestItem add notification to the digest queue
+func (nr *notificationDigestRepo) AddDigestItem(ctx context.Context, item *entity.NotificationDigest) (err error) {
+	_, err = nr.data.DB.Context(ctx).Insert(item)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetDigestUserIDs get the users who have notifications of frequency queued before the time
+func (nr *notificationDigestRepo) GetDigestUserIDs(ctx context.Context, frequency string, before time.Time) (
+	userIDs []string, err error) {
+	userIDs = make([]string, 0)
+	err = nr.data.DB.Context(ctx).Table(entity.NotificationDigest{}.TableName()).
+		Where("frequency = ? AND created_at <= ?", frequency, before).
+		Distinct("user_id").Find(&userIDs)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetDigestItems get the notifications of frequency queued before the time for user
+func (nr *notificationDigestRepo) GetDigestItems(ctx context.Context, userID, frequency string, before time.Time) (
+	items []*entity.NotificationDigest, err error) {
+	items = make([]*entity.NotificationDigest, 0)
+	err = nr.data.DB.Context(ctx).Where("user_id = ? AND frequency = ? AND created_at <= ?", userID, frequency, before).
+		OrderBy("id ASC").Find(&items)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// RemoveDigestItems remove the notifications from the digest queue
+func (nr *notificationDigestRepo) RemoveDigestItems(ctx context.Context, ids []int64) (err error) {
+	if len(ids) == 0 {
+		return nil
+	}
+	_, err = nr.data.DB.Context(ctx).In("id", ids).Delete(&entity.NotificationDigest{})
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
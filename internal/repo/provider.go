@@ -24,12 +24,18 @@ import (
 	"github.com/apache/incubator-answer/internal/repo/activity"
 	"github.com/apache/incubator-answer/internal/repo/activity_common"
 	"github.com/apache/incubator-answer/internal/repo/answer"
+	"github.com/apache/incubator-answer/internal/repo/api_token"
+	"github.com/apache/incubator-answer/internal/repo/attachment"
 	"github.com/apache/incubator-answer/internal/repo/auth"
+	"github.com/apache/incubator-answer/internal/repo/badge"
+	"github.com/apache/incubator-answer/internal/repo/bounty"
 	"github.com/apache/incubator-answer/internal/repo/captcha"
 	"github.com/apache/incubator-answer/internal/repo/collection"
 	"github.com/apache/incubator-answer/internal/repo/comment"
 	"github.com/apache/incubator-answer/internal/repo/config"
 	"github.com/apache/incubator-answer/internal/repo/export"
+	"github.com/apache/incubator-answer/internal/repo/importer"
+	"github.com/apache/incubator-answer/internal/repo/job_queue"
 	"github.com/apache/incubator-answer/internal/repo/limit"
 	"github.com/apache/incubator-answer/internal/repo/meta"
 	"github.com/apache/incubator-answer/internal/repo/notification"
@@ -42,13 +48,17 @@ import (
 	"github.com/apache/incubator-answer/internal/repo/revision"
 	"github.com/apache/incubator-answer/internal/repo/role"
 	"github.com/apache/incubator-answer/internal/repo/search_common"
+	"github.com/apache/incubator-answer/internal/repo/search_sync"
 	"github.com/apache/incubator-answer/internal/repo/site_info"
 	"github.com/apache/incubator-answer/internal/repo/tag"
 	"github.com/apache/incubator-answer/internal/repo/tag_common"
 	"github.com/apache/incubator-answer/internal/repo/unique"
+	"github.com/apache/incubator-answer/internal/repo/uploader"
 	"github.com/apache/incubator-answer/internal/repo/user"
+	"github.com/apache/incubator-answer/internal/repo/user_data"
 	"github.com/apache/incubator-answer/internal/repo/user_external_login"
 	"github.com/apache/incubator-answer/internal/repo/user_notification_config"
+	"github.com/apache/incubator-answer/internal/repo/webhook"
 	"github.com/google/wire"
 )
 
@@ -77,7 +87,9 @@ var ProviderSetRepo = wire.NewSet(
 	activity.NewUserActiveActivityRepo,
 	activity.NewActivityRepo,
 	activity.NewReviewActivityRepo,
+	activity.NewBountyActivityRepo,
 	tag.NewTagRepo,
+	tag.NewTagGroupRepo,
 	tag_common.NewTagCommonRepo,
 	tag.NewTagRelRepo,
 	collection.NewCollectionRepo,
@@ -87,9 +99,11 @@ var ProviderSetRepo = wire.NewSet(
 	search_common.NewSearchRepo,
 	meta.NewMetaRepo,
 	export.NewEmailRepo,
+	export.NewEmailDeliveryRepo,
 	reason.NewReasonRepo,
 	site_info.NewSiteInfo,
 	notification.NewNotificationRepo,
+	notification.NewNotificationDigestRepo,
 	role.NewRoleRepo,
 	role.NewUserRoleRelRepo,
 	role.NewRolePowerRelRepo,
@@ -97,7 +111,20 @@ var ProviderSetRepo = wire.NewSet(
 	user_external_login.NewUserExternalLoginRepo,
 	plugin_config.NewPluginConfigRepo,
 	user_notification_config.NewUserNotificationConfigRepo,
+	user_notification_config.NewNotificationMuteRepo,
 	limit.NewRateLimitRepo,
 	plugin_config.NewPluginUserConfigRepo,
 	review.NewReviewRepo,
+	job_queue.NewJobQueueRepo,
+	webhook.NewWebhookRepo,
+	api_token.NewAPITokenRepo,
+	search_sync.NewSearchSyncRepo,
+	search_sync.NewSearchSyncCheckpointRepo,
+	importer.NewImportRepo,
+	user_data.NewUserDataRepo,
+	bounty.NewBountyRepo,
+	badge.NewBadgeRepo,
+	rank.NewRankRecalculateRepo,
+	attachment.NewAttachmentRepo,
+	uploader.NewUploadedURLRepo,
 )
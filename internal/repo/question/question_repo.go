@@ -387,6 +387,10 @@ func (qr *questionRepo) GetQuestionPage(ctx context.Context, page, pageSize int,
 	case "unanswered":
 		session.Where("question.last_answer_id = 0")
 		session.OrderBy("question.pin desc,question.created_at DESC")
+	case "featured":
+		session.Join("INNER", "question_bounty", "question.id = question_bounty.question_id")
+		session.And("question_bounty.status = ?", entity.QuestionBountyStatusActive)
+		session.OrderBy("question_bounty.amount DESC, question_bounty.expires_at ASC")
 	}
 
 	total, err = pager.Help(page, pageSize, &questionList, &entity.Question{}, session)
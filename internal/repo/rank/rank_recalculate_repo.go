@@ -0,0 +1,122 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package rank
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/apache/incubator-answer/internal/base/data"
+	"github.com/apache/incubator-answer/internal/base/reason"
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/apache/incubator-answer/internal/service/rank"
+	"github.com/apache/incubator-answer/pkg/converter"
+	"github.com/segmentfault/pacman/errors"
+	"xorm.io/builder"
+	"xorm.io/xorm"
+)
+
+// rankRecalculateRepo reputation recalculation repository
+type rankRecalculateRepo struct {
+	data *data.Data
+}
+
+// NewRankRecalculateRepo new repository
+func NewRankRecalculateRepo(data *data.Data) rank.RankRecalculateRepo {
+	return &rankRecalculateRepo{
+		data: data,
+	}
+}
+
+// GetUsersAfter get the users whose id is greater than the last user id, in ascending order
+func (rr *rankRecalculateRepo) GetUsersAfter(ctx context.Context, lastUserID string, limit int) (
+	users []*entity.User, err error) {
+	users = make([]*entity.User, 0)
+	err = rr.data.DB.Context(ctx).Cols("id", "username", "`rank`", "mail_status").
+		Where(builder.Gt{"id": converter.StringToInt64(lastUserID)}).
+		And(builder.Neq{"status": entity.UserStatusDeleted}).
+		Asc("id").Limit(limit).Find(&users)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetRankActivities get the available activities of the user including the ones without rank,
+// in the order they were last updated, which is the time the daily rank limit counts them
+func (rr *rankRecalculateRepo) GetRankActivities(ctx context.Context, userID string) (
+	activities []*entity.Activity, err error) {
+	activities = make([]*entity.Activity, 0)
+	err = rr.data.DB.Context(ctx).Where(builder.Eq{"user_id": userID}).
+		And(builder.Eq{"cancelled": entity.ActivityAvailable}).
+		Asc("updated_at", "id").Find(&activities)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// ApplyRankDiffs update the reputation of the users and the rank of their activities in one transaction,
+// an audit log is recorded for every user. The users whose reputation is changed after it was read are skipped.
+func (rr *rankRecalculateRepo) ApplyRankDiffs(ctx context.Context, diffs []*schema.RankRecalculateDiff,
+	operatorID string) (applied int, err error) {
+	_, err = rr.data.DB.Transaction(func(session *xorm.Session) (result any, err error) {
+		session = session.Context(ctx)
+		applied = 0
+		for _, diff := range diffs {
+			user := &entity.User{}
+			exist, err := session.ID(diff.UserID).ForUpdate().Cols("`rank`").Get(user)
+			if err != nil {
+				return nil, err
+			}
+			if !exist || user.Rank != diff.OldRank {
+				continue
+			}
+			if _, err = session.ID(diff.UserID).Cols("`rank`").Update(&entity.User{Rank: diff.NewRank}); err != nil {
+				return nil, err
+			}
+			for _, act := range diff.Activities {
+				// the updated time is kept, the daily rank limit counts the activities by it
+				_, err = session.ID(act.ID).Cols("`rank`", "has_rank").NoAutoTime().
+					Update(&entity.Activity{Rank: act.Rank, HasRank: act.HasRank})
+				if err != nil {
+					return nil, err
+				}
+			}
+			_, err = session.Insert(&entity.AuditLog{
+				OperatorID: operatorID,
+				Action:     entity.AuditActionUserRankRecalculate,
+				ObjectID:   diff.UserID,
+				Detail: fmt.Sprintf("reputation recalculated from %d to %d, %d activities updated",
+					diff.OldRank, diff.NewRank, diff.ChangedActivities),
+			})
+			if err != nil {
+				return nil, err
+			}
+			applied++
+		}
+		return nil, nil
+	})
+	if err != nil {
+		return 0, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return applied, nil
+}
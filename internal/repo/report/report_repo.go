@@ -21,6 +21,7 @@ package report
 
 import (
 	"context"
+	"time"
 
 	"github.com/apache/incubator-answer/internal/base/pager"
 	"github.com/apache/incubator-answer/internal/schema"
@@ -31,6 +32,7 @@ import (
 	"github.com/apache/incubator-answer/internal/entity"
 	"github.com/apache/incubator-answer/internal/service/unique"
 	"github.com/segmentfault/pacman/errors"
+	"xorm.io/builder"
 )
 
 // reportRepo report repository
@@ -60,41 +62,101 @@ func (rr *reportRepo) AddReport(ctx context.Context, report *entity.Report) (err
 	return
 }
 
-// GetReportListPage get report list page
-func (rr *reportRepo) GetReportListPage(ctx context.Context, dto *schema.GetReportListPageDTO) (
-	reports []*entity.Report, total int64, err error) {
-	cond := &entity.Report{}
-	cond.Status = dto.Status
-	session := rr.data.DB.Context(ctx).Desc("updated_at")
-	total, err = pager.Help(dto.Page, dto.PageSize, &reports, cond, session)
+// GetByID get report by ID
+func (rr *reportRepo) GetByID(ctx context.Context, id string) (report *entity.Report, exist bool, err error) {
+	report = &entity.Report{}
+	exist, err = rr.data.DB.Context(ctx).ID(id).Get(report)
 	if err != nil {
 		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
 	}
 	return
 }
 
-// GetByID get report by ID
-func (rr *reportRepo) GetByID(ctx context.Context, id string) (report *entity.Report, exist bool, err error) {
-	report = &entity.Report{}
-	exist, err = rr.data.DB.Context(ctx).ID(id).Get(report)
+// GetReportCasePage get the page of the pending reports grouped by object
+func (rr *reportRepo) GetReportCasePage(ctx context.Context, dto *schema.GetReportCasePageDTO) (
+	cases []*entity.ReportCaseStat, total int64, err error) {
+	cases = make([]*entity.ReportCaseStat, 0)
+	cond := builder.NewCond().And(builder.Eq{"status": entity.ReportStatusPending})
+	if len(dto.AssigneeUserID) > 0 {
+		cond = cond.And(builder.Eq{"assignee_user_id": dto.AssigneeUserID})
+	}
+
+	_, err = rr.data.DB.Context(ctx).Table(entity.Report{}.TableName()).Where(cond).
+		Select("COUNT(DISTINCT object_id)").Get(&total)
+	if err != nil {
+		return nil, 0, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+
+	page, pageSize := pager.ValPageAndPageSize(dto.Page, dto.PageSize)
+	session := rr.data.DB.Context(ctx).Table(entity.Report{}.TableName()).Where(cond)
+	session.Select("object_id, COUNT(*) AS report_count, MIN(id) AS first_report_id, " +
+		"MAX(id) AS last_report_id, MAX(assignee_user_id) AS assignee_user_id")
+	session.GroupBy("object_id")
+	switch dto.Sort {
+	case schema.ReportCaseSortOldest:
+		session.Asc("first_report_id")
+	case schema.ReportCaseSortMostReported:
+		session.Desc("report_count").Asc("first_report_id")
+	default:
+		session.Desc("last_report_id")
+	}
+	session.Limit(pageSize, (page-1)*pageSize)
+	if err = session.Find(&cases); err != nil {
+		return nil, 0, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return cases, total, nil
+}
+
+// GetPendingReportsByObjectIDs get the pending reports of objects
+func (rr *reportRepo) GetPendingReportsByObjectIDs(ctx context.Context, objectIDs []string) (
+	reports []*entity.Report, err error) {
+	reports = make([]*entity.Report, 0)
+	err = rr.data.DB.Context(ctx).In("object_id", objectIDs).
+		Where("status = ?", entity.ReportStatusPending).Asc("id").Find(&reports)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// UpdatePendingAssignee assign the pending reports of the object to the user, "0" means unassigned
+func (rr *reportRepo) UpdatePendingAssignee(ctx context.Context, objectID, assigneeUserID string) (err error) {
+	_, err = rr.data.DB.Context(ctx).Where("object_id = ? AND status = ?", objectID, entity.ReportStatusPending).
+		Cols("assignee_user_id").Update(&entity.Report{AssigneeUserID: assigneeUserID})
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// UpdatePendingStatus resolve all the pending reports of the object
+func (rr *reportRepo) UpdatePendingStatus(ctx context.Context, objectID string, status int, handledUserID string) (
+	affected int64, err error) {
+	affected, err = rr.data.DB.Context(ctx).Where("object_id = ? AND status = ?", objectID, entity.ReportStatusPending).
+		Cols("status", "handled_user_id", "handled_at").
+		Update(&entity.Report{Status: status, HandledUserID: handledUserID, HandledAt: time.Now()})
 	if err != nil {
 		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
 	}
 	return
 }
 
-// UpdateStatus update report status by ID
-func (rr *reportRepo) UpdateStatus(ctx context.Context, id string, status int) (err error) {
-	_, err = rr.data.DB.Context(ctx).ID(id).Update(&entity.Report{Status: status})
+// GetHandledReports get the reports handled by the moderators since the start time
+func (rr *reportRepo) GetHandledReports(ctx context.Context, startTime time.Time) (reports []*entity.Report, err error) {
+	reports = make([]*entity.Report, 0)
+	err = rr.data.DB.Context(ctx).Cols("id", "created_at", "status", "handled_user_id", "handled_at").
+		Where("handled_user_id <> 0 AND handled_at >= ?", startTime).
+		In("status", []int{entity.ReportStatusCompleted, entity.ReportStatusIgnore}).Find(&reports)
 	if err != nil {
 		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
 	}
 	return
 }
 
+// GetReportCount get the count of the pending report cases
 func (rr *reportRepo) GetReportCount(ctx context.Context) (count int64, err error) {
-	list := make([]*entity.Report, 0)
-	count, err = rr.data.DB.Context(ctx).Where("status =?", entity.ReportStatusPending).FindAndCount(&list)
+	_, err = rr.data.DB.Context(ctx).Table(entity.Report{}.TableName()).
+		Where("status = ?", entity.ReportStatusPending).Select("COUNT(DISTINCT object_id)").Get(&count)
 	if err != nil {
 		return count, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
 	}
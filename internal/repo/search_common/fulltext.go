@@ -0,0 +1,215 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package search_common
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	"xorm.io/xorm/schemas"
+)
+
+// The full-text indexes used here are created by migrations, the expressions and table names
+// must be the same as them, otherwise the index will not be used.
+var fullTextColumns = map[string][]string{
+	"question": {"title", "original_text"},
+	"answer":   {"original_text"},
+}
+
+// fullTextSearcher build the condition and relevance with the native full-text search of database
+type fullTextSearcher interface {
+	// supports whether the words can be searched by full-text index, otherwise fall back to LIKE
+	supports(words []string) bool
+	// match returns the condition that matches any of the words
+	match(table string, words []string) (cond string, args []interface{})
+	// relevance returns the expression of relevance, the bigger the better
+	relevance(table string, words []string) (field string, args []interface{})
+}
+
+// newFullTextSearcher returns nil if the database does not support full-text search
+func newFullTextSearcher(dbType schemas.DBType) fullTextSearcher {
+	switch dbType {
+	case schemas.MYSQL:
+		return &mysqlFullTextSearcher{}
+	case schemas.POSTGRES:
+		return &postgresFullTextSearcher{}
+	case schemas.SQLITE:
+		return &sqliteFullTextSearcher{}
+	}
+	return nil
+}
+
+// mysqlFullTextSearcher use FULLTEXT index with boolean mode
+type mysqlFullTextSearcher struct{}
+
+// mysqlMinTokenSize the default value of innodb_ft_min_token_size, shorter words are not indexed
+const mysqlMinTokenSize = 3
+
+func (s *mysqlFullTextSearcher) supports(words []string) bool {
+	if len(words) == 0 {
+		return false
+	}
+	for _, word := range words {
+		word = s.clean(word)
+		if hasCJK(word) || len([]rune(word)) < mysqlMinTokenSize {
+			return false
+		}
+	}
+	return true
+}
+
+func (s *mysqlFullTextSearcher) match(table string, words []string) (cond string, args []interface{}) {
+	terms := make([]string, 0, len(words))
+	for _, word := range words {
+		terms = append(terms, s.clean(word)+"*")
+	}
+	return fmt.Sprintf("MATCH(%s) AGAINST (? IN BOOLEAN MODE)", s.columns(table)),
+		[]interface{}{strings.Join(terms, " ")}
+}
+
+func (s *mysqlFullTextSearcher) relevance(table string, words []string) (field string, args []interface{}) {
+	terms := make([]string, 0, len(words))
+	for _, word := range words {
+		terms = append(terms, s.clean(word))
+	}
+	return fmt.Sprintf("MATCH(%s) AGAINST (? IN NATURAL LANGUAGE MODE)", s.columns(table)),
+		[]interface{}{strings.Join(terms, " ")}
+}
+
+func (s *mysqlFullTextSearcher) columns(table string) string {
+	columns := make([]string, 0)
+	for _, column := range fullTextColumns[table] {
+		columns = append(columns, "`"+table+"`.`"+column+"`")
+	}
+	return strings.Join(columns, ", ")
+}
+
+// clean remove the operators of boolean mode
+func (s *mysqlFullTextSearcher) clean(word string) string {
+	return strings.Map(func(r rune) rune {
+		if strings.ContainsRune(`+-<>()~*"@`, r) {
+			return -1
+		}
+		return r
+	}, word)
+}
+
+// postgresFullTextSearcher use GIN index of tsvector expression
+type postgresFullTextSearcher struct{}
+
+// postgresTextSearchConfig the text search config used by index and query, it decides how words are stemmed
+const postgresTextSearchConfig = "english"
+
+func (s *postgresFullTextSearcher) supports(words []string) bool {
+	if len(words) == 0 {
+		return false
+	}
+	for _, word := range words {
+		word = s.clean(word)
+		if len(word) == 0 || hasCJK(word) {
+			return false
+		}
+	}
+	return true
+}
+
+func (s *postgresFullTextSearcher) match(table string, words []string) (cond string, args []interface{}) {
+	return fmt.Sprintf("%s @@ to_tsquery('%s', ?)", s.vector(table), postgresTextSearchConfig),
+		[]interface{}{s.query(words)}
+}
+
+func (s *postgresFullTextSearcher) relevance(table string, words []string) (field string, args []interface{}) {
+	return fmt.Sprintf("ts_rank(%s, to_tsquery('%s', ?))", s.vector(table), postgresTextSearchConfig),
+		[]interface{}{s.query(words)}
+}
+
+func (s *postgresFullTextSearcher) vector(table string) string {
+	columns := make([]string, 0)
+	for _, column := range fullTextColumns[table] {
+		columns = append(columns, "`"+table+"`.`"+column+"`")
+	}
+	return fmt.Sprintf("to_tsvector('%s', %s)", postgresTextSearchConfig, strings.Join(columns, " || ' ' || "))
+}
+
+// query matches any of the words with prefix, eg: word1:* | word2:*
+func (s *postgresFullTextSearcher) query(words []string) string {
+	terms := make([]string, 0, len(words))
+	for _, word := range words {
+		terms = append(terms, s.clean(word)+":*")
+	}
+	return strings.Join(terms, " | ")
+}
+
+// clean only keep letters and digits, other characters are operators of tsquery
+func (s *postgresFullTextSearcher) clean(word string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return r
+		}
+		return -1
+	}, word)
+}
+
+// sqliteFullTextSearcher use FTS5 virtual table, the rowid of virtual table is the id of content
+type sqliteFullTextSearcher struct{}
+
+func (s *sqliteFullTextSearcher) supports(words []string) bool {
+	if len(words) == 0 {
+		return false
+	}
+	for _, word := range words {
+		if hasCJK(word) {
+			return false
+		}
+	}
+	return true
+}
+
+func (s *sqliteFullTextSearcher) match(table string, words []string) (cond string, args []interface{}) {
+	return fmt.Sprintf("`%s`.`id` IN (SELECT rowid FROM %s_fts WHERE %s_fts MATCH ?)", table, table, table),
+		[]interface{}{s.query(words)}
+}
+
+func (s *sqliteFullTextSearcher) relevance(table string, words []string) (field string, args []interface{}) {
+	// bm25 returns negative value, the smaller the better
+	return fmt.Sprintf("(SELECT -bm25(%s_fts) FROM %s_fts WHERE %s_fts MATCH ? AND rowid = `%s`.`id`)",
+			table, table, table, table),
+		[]interface{}{s.query(words)}
+}
+
+// query matches any of the words with prefix, eg: "word1"* OR "word2"*
+func (s *sqliteFullTextSearcher) query(words []string) string {
+	terms := make([]string, 0, len(words))
+	for _, word := range words {
+		terms = append(terms, `"`+strings.ReplaceAll(word, `"`, `""`)+`"*`)
+	}
+	return strings.Join(terms, " OR ")
+}
+
+// hasCJK the full-text parsers of databases can not split CJK words, so LIKE is better for them
+func hasCJK(word string) bool {
+	for _, r := range word {
+		if unicode.In(r, unicode.Han, unicode.Hiragana, unicode.Katakana, unicode.Hangul) {
+			return true
+		}
+	}
+	return false
+}
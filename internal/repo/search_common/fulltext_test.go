@@ -0,0 +1,53 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package search_common
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMySQLFullTextSearcher(t *testing.T) {
+	s := &mysqlFullTextSearcher{}
+	assert.True(t, s.supports([]string{"golang", "test"}))
+	assert.False(t, s.supports([]string{"go"}))
+	assert.False(t, s.supports([]string{"搜索引擎"}))
+	assert.False(t, s.supports(nil))
+
+	cond, args := s.match("question", []string{"+golang", "test"})
+	assert.Equal(t, "MATCH(`question`.`title`, `question`.`original_text`) AGAINST (? IN BOOLEAN MODE)", cond)
+	assert.Equal(t, []interface{}{"golang* test*"}, args)
+}
+
+func TestPostgresFullTextSearcher(t *testing.T) {
+	s := &postgresFullTextSearcher{}
+	assert.True(t, s.supports([]string{"go"}))
+	assert.False(t, s.supports([]string{"&|"}))
+
+	_, args := s.match("answer", []string{"go!", "test"})
+	assert.Equal(t, []interface{}{"go:* | test:*"}, args)
+	assert.Equal(t, "to_tsvector('english', `answer`.`original_text`)", s.vector("answer"))
+}
+
+func TestSQLiteFullTextSearcher(t *testing.T) {
+	s := &sqliteFullTextSearcher{}
+	assert.Equal(t, `"go"* OR "say ""hi"""*`, s.query([]string{"go", `say "hi"`}))
+}
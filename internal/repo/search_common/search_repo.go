@@ -47,6 +47,11 @@ import (
 )
 
 var (
+	// likeColumns the columns searched by LIKE when full-text search is not available
+	likeColumns = map[string][]string{
+		"question": {"title", "original_text"},
+		"answer":   {"`answer`.`original_text`"},
+	}
 	qFields = []string{
 		"`question`.`id`",
 		"`question`.`id` as `question_id`",
@@ -81,6 +86,7 @@ type searchRepo struct {
 	userCommon   *usercommon.UserCommon
 	uniqueIDRepo unique.UniqueIDRepo
 	tagCommon    *tagcommon.TagCommonService
+	fullText     fullTextSearcher
 }
 
 // NewSearchRepo new repository
@@ -95,6 +101,7 @@ func NewSearchRepo(
 		uniqueIDRepo: uniqueIDRepo,
 		userCommon:   userCommon,
 		tagCommon:    tagCommon,
+		fullText:     newFullTextSearcher(data.DB.Dialect().URI().DBType),
 	}
 }
 
@@ -113,8 +120,8 @@ func (sr *searchRepo) SearchContents(ctx context.Context, words []string, tagIDs
 
 	if order == "relevance" {
 		if len(words) > 0 {
-			qfs, argsQ = addRelevanceField([]string{"title", "original_text"}, words, qfs)
-			afs, argsA = addRelevanceField([]string{"`answer`.`original_text`"}, words, afs)
+			qfs, argsQ = sr.relevanceField("question", words, qfs)
+			afs, argsA = sr.relevanceField("answer", words, afs)
 		} else {
 			order = "newest"
 		}
@@ -133,20 +140,13 @@ func (sr *searchRepo) SearchContents(ctx context.Context, words []string, tagIDs
 	argsQ = append(argsQ, entity.QuestionStatusDeleted, entity.QuestionShow)
 	argsA = append(argsA, entity.QuestionStatusDeleted, entity.AnswerStatusDeleted, entity.QuestionShow)
 
-	likeConQ := builder.NewCond()
-	likeConA := builder.NewCond()
-	for _, word := range words {
-		likeConQ = likeConQ.Or(builder.Like{"title", word}).
-			Or(builder.Like{"original_text", word})
-		argsQ = append(argsQ, "%"+word+"%")
-		argsQ = append(argsQ, "%"+word+"%")
+	matchConQ, matchArgsQ := sr.matchCond("question", words)
+	matchConA, matchArgsA := sr.matchCond("answer", words)
+	argsQ = append(argsQ, matchArgsQ...)
+	argsA = append(argsA, matchArgsA...)
 
-		likeConA = likeConA.Or(builder.Like{"`answer`.original_text", word})
-		argsA = append(argsA, "%"+word+"%")
-	}
-
-	b.Where(likeConQ)
-	ub.Where(likeConA)
+	b.Where(matchConQ)
+	ub.Where(matchConA)
 
 	// check tag
 	for ti, tagID := range tagIDs {
@@ -249,7 +249,7 @@ func (sr *searchRepo) SearchQuestions(ctx context.Context, words []string, tagID
 	)
 	if order == "relevance" {
 		if len(words) > 0 {
-			qfs, args = addRelevanceField([]string{"title", "original_text"}, words, qfs)
+			qfs, args = sr.relevanceField("question", words, qfs)
 		} else {
 			order = "newest"
 		}
@@ -260,14 +260,9 @@ func (sr *searchRepo) SearchQuestions(ctx context.Context, words []string, tagID
 	b.Where(builder.Lt{"`question`.`status`": entity.QuestionStatusDeleted}).And(builder.Eq{"`question`.`show`": entity.QuestionShow})
 	args = append(args, entity.QuestionStatusDeleted, entity.QuestionShow)
 
-	likeConQ := builder.NewCond()
-	for _, word := range words {
-		likeConQ = likeConQ.Or(builder.Like{"title", word}).
-			Or(builder.Like{"original_text", word})
-		args = append(args, "%"+word+"%")
-		args = append(args, "%"+word+"%")
-	}
-	b.Where(likeConQ)
+	matchConQ, matchArgsQ := sr.matchCond("question", words)
+	args = append(args, matchArgsQ...)
+	b.Where(matchConQ)
 
 	// check tag
 	for ti, tagID := range tagIDs {
@@ -360,7 +355,7 @@ func (sr *searchRepo) SearchAnswers(ctx context.Context, words []string, tagIDs
 	)
 	if order == "relevance" {
 		if len(words) > 0 {
-			afs, args = addRelevanceField([]string{"`answer`.`original_text`"}, words, afs)
+			afs, args = sr.relevanceField("answer", words, afs)
 		} else {
 			order = "newest"
 		}
@@ -373,13 +368,10 @@ func (sr *searchRepo) SearchAnswers(ctx context.Context, words []string, tagIDs
 		And(builder.Lt{"`answer`.`status`": entity.AnswerStatusDeleted}).And(builder.Eq{"`question`.`show`": entity.QuestionShow})
 	args = append(args, entity.QuestionStatusDeleted, entity.AnswerStatusDeleted, entity.QuestionShow)
 
-	likeConA := builder.NewCond()
-	for _, word := range words {
-		likeConA = likeConA.Or(builder.Like{"`answer`.original_text", word})
-		args = append(args, "%"+word+"%")
-	}
+	matchConA, matchArgsA := sr.matchCond("answer", words)
+	args = append(args, matchArgsA...)
 
-	b.Where(likeConA)
+	b.Where(matchConA)
 
 	// check tag
 	for ti, tagID := range tagIDs {
@@ -572,6 +564,34 @@ func (sr *searchRepo) parseResult(ctx context.Context, res []map[string][]byte,
 	return resultList, nil
 }
 
+// relevanceField append the relevance field to fields, use the rank of full-text search if possible
+func (sr *searchRepo) relevanceField(table string, words, fields []string) (res []string, args []interface{}) {
+	if sr.fullText == nil || !sr.fullText.supports(words) {
+		return addRelevanceField(likeColumns[table], words, fields)
+	}
+	field, args := sr.fullText.relevance(table, words)
+	res = make([]string, 0, len(fields)+1)
+	res = append(res, fields...)
+	res = append(res, field+" as relevance")
+	return res, args
+}
+
+// matchCond returns the condition that matches any of the words, use full-text search if possible
+func (sr *searchRepo) matchCond(table string, words []string) (cond builder.Cond, args []interface{}) {
+	if sr.fullText != nil && sr.fullText.supports(words) {
+		sql, args := sr.fullText.match(table, words)
+		return builder.Expr(sql, args...), args
+	}
+	cond = builder.NewCond()
+	for _, word := range words {
+		for _, column := range likeColumns[table] {
+			cond = cond.Or(builder.Like{column, word})
+			args = append(args, "%"+word+"%")
+		}
+	}
+	return cond, args
+}
+
 func addRelevanceField(searchFields, words, fields []string) (res []string, args []interface{}) {
 	relevanceRes := []string{}
 	args = []interface{}{}
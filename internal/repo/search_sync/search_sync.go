@@ -21,45 +21,84 @@ package search_sync
 
 import (
 	"context"
+	"time"
+
 	"github.com/apache/incubator-answer/internal/base/constant"
 	"github.com/apache/incubator-answer/internal/base/data"
 	"github.com/apache/incubator-answer/internal/entity"
 	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/apache/incubator-answer/internal/service/search_sync"
 	"github.com/apache/incubator-answer/pkg/uid"
 	"github.com/apache/incubator-answer/plugin"
 	"github.com/segmentfault/pacman/log"
+	"xorm.io/xorm"
 )
 
 func NewPluginSyncer(data *data.Data) plugin.SearchSyncer {
 	return &PluginSyncer{data: data}
 }
 
+// NewSearchSyncRepo new repository
+func NewSearchSyncRepo(data *data.Data) search_sync.SearchSyncRepo {
+	return &PluginSyncer{data: data}
+}
+
 type PluginSyncer struct {
 	data *data.Data
 }
 
 func (p *PluginSyncer) GetAnswersPage(ctx context.Context, page, pageSize int) (
+	answerList []*plugin.SearchContent, err error) {
+	return p.GetAnswersPageSince(ctx, time.Time{}, page, pageSize)
+}
+
+func (p *PluginSyncer) GetQuestionsPage(ctx context.Context, page, pageSize int) (
+	questionList []*plugin.SearchContent, err error) {
+	return p.GetQuestionsPageSince(ctx, time.Time{}, page, pageSize)
+}
+
+// GetAnswersPageSince get answers created or updated after since, order by id to make the pages stable
+func (p *PluginSyncer) GetAnswersPageSince(ctx context.Context, since time.Time, page, pageSize int) (
 	answerList []*plugin.SearchContent, err error) {
 	answers := make([]*entity.Answer, 0)
 	startNum := (page - 1) * pageSize
-	err = p.data.DB.Context(ctx).Limit(pageSize, startNum).Find(&answers)
+	err = p.sinceSession(ctx, since).Asc("id").Limit(pageSize, startNum).Find(&answers)
 	if err != nil {
 		return nil, err
 	}
 	return p.convertAnswers(ctx, answers)
 }
 
-func (p *PluginSyncer) GetQuestionsPage(ctx context.Context, page, pageSize int) (
+// GetQuestionsPageSince get questions created or updated after since, order by id to make the pages stable
+func (p *PluginSyncer) GetQuestionsPageSince(ctx context.Context, since time.Time, page, pageSize int) (
 	questionList []*plugin.SearchContent, err error) {
 	questions := make([]*entity.Question, 0)
 	startNum := (page - 1) * pageSize
-	err = p.data.DB.Context(ctx).Limit(pageSize, startNum).Find(&questions)
+	err = p.sinceSession(ctx, since).Asc("id").Limit(pageSize, startNum).Find(&questions)
 	if err != nil {
 		return nil, err
 	}
 	return p.convertQuestions(ctx, questions)
 }
 
+// CountAnswers count answers created or updated after since
+func (p *PluginSyncer) CountAnswers(ctx context.Context, since time.Time) (count int64, err error) {
+	return p.sinceSession(ctx, since).Count(&entity.Answer{})
+}
+
+// CountQuestions count questions created or updated after since
+func (p *PluginSyncer) CountQuestions(ctx context.Context, since time.Time) (count int64, err error) {
+	return p.sinceSession(ctx, since).Count(&entity.Question{})
+}
+
+func (p *PluginSyncer) sinceSession(ctx context.Context, since time.Time) *xorm.Session {
+	session := p.data.DB.Context(ctx)
+	if !since.IsZero() {
+		session.Where("created_at >= ? OR updated_at >= ?", since, since)
+	}
+	return session
+}
+
 func (p *PluginSyncer) convertAnswers(ctx context.Context, answers []*entity.Answer) (
 	answerList []*plugin.SearchContent, err error) {
 	for _, answer := range answers {
@@ -0,0 +1,67 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package search_sync
+
+import (
+	"context"
+
+	"github.com/apache/incubator-answer/internal/base/data"
+	"github.com/apache/incubator-answer/internal/base/reason"
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/service/search_sync"
+	"github.com/segmentfault/pacman/errors"
+)
+
+// searchSyncCheckpointRepo search sync checkpoint repository
+type searchSyncCheckpointRepo struct {
+	data *data.Data
+}
+
+// NewSearchSyncCheckpointRepo new repository
+func NewSearchSyncCheckpointRepo(data *data.Data) search_sync.SearchSyncCheckpointRepo {
+	return &searchSyncCheckpointRepo{
+		data: data,
+	}
+}
+
+// GetCheckpoint get checkpoint of search plugin
+func (sr *searchSyncCheckpointRepo) GetCheckpoint(ctx context.Context, pluginSlugName string) (
+	checkpoint *entity.SearchSyncCheckpoint, exist bool, err error) {
+	checkpoint = &entity.SearchSyncCheckpoint{}
+	exist, err = sr.data.DB.Context(ctx).Where("plugin_slug_name = ?", pluginSlugName).Get(checkpoint)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// SaveCheckpoint add or update checkpoint
+func (sr *searchSyncCheckpointRepo) SaveCheckpoint(ctx context.Context, checkpoint *entity.SearchSyncCheckpoint) (
+	err error) {
+	if checkpoint.ID == 0 {
+		_, err = sr.data.DB.Context(ctx).Insert(checkpoint)
+	} else {
+		_, err = sr.data.DB.Context(ctx).ID(checkpoint.ID).AllCols().Update(checkpoint)
+	}
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
@@ -0,0 +1,111 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package tag
+
+import (
+	"context"
+
+	"github.com/apache/incubator-answer/internal/base/data"
+	"github.com/apache/incubator-answer/internal/base/reason"
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/service/tag_common"
+	"github.com/segmentfault/pacman/errors"
+	"xorm.io/xorm"
+)
+
+// tagGroupRepo tag group repository
+type tagGroupRepo struct {
+	data *data.Data
+}
+
+// NewTagGroupRepo new repository
+func NewTagGroupRepo(data *data.Data) tag_common.TagGroupRepo {
+	return &tagGroupRepo{
+		data: data,
+	}
+}
+
+// AddTagGroup add tag group
+func (tr *tagGroupRepo) AddTagGroup(ctx context.Context, tagGroup *entity.TagGroup) (err error) {
+	_, err = tr.data.DB.Context(ctx).Insert(tagGroup)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// UpdateTagGroup update tag group
+func (tr *tagGroupRepo) UpdateTagGroup(ctx context.Context, tagGroup *entity.TagGroup) (err error) {
+	_, err = tr.data.DB.Context(ctx).ID(tagGroup.ID).
+		Cols("name", "description", "sort_order").Update(tagGroup)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// RemoveTagGroup remove tag group, the tags of this group become ungrouped
+func (tr *tagGroupRepo) RemoveTagGroup(ctx context.Context, tagGroupID int64) (err error) {
+	_, err = tr.data.DB.Transaction(func(session *xorm.Session) (interface{}, error) {
+		session = session.Context(ctx)
+		if _, err := session.ID(tagGroupID).Delete(&entity.TagGroup{}); err != nil {
+			return nil, err
+		}
+		_, err := session.Where("tag_group_id = ?", tagGroupID).
+			MustCols("tag_group_id").Update(&entity.Tag{TagGroupID: 0})
+		return nil, err
+	})
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetTagGroup get tag group one
+func (tr *tagGroupRepo) GetTagGroup(ctx context.Context, tagGroupID int64) (
+	tagGroup *entity.TagGroup, exist bool, err error) {
+	tagGroup = &entity.TagGroup{}
+	exist, err = tr.data.DB.Context(ctx).ID(tagGroupID).Get(tagGroup)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetTagGroupByName get tag group by name
+func (tr *tagGroupRepo) GetTagGroupByName(ctx context.Context, name string) (
+	tagGroup *entity.TagGroup, exist bool, err error) {
+	tagGroup = &entity.TagGroup{}
+	exist, err = tr.data.DB.Context(ctx).Where("name = ?", name).Get(tagGroup)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetTagGroupList get all tag groups
+func (tr *tagGroupRepo) GetTagGroupList(ctx context.Context) (tagGroups []*entity.TagGroup, err error) {
+	tagGroups = make([]*entity.TagGroup, 0)
+	err = tr.data.DB.Context(ctx).Asc("sort_order", "id").Find(&tagGroups)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
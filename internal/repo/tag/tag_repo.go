@@ -138,3 +138,60 @@ func (tr *tagRepo) GetTagList(ctx context.Context, tag *entity.Tag) (tagList []*
 	}
 	return
 }
+
+// GetIDsByMainTagIDs get the synonym tag ids of the main tags
+func (tr *tagRepo) GetIDsByMainTagIDs(ctx context.Context, mainTagIDs []string) (tagIDs []string, err error) {
+	tagIDs = make([]string, 0)
+	if len(mainTagIDs) == 0 {
+		return tagIDs, nil
+	}
+	session := tr.data.DB.Context(ctx).Table(entity.Tag{}.TableName()).Cols("id")
+	session.Where(builder.Eq{"status": entity.TagStatusAvailable}).In("main_tag_id", mainTagIDs)
+	err = session.Find(&tagIDs)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetTagListByParentIDs get the child tags of the parent tags
+func (tr *tagRepo) GetTagListByParentIDs(ctx context.Context, parentTagIDs []string) (tagList []*entity.Tag, err error) {
+	tagList = make([]*entity.Tag, 0)
+	if len(parentTagIDs) == 0 {
+		return tagList, nil
+	}
+	session := tr.data.DB.Context(ctx).Where(builder.Eq{"status": entity.TagStatusAvailable}).In("parent_tag_id", parentTagIDs)
+	err = session.Asc("slug_name").Find(&tagList)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetHierarchyTagList get the tags which have parent, children or group
+func (tr *tagRepo) GetHierarchyTagList(ctx context.Context) (tagList []*entity.Tag, err error) {
+	tagList = make([]*entity.Tag, 0)
+	parentIDs := builder.Select("parent_tag_id").From(entity.Tag{}.TableName()).Where(builder.And(
+		builder.Neq{"parent_tag_id": 0}, builder.Eq{"status": entity.TagStatusAvailable}))
+	session := tr.data.DB.Context(ctx).Where(builder.Eq{"status": entity.TagStatusAvailable, "main_tag_id": 0})
+	session.And(builder.Or(
+		builder.Neq{"parent_tag_id": 0},
+		builder.Neq{"tag_group_id": 0},
+		builder.In("id", parentIDs),
+	))
+	err = session.Asc("slug_name").Find(&tagList)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// UpdateTagStructure update the parent and group of the tag
+func (tr *tagRepo) UpdateTagStructure(ctx context.Context, tagID string, parentTagID, tagGroupID int64) (err error) {
+	bean := &entity.Tag{ParentTagID: parentTagID, TagGroupID: tagGroupID}
+	_, err = tr.data.DB.Context(ctx).ID(tagID).MustCols("parent_tag_id", "tag_group_id").Update(bean)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
@@ -0,0 +1,134 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package uploader
+
+import (
+	"context"
+
+	"github.com/apache/incubator-answer/internal/base/data"
+	"github.com/apache/incubator-answer/internal/base/reason"
+	"github.com/apache/incubator-answer/internal/service/uploader"
+	"github.com/apache/incubator-answer/pkg/converter"
+	"github.com/segmentfault/pacman/errors"
+	"xorm.io/builder"
+)
+
+// uploadedURLColumns the columns that may contain the urls of uploaded files
+var uploadedURLColumns = []struct {
+	table   string
+	columns []string
+}{
+	{table: "question", columns: []string{"original_text", "parsed_text"}},
+	{table: "answer", columns: []string{"original_text", "parsed_text"}},
+	{table: "comment", columns: []string{"original_text", "parsed_text"}},
+	{table: "tag", columns: []string{"original_text", "parsed_text"}},
+	{table: "revision", columns: []string{"content"}},
+	{table: "user", columns: []string{"avatar", "bio", "bio_html"}},
+	{table: "site_info", columns: []string{"content"}},
+}
+
+// scanPageSize the rows read in every page when scanning the contents
+const scanPageSize = 500
+
+// uploadedURLRepo the urls of uploaded files in contents
+type uploadedURLRepo struct {
+	data *data.Data
+}
+
+// NewUploadedURLRepo new repository
+func NewUploadedURLRepo(data *data.Data) uploader.UploadedURLRepo {
+	return &uploadedURLRepo{
+		data: data,
+	}
+}
+
+// CountURLPrefix count the rows containing the url prefix
+func (ur *uploadedURLRepo) CountURLPrefix(ctx context.Context, urlPrefix string) (count int64, err error) {
+	for _, item := range uploadedURLColumns {
+		cond := builder.NewCond()
+		for _, column := range item.columns {
+			cond = cond.Or(builder.Like{column, urlPrefix})
+		}
+		c, err := ur.data.DB.Context(ctx).Table(item.table).Where(cond).Count()
+		if err != nil {
+			return 0, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+		}
+		count += c
+	}
+	return count, nil
+}
+
+// ReplaceURLPrefix replace the url prefix in the contents in one transaction
+func (ur *uploadedURLRepo) ReplaceURLPrefix(ctx context.Context, oldURLPrefix, newURLPrefix string) (
+	affected int64, err error) {
+	session := ur.data.DB.NewSession().Context(ctx)
+	defer session.Close()
+	if err = session.Begin(); err != nil {
+		return 0, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	for _, item := range uploadedURLColumns {
+		cond := builder.NewCond()
+		set := builder.Eq{}
+		for _, column := range item.columns {
+			cond = cond.Or(builder.Like{column, oldURLPrefix})
+			set[column] = builder.Expr("REPLACE("+column+", ?, ?)", oldURLPrefix, newURLPrefix)
+		}
+		sql, args, err := builder.Update(set).From(ur.data.DB.Quote(item.table)).Where(cond).ToSQL()
+		if err != nil {
+			_ = session.Rollback()
+			return 0, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+		}
+		result, err := session.Exec(append([]interface{}{sql}, args...)...)
+		if err != nil {
+			_ = session.Rollback()
+			return 0, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+		}
+		n, _ := result.RowsAffected()
+		affected += n
+	}
+	if err = session.Commit(); err != nil {
+		return 0, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return affected, nil
+}
+
+// ScanContents calls the fn with every content that may contain the urls of uploaded files,
+// the rows are paged by id so that the rows changed during the scan are neither skipped nor repeated
+func (ur *uploadedURLRepo) ScanContents(ctx context.Context, fn func(content string)) (err error) {
+	for _, item := range uploadedURLColumns {
+		for lastID := int64(0); ; {
+			rows, err := ur.data.DB.Context(ctx).Table(item.table).Cols(append([]string{"id"}, item.columns...)...).
+				Where(builder.Gt{"id": lastID}).OrderBy("id").Limit(scanPageSize).QueryString()
+			if err != nil {
+				return errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+			}
+			for _, row := range rows {
+				for _, column := range item.columns {
+					fn(row[column])
+				}
+				lastID = converter.StringToInt64(row["id"])
+			}
+			if len(rows) < scanPageSize {
+				break
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,162 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package user_data
+
+import (
+	"context"
+
+	"github.com/apache/incubator-answer/internal/base/data"
+	"github.com/apache/incubator-answer/internal/base/reason"
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/service/user_data"
+	"github.com/segmentfault/pacman/errors"
+	"xorm.io/xorm"
+)
+
+// userDataRepo user data repository
+type userDataRepo struct {
+	data *data.Data
+}
+
+// NewUserDataRepo new repository
+func NewUserDataRepo(data *data.Data) user_data.UserDataRepo {
+	return &userDataRepo{
+		data: data,
+	}
+}
+
+// GetUserQuestions get all questions of user, including the deleted ones
+func (ur *userDataRepo) GetUserQuestions(ctx context.Context, userID string) (questions []*entity.Question, err error) {
+	questions = make([]*entity.Question, 0)
+	err = ur.data.DB.Context(ctx).Where("user_id = ?", userID).OrderBy("created_at ASC").Find(&questions)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetUserAnswers get all answers of user, including the deleted ones
+func (ur *userDataRepo) GetUserAnswers(ctx context.Context, userID string) (answers []*entity.Answer, err error) {
+	answers = make([]*entity.Answer, 0)
+	err = ur.data.DB.Context(ctx).Where("user_id = ?", userID).OrderBy("created_at ASC").Find(&answers)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetUserComments get all comments of user, including the deleted ones
+func (ur *userDataRepo) GetUserComments(ctx context.Context, userID string) (comments []*entity.Comment, err error) {
+	comments = make([]*entity.Comment, 0)
+	err = ur.data.DB.Context(ctx).Where("user_id = ?", userID).OrderBy("created_at ASC").Find(&comments)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetUserActivities get the activities of user by activity types
+func (ur *userDataRepo) GetUserActivities(ctx context.Context, userID string, activityTypes []int) (
+	activities []*entity.Activity, err error) {
+	activities = make([]*entity.Activity, 0)
+	err = ur.data.DB.Context(ctx).Where("user_id = ?", userID).In("activity_type", activityTypes).
+		OrderBy("created_at ASC").Find(&activities)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetUserCollections get all collections of user
+func (ur *userDataRepo) GetUserCollections(ctx context.Context, userID string) (
+	collections []*entity.Collection, err error) {
+	collections = make([]*entity.Collection, 0)
+	err = ur.data.DB.Context(ctx).Where("user_id = ?", userID).OrderBy("created_at ASC").Find(&collections)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetUserNotifications get all notifications of user
+func (ur *userDataRepo) GetUserNotifications(ctx context.Context, userID string) (
+	notifications []*entity.Notification, err error) {
+	notifications = make([]*entity.Notification, 0)
+	err = ur.data.DB.Context(ctx).Where("user_id = ?", userID).OrderBy("created_at ASC").Find(&notifications)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetUserExternalLogins get all external logins of user
+func (ur *userDataRepo) GetUserExternalLogins(ctx context.Context, userID string) (
+	logins []*entity.UserExternalLogin, err error) {
+	logins = make([]*entity.UserExternalLogin, 0)
+	err = ur.data.DB.Context(ctx).Where("user_id = ?", userID).Find(&logins)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// EraseUser overwrite the personal columns of user, remove the external logins, notifications, api tokens
+// and the email delivery logs of user and record the audit log in one transaction
+func (ur *userDataRepo) EraseUser(ctx context.Context, user *entity.User, auditLog *entity.AuditLog) (err error) {
+	_, err = ur.data.DB.Transaction(func(session *xorm.Session) (result any, err error) {
+		session = session.Context(ctx)
+		origin := &entity.User{}
+		if _, err = session.ID(user.ID).Cols("e_mail").Get(origin); err != nil {
+			return nil, err
+		}
+		// the delivery logs have no user id, they are matched by the email address of user
+		if len(origin.EMail) > 0 {
+			if _, err = session.Where("to_email = ?", origin.EMail).Delete(&entity.EmailDelivery{}); err != nil {
+				return nil, err
+			}
+		}
+		_, err = session.ID(user.ID).Cols("username", "display_name", "e_mail", "mobile", "ip_info", "pass",
+			"avatar", "bio", "bio_html", "website", "location", "status", "deleted_at").Update(user)
+		if err != nil {
+			return nil, err
+		}
+		if _, err = session.Where("user_id = ?", user.ID).Delete(&entity.UserExternalLogin{}); err != nil {
+			return nil, err
+		}
+		if _, err = session.Where("user_id = ?", user.ID).Delete(&entity.Notification{}); err != nil {
+			return nil, err
+		}
+		if _, err = session.Where("user_id = ?", user.ID).Delete(&entity.NotificationDigest{}); err != nil {
+			return nil, err
+		}
+		if _, err = session.Where("user_id = ?", user.ID).Delete(&entity.NotificationMute{}); err != nil {
+			return nil, err
+		}
+		if _, err = session.Where("user_id = ?", user.ID).Delete(&entity.APIToken{}); err != nil {
+			return nil, err
+		}
+		_, err = session.Insert(auditLog)
+		return nil, err
+	})
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
@@ -0,0 +1,68 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package user_notification_config
+
+import (
+	"context"
+
+	"github.com/apache/incubator-answer/internal/base/data"
+	"github.com/apache/incubator-answer/internal/base/reason"
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/service/user_notification_config"
+	"github.com/segmentfault/pacman/errors"
+)
+
+// notificationMuteRepo notification mute repository
+type notificationMuteRepo struct {
+	data *data.Data
+}
+
+// NewNotificationMuteRepo new repository
+func NewNotificationMuteRepo(data *data.Data) user_notification_config.NotificationMuteRepo {
+	return &notificationMuteRepo{
+		data: data,
+	}
+}
+
+// AddMute mute the object for user, if already muted, do nothing
+func (nr *notificationMuteRepo) AddMute(ctx context.Context, userID, objectID string) (err error) {
+	mute := &entity.NotificationMute{UserID: userID, ObjectID: objectID}
+	exist, err := nr.data.DB.Context(ctx).Exist(mute)
+	if err != nil {
+		return errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	if exist {
+		return nil
+	}
+	_, err = nr.data.DB.Context(ctx).Insert(mute)
+	if err != nil {
+		return errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return nil
+}
+
+// IsMuted check whether the object is muted by user
+func (nr *notificationMuteRepo) IsMuted(ctx context.Context, userID, objectID string) (muted bool, err error) {
+	muted, err = nr.data.DB.Context(ctx).Exist(&entity.NotificationMute{UserID: userID, ObjectID: objectID})
+	if err != nil {
+		return false, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return muted, nil
+}
@@ -0,0 +1,148 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package webhook
+
+import (
+	"context"
+
+	"github.com/apache/incubator-answer/internal/base/data"
+	"github.com/apache/incubator-answer/internal/base/pager"
+	"github.com/apache/incubator-answer/internal/base/reason"
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/service/webhook"
+	"github.com/segmentfault/pacman/errors"
+)
+
+// webhookRepo webhook repository
+type webhookRepo struct {
+	data *data.Data
+}
+
+// NewWebhookRepo new repository
+func NewWebhookRepo(data *data.Data) webhook.WebhookRepo {
+	return &webhookRepo{
+		data: data,
+	}
+}
+
+// AddWebhook add webhook
+func (wr *webhookRepo) AddWebhook(ctx context.Context, webhook *entity.Webhook) (err error) {
+	_, err = wr.data.DB.Context(ctx).Insert(webhook)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// UpdateWebhook update webhook
+func (wr *webhookRepo) UpdateWebhook(ctx context.Context, webhook *entity.Webhook) (err error) {
+	_, err = wr.data.DB.Context(ctx).ID(webhook.ID).
+		Cols("name", "url", "secret", "events", "status").Update(webhook)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// RemoveWebhook remove webhook and its delivery logs
+func (wr *webhookRepo) RemoveWebhook(ctx context.Context, webhookID int64) (err error) {
+	_, err = wr.data.DB.Context(ctx).ID(webhookID).Delete(&entity.Webhook{})
+	if err != nil {
+		return errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	_, err = wr.data.DB.Context(ctx).Where("webhook_id = ?", webhookID).Delete(&entity.WebhookDelivery{})
+	if err != nil {
+		return errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return nil
+}
+
+// GetWebhook get webhook one
+func (wr *webhookRepo) GetWebhook(ctx context.Context, webhookID int64) (
+	webhook *entity.Webhook, exist bool, err error) {
+	webhook = &entity.Webhook{}
+	exist, err = wr.data.DB.Context(ctx).ID(webhookID).Get(webhook)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetWebhookList get all webhooks
+func (wr *webhookRepo) GetWebhookList(ctx context.Context) (webhooks []*entity.Webhook, err error) {
+	webhooks = make([]*entity.Webhook, 0)
+	err = wr.data.DB.Context(ctx).Asc("id").Find(&webhooks)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetEnabledWebhookList get all enabled webhooks
+func (wr *webhookRepo) GetEnabledWebhookList(ctx context.Context) (webhooks []*entity.Webhook, err error) {
+	webhooks = make([]*entity.Webhook, 0)
+	err = wr.data.DB.Context(ctx).Asc("id").Find(&webhooks, &entity.Webhook{Status: entity.WebhookStatusEnabled})
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// AddDelivery add webhook delivery
+func (wr *webhookRepo) AddDelivery(ctx context.Context, delivery *entity.WebhookDelivery) (err error) {
+	_, err = wr.data.DB.Context(ctx).Insert(delivery)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// UpdateDelivery update webhook delivery result
+func (wr *webhookRepo) UpdateDelivery(ctx context.Context, delivery *entity.WebhookDelivery) (err error) {
+	_, err = wr.data.DB.Context(ctx).ID(delivery.ID).
+		Cols("status", "attempts", "response_code", "response_body", "error", "duration").Update(delivery)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetDelivery get webhook delivery one
+func (wr *webhookRepo) GetDelivery(ctx context.Context, deliveryID int64) (
+	delivery *entity.WebhookDelivery, exist bool, err error) {
+	delivery = &entity.WebhookDelivery{}
+	exist, err = wr.data.DB.Context(ctx).ID(deliveryID).Get(delivery)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetDeliveryPage get webhook delivery page, the latest first
+func (wr *webhookRepo) GetDeliveryPage(ctx context.Context, page, pageSize int, webhookID int64) (
+	deliveries []*entity.WebhookDelivery, total int64, err error) {
+	session := wr.data.DB.Context(ctx).Desc("id")
+	deliveries = make([]*entity.WebhookDelivery, 0)
+	total, err = pager.Help(page, pageSize, &deliveries, &entity.WebhookDelivery{WebhookID: webhookID}, session)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
@@ -27,34 +27,47 @@ import (
 )
 
 type AnswerAPIRouter struct {
-	langController          *controller.LangController
-	userController          *controller.UserController
-	commentController       *controller.CommentController
-	reportController        *controller.ReportController
-	voteController          *controller.VoteController
-	tagController           *controller.TagController
-	followController        *controller.FollowController
-	collectionController    *controller.CollectionController
-	questionController      *controller.QuestionController
-	answerController        *controller.AnswerController
-	searchController        *controller.SearchController
-	revisionController      *controller.RevisionController
-	rankController          *controller.RankController
-	adminUserController     *controller_admin.UserAdminController
-	reasonController        *controller.ReasonController
-	themeController         *controller_admin.ThemeController
-	adminSiteInfoController *controller_admin.SiteInfoController
-	siteInfoController      *controller.SiteInfoController
-	notificationController  *controller.NotificationController
-	dashboardController     *controller.DashboardController
-	uploadController        *controller.UploadController
-	activityController      *controller.ActivityController
-	roleController          *controller_admin.RoleController
-	pluginController        *controller_admin.PluginController
-	permissionController    *controller.PermissionController
-	userPluginController    *controller.UserPluginController
-	reviewController        *controller.ReviewController
-	metaController          *controller.MetaController
+	langController            *controller.LangController
+	userController            *controller.UserController
+	commentController         *controller.CommentController
+	reportController          *controller.ReportController
+	voteController            *controller.VoteController
+	tagController             *controller.TagController
+	followController          *controller.FollowController
+	collectionController      *controller.CollectionController
+	questionController        *controller.QuestionController
+	answerController          *controller.AnswerController
+	searchController          *controller.SearchController
+	revisionController        *controller.RevisionController
+	rankController            *controller.RankController
+	adminUserController       *controller_admin.UserAdminController
+	reasonController          *controller.ReasonController
+	themeController           *controller_admin.ThemeController
+	adminSiteInfoController   *controller_admin.SiteInfoController
+	siteInfoController        *controller.SiteInfoController
+	notificationController    *controller.NotificationController
+	dashboardController       *controller.DashboardController
+	uploadController          *controller.UploadController
+	activityController        *controller.ActivityController
+	roleController            *controller_admin.RoleController
+	pluginController          *controller_admin.PluginController
+	permissionController      *controller.PermissionController
+	userPluginController      *controller.UserPluginController
+	reviewController          *controller.ReviewController
+	metaController            *controller.MetaController
+	jobQueueController        *controller_admin.JobQueueController
+	webhookController         *controller_admin.WebhookController
+	apiTokenController        *controller.APITokenController
+	searchSyncController      *controller_admin.SearchSyncController
+	importController          *controller_admin.ImportController
+	contentExportController   *controller_admin.ContentExportController
+	userDataController        *controller.UserDataController
+	emailDeliveryController   *controller_admin.EmailDeliveryController
+	inboundMailController     *controller.InboundMailController
+	bountyController          *controller.BountyController
+	badgeController           *controller.BadgeController
+	adminBadgeController      *controller_admin.BadgeController
+	rankRecalculateController *controller_admin.RankRecalculateController
 }
 
 func NewAnswerAPIRouter(
@@ -86,36 +99,62 @@ func NewAnswerAPIRouter(
 	userPluginController *controller.UserPluginController,
 	reviewController *controller.ReviewController,
 	metaController *controller.MetaController,
+	jobQueueController *controller_admin.JobQueueController,
+	webhookController *controller_admin.WebhookController,
+	apiTokenController *controller.APITokenController,
+	searchSyncController *controller_admin.SearchSyncController,
+	importController *controller_admin.ImportController,
+	contentExportController *controller_admin.ContentExportController,
+	userDataController *controller.UserDataController,
+	emailDeliveryController *controller_admin.EmailDeliveryController,
+	inboundMailController *controller.InboundMailController,
+	bountyController *controller.BountyController,
+	badgeController *controller.BadgeController,
+	adminBadgeController *controller_admin.BadgeController,
+	rankRecalculateController *controller_admin.RankRecalculateController,
 ) *AnswerAPIRouter {
 	return &AnswerAPIRouter{
-		langController:          langController,
-		userController:          userController,
-		commentController:       commentController,
-		reportController:        reportController,
-		voteController:          voteController,
-		tagController:           tagController,
-		followController:        followController,
-		collectionController:    collectionController,
-		questionController:      questionController,
-		answerController:        answerController,
-		searchController:        searchController,
-		revisionController:      revisionController,
-		rankController:          rankController,
-		adminUserController:     adminUserController,
-		reasonController:        reasonController,
-		themeController:         themeController,
-		adminSiteInfoController: adminSiteInfoController,
-		notificationController:  notificationController,
-		siteInfoController:      siteInfoController,
-		dashboardController:     dashboardController,
-		uploadController:        uploadController,
-		activityController:      activityController,
-		roleController:          roleController,
-		pluginController:        pluginController,
-		permissionController:    permissionController,
-		userPluginController:    userPluginController,
-		reviewController:        reviewController,
-		metaController:          metaController,
+		langController:            langController,
+		userController:            userController,
+		commentController:         commentController,
+		reportController:          reportController,
+		voteController:            voteController,
+		tagController:             tagController,
+		followController:          followController,
+		collectionController:      collectionController,
+		questionController:        questionController,
+		answerController:          answerController,
+		searchController:          searchController,
+		revisionController:        revisionController,
+		rankController:            rankController,
+		adminUserController:       adminUserController,
+		reasonController:          reasonController,
+		themeController:           themeController,
+		adminSiteInfoController:   adminSiteInfoController,
+		notificationController:    notificationController,
+		siteInfoController:        siteInfoController,
+		dashboardController:       dashboardController,
+		uploadController:          uploadController,
+		activityController:        activityController,
+		roleController:            roleController,
+		pluginController:          pluginController,
+		permissionController:      permissionController,
+		userPluginController:      userPluginController,
+		reviewController:          reviewController,
+		metaController:            metaController,
+		jobQueueController:        jobQueueController,
+		webhookController:         webhookController,
+		apiTokenController:        apiTokenController,
+		searchSyncController:      searchSyncController,
+		importController:          importController,
+		contentExportController:   contentExportController,
+		userDataController:        userDataController,
+		emailDeliveryController:   emailDeliveryController,
+		inboundMailController:     inboundMailController,
+		bountyController:          bountyController,
+		badgeController:           badgeController,
+		adminBadgeController:      adminBadgeController,
+		rankRecalculateController: rankRecalculateController,
 	}
 }
 
@@ -131,6 +170,9 @@ func (a *AnswerAPIRouter) RegisterMustUnAuthAnswerAPIRouter(authUserMiddleware *
 	// user
 	r.GET("/user/info", a.userController.GetUserInfoByUserID)
 	r.GET("/user/action/record", authUserMiddleware.Auth(), a.userController.ActionRecord)
+
+	// reply-by-email, the MTA pipe is authorized by the inbound mail secret
+	r.POST("/inbound/mail", a.inboundMailController.ReceiveMail)
 	routerGroup := r.Group("", middleware.BanAPIForUserCenter)
 	routerGroup.POST("/user/login/email", a.userController.UserEmailLogin)
 	routerGroup.POST("/user/register/email", a.userController.UserRegisterByEmail)
@@ -139,6 +181,7 @@ func (a *AnswerAPIRouter) RegisterMustUnAuthAnswerAPIRouter(authUserMiddleware *
 	routerGroup.POST("/user/password/reset", a.userController.RetrievePassWord)
 	routerGroup.POST("/user/password/replacement", a.userController.UseRePassWord)
 	routerGroup.PUT("/user/notification/unsubscribe", a.userController.UserUnsubscribeNotification)
+	routerGroup.POST("/user/notification/unsubscribe/one-click", a.userController.UserUnsubscribeNotificationOneClick)
 
 	// plugins
 	r.GET("/plugin/status", a.pluginController.GetAllPluginStatus)
@@ -162,6 +205,10 @@ func (a *AnswerAPIRouter) RegisterUnAuthAnswerAPIRouter(r *gin.RouterGroup) {
 	r.GET("/question/similar/tag", a.questionController.SimilarQuestion)
 	r.GET("/personal/qa/top", a.questionController.UserTop)
 	r.GET("/personal/question/page", a.questionController.PersonalQuestionPage)
+	r.GET("/question/bounty", a.bountyController.GetQuestionBounty)
+
+	// badge
+	r.GET("/badges", a.badgeController.GetBadgeList)
 
 	// comment
 	r.GET("/comment/page", a.commentController.GetCommentWithPage)
@@ -170,9 +217,11 @@ func (a *AnswerAPIRouter) RegisterUnAuthAnswerAPIRouter(r *gin.RouterGroup) {
 
 	// revision
 	r.GET("/revisions", a.revisionController.GetRevisionList)
+	r.GET("/revisions/diff", a.revisionController.GetRevisionDiff)
 
 	// tag
 	r.GET("/tags/page", a.tagController.GetTagWithPage)
+	r.GET("/tags/tree", a.tagController.GetTagTree)
 	r.GET("/tags/following", a.tagController.GetFollowingTags)
 	r.GET("/tag", a.tagController.GetTagInfo)
 	r.GET("/tags", a.tagController.GetTagsBySlugName)
@@ -199,6 +248,7 @@ func (a *AnswerAPIRouter) RegisterAnswerAPIRouter(r *gin.RouterGroup) {
 	// revisions
 	r.GET("/revisions/unreviewed", a.revisionController.GetUnreviewedRevisionList)
 	r.PUT("/revisions/audit", a.revisionController.RevisionAudit)
+	r.PUT("/revisions/rollback", a.revisionController.RollbackRevision)
 	r.GET("/revisions/edit/check", a.revisionController.CheckCanUpdateRevision)
 	r.GET("/reviewing/type", a.revisionController.GetReviewingType)
 
@@ -211,6 +261,9 @@ func (a *AnswerAPIRouter) RegisterAnswerAPIRouter(r *gin.RouterGroup) {
 	r.POST("/report", a.reportController.AddReport)
 	r.GET("/report/unreviewed/post", a.reportController.GetUnreviewedReportPostPage)
 	r.PUT("/report/review", a.reportController.ReviewReport)
+	r.GET("/report/case/page", a.reportController.GetReportCasePage)
+	r.PUT("/report/case/assign", a.reportController.AssignReportCase)
+	r.PUT("/report/case/review/bulk", a.reportController.BulkReviewReportCase)
 
 	// review
 	r.GET("/review/pending/post/page", a.reviewController.GetUnreviewedPostPage)
@@ -247,6 +300,8 @@ func (a *AnswerAPIRouter) RegisterAnswerAPIRouter(r *gin.RouterGroup) {
 	r.PUT("/question/reopen", a.questionController.ReopenQuestion)
 	r.GET("/question/similar", a.questionController.GetSimilarQuestions)
 	r.POST("/question/recover", a.questionController.QuestionRecover)
+	r.POST("/question/bounty", a.bountyController.OfferBounty)
+	r.PUT("/question/bounty/award", a.bountyController.AwardBounty)
 
 	// answer
 	r.POST("/answer", a.answerController.Add)
@@ -261,6 +316,12 @@ func (a *AnswerAPIRouter) RegisterAnswerAPIRouter(r *gin.RouterGroup) {
 	r.PUT("/user/interface", a.userController.UserUpdateInterface)
 	r.GET("/user/notification/config", a.userController.GetUserNotificationConfig)
 	r.PUT("/user/notification/config", a.userController.UpdateUserNotificationConfig)
+	r.GET("/user/data/export", a.userDataController.ExportUserData)
+
+	// api token
+	r.GET("/user/api-tokens", a.apiTokenController.GetAPITokenList)
+	r.POST("/user/api-token", a.apiTokenController.CreateAPIToken)
+	r.DELETE("/user/api-token", a.apiTokenController.RevokeAPIToken)
 	r.GET("/user/info/search", a.userController.SearchUserListByName)
 
 	// vote
@@ -305,6 +366,7 @@ func (a *AnswerAPIRouter) RegisterAnswerAdminAPIRouter(r *gin.RouterGroup) {
 	// user
 	r.GET("/users/page", a.adminUserController.GetUserPage)
 	r.PUT("/user/status", a.adminUserController.UpdateUserStatus)
+	r.POST("/user/erase", a.adminUserController.EraseUser)
 	r.PUT("/user/role", a.adminUserController.UpdateUserRole)
 	r.GET("/user/activation", a.adminUserController.GetUserActivation)
 	r.POST("/user/activation", a.adminUserController.SendUserActivation)
@@ -313,6 +375,13 @@ func (a *AnswerAPIRouter) RegisterAnswerAdminAPIRouter(r *gin.RouterGroup) {
 	r.PUT("/user/password", a.adminUserController.UpdateUserPassword)
 	r.PUT("/user/profile", a.adminUserController.EditUserProfile)
 
+	// tag
+	r.GET("/tag/groups", a.tagController.AdminGetTagGroupList)
+	r.POST("/tag/group", a.tagController.AdminAddTagGroup)
+	r.PUT("/tag/group", a.tagController.AdminUpdateTagGroup)
+	r.DELETE("/tag/group", a.tagController.AdminRemoveTagGroup)
+	r.PUT("/tag/structure", a.tagController.AdminUpdateTagStructure)
+
 	// reason
 	r.GET("/reasons", a.reasonController.Reasons)
 
@@ -359,4 +428,41 @@ func (a *AnswerAPIRouter) RegisterAnswerAdminAPIRouter(r *gin.RouterGroup) {
 	r.PUT("/plugin/status", a.pluginController.UpdatePluginStatus)
 	r.GET("/plugin/config", a.pluginController.GetPluginConfig)
 	r.PUT("/plugin/config", a.pluginController.UpdatePluginConfig)
+
+	// queue
+	r.GET("/queue/jobs/page", a.jobQueueController.GetJobPage)
+	r.PUT("/queue/job/retry", a.jobQueueController.RetryJob)
+	r.DELETE("/queue/job", a.jobQueueController.RemoveJob)
+
+	// webhook
+	r.GET("/webhooks", a.webhookController.GetWebhookList)
+	r.POST("/webhook", a.webhookController.AddWebhook)
+	r.PUT("/webhook", a.webhookController.UpdateWebhook)
+	r.DELETE("/webhook", a.webhookController.RemoveWebhook)
+	r.POST("/webhook/test", a.webhookController.TestWebhook)
+	r.GET("/webhook/deliveries/page", a.webhookController.GetDeliveryPage)
+	r.GET("/webhook/events", a.webhookController.GetEvents)
+
+	// email
+	r.GET("/email/deliveries/page", a.emailDeliveryController.GetDeliveryPage)
+
+	// search
+	r.GET("/search/reindex", a.searchSyncController.GetReindexStatus)
+	r.POST("/search/reindex", a.searchSyncController.StartReindex)
+
+	// badge
+	r.GET("/badges/backfill", a.adminBadgeController.GetBackfillStatus)
+	r.POST("/badges/backfill", a.adminBadgeController.StartBackfill)
+
+	// rank
+	r.GET("/rank/recalculate", a.rankRecalculateController.GetRecalculateStatus)
+	r.POST("/rank/recalculate", a.rankRecalculateController.StartRecalculate)
+
+	// import
+	r.POST("/import/stackexchange", a.importController.StartStackExchangeImport)
+
+	// export
+	r.POST("/export/content", a.contentExportController.StartContentExport)
+	r.GET("/export/content/files", a.contentExportController.GetContentExportFiles)
+	r.GET("/export/content/download", a.contentExportController.DownloadContentExportFile)
 }
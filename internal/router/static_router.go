@@ -20,23 +20,37 @@
 package router
 
 import (
+	"github.com/apache/incubator-answer/internal/controller"
 	"github.com/apache/incubator-answer/internal/service/service_config"
+	"github.com/apache/incubator-answer/pkg/storage"
 	"github.com/gin-gonic/gin"
 )
 
 // StaticRouter static api router
 type StaticRouter struct {
-	serviceConfig *service_config.ServiceConfig
+	serviceConfig    *service_config.ServiceConfig
+	uploadController *controller.UploadController
 }
 
 // NewStaticRouter new static api router
-func NewStaticRouter(serviceConfig *service_config.ServiceConfig) *StaticRouter {
+func NewStaticRouter(
+	serviceConfig *service_config.ServiceConfig,
+	uploadController *controller.UploadController,
+) *StaticRouter {
 	return &StaticRouter{
-		serviceConfig: serviceConfig,
+		serviceConfig:    serviceConfig,
+		uploadController: uploadController,
 	}
 }
 
 // RegisterStaticRouter register static api router
 func (a *StaticRouter) RegisterStaticRouter(r *gin.RouterGroup) {
-	r.Static("/uploads", a.serviceConfig.UploadPath)
+	r.GET("/attachments/:id", a.uploadController.DownloadAttachment)
+	if a.serviceConfig.Storage.GetType() == storage.TypeLocal {
+		r.Static("/uploads", a.serviceConfig.UploadPath)
+		return
+	}
+	// the files are in the storage, except the ones kept on local disk when the storage was not available
+	r.GET("/uploads/*filepath", a.uploadController.GetUploadedFile)
+	r.HEAD("/uploads/*filepath", a.uploadController.GetUploadedFile)
 }
@@ -55,6 +55,12 @@ func (a *TemplateRouter) RegisterTemplateRouter(r *gin.RouterGroup, baseURLPath
 	seoNoAuth.GET("/sitemap.xml", a.templateController.Sitemap)
 	seoNoAuth.GET("/sitemap/:page", a.templateController.SitemapPage)
 
+	seoNoAuth.GET("/questions/feed", a.templateController.QuestionFeed)
+	// not under /questions/:id, the feed would be taken for the question titled "feed"
+	seoNoAuth.GET("/feeds/questions/:id", a.templateController.QuestionInfoFeed)
+	seoNoAuth.GET("/tags/:tag/feed", a.templateController.TagFeed)
+	seoNoAuth.GET("/users/:username/feed", a.templateController.UserFeed)
+
 	seoNoAuth.GET("/robots.txt", a.siteInfoController.GetRobots)
 	seoNoAuth.GET("/custom.css", a.siteInfoController.GetCss)
 
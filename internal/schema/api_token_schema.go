@@ -0,0 +1,53 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+package schema
+
+// CreateAPITokenReq create api token request
+type CreateAPITokenReq struct {
+	Name string `validate:"required,notblank,gt=0,lte=100" json:"name"`
+	// read: only GET requests, write: all requests except admin api, admin: all requests
+	Scope string `validate:"required,oneof=read write admin" json:"scope"`
+	// the token will never expire if it is 0
+	ExpiresInDays int    `validate:"omitempty,gte=0,lte=3650" json:"expires_in_days"`
+	UserID        string `json:"-"`
+	IsAdmin       bool   `json:"-"`
+}
+
+// CreateAPITokenResp create api token response, the token is only returned once
+type CreateAPITokenResp struct {
+	*GetAPITokenResp
+	Token string `json:"token"`
+}
+
+// GetAPITokenResp api token info
+type GetAPITokenResp struct {
+	ID          int64  `json:"id"`
+	Name        string `json:"name"`
+	TokenPrefix string `json:"token_prefix"`
+	Scope       string `json:"scope"`
+	ExpiredAt   int64  `json:"expired_at"`
+	LastUsedAt  int64  `json:"last_used_at"`
+	CreatedAt   int64  `json:"created_at"`
+}
+
+// RevokeAPITokenReq revoke api token request
+type RevokeAPITokenReq struct {
+	ID     int64  `validate:"required" json:"id"`
+	UserID string `json:"-"`
+}
@@ -0,0 +1,79 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package schema
+
+import (
+	"github.com/apache/incubator-answer/internal/base/constant"
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/plugin"
+)
+
+// BadgeBackfillBatchSize the number of users evaluated in one batch of the back-fill job
+const BadgeBackfillBatchSize = 100
+
+// BadgeLevelMapping badge level mapping
+var BadgeLevelMapping = map[int]plugin.BadgeLevel{
+	entity.BadgeLevelBronze: plugin.BadgeLevelBronze,
+	entity.BadgeLevelSilver: plugin.BadgeLevelSilver,
+	entity.BadgeLevelGold:   plugin.BadgeLevelGold,
+}
+
+// BadgeEventMsg the activity that makes the badges of the user evaluated
+type BadgeEventMsg struct {
+	UserID          string
+	ActivityTypeKey constant.ActivityTypeKey
+	ObjectID        string
+}
+
+// BadgeInfo badge info
+type BadgeInfo struct {
+	Name        string            `json:"name"`
+	Level       plugin.BadgeLevel `json:"level"`
+	Title       string            `json:"title"`
+	Description string            `json:"description"`
+}
+
+// UserBadgeInfo the badge awarded to the user
+type UserBadgeInfo struct {
+	BadgeInfo
+	// the tag id of the tag badges
+	ObjectID string `json:"object_id,omitempty"`
+	// the slug name of the tag of the tag badges
+	ObjectName string `json:"object_name,omitempty"`
+	AwardedAt  int64  `json:"awarded_at"`
+}
+
+// UserBadgeCount the amount of the badges of the user in every level
+type UserBadgeCount struct {
+	Gold   int `json:"gold"`
+	Silver int `json:"silver"`
+	Bronze int `json:"bronze"`
+}
+
+// BadgeBackfillProgress the progress of the badge back-fill job
+type BadgeBackfillProgress struct {
+	Running bool `json:"running"`
+	// the number of users evaluated
+	Users int `json:"users"`
+	// the number of badges awarded
+	Awarded    int   `json:"awarded"`
+	StartedAt  int64 `json:"started_at"`
+	FinishedAt int64 `json:"finished_at"`
+}
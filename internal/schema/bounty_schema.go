@@ -0,0 +1,82 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package schema
+
+import "time"
+
+const (
+	// BountyMinAmount the minimum reputation of a bounty
+	BountyMinAmount = 50
+	// BountyMaxAmount the maximum reputation of a bounty
+	BountyMaxAmount = 500
+	// BountyDuration how long a bounty is open before it expires
+	BountyDuration = 7 * 24 * time.Hour
+	// BountyExpireBatchSize the number of bounties expired in one query of the cron job
+	BountyExpireBatchSize = 100
+
+	BountyStatusActive  = "active"
+	BountyStatusAwarded = "awarded"
+	BountyStatusExpired = "expired"
+)
+
+// OfferBountyReq offer bounty request
+type OfferBountyReq struct {
+	// question id
+	QuestionID string `validate:"required" json:"question_id"`
+	// reputation offered, between BountyMinAmount and BountyMaxAmount
+	Amount int    `validate:"required" json:"amount"`
+	UserID string `json:"-"`
+}
+
+// AwardBountyReq award bounty request
+type AwardBountyReq struct {
+	// question id
+	QuestionID string `validate:"required" json:"question_id"`
+	// answer id
+	AnswerID string `validate:"required" json:"answer_id"`
+	UserID   string `json:"-"`
+}
+
+// GetQuestionBountyReq get question bounty request
+type GetQuestionBountyReq struct {
+	// question id
+	QuestionID string `validate:"required" form:"question_id"`
+}
+
+// GetQuestionBountyResp get question bounty response
+type GetQuestionBountyResp struct {
+	// the active bounty, nil if the question has no active bounty
+	Active *QuestionBountyInfo `json:"active"`
+	// all bounties of the question, the newest first
+	History []*QuestionBountyInfo `json:"history"`
+}
+
+// QuestionBountyInfo question bounty info
+type QuestionBountyInfo struct {
+	ID              string         `json:"id"`
+	Amount          int            `json:"amount"`
+	Status          string         `json:"status"`
+	CreatedAt       int64          `json:"created_at"`
+	ExpiresAt       int64          `json:"expires_at"`
+	UserInfo        *UserBasicInfo `json:"user_info"`
+	AwardedAnswerID string         `json:"awarded_answer_id"`
+	AwardedUserInfo *UserBasicInfo `json:"awarded_user_info,omitempty"`
+	AwardedAt       int64          `json:"awarded_at"`
+}
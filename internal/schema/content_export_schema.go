@@ -0,0 +1,123 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package schema
+
+import "time"
+
+// ContentExportReq export all questions with their answers, comments, tags and revisions
+type ContentExportReq struct {
+	// include the deleted questions and answers
+	IncludeDeleted bool `json:"include_deleted"`
+	// include the questions and answers that are waiting for review
+	IncludePending bool `json:"include_pending"`
+	// include the questions hidden by admin
+	IncludeHidden bool `json:"include_hidden"`
+}
+
+// ContentExportFileResp the exported archive
+type ContentExportFileResp struct {
+	FileName  string `json:"file_name"`
+	Size      int64  `json:"size"`
+	CreatedAt int64  `json:"created_at"`
+}
+
+// ContentExportDownloadReq download the exported archive
+type ContentExportDownloadReq struct {
+	FileName string `validate:"required,gt=0,lte=100" form:"file_name"`
+}
+
+// ContentExportManifest describe the exported archive
+type ContentExportManifest struct {
+	FormatVersion  int       `json:"format_version"`
+	CreatedAt      time.Time `json:"created_at"`
+	IncludeDeleted bool      `json:"include_deleted"`
+	IncludePending bool      `json:"include_pending"`
+	IncludeHidden  bool      `json:"include_hidden"`
+	Questions      int       `json:"questions"`
+	Answers        int       `json:"answers"`
+	Comments       int       `json:"comments"`
+}
+
+// ContentExportUser the author of exported content, only the public fields are exported
+type ContentExportUser struct {
+	ID          string `json:"id"`
+	Username    string `json:"username"`
+	DisplayName string `json:"display_name"`
+}
+
+// ContentExportTag the tag of exported question
+type ContentExportTag struct {
+	SlugName    string `json:"slug_name"`
+	DisplayName string `json:"display_name"`
+}
+
+// ContentExportComment the exported comment
+type ContentExportComment struct {
+	ID             string             `json:"id"`
+	Author         *ContentExportUser `json:"author"`
+	ReplyCommentID string             `json:"reply_comment_id,omitempty"`
+	Content        string             `json:"content"`
+	VoteCount      int                `json:"vote_count"`
+	CreatedAt      time.Time          `json:"created_at"`
+}
+
+// ContentExportRevision the exported revision, content is the snapshot of question or answer
+type ContentExportRevision struct {
+	ID        string             `json:"id"`
+	Author    *ContentExportUser `json:"author"`
+	Title     string             `json:"title"`
+	Log       string             `json:"log"`
+	Status    int                `json:"status"`
+	Content   string             `json:"content"`
+	CreatedAt time.Time          `json:"created_at"`
+}
+
+// ContentExportAnswer the exported answer
+type ContentExportAnswer struct {
+	ID        string                   `json:"id"`
+	Author    *ContentExportUser       `json:"author"`
+	Content   string                   `json:"content"`
+	Status    string                   `json:"status"`
+	Accepted  bool                     `json:"accepted"`
+	VoteCount int                      `json:"vote_count"`
+	CreatedAt time.Time                `json:"created_at"`
+	UpdatedAt time.Time                `json:"updated_at"`
+	Comments  []*ContentExportComment  `json:"comments"`
+	Revisions []*ContentExportRevision `json:"revisions"`
+}
+
+// ContentExportQuestion the exported question, one document per question
+type ContentExportQuestion struct {
+	ID               string                   `json:"id"`
+	Title            string                   `json:"title"`
+	Author           *ContentExportUser       `json:"author"`
+	Content          string                   `json:"content"`
+	Status           string                   `json:"status"`
+	Hidden           bool                     `json:"hidden"`
+	Tags             []*ContentExportTag      `json:"tags"`
+	VoteCount        int                      `json:"vote_count"`
+	ViewCount        int                      `json:"view_count"`
+	AcceptedAnswerID string                   `json:"accepted_answer_id,omitempty"`
+	CreatedAt        time.Time                `json:"created_at"`
+	UpdatedAt        time.Time                `json:"updated_at"`
+	Comments         []*ContentExportComment  `json:"comments"`
+	Revisions        []*ContentExportRevision `json:"revisions"`
+	Answers          []*ContentExportAnswer   `json:"answers"`
+}
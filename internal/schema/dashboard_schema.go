@@ -26,26 +26,39 @@ var AppStartTime time.Time
 const (
 	DashboardCacheKey  = "answer:dashboard"
 	DashboardCacheTime = 60 * time.Minute
+	// ReportModeratorStatDays the flags handled in these days are counted in the moderator stats
+	ReportModeratorStatDays = 30
 )
 
 type DashboardInfo struct {
-	QuestionCount         int64                `json:"question_count"`
-	AnswerCount           int64                `json:"answer_count"`
-	CommentCount          int64                `json:"comment_count"`
-	VoteCount             int64                `json:"vote_count"`
-	UserCount             int64                `json:"user_count"`
-	ReportCount           int64                `json:"report_count"`
-	UploadingFiles        bool                 `json:"uploading_files"`
-	SMTP                  string               `json:"smtp"`
-	HTTPS                 bool                 `json:"https"`
-	TimeZone              string               `json:"time_zone"`
-	OccupyingStorageSpace string               `json:"occupying_storage_space"`
-	AppStartTime          string               `json:"app_start_time"`
-	VersionInfo           DashboardInfoVersion `json:"version_info"`
-	LoginRequired         bool                 `json:"login_required"`
-	GoVersion             string               `json:"go_version"`
-	DatabaseVersion       string               `json:"database_version"`
-	DatabaseSize          string               `json:"database_size"`
+	QuestionCount         int64                  `json:"question_count"`
+	AnswerCount           int64                  `json:"answer_count"`
+	CommentCount          int64                  `json:"comment_count"`
+	VoteCount             int64                  `json:"vote_count"`
+	UserCount             int64                  `json:"user_count"`
+	ReportCount           int64                  `json:"report_count"`
+	UploadingFiles        bool                   `json:"uploading_files"`
+	SMTP                  string                 `json:"smtp"`
+	HTTPS                 bool                   `json:"https"`
+	TimeZone              string                 `json:"time_zone"`
+	OccupyingStorageSpace string                 `json:"occupying_storage_space"`
+	AppStartTime          string                 `json:"app_start_time"`
+	VersionInfo           DashboardInfoVersion   `json:"version_info"`
+	LoginRequired         bool                   `json:"login_required"`
+	GoVersion             string                 `json:"go_version"`
+	DatabaseVersion       string                 `json:"database_version"`
+	DatabaseSize          string                 `json:"database_size"`
+	ReportModeratorStats  []*ReportModeratorStat `json:"report_moderator_stats"`
+}
+
+// ReportModeratorStat the flags resolved by the moderator in the recent days
+type ReportModeratorStat struct {
+	UserID               string `json:"user_id"`
+	Username             string `json:"username"`
+	DisplayName          string `json:"display_name"`
+	HandledCount         int    `json:"handled_count"`
+	IgnoredCount         int    `json:"ignored_count"`
+	AvgResolutionSeconds int64  `json:"avg_resolution_seconds"`
 }
 
 type DashboardInfoVersion struct {
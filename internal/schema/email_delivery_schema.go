@@ -0,0 +1,46 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package schema
+
+// EmailDeliveryJob email delivery job in queue
+type EmailDeliveryJob struct {
+	DeliveryID int64 `json:"delivery_id"`
+}
+
+// GetEmailDeliveryPageReq get email delivery page request
+type GetEmailDeliveryPageReq struct {
+	Status   string `validate:"omitempty,oneof=queued sent failed bounced" form:"status"`
+	Page     int    `validate:"omitempty,min=1" form:"page"`
+	PageSize int    `validate:"omitempty,min=1" form:"page_size"`
+}
+
+// GetEmailDeliveryResp get email delivery response, the body is not returned because it may contain
+// the password reset or email verification link
+type GetEmailDeliveryResp struct {
+	DeliveryID int64  `json:"delivery_id"`
+	ToEmail    string `json:"to_email"`
+	Subject    string `json:"subject"`
+	Status     string `json:"status" enums:"queued,sent,failed,bounced"`
+	Attempts   int    `json:"attempts"`
+	Error      string `json:"error"`
+	CreatedAt  int64  `json:"created_at"`
+	UpdatedAt  int64  `json:"updated_at"`
+	SentAt     int64  `json:"sent_at"`
+}
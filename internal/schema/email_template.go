@@ -30,6 +30,7 @@ const (
 	ConfirmNewEmailSourceType   EmailSourceType = "password-reset"
 	UnsubscribeSourceType       EmailSourceType = "unsubscribe"
 	BindingSourceType           EmailSourceType = "binding"
+	MuteQuestionSourceType      EmailSourceType = "mute-question"
 )
 
 type EmailSourceType string
@@ -40,6 +41,8 @@ type EmailCodeContent struct {
 	UserID     string          `json:"user_id"`
 	// Used for unsubscribe notification
 	NotificationSources []constant.NotificationSource `json:"notification_source,omitempty"`
+	// Used for mute the question notification
+	ObjectID string `json:"object_id,omitempty"`
 	// Used for third-party login account binding
 	BindingKey string `json:"binding_key,omitempty"`
 	// Skip the validation of the latest code
@@ -81,6 +84,7 @@ type NewAnswerTemplateRawData struct {
 	AnswerID              string
 	AnswerSummary         string
 	UnsubscribeCode       string
+	MuteCode              string
 }
 
 type NewAnswerTemplateData struct {
@@ -90,6 +94,7 @@ type NewAnswerTemplateData struct {
 	AnswerUrl      string
 	AnswerSummary  string
 	UnsubscribeUrl string
+	MuteUrl        string
 }
 
 type NewInviteAnswerTemplateRawData struct {
@@ -115,6 +120,7 @@ type NewCommentTemplateRawData struct {
 	CommentID              string
 	CommentSummary         string
 	UnsubscribeCode        string
+	MuteCode               string
 }
 
 type NewCommentTemplateData struct {
@@ -124,6 +130,7 @@ type NewCommentTemplateData struct {
 	CommentUrl     string
 	CommentSummary string
 	UnsubscribeUrl string
+	MuteUrl        string
 }
 
 type NewQuestionTemplateRawData struct {
@@ -142,3 +149,17 @@ type NewQuestionTemplateData struct {
 	Tags           string
 	UnsubscribeUrl string
 }
+
+// DigestItemTemplateData one notification in the digest email
+type DigestItemTemplateData struct {
+	Title   string
+	Url     string
+	Summary string
+}
+
+type DigestTemplateData struct {
+	SiteName       string
+	Count          int
+	Items          string
+	UnsubscribeUrl string
+}
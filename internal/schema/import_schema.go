@@ -0,0 +1,40 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package schema
+
+// StackExchangeImportReq import a Stack Exchange data dump
+type StackExchangeImportReq struct {
+	// the directory contains Posts.xml, Users.xml, Comments.xml, Votes.xml, Tags.xml and PostHistory.xml
+	Dir string `validate:"required,gt=0,lte=1000" json:"dir"`
+	// the owner of the contents whose author can not be found, default is the first admin
+	UserID string `json:"-"`
+}
+
+// StackExchangeImportResult the count of imported objects
+type StackExchangeImportResult struct {
+	Users     int `json:"users"`
+	Tags      int `json:"tags"`
+	Questions int `json:"questions"`
+	Answers   int `json:"answers"`
+	Comments  int `json:"comments"`
+	Revisions int `json:"revisions"`
+	Votes     int `json:"votes"`
+	Skipped   int `json:"skipped"`
+}
@@ -346,6 +346,7 @@ const (
 	QuestionOrderCondHot        = "hot"
 	QuestionOrderCondScore      = "score"
 	QuestionOrderCondUnanswered = "unanswered"
+	QuestionOrderCondFeatured   = "featured"
 
 	// HotInDays limit max days of the hottest question
 	HotInDays = 90
@@ -355,7 +356,7 @@ const (
 type QuestionPageReq struct {
 	Page      int    `validate:"omitempty,min=1" form:"page"`
 	PageSize  int    `validate:"omitempty,min=1" form:"page_size"`
-	OrderCond string `validate:"omitempty,oneof=newest active hot score unanswered" form:"order"`
+	OrderCond string `validate:"omitempty,oneof=newest active hot score unanswered featured" form:"order"`
 	Tag       string `validate:"omitempty,gt=0,lte=100" form:"tag"`
 	Username  string `validate:"omitempty,gt=0,lte=100" form:"username"`
 	InDays    int    `validate:"omitempty,min=1" form:"in_days"`
@@ -397,6 +398,9 @@ type QuestionPageResp struct {
 	LastAnsweredUserID string    `json:"-"`
 	LastAnsweredAt     time.Time `json:"-"`
 
+	// reputation of the active bounty, 0 if there is no active bounty
+	BountyAmount int `json:"bounty_amount"`
+
 	// operator information
 	OperatedAt    int64                     `json:"operated_at"`
 	Operator      *QuestionPageRespOperator `json:"operator"`
@@ -0,0 +1,55 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package schema
+
+// GetQueueJobPageReq get queue job page request
+type GetQueueJobPageReq struct {
+	// page
+	Page int `validate:"omitempty,min=1" form:"page"`
+	// page size
+	PageSize int `validate:"omitempty,min=1" form:"page_size"`
+	// queue name
+	QueueName string `validate:"omitempty,gt=0,lte=64" form:"queue_name"`
+	// job status
+	Status string `validate:"omitempty,oneof=pending running dead" form:"status"`
+}
+
+// GetQueueJobPageResp get queue job page response
+type GetQueueJobPageResp struct {
+	JobID       int64  `json:"job_id"`
+	QueueName   string `json:"queue_name"`
+	Payload     string `json:"payload"`
+	Status      string `json:"status" enums:"pending,running,dead"`
+	Attempts    int    `json:"attempts"`
+	MaxAttempts int    `json:"max_attempts"`
+	LastError   string `json:"last_error"`
+	CreatedAt   int64  `json:"created_at"`
+	NextRunAt   int64  `json:"next_run_at"`
+}
+
+// RetryQueueJobReq retry queue job request
+type RetryQueueJobReq struct {
+	JobID int64 `validate:"required" json:"job_id"`
+}
+
+// RemoveQueueJobReq remove queue job request
+type RemoveQueueJobReq struct {
+	JobID int64 `validate:"required" json:"job_id"`
+}
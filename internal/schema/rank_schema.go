@@ -19,6 +19,8 @@
 
 package schema
 
+import "github.com/apache/incubator-answer/internal/entity"
+
 // GetRankPersonalWithPageReq get rank list page request
 type GetRankPersonalWithPageReq struct {
 	// page
@@ -54,3 +56,47 @@ type GetRankPersonalPageResp struct {
 	// rank type
 	RankType string `json:"rank_type"`
 }
+
+const (
+	// RankRecalculateDefaultBatchSize the default number of users recalculated in one transaction
+	RankRecalculateDefaultBatchSize = 100
+	// RankRecalculateMaxReportDiffs the maximum number of differences kept in the progress
+	RankRecalculateMaxReportDiffs = 1000
+)
+
+// RankRecalculateReq recalculate the reputation of all users from their activities
+type RankRecalculateReq struct {
+	// only report the differences, nothing is changed
+	DryRun    bool   `json:"dry_run"`
+	BatchSize int    `validate:"omitempty,min=1,max=1000" json:"batch_size"`
+	UserID    string `json:"-"`
+}
+
+// RankRecalculateDiff the difference between the current reputation of the user and the recalculated one
+type RankRecalculateDiff struct {
+	UserID   string `json:"user_id"`
+	Username string `json:"username"`
+	OldRank  int    `json:"old_rank"`
+	NewRank  int    `json:"new_rank"`
+	// the activities whose rank is changed by the current rules
+	Activities []*entity.Activity `json:"-"`
+	// the number of the activities whose rank is changed
+	ChangedActivities int `json:"changed_activities"`
+}
+
+// RankRecalculateProgress the progress of the reputation recalculation
+type RankRecalculateProgress struct {
+	DryRun  bool `json:"dry_run"`
+	Running bool `json:"running"`
+	// the number of users recalculated
+	Users int `json:"users"`
+	// the number of users whose reputation is different from the recalculated one
+	Changed int `json:"changed"`
+	// the number of users whose reputation is updated, the users changed during the recalculation are skipped
+	Applied int `json:"applied"`
+	// the first RankRecalculateMaxReportDiffs differences
+	Diffs      []*RankRecalculateDiff `json:"diffs"`
+	StartedAt  int64                  `json:"started_at"`
+	FinishedAt int64                  `json:"finished_at"`
+	Error      string                 `json:"error"`
+}
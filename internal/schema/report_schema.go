@@ -62,11 +62,21 @@ type ReportHandleReq struct {
 	FlaggedContent string `validate:"omitempty" comment:"flagged content" form:"flagged_content" json:"flagged_content"`
 }
 
-// GetReportListPageDTO report list data transfer object
-type GetReportListPageDTO struct {
-	Page     int
-	PageSize int
-	Status   int
+const (
+	ReportCaseSortNewest       = "newest"
+	ReportCaseSortOldest       = "oldest"
+	ReportCaseSortMostReported = "most_reported"
+
+	ReportCaseAssigneeMe         = "me"
+	ReportCaseAssigneeUnassigned = "unassigned"
+)
+
+// GetReportCasePageDTO report case list data transfer object
+type GetReportCasePageDTO struct {
+	Page           int
+	PageSize       int
+	Sort           string
+	AssigneeUserID string
 }
 
 // GetReportListPageResp get report list
@@ -92,6 +102,54 @@ type GetReportListPageResp struct {
 	SubmitterUser    UserBasicInfo `json:"submitter_user"`
 	Reason           *ReasonItem   `json:"reason"`
 	ReasonContent    string        `json:"reason_content"`
+	// all the pending flags of this object, oldest first
+	ReportCount  int               `json:"report_count"`
+	Flags        []*ReportCaseFlag `json:"flags"`
+	AssigneeUser *UserBasicInfo    `json:"assignee_user"`
+	// aging of the case, it is overdue when it is not handled before the sla deadline
+	FirstReportedAt int64 `json:"first_reported_at"`
+	AgeSeconds      int64 `json:"age_seconds"`
+	SLADeadline     int64 `json:"sla_deadline"`
+	Overdue         bool  `json:"overdue"`
+}
+
+// ReportCaseFlag one flag of the report case
+type ReportCaseFlag struct {
+	FlagID        string        `json:"flag_id"`
+	SubmitAt      int64         `json:"submit_at"`
+	SubmitterUser UserBasicInfo `json:"submitter_user"`
+	Reason        *ReasonItem   `json:"reason"`
+	ReasonContent string        `json:"reason_content"`
+}
+
+// GetReportCasePageReq get report case page request
+type GetReportCasePageReq struct {
+	Page     int    `validate:"omitempty,min=1" form:"page"`
+	PageSize int    `validate:"omitempty,min=1,max=50" form:"page_size"`
+	Sort     string `validate:"omitempty,oneof=newest oldest most_reported" form:"sort"`
+	// assignee: empty means all, me, unassigned or the user id
+	Assignee string `validate:"omitempty,lte=30" form:"assignee"`
+	UserID   string `json:"-"`
+}
+
+// AssignReportCaseReq assign report case request
+type AssignReportCaseReq struct {
+	ObjectID string `validate:"required" json:"object_id"`
+	// empty means unassign
+	AssigneeUserID string `validate:"omitempty" json:"assignee_user_id"`
+	UserID         string `json:"-"`
+}
+
+// BulkReviewReportCaseReq review the report cases in bulk
+type BulkReviewReportCaseReq struct {
+	ObjectIDs     []string `validate:"required,min=1,max=50,dive,required" json:"object_ids"`
+	OperationType string   `validate:"required,oneof=approve_report ignore_report" json:"operation_type"`
+	UserID        string   `json:"-"`
+}
+
+// BulkReviewReportCaseResp bulk review report case response
+type BulkReviewReportCaseResp struct {
+	HandledCount int `json:"handled_count"`
 }
 
 // GetUnreviewedReportPostPageReq get unreviewed report post page request
@@ -104,7 +162,7 @@ type GetUnreviewedReportPostPageReq struct {
 // ReviewReportReq review report request
 type ReviewReportReq struct {
 	FlagID        string     `validate:"required" json:"flag_id"`
-	OperationType string     `validate:"required,oneof=edit_post close_post delete_post unlist_post ignore_report" json:"operation_type"`
+	OperationType string     `validate:"required,oneof=edit_post close_post delete_post unlist_post ignore_report approve_report" json:"operation_type"`
 	CloseType     int        `validate:"omitempty" json:"close_type"`
 	CloseMsg      string     `validate:"omitempty" json:"close_msg"`
 	Title         string     `validate:"omitempty,notblank,gte=6,lte=150" json:"title"`
@@ -23,6 +23,7 @@ import (
 	"time"
 
 	"github.com/apache/incubator-answer/internal/base/constant"
+	"github.com/apache/incubator-answer/pkg/diff"
 )
 
 // AddRevisionDTO add revision request
@@ -105,6 +106,61 @@ type GetRevisionResp struct {
 	Log             string        `json:"reason"`
 }
 
+// GetRevisionDiffReq get revision diff request
+type GetRevisionDiffReq struct {
+	// object id
+	ObjectID string `validate:"required" form:"object_id"`
+	// revision id of the old version
+	FromRevisionID string `validate:"required" form:"from"`
+	// revision id of the new version
+	ToRevisionID string `validate:"required" form:"to"`
+}
+
+// GetRevisionDiffResp get revision diff response
+type GetRevisionDiffResp struct {
+	ObjectID   string            `json:"object_id"`
+	ObjectType string            `json:"object_type"`
+	From       *RevisionDiffSide `json:"from"`
+	To         *RevisionDiffSide `json:"to"`
+	// word diff of the question title or tag display name, empty for answers
+	Title []diff.Segment `json:"title"`
+	// tag changes, only for questions
+	Tags *RevisionTagsDiff `json:"tags,omitempty"`
+	// line diff of the markdown content
+	Content []diff.Line `json:"content"`
+}
+
+// RevisionDiffSide one side of the revision diff
+type RevisionDiffSide struct {
+	ID              string        `json:"id"`
+	CreatedAtParsed int64         `json:"create_at"`
+	UserInfo        UserBasicInfo `json:"user_info"`
+	Log             string        `json:"reason"`
+}
+
+// RevisionTagsDiff tag slug names added, removed or kept between two question revisions
+type RevisionTagsDiff struct {
+	Added     []string `json:"added"`
+	Removed   []string `json:"removed"`
+	Unchanged []string `json:"unchanged"`
+}
+
+// RollbackRevisionReq rollback revision request
+type RollbackRevisionReq struct {
+	// object id
+	ObjectID string `validate:"required" json:"object_id"`
+	// revision id to roll back to
+	RevisionID  string `validate:"required" json:"revision_id"`
+	EditSummary string `validate:"omitempty" json:"edit_summary"`
+	UserID      string `json:"-"`
+}
+
+// RollbackRevisionResp rollback revision response
+type RollbackRevisionResp struct {
+	// the new revision created from the old one
+	RevisionID string `json:"revision_id"`
+}
+
 // GetReviewingTypeReq get reviewing type request
 type GetReviewingTypeReq struct {
 	CanReviewQuestion bool   `json:"-"`
@@ -0,0 +1,48 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+package schema
+
+// SearchReindexReq rebuild the index of the active search plugin
+type SearchReindexReq struct {
+	// only sync the contents updated after the last finished run
+	Incremental bool `json:"incremental"`
+	// only count the contents that need to be synced
+	DryRun bool `json:"dry_run"`
+	// ignore the unfinished checkpoint and start a new run
+	Restart  bool `json:"restart"`
+	PageSize int  `validate:"omitempty,min=1,max=1000" json:"page_size"`
+}
+
+// SearchReindexProgress the progress of rebuilding search index
+type SearchReindexProgress struct {
+	PluginSlugName string `json:"plugin_slug_name"`
+	Mode           string `json:"mode"`
+	DryRun         bool   `json:"dry_run"`
+	Running        bool   `json:"running"`
+	Resumed        bool   `json:"resumed"`
+	ObjectType     string `json:"object_type"`
+	Page           int    `json:"page"`
+	QuestionTotal  int64  `json:"question_total"`
+	AnswerTotal    int64  `json:"answer_total"`
+	Synced         int64  `json:"synced"`
+	Since          int64  `json:"since"`
+	StartedAt      int64  `json:"started_at"`
+	FinishedAt     int64  `json:"finished_at"`
+	Error          string `json:"error"`
+}
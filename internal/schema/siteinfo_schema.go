@@ -76,7 +76,9 @@ type SiteWriteReq struct {
 	RequiredTag    bool            `validate:"omitempty" json:"required_tag"`
 	RecommendTags  []*SiteWriteTag `validate:"omitempty,dive" json:"recommend_tags"`
 	ReservedTags   []*SiteWriteTag `validate:"omitempty,dive" json:"reserved_tags"`
-	UserID         string          `json:"-"`
+	// AttachmentTypes the file types allowed to be attached to posts, attachment is disabled if it is empty
+	AttachmentTypes []*SiteWriteAttachmentType `validate:"omitempty,dive" json:"attachment_types"`
+	UserID          string                     `json:"-"`
 }
 
 // SiteWriteTag site write response tag
@@ -85,6 +87,29 @@ type SiteWriteTag struct {
 	DisplayName string `json:"display_name"`
 }
 
+// SiteWriteAttachmentType the file type allowed to be attached to posts
+type SiteWriteAttachmentType struct {
+	// Extension the extension of file, eg: .pdf
+	Extension string `validate:"required,gt=0,lte=16" json:"extension"`
+	// MaxSize the max size of file in MB
+	MaxSize int `validate:"required,min=1,max=1024" json:"max_size"`
+}
+
+// FormatAttachmentTypes the extensions are lower case and start with dot, the duplicate ones are removed
+func (r *SiteWriteReq) FormatAttachmentTypes() {
+	types := make([]*SiteWriteAttachmentType, 0, len(r.AttachmentTypes))
+	exist := make(map[string]bool)
+	for _, t := range r.AttachmentTypes {
+		t.Extension = "." + strings.TrimPrefix(strings.ToLower(strings.TrimSpace(t.Extension)), ".")
+		if len(t.Extension) == 1 || exist[t.Extension] {
+			continue
+		}
+		exist[t.Extension] = true
+		types = append(types, t)
+	}
+	r.AttachmentTypes = types
+}
+
 // SiteLegalReq site branding request
 type SiteLegalReq struct {
 	TermsOfServiceOriginalText string `json:"terms_of_service_original_text"`
@@ -207,6 +232,26 @@ type ThemeOption struct {
 // SiteWriteResp site write response
 type SiteWriteResp SiteWriteReq
 
+// GetAttachmentType get the allowed attachment type of the extension, nil if it is not allowed
+func (r *SiteWriteResp) GetAttachmentType(ext string) *SiteWriteAttachmentType {
+	for _, t := range r.AttachmentTypes {
+		if t.Extension == strings.ToLower(ext) {
+			return t
+		}
+	}
+	return nil
+}
+
+// MaxAttachmentSize the max size of all allowed attachment types in MB
+func (r *SiteWriteResp) MaxAttachmentSize() (maxSize int) {
+	for _, t := range r.AttachmentTypes {
+		if t.MaxSize > maxSize {
+			maxSize = t.MaxSize
+		}
+	}
+	return maxSize
+}
+
 // SiteLegalResp site write response
 type SiteLegalResp SiteLegalReq
 
@@ -0,0 +1,45 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package schema
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSiteWriteReq_FormatAttachmentTypes(t *testing.T) {
+	req := &SiteWriteReq{AttachmentTypes: []*SiteWriteAttachmentType{
+		{Extension: "PDF", MaxSize: 10},
+		{Extension: " .log", MaxSize: 2},
+		{Extension: ".pdf", MaxSize: 20},
+		{Extension: ".", MaxSize: 1},
+		{Extension: ".zip", MaxSize: 50},
+	}}
+	req.FormatAttachmentTypes()
+	assert.Len(t, req.AttachmentTypes, 3)
+
+	resp := SiteWriteResp(*req)
+	assert.Equal(t, 10, resp.GetAttachmentType(".PDF").MaxSize)
+	assert.Equal(t, 2, resp.GetAttachmentType(".log").MaxSize)
+	assert.Nil(t, resp.GetAttachmentType(".exe"))
+	assert.Equal(t, 50, resp.MaxAttachmentSize())
+	assert.Equal(t, 0, (&SiteWriteResp{}).MaxAttachmentSize())
+}
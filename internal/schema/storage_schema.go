@@ -0,0 +1,50 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package schema
+
+// StorageMigrateReq the options of migrating the uploaded files between storages
+type StorageMigrateReq struct {
+	// From the type of source storage
+	From string
+	// To the type of target storage
+	To string
+	// FromURLPrefix the url prefix of files in the source storage, default is the one used by the uploader
+	FromURLPrefix string
+	// ToURLPrefix the url prefix of files in the target storage, default is the one used by the uploader
+	ToURLPrefix string
+	// DryRun only count the files and contents that need to be changed
+	DryRun bool
+}
+
+// StorageMigrateResp the result of migrating the uploaded files
+type StorageMigrateResp struct {
+	// Files the number of files in the source storage
+	Files int `json:"files"`
+	// Copied the number of files copied to the target storage
+	Copied int `json:"copied"`
+	// Skipped the number of files already in the target storage
+	Skipped int `json:"skipped"`
+	// OldURLPrefix the url prefix replaced in the contents
+	OldURLPrefix string `json:"old_url_prefix"`
+	// NewURLPrefix the url prefix written to the contents
+	NewURLPrefix string `json:"new_url_prefix"`
+	// Rewritten the number of rows whose urls are rewritten
+	Rewritten int64 `json:"rewritten"`
+}
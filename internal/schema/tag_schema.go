@@ -87,6 +87,10 @@ type GetTagResp struct {
 	MainTagSlugName string `json:"main_tag_slug_name"`
 	Recommend       bool   `json:"recommend"`
 	Reserved        bool   `json:"reserved"`
+	// ancestors of the tag, ordered from the root to the parent
+	Ancestors []*TagTreeNode `json:"ancestors"`
+	Children  []*TagTreeNode `json:"children"`
+	TagGroup  *TagGroupResp  `json:"tag_group"`
 }
 
 func (tr *GetTagResp) GetExcerpt() {
@@ -305,3 +309,64 @@ type GetTagBasicResp struct {
 	Recommend   bool   `json:"recommend"`
 	Reserved    bool   `json:"reserved"`
 }
+
+// TagTreeNode tag node of the tag tree
+type TagTreeNode struct {
+	TagID         string         `json:"tag_id"`
+	SlugName      string         `json:"slug_name"`
+	DisplayName   string         `json:"display_name"`
+	QuestionCount int            `json:"question_count"`
+	Children      []*TagTreeNode `json:"children,omitempty"`
+}
+
+// TagGroupResp tag group response
+type TagGroupResp struct {
+	TagGroupID  int64  `json:"tag_group_id"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	SortOrder   int    `json:"sort_order"`
+}
+
+// TagGroupTree the tag trees of the group
+type TagGroupTree struct {
+	TagGroupResp
+	Tags []*TagTreeNode `json:"tags"`
+}
+
+// GetTagTreeResp get tag tree response
+type GetTagTreeResp struct {
+	Groups []*TagGroupTree `json:"groups"`
+	// the tag trees which are not in any group
+	Ungrouped []*TagTreeNode `json:"ungrouped"`
+}
+
+// AddTagGroupReq add tag group request
+type AddTagGroupReq struct {
+	Name        string `validate:"required,notblank,lte=50" json:"name"`
+	Description string `validate:"omitempty,lte=500" json:"description"`
+	SortOrder   int    `validate:"omitempty" json:"sort_order"`
+}
+
+// UpdateTagGroupReq update tag group request
+type UpdateTagGroupReq struct {
+	TagGroupID  int64  `validate:"required" json:"tag_group_id"`
+	Name        string `validate:"required,notblank,lte=50" json:"name"`
+	Description string `validate:"omitempty,lte=500" json:"description"`
+	SortOrder   int    `validate:"omitempty" json:"sort_order"`
+}
+
+// RemoveTagGroupReq remove tag group request
+type RemoveTagGroupReq struct {
+	TagGroupID int64 `validate:"required" json:"tag_group_id"`
+}
+
+// UpdateTagStructureReq move the tag in the tag tree
+type UpdateTagStructureReq struct {
+	TagID string `validate:"required" json:"tag_id"`
+	// empty means the tag is a root tag
+	ParentTagID string `validate:"omitempty" json:"parent_tag_id"`
+	// 0 means the tag is not in any group
+	TagGroupID  int64  `validate:"omitempty" json:"tag_group_id"`
+	EditSummary string `validate:"omitempty,lte=100" json:"edit_summary"`
+	UserID      string `json:"-"`
+}
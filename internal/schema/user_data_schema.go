@@ -0,0 +1,99 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package schema
+
+// UserDataProfile the profile of user in the personal data export, the password is never exported
+type UserDataProfile struct {
+	ID            string `json:"id"`
+	Username      string `json:"username"`
+	DisplayName   string `json:"display_name"`
+	EMail         string `json:"e_mail"`
+	Mobile        string `json:"mobile"`
+	Avatar        string `json:"avatar"`
+	Bio           string `json:"bio"`
+	Website       string `json:"website"`
+	Location      string `json:"location"`
+	IPInfo        string `json:"ip_info"`
+	Language      string `json:"language"`
+	Rank          int    `json:"rank"`
+	Status        string `json:"status"`
+	CreatedAt     int64  `json:"created_at"`
+	LastLoginDate int64  `json:"last_login_date"`
+}
+
+// UserDataPost the question or answer in the personal data export
+type UserDataPost struct {
+	ID         string `json:"id"`
+	QuestionID string `json:"question_id,omitempty"`
+	Title      string `json:"title,omitempty"`
+	Content    string `json:"content"`
+	Status     int    `json:"status"`
+	VoteCount  int    `json:"vote_count"`
+	CreatedAt  int64  `json:"created_at"`
+	UpdatedAt  int64  `json:"updated_at"`
+}
+
+// UserDataComment the comment in the personal data export
+type UserDataComment struct {
+	ID         string `json:"id"`
+	ObjectID   string `json:"object_id"`
+	QuestionID string `json:"question_id"`
+	Content    string `json:"content"`
+	Status     int    `json:"status"`
+	CreatedAt  int64  `json:"created_at"`
+}
+
+// UserDataVote the vote in the personal data export
+type UserDataVote struct {
+	ObjectID  string `json:"object_id"`
+	Type      string `json:"type"`
+	Cancelled bool   `json:"cancelled"`
+	CreatedAt int64  `json:"created_at"`
+}
+
+// UserDataCollection the collection in the personal data export
+type UserDataCollection struct {
+	ObjectID  string `json:"object_id"`
+	CreatedAt int64  `json:"created_at"`
+}
+
+// UserDataNotification the notification in the personal data export
+type UserDataNotification struct {
+	ObjectID  string `json:"object_id"`
+	Type      int    `json:"type"`
+	Content   string `json:"content"`
+	IsRead    bool   `json:"is_read"`
+	CreatedAt int64  `json:"created_at"`
+}
+
+// UserDataExternalLogin the external login in the personal data export
+type UserDataExternalLogin struct {
+	Provider   string `json:"provider"`
+	ExternalID string `json:"external_id"`
+	MetaInfo   string `json:"meta_info"`
+	CreatedAt  int64  `json:"created_at"`
+}
+
+// EraseUserReq erase the personal data of user
+type EraseUserReq struct {
+	// user id
+	UserID      string `validate:"required" json:"user_id"`
+	LoginUserID string `json:"-"`
+}
@@ -26,8 +26,26 @@ import (
 )
 
 type NotificationChannelConfig struct {
-	Key    constant.NotificationChannelKey `json:"key"`
-	Enable bool                            `json:"enable"`
+	Key       constant.NotificationChannelKey `json:"key"`
+	Enable    bool                            `json:"enable"`
+	Frequency constant.NotificationFrequency  `json:"frequency,omitempty"`
+}
+
+// IsDigest the notifications are collected and sent as a digest instead of one by one
+func (n *NotificationChannelConfig) IsDigest() bool {
+	for _, frequency := range constant.NotificationDigestFrequencies {
+		if n.Frequency == frequency {
+			return true
+		}
+	}
+	return false
+}
+
+// formatFrequency the unknown frequency is treated as immediate
+func (n *NotificationChannelConfig) formatFrequency() {
+	if !n.IsDigest() {
+		n.Frequency = constant.NotificationFrequencyImmediate
+	}
 }
 
 type NotificationChannels []*NotificationChannelConfig
@@ -86,6 +104,9 @@ func (n *NotificationConfig) Format() {
 		n.AllNewQuestionForFollowingTags.Key = constant.EmailChannel
 		n.AllNewQuestionForFollowingTags.Enable = false
 	}
+	n.Inbox.formatFrequency()
+	n.AllNewQuestion.formatFrequency()
+	n.AllNewQuestionForFollowingTags.formatFrequency()
 }
 
 // UpdateUserNotificationConfigReq update user notification config request
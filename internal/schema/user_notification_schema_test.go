@@ -0,0 +1,49 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package schema
+
+import (
+	"testing"
+
+	"github.com/apache/incubator-answer/internal/base/constant"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNotificationConfigFormatFrequency(t *testing.T) {
+	nc := &NotificationConfig{
+		Inbox:          NotificationChannelConfig{Key: constant.EmailChannel, Enable: true},
+		AllNewQuestion: NotificationChannelConfig{Key: constant.EmailChannel, Enable: true, Frequency: "monthly"},
+		AllNewQuestionForFollowingTags: NotificationChannelConfig{
+			Key: constant.EmailChannel, Enable: true, Frequency: constant.NotificationFrequencyDaily},
+	}
+	nc.Format()
+
+	assert.Equal(t, constant.NotificationFrequencyImmediate, nc.Inbox.Frequency)
+	assert.False(t, nc.Inbox.IsDigest())
+	assert.Equal(t, constant.NotificationFrequencyImmediate, nc.AllNewQuestion.Frequency)
+	assert.Equal(t, constant.NotificationFrequencyDaily, nc.AllNewQuestionForFollowingTags.Frequency)
+	assert.True(t, nc.AllNewQuestionForFollowingTags.IsDigest())
+}
+
+func TestNewNotificationChannelsFormJsonWithoutFrequency(t *testing.T) {
+	channels := NewNotificationChannelsFormJson(`[{"key":"email","enable":true}]`)
+	assert.Len(t, channels, 1)
+	assert.False(t, channels[0].IsDigest())
+}
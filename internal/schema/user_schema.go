@@ -156,6 +156,10 @@ type GetOtherUserInfoByUsernameResp struct {
 	Location  string `json:"location"`
 	Status    string `json:"status"`
 	StatusMsg string `json:"status_msg,omitempty"`
+	// badge count
+	BadgeCount *UserBadgeCount `json:"badge_count"`
+	// badges, the higher level first
+	Badges []*UserBadgeInfo `json:"badges"`
 }
 
 func (r *GetOtherUserInfoByUsernameResp) ConvertFromUserEntity(userInfo *entity.User) {
@@ -413,6 +417,15 @@ type UserUnsubscribeNotificationReq struct {
 	Content string `json:"-"`
 }
 
+// UserUnsubscribeNotificationOneClickReq RFC 8058 one-click unsubscribe request,
+// the mail client posts "List-Unsubscribe=One-Click" to the url in the List-Unsubscribe header.
+// The code is only in the url of the emails sent before the signed token.
+type UserUnsubscribeNotificationOneClickReq struct {
+	Token           string `validate:"required_without=Code,omitempty,lte=500" form:"token"`
+	Code            string `validate:"required_without=Token,omitempty,lte=500" form:"code"`
+	ListUnsubscribe string `validate:"omitempty" form:"List-Unsubscribe"`
+}
+
 // GetUserStaffReq get user staff request
 type GetUserStaffReq struct {
 	Username string `validate:"omitempty,gt=0,lte=500" form:"username"`
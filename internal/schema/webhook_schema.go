@@ -0,0 +1,152 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package schema
+
+import (
+	"github.com/apache/incubator-answer/internal/base/constant"
+	"github.com/apache/incubator-answer/internal/base/reason"
+	"github.com/apache/incubator-answer/internal/base/validator"
+	"github.com/segmentfault/pacman/errors"
+)
+
+// WebhookEventMsg the event that will be delivered to the subscribed webhooks
+type WebhookEventMsg struct {
+	Event         constant.ActivityTypeKey
+	ObjectID      string
+	UserID        string
+	TriggerUserID string
+	ExtraInfo     map[string]string
+}
+
+// WebhookPayload webhook request body
+type WebhookPayload struct {
+	Event         string                `json:"event"`
+	Timestamp     int64                 `json:"timestamp"`
+	UserID        string                `json:"user_id,omitempty"`
+	TriggerUserID string                `json:"trigger_user_id,omitempty"`
+	Object        *WebhookPayloadObject `json:"object,omitempty"`
+	ExtraInfo     map[string]string     `json:"extra_info,omitempty"`
+}
+
+// WebhookPayloadObject the object which the event happened on
+type WebhookPayloadObject struct {
+	ObjectID      string `json:"object_id"`
+	ObjectType    string `json:"object_type"`
+	Title         string `json:"title,omitempty"`
+	QuestionID    string `json:"question_id,omitempty"`
+	AnswerID      string `json:"answer_id,omitempty"`
+	CommentID     string `json:"comment_id,omitempty"`
+	TagID         string `json:"tag_id,omitempty"`
+	CreatorUserID string `json:"creator_user_id,omitempty"`
+	URL           string `json:"url,omitempty"`
+}
+
+// WebhookDeliveryJob webhook delivery job in queue
+type WebhookDeliveryJob struct {
+	DeliveryID int64 `json:"delivery_id"`
+}
+
+// AddWebhookReq add webhook request
+type AddWebhookReq struct {
+	Name   string   `validate:"required,notblank,gt=0,lte=100" json:"name"`
+	URL    string   `validate:"required,url,lte=512" json:"url"`
+	Secret string   `validate:"omitempty,lte=128" json:"secret"`
+	Events []string `validate:"required,gt=0,dive,required" json:"events"`
+}
+
+func (r *AddWebhookReq) Check() (errFields []*validator.FormErrorField, err error) {
+	return checkWebhookEvents(r.Events)
+}
+
+// UpdateWebhookReq update webhook request
+type UpdateWebhookReq struct {
+	WebhookID int64    `validate:"required" json:"webhook_id"`
+	Name      string   `validate:"required,notblank,gt=0,lte=100" json:"name"`
+	URL       string   `validate:"required,url,lte=512" json:"url"`
+	Secret    string   `validate:"omitempty,lte=128" json:"secret"`
+	Events    []string `validate:"required,gt=0,dive,required" json:"events"`
+	Enabled   bool     `json:"enabled"`
+}
+
+func (r *UpdateWebhookReq) Check() (errFields []*validator.FormErrorField, err error) {
+	return checkWebhookEvents(r.Events)
+}
+
+func checkWebhookEvents(events []string) (errFields []*validator.FormErrorField, err error) {
+	supported := make(map[string]bool, len(constant.WebhookEvents))
+	for _, event := range constant.WebhookEvents {
+		supported[string(event)] = true
+	}
+	for _, event := range events {
+		if !supported[event] {
+			errFields = append(errFields, &validator.FormErrorField{
+				ErrorField: "events",
+				ErrorMsg:   reason.WebhookEventUnsupported,
+			})
+			return errFields, errors.BadRequest(reason.WebhookEventUnsupported)
+		}
+	}
+	return nil, nil
+}
+
+// RemoveWebhookReq remove webhook request
+type RemoveWebhookReq struct {
+	WebhookID int64 `validate:"required" json:"webhook_id"`
+}
+
+// TestWebhookReq test webhook request
+type TestWebhookReq struct {
+	WebhookID int64 `validate:"required" json:"webhook_id"`
+}
+
+// GetWebhookResp get webhook response
+type GetWebhookResp struct {
+	WebhookID int64    `json:"webhook_id"`
+	Name      string   `json:"name"`
+	URL       string   `json:"url"`
+	Secret    string   `json:"secret"`
+	Events    []string `json:"events"`
+	Enabled   bool     `json:"enabled"`
+	CreatedAt int64    `json:"created_at"`
+	UpdatedAt int64    `json:"updated_at"`
+}
+
+// GetWebhookDeliveryPageReq get webhook delivery page request
+type GetWebhookDeliveryPageReq struct {
+	WebhookID int64 `validate:"required" form:"webhook_id"`
+	Page      int   `validate:"omitempty,min=1" form:"page"`
+	PageSize  int   `validate:"omitempty,min=1" form:"page_size"`
+}
+
+// GetWebhookDeliveryResp get webhook delivery response
+type GetWebhookDeliveryResp struct {
+	DeliveryID   int64  `json:"delivery_id"`
+	WebhookID    int64  `json:"webhook_id"`
+	Event        string `json:"event"`
+	Payload      string `json:"payload"`
+	Status       string `json:"status" enums:"pending,success,failed"`
+	Attempts     int    `json:"attempts"`
+	ResponseCode int    `json:"response_code"`
+	ResponseBody string `json:"response_body"`
+	Error        string `json:"error"`
+	Duration     int64  `json:"duration"`
+	CreatedAt    int64  `json:"created_at"`
+	UpdatedAt    int64  `json:"updated_at"`
+}
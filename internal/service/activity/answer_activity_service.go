@@ -21,9 +21,13 @@ package activity
 
 import (
 	"context"
+
+	"github.com/apache/incubator-answer/internal/base/constant"
 	"github.com/apache/incubator-answer/internal/schema"
 	"github.com/apache/incubator-answer/internal/service/activity_type"
+	"github.com/apache/incubator-answer/internal/service/badge_queue"
 	"github.com/apache/incubator-answer/internal/service/config"
+	"github.com/apache/incubator-answer/internal/service/webhook_queue"
 	"github.com/segmentfault/pacman/log"
 )
 
@@ -35,18 +39,24 @@ type AnswerActivityRepo interface {
 
 // AnswerActivityService answer activity service
 type AnswerActivityService struct {
-	answerActivityRepo AnswerActivityRepo
-	configService      *config.ConfigService
+	answerActivityRepo  AnswerActivityRepo
+	configService       *config.ConfigService
+	webhookQueueService webhook_queue.WebhookQueueService
+	badgeQueueService   badge_queue.BadgeQueueService
 }
 
 // NewAnswerActivityService new comment service
 func NewAnswerActivityService(
 	answerActivityRepo AnswerActivityRepo,
 	configService *config.ConfigService,
+	webhookQueueService webhook_queue.WebhookQueueService,
+	badgeQueueService badge_queue.BadgeQueueService,
 ) *AnswerActivityService {
 	return &AnswerActivityService{
-		answerActivityRepo: answerActivityRepo,
-		configService:      configService,
+		answerActivityRepo:  answerActivityRepo,
+		configService:       configService,
+		webhookQueueService: webhookQueueService,
+		badgeQueueService:   badgeQueueService,
 	}
 }
 
@@ -58,7 +68,28 @@ func (as *AnswerActivityService) AcceptAnswer(ctx context.Context,
 		questionObjID, questionUserID)
 	operationInfo := as.createAcceptAnswerOperationInfo(ctx, loginUserID,
 		answerObjID, questionObjID, questionUserID, answerUserID, isSelf)
-	return as.answerActivityRepo.SaveAcceptAnswerActivity(ctx, operationInfo)
+	if err = as.answerActivityRepo.SaveAcceptAnswerActivity(ctx, operationInfo); err != nil {
+		return err
+	}
+
+	as.webhookQueueService.Send(ctx, &schema.WebhookEventMsg{
+		Event:         constant.ActQuestionAccept,
+		ObjectID:      questionObjID,
+		UserID:        questionUserID,
+		TriggerUserID: loginUserID,
+	})
+	as.webhookQueueService.Send(ctx, &schema.WebhookEventMsg{
+		Event:         constant.ActAnswerAccept,
+		ObjectID:      answerObjID,
+		UserID:        answerUserID,
+		TriggerUserID: loginUserID,
+	})
+	as.badgeQueueService.Send(ctx, &schema.BadgeEventMsg{
+		UserID:          answerUserID,
+		ActivityTypeKey: constant.ActAnswerAccept,
+		ObjectID:        answerObjID,
+	})
+	return nil
 }
 
 // CancelAcceptAnswer cancel accept answer change activity
@@ -26,6 +26,8 @@ import (
 	"github.com/apache/incubator-answer/internal/entity"
 	"github.com/apache/incubator-answer/internal/schema"
 	"github.com/apache/incubator-answer/internal/service/activity_queue"
+	"github.com/apache/incubator-answer/internal/service/badge_queue"
+	"github.com/apache/incubator-answer/internal/service/webhook_queue"
 	"github.com/apache/incubator-answer/pkg/converter"
 	"github.com/apache/incubator-answer/pkg/uid"
 	"github.com/segmentfault/pacman/log"
@@ -49,16 +51,22 @@ type ActivityRepo interface {
 type ActivityCommon struct {
 	activityRepo         ActivityRepo
 	activityQueueService activity_queue.ActivityQueueService
+	webhookQueueService  webhook_queue.WebhookQueueService
+	badgeQueueService    badge_queue.BadgeQueueService
 }
 
 // NewActivityCommon new activity common
 func NewActivityCommon(
 	activityRepo ActivityRepo,
 	activityQueueService activity_queue.ActivityQueueService,
+	webhookQueueService webhook_queue.WebhookQueueService,
+	badgeQueueService badge_queue.BadgeQueueService,
 ) *ActivityCommon {
 	activity := &ActivityCommon{
 		activityRepo:         activityRepo,
 		activityQueueService: activityQueueService,
+		webhookQueueService:  webhookQueueService,
+		badgeQueueService:    badgeQueueService,
 	}
 	activity.activityQueueService.RegisterHandler(activity.HandleActivity)
 	return activity
@@ -86,5 +94,21 @@ func (ac *ActivityCommon) HandleActivity(ctx context.Context, msg *schema.Activi
 	if err := ac.activityRepo.AddActivity(ctx, act); err != nil {
 		return err
 	}
+
+	webhookMsg := &schema.WebhookEventMsg{
+		Event:     msg.ActivityTypeKey,
+		ObjectID:  msg.ObjectID,
+		UserID:    msg.UserID,
+		ExtraInfo: msg.ExtraInfo,
+	}
+	if msg.TriggerUserID > 0 {
+		webhookMsg.TriggerUserID = converter.IntToString(msg.TriggerUserID)
+	}
+	ac.webhookQueueService.Send(ctx, webhookMsg)
+	ac.badgeQueueService.Send(ctx, &schema.BadgeEventMsg{
+		UserID:          msg.UserID,
+		ActivityTypeKey: msg.ActivityTypeKey,
+		ObjectID:        msg.ObjectID,
+	})
 	return nil
 }
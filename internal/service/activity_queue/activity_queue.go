@@ -21,49 +21,44 @@ package activity_queue
 
 import (
 	"context"
+	"encoding/json"
 
 	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/apache/incubator-answer/internal/service/job_queue"
 	"github.com/segmentfault/pacman/log"
 )
 
+// ActivityQueueName the name of the persisted queue
+const ActivityQueueName = "activity"
+
 type ActivityQueueService interface {
 	Send(ctx context.Context, msg *schema.ActivityMsg)
 	RegisterHandler(handler func(ctx context.Context, msg *schema.ActivityMsg) error)
 }
 
 type activityQueueService struct {
-	Queue   chan *schema.ActivityMsg
-	Handler func(ctx context.Context, msg *schema.ActivityMsg) error
+	jobQueueService *job_queue.JobQueueService
 }
 
 func (ns *activityQueueService) Send(ctx context.Context, msg *schema.ActivityMsg) {
-	ns.Queue <- msg
+	if err := ns.jobQueueService.Enqueue(ctx, ActivityQueueName, msg); err != nil {
+		log.Errorf("send activity failed: %v", err)
+	}
 }
 
 func (ns *activityQueueService) RegisterHandler(
 	handler func(ctx context.Context, msg *schema.ActivityMsg) error) {
-	ns.Handler = handler
-}
-
-func (ns *activityQueueService) working() {
-	go func() {
-		for msg := range ns.Queue {
-			log.Debugf("received activity %+v", msg)
-			if ns.Handler == nil {
-				log.Warnf("no handler for activity")
-				continue
-			}
-			if err := ns.Handler(context.Background(), msg); err != nil {
-				log.Error(err)
-			}
+	ns.jobQueueService.RegisterHandler(ActivityQueueName, func(ctx context.Context, payload []byte) error {
+		msg := &schema.ActivityMsg{}
+		if err := json.Unmarshal(payload, msg); err != nil {
+			return job_queue.Permanent(err)
 		}
-	}()
+		log.Debugf("received activity %+v", msg)
+		return handler(ctx, msg)
+	})
 }
 
 // NewActivityQueueService create a new activity queue service
-func NewActivityQueueService() ActivityQueueService {
-	ns := &activityQueueService{}
-	ns.Queue = make(chan *schema.ActivityMsg, 128)
-	ns.working()
-	return ns
+func NewActivityQueueService(jobQueueService *job_queue.JobQueueService) ActivityQueueService {
+	return &activityQueueService{jobQueueService: jobQueueService}
 }
@@ -32,6 +32,8 @@ const (
 	AnswerAccept      = "answer.accept"
 	CommentVoteUp     = "comment.vote_up"
 	EditAccepted      = "edit.accepted"
+
+	AnswerBountyAwarded = "answer.bounty_awarded"
 )
 
 var (
@@ -72,5 +74,7 @@ var (
 		AnswerAccept:      "action_activity_type.accept",
 		CommentVoteUp:     "action_activity_type.upvote",
 		EditAccepted:      "action_activity_type.edit",
+
+		AnswerBountyAwarded: "action_activity_type.bounty_awarded",
 	}
 )
@@ -29,6 +29,7 @@ import (
 	"github.com/apache/incubator-answer/pkg/uid"
 )
 
+//go:generate mockgen -source=./answer.go -destination=../mock/answer_repo_mock.go -package=mock
 type AnswerRepo interface {
 	AddAnswer(ctx context.Context, answer *entity.Answer) (err error)
 	RemoveAnswer(ctx context.Context, id string) (err error)
@@ -0,0 +1,218 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+package api_token
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/apache/incubator-answer/internal/base/reason"
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/apache/incubator-answer/internal/service/role"
+	usercommon "github.com/apache/incubator-answer/internal/service/user_common"
+	"github.com/segmentfault/pacman/errors"
+	"github.com/segmentfault/pacman/log"
+)
+
+const (
+	// TokenPrefix all api tokens start with this prefix, so they can be told apart from session tokens
+	TokenPrefix = "ans_"
+	// maxTokensPerUser the max number of api tokens one user can hold
+	maxTokensPerUser = 20
+	// lastUsedUpdateInterval avoid writing the database on every request
+	lastUsedUpdateInterval = 10 * time.Minute
+)
+
+// APITokenRepo api token repository
+type APITokenRepo interface {
+	AddAPIToken(ctx context.Context, token *entity.APIToken) (err error)
+	RemoveAPIToken(ctx context.Context, userID string, tokenID int64) (affected int64, err error)
+	GetAPITokenByHash(ctx context.Context, tokenHash string) (token *entity.APIToken, exist bool, err error)
+	GetAPITokenList(ctx context.Context, userID string) (tokens []*entity.APIToken, err error)
+	CountAPIToken(ctx context.Context, userID string) (count int64, err error)
+	UpdateLastUsedAt(ctx context.Context, tokenID int64, lastUsedAt time.Time) (err error)
+}
+
+// APITokenService api token service
+type APITokenService struct {
+	apiTokenRepo       APITokenRepo
+	userRepo           usercommon.UserRepo
+	userRoleRelService *role.UserRoleRelService
+}
+
+// NewAPITokenService new api token service
+func NewAPITokenService(
+	apiTokenRepo APITokenRepo,
+	userRepo usercommon.UserRepo,
+	userRoleRelService *role.UserRoleRelService,
+) *APITokenService {
+	return &APITokenService{
+		apiTokenRepo:       apiTokenRepo,
+		userRepo:           userRepo,
+		userRoleRelService: userRoleRelService,
+	}
+}
+
+// IsAPIToken whether the token is an api token rather than a session token
+func IsAPIToken(token string) bool {
+	return strings.HasPrefix(token, TokenPrefix)
+}
+
+// ScopeAllowed whether the request is allowed by the scope of api token
+func ScopeAllowed(scope, method string, adminAPI bool) bool {
+	switch scope {
+	case entity.APITokenScopeRead:
+		return !adminAPI && (method == http.MethodGet || method == http.MethodHead || method == http.MethodOptions)
+	case entity.APITokenScopeWrite:
+		return !adminAPI
+	case entity.APITokenScopeAdmin:
+		return true
+	}
+	return false
+}
+
+// CreateAPIToken create api token, the plain token is only returned here
+func (as *APITokenService) CreateAPIToken(ctx context.Context, req *schema.CreateAPITokenReq) (
+	resp *schema.CreateAPITokenResp, err error) {
+	if req.Scope == entity.APITokenScopeAdmin && !req.IsAdmin {
+		return nil, errors.Forbidden(reason.APITokenScopeNotAllowed)
+	}
+	count, err := as.apiTokenRepo.CountAPIToken(ctx, req.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if count >= maxTokensPerUser {
+		return nil, errors.BadRequest(reason.APITokenTooMany)
+	}
+
+	plainToken, err := generateToken()
+	if err != nil {
+		return nil, errors.InternalServer(reason.UnknownError).WithError(err).WithStack()
+	}
+	token := &entity.APIToken{
+		UserID:      req.UserID,
+		Name:        req.Name,
+		TokenHash:   hashToken(plainToken),
+		TokenPrefix: plainToken[:len(TokenPrefix)+6],
+		Scope:       req.Scope,
+	}
+	if req.ExpiresInDays > 0 {
+		token.ExpiredAt = time.Now().AddDate(0, 0, req.ExpiresInDays)
+	}
+	if err = as.apiTokenRepo.AddAPIToken(ctx, token); err != nil {
+		return nil, err
+	}
+	return &schema.CreateAPITokenResp{GetAPITokenResp: formatAPIToken(token), Token: plainToken}, nil
+}
+
+// GetAPITokenList get api tokens of user
+func (as *APITokenService) GetAPITokenList(ctx context.Context, userID string) (
+	resp []*schema.GetAPITokenResp, err error) {
+	tokens, err := as.apiTokenRepo.GetAPITokenList(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	resp = make([]*schema.GetAPITokenResp, 0, len(tokens))
+	for _, token := range tokens {
+		resp = append(resp, formatAPIToken(token))
+	}
+	return resp, nil
+}
+
+// RevokeAPIToken revoke api token, user can only revoke their own tokens
+func (as *APITokenService) RevokeAPIToken(ctx context.Context, req *schema.RevokeAPITokenReq) (err error) {
+	affected, err := as.apiTokenRepo.RemoveAPIToken(ctx, req.UserID, req.ID)
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return errors.BadRequest(reason.APITokenNotFound)
+	}
+	return nil
+}
+
+// GetUserCacheInfo get user info by api token, return nil if the token is invalid or expired
+func (as *APITokenService) GetUserCacheInfo(ctx context.Context, plainToken string) (
+	userInfo *entity.UserCacheInfo, scope string, err error) {
+	token, exist, err := as.apiTokenRepo.GetAPITokenByHash(ctx, hashToken(plainToken))
+	if err != nil || !exist {
+		return nil, "", err
+	}
+	now := time.Now()
+	if !token.ExpiredAt.IsZero() && now.After(token.ExpiredAt) {
+		return nil, "", nil
+	}
+
+	user, exist, err := as.userRepo.GetByUserID(ctx, token.UserID)
+	if err != nil || !exist {
+		return nil, "", err
+	}
+	roleID, err := as.userRoleRelService.GetUserRole(ctx, user.ID)
+	if err != nil {
+		return nil, "", err
+	}
+	if now.Sub(token.LastUsedAt) > lastUsedUpdateInterval {
+		if err := as.apiTokenRepo.UpdateLastUsedAt(ctx, token.ID, now); err != nil {
+			log.Error(err)
+		}
+	}
+	userInfo = &entity.UserCacheInfo{
+		UserID:      user.ID,
+		UserStatus:  user.Status,
+		EmailStatus: user.MailStatus,
+		RoleID:      roleID,
+	}
+	return userInfo, token.Scope, nil
+}
+
+func generateToken() (string, error) {
+	b := make([]byte, 24)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return TokenPrefix + hex.EncodeToString(b), nil
+}
+
+func hashToken(plainToken string) string {
+	sum := sha256.Sum256([]byte(plainToken))
+	return hex.EncodeToString(sum[:])
+}
+
+func formatAPIToken(token *entity.APIToken) *schema.GetAPITokenResp {
+	resp := &schema.GetAPITokenResp{
+		ID:          token.ID,
+		Name:        token.Name,
+		TokenPrefix: token.TokenPrefix,
+		Scope:       token.Scope,
+		CreatedAt:   token.CreatedAt.Unix(),
+	}
+	if !token.ExpiredAt.IsZero() {
+		resp.ExpiredAt = token.ExpiredAt.Unix()
+	}
+	if !token.LastUsedAt.IsZero() {
+		resp.LastUsedAt = token.LastUsedAt.Unix()
+	}
+	return resp
+}
@@ -0,0 +1,46 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package api_token
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestScopeAllowed(t *testing.T) {
+	assert.True(t, ScopeAllowed(entity.APITokenScopeRead, http.MethodGet, false))
+	assert.False(t, ScopeAllowed(entity.APITokenScopeRead, http.MethodPost, false))
+	assert.False(t, ScopeAllowed(entity.APITokenScopeRead, http.MethodGet, true))
+	assert.True(t, ScopeAllowed(entity.APITokenScopeWrite, http.MethodPost, false))
+	assert.False(t, ScopeAllowed(entity.APITokenScopeWrite, http.MethodGet, true))
+	assert.True(t, ScopeAllowed(entity.APITokenScopeAdmin, http.MethodDelete, true))
+	assert.False(t, ScopeAllowed("unknown", http.MethodGet, false))
+}
+
+func TestGenerateToken(t *testing.T) {
+	token, err := generateToken()
+	assert.NoError(t, err)
+	assert.True(t, IsAPIToken(token))
+	assert.Len(t, hashToken(token), 64)
+	assert.False(t, IsAPIToken("0b6a3f0e-5c3b-11ee-8c99-0242ac120002"))
+}
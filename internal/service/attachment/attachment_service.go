@@ -0,0 +1,118 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package attachment
+
+import (
+	"context"
+	"regexp"
+	"time"
+
+	"github.com/apache/incubator-answer/internal/base/constant"
+	"github.com/apache/incubator-answer/internal/base/reason"
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/pkg/obj"
+	"github.com/apache/incubator-answer/pkg/uid"
+	"github.com/segmentfault/pacman/errors"
+	"github.com/segmentfault/pacman/log"
+)
+
+// attachmentURLRegexp matches the download url of attachment in the content of post
+var attachmentURLRegexp = regexp.MustCompile(`/attachments/([0-9a-f]{32})\b`)
+
+//go:generate mockgen -source=./attachment_service.go -destination=../mock/attachment_repo_mock.go -package=mock
+
+// AttachmentRepo attachment repository
+type AttachmentRepo interface {
+	AddAttachment(ctx context.Context, attachment *entity.Attachment) (err error)
+	GetAttachmentByFileID(ctx context.Context, fileID string) (attachment *entity.Attachment, exist bool, err error)
+	IncreaseDownloadCount(ctx context.Context, id int64) (err error)
+	LinkAttachments(ctx context.Context, objectID string, fileIDs []string) (err error)
+	GetUnlinkedAttachments(ctx context.Context, createdBefore time.Time, lastID int64, limit int) (
+		attachments []*entity.Attachment, err error)
+	RemoveUnlinkedAttachment(ctx context.Context, id int64) (removed bool, err error)
+}
+
+// AttachmentService attachment service
+type AttachmentService struct {
+	attachmentRepo AttachmentRepo
+}
+
+// NewAttachmentService new attachment service
+func NewAttachmentService(attachmentRepo AttachmentRepo) *AttachmentService {
+	return &AttachmentService{
+		attachmentRepo: attachmentRepo,
+	}
+}
+
+// AddAttachment add attachment
+func (as *AttachmentService) AddAttachment(ctx context.Context, attachment *entity.Attachment) (err error) {
+	return as.attachmentRepo.AddAttachment(ctx, attachment)
+}
+
+// GetAttachment get attachment by the file id
+func (as *AttachmentService) GetAttachment(ctx context.Context, fileID string) (
+	attachment *entity.Attachment, err error) {
+	attachment, exist, err := as.attachmentRepo.GetAttachmentByFileID(ctx, fileID)
+	if err != nil {
+		return nil, err
+	}
+	if !exist {
+		return nil, errors.NotFound(reason.ObjectNotFound)
+	}
+	return attachment, nil
+}
+
+// IncreaseDownloadCount count the download of attachment
+func (as *AttachmentService) IncreaseDownloadCount(ctx context.Context, attachment *entity.Attachment) {
+	if err := as.attachmentRepo.IncreaseDownloadCount(ctx, attachment.ID); err != nil {
+		log.Error(err)
+	}
+}
+
+// LinkAttachments link the attachments used in the content to the question or answer,
+// the attachments already used in other posts are not changed
+func (as *AttachmentService) LinkAttachments(ctx context.Context, objectID, content string) {
+	objectType, err := obj.GetObjectTypeStrByObjectID(uid.DeShortID(objectID))
+	if err != nil || (objectType != constant.QuestionObjectType && objectType != constant.AnswerObjectType) {
+		return
+	}
+	fileIDs := make([]string, 0)
+	for _, match := range attachmentURLRegexp.FindAllStringSubmatch(content, -1) {
+		fileIDs = append(fileIDs, match[1])
+	}
+	if len(fileIDs) == 0 {
+		return
+	}
+	if err = as.attachmentRepo.LinkAttachments(ctx, uid.DeShortID(objectID), fileIDs); err != nil {
+		log.Error(err)
+	}
+}
+
+// GetUnlinkedAttachments get the attachments that are not used in any question or answer
+// and created before the time, ordered by id and start after the last id
+func (as *AttachmentService) GetUnlinkedAttachments(ctx context.Context, createdBefore time.Time, lastID int64,
+	limit int) (attachments []*entity.Attachment, err error) {
+	return as.attachmentRepo.GetUnlinkedAttachments(ctx, createdBefore, lastID, limit)
+}
+
+// RemoveUnlinkedAttachment remove the record of attachment, the attachment linked in the meantime is kept
+func (as *AttachmentService) RemoveUnlinkedAttachment(ctx context.Context, id int64) (removed bool, err error) {
+	return as.attachmentRepo.RemoveUnlinkedAttachment(ctx, id)
+}
@@ -0,0 +1,81 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package badge
+
+import (
+	"github.com/apache/incubator-answer/internal/base/constant"
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/plugin"
+)
+
+// builtinBadge the badge awarded when the counter of the user reaches the threshold
+type builtinBadge struct {
+	Name  string
+	Level int
+	// the activities that make the badge evaluated, empty means all activities
+	Triggers  []constant.ActivityTypeKey
+	Threshold int64
+	// PerTag the badge is awarded once for every tag that the user has enough upvoted answers in,
+	// the counter is not used
+	PerTag  bool
+	Counter func(stats *plugin.BadgeUserStats) int64
+}
+
+// TriggeredBy the badge should be evaluated for the activity, the empty activity means the back-fill job
+func (b *builtinBadge) TriggeredBy(activityTypeKey constant.ActivityTypeKey) bool {
+	if len(activityTypeKey) == 0 || len(b.Triggers) == 0 {
+		return true
+	}
+	for _, trigger := range b.Triggers {
+		if trigger == activityTypeKey {
+			return true
+		}
+	}
+	return false
+}
+
+var (
+	questionCounter = func(stats *plugin.BadgeUserStats) int64 { return int64(stats.QuestionCount) }
+	answerCounter   = func(stats *plugin.BadgeUserStats) int64 { return int64(stats.AnswerCount) }
+	acceptedCounter = func(stats *plugin.BadgeUserStats) int64 { return stats.AcceptedAnswerCount }
+	activeCounter   = func(stats *plugin.BadgeUserStats) int64 { return stats.ActiveDays }
+
+	builtinBadges = []*builtinBadge{
+		{Name: "first_question", Level: entity.BadgeLevelBronze, Threshold: 1, Counter: questionCounter,
+			Triggers: []constant.ActivityTypeKey{constant.ActQuestionAsked}},
+		{Name: "first_answer", Level: entity.BadgeLevelBronze, Threshold: 1, Counter: answerCounter,
+			Triggers: []constant.ActivityTypeKey{constant.ActAnswerAnswered}},
+		{Name: "first_accepted_answer", Level: entity.BadgeLevelBronze, Threshold: 1, Counter: acceptedCounter,
+			Triggers: []constant.ActivityTypeKey{constant.ActAnswerAccept}},
+		{Name: "accepted_answers_25", Level: entity.BadgeLevelSilver, Threshold: 25, Counter: acceptedCounter,
+			Triggers: []constant.ActivityTypeKey{constant.ActAnswerAccept}},
+		{Name: "accepted_answers_100", Level: entity.BadgeLevelGold, Threshold: 100, Counter: acceptedCounter,
+			Triggers: []constant.ActivityTypeKey{constant.ActAnswerAccept}},
+		{Name: "tag_bronze", Level: entity.BadgeLevelBronze, Threshold: 10, PerTag: true,
+			Triggers: []constant.ActivityTypeKey{constant.ActAnswerUpvote}},
+		{Name: "tag_silver", Level: entity.BadgeLevelSilver, Threshold: 50, PerTag: true,
+			Triggers: []constant.ActivityTypeKey{constant.ActAnswerUpvote}},
+		{Name: "tag_gold", Level: entity.BadgeLevelGold, Threshold: 200, PerTag: true,
+			Triggers: []constant.ActivityTypeKey{constant.ActAnswerUpvote}},
+		{Name: "active_30_days", Level: entity.BadgeLevelBronze, Threshold: 30, Counter: activeCounter},
+		{Name: "active_100_days", Level: entity.BadgeLevelSilver, Threshold: 100, Counter: activeCounter},
+		{Name: "active_365_days", Level: entity.BadgeLevelGold, Threshold: 365, Counter: activeCounter},
+	}
+)
@@ -0,0 +1,410 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package badge
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/apache/incubator-answer/internal/base/constant"
+	"github.com/apache/incubator-answer/internal/base/handler"
+	"github.com/apache/incubator-answer/internal/base/reason"
+	"github.com/apache/incubator-answer/internal/base/translator"
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/apache/incubator-answer/internal/service/badge_queue"
+	"github.com/apache/incubator-answer/internal/service/notice_queue"
+	"github.com/apache/incubator-answer/internal/service/tag_common"
+	usercommon "github.com/apache/incubator-answer/internal/service/user_common"
+	"github.com/apache/incubator-answer/plugin"
+	"github.com/gin-gonic/gin"
+	"github.com/segmentfault/pacman/errors"
+	"github.com/segmentfault/pacman/log"
+)
+
+//go:generate mockgen -source=./badge_service.go -destination=../mock/badge_repo_mock.go -package=mock
+
+// BadgeRepo user badge repository
+type BadgeRepo interface {
+	AddUserBadge(ctx context.Context, userBadge *entity.UserBadge) (added bool, err error)
+	GetUserBadgeList(ctx context.Context, userID string) (badgeList []*entity.UserBadge, err error)
+	GetUserIDsAfter(ctx context.Context, lastUserID string, limit int) (userIDs []string, err error)
+	CountAcceptedAnswer(ctx context.Context, userID string) (count int64, err error)
+	GetUpvotedAnswerTagStat(ctx context.Context, userID string) (tagStat []*entity.UserBadgeTagStat, err error)
+	CountActiveDays(ctx context.Context, userID string) (count int64, err error)
+}
+
+// BadgeService badge service
+type BadgeService struct {
+	badgeRepo                BadgeRepo
+	userRepo                 usercommon.UserRepo
+	tagCommonRepo            tag_common.TagCommonRepo
+	notificationQueueService notice_queue.NotificationQueueService
+	lock                     sync.Mutex
+	progress                 *schema.BadgeBackfillProgress
+}
+
+// NewBadgeService new badge service
+func NewBadgeService(
+	badgeRepo BadgeRepo,
+	userRepo usercommon.UserRepo,
+	tagCommonRepo tag_common.TagCommonRepo,
+	notificationQueueService notice_queue.NotificationQueueService,
+	badgeQueueService badge_queue.BadgeQueueService,
+) *BadgeService {
+	bs := &BadgeService{
+		badgeRepo:                badgeRepo,
+		userRepo:                 userRepo,
+		tagCommonRepo:            tagCommonRepo,
+		notificationQueueService: notificationQueueService,
+	}
+	badgeQueueService.RegisterHandler(bs.HandleBadgeEvent)
+	return bs
+}
+
+// HandleBadgeEvent evaluate the badges of the user after the activity
+func (bs *BadgeService) HandleBadgeEvent(ctx context.Context, msg *schema.BadgeEventMsg) error {
+	_, err := bs.EvaluateUser(ctx, msg.UserID, msg.ActivityTypeKey, msg.ObjectID)
+	return err
+}
+
+// EvaluateUser award the badges that the user has earned. Only the badges triggered by the activity are evaluated,
+// all badges are evaluated if the activity is empty.
+func (bs *BadgeService) EvaluateUser(ctx context.Context, userID string,
+	activityTypeKey constant.ActivityTypeKey, objectID string) (awarded int, err error) {
+	userInfo, exist, err := bs.userRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		return 0, err
+	}
+	if !exist || userInfo.Status == entity.UserStatusDeleted {
+		return 0, nil
+	}
+	ownedBadges, err := bs.badgeRepo.GetUserBadgeList(ctx, userID)
+	if err != nil {
+		return 0, err
+	}
+	owned := make(map[string]bool, len(ownedBadges))
+	for _, userBadge := range ownedBadges {
+		owned[userBadgeKey(userBadge.BadgeName, userBadge.ObjectID)] = true
+	}
+
+	candidates, err := bs.evaluateBuiltinBadges(ctx, userInfo, activityTypeKey, objectID, owned)
+	if err != nil {
+		return 0, err
+	}
+	for _, candidate := range candidates {
+		if owned[userBadgeKey(candidate.BadgeName, candidate.ObjectID)] {
+			continue
+		}
+		candidate.UserID = userID
+		added, err := bs.badgeRepo.AddUserBadge(ctx, candidate)
+		if err != nil {
+			return awarded, err
+		}
+		if !added {
+			continue
+		}
+		owned[userBadgeKey(candidate.BadgeName, candidate.ObjectID)] = true
+		awarded++
+		bs.notificationQueueService.Send(ctx, &schema.NotificationMsg{
+			TriggerUserID:      userID,
+			ReceiverUserID:     userID,
+			Type:               schema.NotificationTypeAchievement,
+			Title:              candidate.BadgeName,
+			ObjectID:           strconv.FormatInt(candidate.ID, 10),
+			ObjectType:         constant.BadgeObjectType,
+			NotificationAction: constant.NotificationEarnedBadge,
+		})
+	}
+	return awarded, nil
+}
+
+func (bs *BadgeService) evaluateBuiltinBadges(ctx context.Context, userInfo *entity.User,
+	activityTypeKey constant.ActivityTypeKey, objectID string, owned map[string]bool) (
+	candidates []*entity.UserBadge, err error) {
+	var stats *plugin.BadgeUserStats
+	var tagStat []*entity.UserBadgeTagStat
+	for _, badge := range builtinBadges {
+		if !badge.TriggeredBy(activityTypeKey) {
+			continue
+		}
+		if badge.PerTag {
+			if tagStat == nil {
+				tagStat, err = bs.badgeRepo.GetUpvotedAnswerTagStat(ctx, userInfo.ID)
+				if err != nil {
+					return nil, err
+				}
+			}
+			for _, stat := range tagStat {
+				if stat.AnswerAmount >= badge.Threshold {
+					candidates = append(candidates, &entity.UserBadge{
+						BadgeName: badge.Name, ObjectID: stat.TagID, Level: badge.Level})
+				}
+			}
+			continue
+		}
+		if owned[userBadgeKey(badge.Name, "0")] {
+			continue
+		}
+		if stats == nil {
+			stats, err = bs.getUserStats(ctx, userInfo)
+			if err != nil {
+				return nil, err
+			}
+		}
+		if badge.Counter(stats) >= badge.Threshold {
+			candidates = append(candidates, &entity.UserBadge{BadgeName: badge.Name, ObjectID: "0", Level: badge.Level})
+		}
+	}
+
+	// the plugins receive every activity, they decide which ones are interesting by themselves
+	event := &plugin.BadgeEvent{UserID: userInfo.ID, ActivityType: string(activityTypeKey), ObjectID: objectID}
+	err = plugin.CallBadge(func(fn plugin.Badge) (err error) {
+		if stats == nil {
+			stats, err = bs.getUserStats(ctx, userInfo)
+			if err != nil {
+				return err
+			}
+		}
+		event.Stats = *stats
+		definitions := make(map[string]plugin.BadgeDefinition)
+		for _, definition := range fn.Badges() {
+			definitions[definition.Name] = definition
+		}
+		for _, name := range fn.Evaluate(event) {
+			definition, ok := definitions[name]
+			if !ok {
+				log.Warnf("badge plugin %s awarded unknown badge %s", fn.Info().SlugName, name)
+				continue
+			}
+			candidates = append(candidates, &entity.UserBadge{
+				BadgeName: pluginBadgeName(fn.Info().SlugName, name), ObjectID: "0", Level: badgeLevel(definition.Level)})
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return candidates, nil
+}
+
+func (bs *BadgeService) getUserStats(ctx context.Context, userInfo *entity.User) (
+	stats *plugin.BadgeUserStats, err error) {
+	stats = &plugin.BadgeUserStats{
+		Rank:          userInfo.Rank,
+		QuestionCount: userInfo.QuestionCount,
+		AnswerCount:   userInfo.AnswerCount,
+	}
+	stats.AcceptedAnswerCount, err = bs.badgeRepo.CountAcceptedAnswer(ctx, userInfo.ID)
+	if err != nil {
+		return nil, err
+	}
+	stats.ActiveDays, err = bs.badgeRepo.CountActiveDays(ctx, userInfo.ID)
+	if err != nil {
+		return nil, err
+	}
+	return stats, nil
+}
+
+// GetBadgeList get all badges, the built-in badges first
+func (bs *BadgeService) GetBadgeList(ctx context.Context) (resp []*schema.BadgeInfo, err error) {
+	resp = make([]*schema.BadgeInfo, 0, len(builtinBadges))
+	for _, badge := range builtinBadges {
+		resp = append(resp, bs.formatBuiltinBadge(ctx, badge))
+	}
+	_ = plugin.CallBadge(func(fn plugin.Badge) error {
+		for _, definition := range fn.Badges() {
+			resp = append(resp, formatPluginBadge(ctx, fn.Info().SlugName, definition))
+		}
+		return nil
+	})
+	return resp, nil
+}
+
+// GetUserBadges get the badges of the user and the amount of them in every level
+func (bs *BadgeService) GetUserBadges(ctx context.Context, userID string) (
+	count *schema.UserBadgeCount, badges []*schema.UserBadgeInfo, err error) {
+	userBadges, err := bs.badgeRepo.GetUserBadgeList(ctx, userID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	definitions := make(map[string]*schema.BadgeInfo)
+	for _, badge := range builtinBadges {
+		definitions[badge.Name] = bs.formatBuiltinBadge(ctx, badge)
+	}
+	_ = plugin.CallBadge(func(fn plugin.Badge) error {
+		for _, definition := range fn.Badges() {
+			info := formatPluginBadge(ctx, fn.Info().SlugName, definition)
+			definitions[info.Name] = info
+		}
+		return nil
+	})
+
+	tagIDs := make([]string, 0)
+	for _, userBadge := range userBadges {
+		if userBadge.ObjectID != "0" {
+			tagIDs = append(tagIDs, userBadge.ObjectID)
+		}
+	}
+	tagNames := make(map[string]string, len(tagIDs))
+	if len(tagIDs) > 0 {
+		tagList, err := bs.tagCommonRepo.GetTagListByIDs(ctx, tagIDs)
+		if err != nil {
+			return nil, nil, err
+		}
+		for _, tag := range tagList {
+			tagNames[tag.ID] = tag.SlugName
+		}
+	}
+
+	count = &schema.UserBadgeCount{}
+	badges = make([]*schema.UserBadgeInfo, 0, len(userBadges))
+	for _, userBadge := range userBadges {
+		info := &schema.UserBadgeInfo{AwardedAt: userBadge.CreatedAt.Unix()}
+		if definition, ok := definitions[userBadge.BadgeName]; ok {
+			info.BadgeInfo = *definition
+		} else {
+			// the plugin that provides the badge is disabled
+			info.Name = userBadge.BadgeName
+			info.Title = userBadge.BadgeName
+			info.Level = schema.BadgeLevelMapping[userBadge.Level]
+		}
+		if userBadge.ObjectID != "0" {
+			info.ObjectID = userBadge.ObjectID
+			info.ObjectName = tagNames[userBadge.ObjectID]
+		}
+		switch userBadge.Level {
+		case entity.BadgeLevelGold:
+			count.Gold++
+		case entity.BadgeLevelSilver:
+			count.Silver++
+		default:
+			count.Bronze++
+		}
+		badges = append(badges, info)
+	}
+	return count, badges, nil
+}
+
+// StartBackfill evaluate the badges of all users in background
+func (bs *BadgeService) StartBackfill(ctx context.Context) (resp *schema.BadgeBackfillProgress, err error) {
+	if progress := bs.GetBackfillStatus(ctx); progress.Running {
+		return nil, errors.BadRequest(reason.BadgeBackfillIsRunning)
+	}
+	go bs.BackfillCron(context.Background())
+	return &schema.BadgeBackfillProgress{Running: true}, nil
+}
+
+// GetBackfillStatus get the progress of the running back-fill job, or the result of the last one
+func (bs *BadgeService) GetBackfillStatus(ctx context.Context) (resp *schema.BadgeBackfillProgress) {
+	bs.lock.Lock()
+	defer bs.lock.Unlock()
+	if bs.progress == nil {
+		return &schema.BadgeBackfillProgress{}
+	}
+	progress := *bs.progress
+	return &progress
+}
+
+// BackfillCron evaluate all badges of all users, the badges added after the users earned them are awarded by it
+func (bs *BadgeService) BackfillCron(ctx context.Context) {
+	bs.lock.Lock()
+	if bs.progress != nil && bs.progress.Running {
+		bs.lock.Unlock()
+		return
+	}
+	bs.progress = &schema.BadgeBackfillProgress{Running: true, StartedAt: time.Now().Unix()}
+	bs.lock.Unlock()
+	defer func() {
+		bs.lock.Lock()
+		bs.progress.Running = false
+		bs.progress.FinishedAt = time.Now().Unix()
+		bs.lock.Unlock()
+	}()
+
+	lastUserID := "0"
+	for {
+		userIDs, err := bs.badgeRepo.GetUserIDsAfter(ctx, lastUserID, schema.BadgeBackfillBatchSize)
+		if err != nil {
+			log.Errorf("get users for badge back-fill failed: %v", err)
+			return
+		}
+		for _, userID := range userIDs {
+			awarded, err := bs.EvaluateUser(ctx, userID, "", "")
+			if err != nil {
+				log.Errorf("evaluate badges of user %s failed: %v", userID, err)
+			}
+			bs.lock.Lock()
+			bs.progress.Users++
+			bs.progress.Awarded += awarded
+			bs.lock.Unlock()
+		}
+		if len(userIDs) < schema.BadgeBackfillBatchSize {
+			return
+		}
+		lastUserID = userIDs[len(userIDs)-1]
+	}
+}
+
+func (bs *BadgeService) formatBuiltinBadge(ctx context.Context, badge *builtinBadge) *schema.BadgeInfo {
+	lang := handler.GetLangByCtx(ctx)
+	return &schema.BadgeInfo{
+		Name:        badge.Name,
+		Level:       schema.BadgeLevelMapping[badge.Level],
+		Title:       translator.Tr(lang, fmt.Sprintf("badge.%s.title", badge.Name)),
+		Description: translator.Tr(lang, fmt.Sprintf("badge.%s.description", badge.Name)),
+	}
+}
+
+func formatPluginBadge(ctx context.Context, slugName string, definition plugin.BadgeDefinition) *schema.BadgeInfo {
+	info := &schema.BadgeInfo{
+		Name:  pluginBadgeName(slugName, definition.Name),
+		Level: schema.BadgeLevelMapping[badgeLevel(definition.Level)],
+		Title: definition.Name,
+	}
+	// the translator of plugins only works with the request context
+	if ginCtx, ok := ctx.(*gin.Context); ok {
+		info.Title = definition.Title.Translate(ginCtx)
+		info.Description = definition.Description.Translate(ginCtx)
+	}
+	return info
+}
+
+// pluginBadgeName the badges of plugins are prefixed with the plugin slug name, so they never conflict
+func pluginBadgeName(slugName, name string) string {
+	return slugName + ":" + name
+}
+
+func badgeLevel(level plugin.BadgeLevel) int {
+	for l, name := range schema.BadgeLevelMapping {
+		if name == level {
+			return l
+		}
+	}
+	return entity.BadgeLevelBronze
+}
+
+func userBadgeKey(badgeName, objectID string) string {
+	return badgeName + "#" + objectID
+}
@@ -0,0 +1,195 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package badge
+
+import (
+	"context"
+	"testing"
+
+	"github.com/apache/incubator-answer/internal/base/constant"
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/apache/incubator-answer/internal/service/mock"
+	"github.com/apache/incubator-answer/plugin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+// testBadgeEnv keeps the badges, the stats and the notifications of the mocked dependencies in memory
+type testBadgeEnv struct {
+	badges        []*entity.UserBadge
+	acceptedCount int64
+	activeDays    int64
+	tagStat       []*entity.UserBadgeTagStat
+	user          *entity.User
+	msgs          []*schema.NotificationMsg
+}
+
+func (e *testBadgeEnv) addUserBadge(ctx context.Context, userBadge *entity.UserBadge) (bool, error) {
+	for _, b := range e.badges {
+		if b.UserID == userBadge.UserID && b.BadgeName == userBadge.BadgeName && b.ObjectID == userBadge.ObjectID {
+			return false, nil
+		}
+	}
+	userBadge.ID = int64(len(e.badges) + 1)
+	e.badges = append(e.badges, userBadge)
+	return true, nil
+}
+
+func newTestBadgeService(t *testing.T, env *testBadgeEnv) *BadgeService {
+	ctl := gomock.NewController(t)
+
+	badgeRepo := mock.NewMockBadgeRepo(ctl)
+	badgeRepo.EXPECT().AddUserBadge(gomock.Any(), gomock.Any()).DoAndReturn(env.addUserBadge).AnyTimes()
+	badgeRepo.EXPECT().GetUserBadgeList(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, userID string) ([]*entity.UserBadge, error) {
+			return env.badges, nil
+		}).AnyTimes()
+	badgeRepo.EXPECT().GetUserIDsAfter(gomock.Any(), "0", gomock.Any()).Return([]string{"1"}, nil).AnyTimes()
+	badgeRepo.EXPECT().GetUserIDsAfter(gomock.Any(), gomock.Not("0"), gomock.Any()).Return(nil, nil).AnyTimes()
+	badgeRepo.EXPECT().CountAcceptedAnswer(gomock.Any(), gomock.Any()).Return(env.acceptedCount, nil).AnyTimes()
+	badgeRepo.EXPECT().GetUpvotedAnswerTagStat(gomock.Any(), gomock.Any()).Return(env.tagStat, nil).AnyTimes()
+	badgeRepo.EXPECT().CountActiveDays(gomock.Any(), gomock.Any()).Return(env.activeDays, nil).AnyTimes()
+
+	userRepo := mock.NewMockUserRepo(ctl)
+	userRepo.EXPECT().GetByUserID(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, userID string) (*entity.User, bool, error) {
+			return env.user, env.user.ID == userID, nil
+		}).AnyTimes()
+
+	tagCommonRepo := mock.NewMockTagCommonRepo(ctl)
+	tagCommonRepo.EXPECT().GetTagListByIDs(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, ids []string) ([]*entity.Tag, error) {
+			tagList := make([]*entity.Tag, 0)
+			for _, id := range ids {
+				tagList = append(tagList, &entity.Tag{ID: id, SlugName: "tag-" + id})
+			}
+			return tagList, nil
+		}).AnyTimes()
+
+	notificationQueue := mock.NewMockNotificationQueueService(ctl)
+	notificationQueue.EXPECT().Send(gomock.Any(), gomock.Any()).Do(
+		func(ctx context.Context, msg *schema.NotificationMsg) {
+			env.msgs = append(env.msgs, msg)
+		}).AnyTimes()
+
+	badgeQueue := mock.NewMockBadgeQueueService(ctl)
+	badgeQueue.EXPECT().Send(gomock.Any(), gomock.Any()).AnyTimes()
+	badgeQueue.EXPECT().RegisterHandler(gomock.Any()).AnyTimes()
+
+	return NewBadgeService(badgeRepo, userRepo, tagCommonRepo, notificationQueue, badgeQueue)
+}
+
+// testBadgePlugin is a badge plugin registered for the test
+type testBadgePlugin struct{}
+
+func (m *testBadgePlugin) Info() plugin.Info {
+	return plugin.Info{SlugName: "mock_badge"}
+}
+func (m *testBadgePlugin) Badges() []plugin.BadgeDefinition {
+	return []plugin.BadgeDefinition{{Name: "high_rank", Level: plugin.BadgeLevelGold}}
+}
+func (m *testBadgePlugin) Evaluate(event *plugin.BadgeEvent) []string {
+	if event.Stats.Rank >= 1000 {
+		return []string{"high_rank", "unknown"}
+	}
+	return nil
+}
+
+func TestBuiltinBadge_TriggeredBy(t *testing.T) {
+	badge := &builtinBadge{Triggers: []constant.ActivityTypeKey{constant.ActAnswerAccept}}
+	assert.True(t, badge.TriggeredBy(constant.ActAnswerAccept))
+	assert.True(t, badge.TriggeredBy(""))
+	assert.False(t, badge.TriggeredBy(constant.ActQuestionAsked))
+	assert.True(t, (&builtinBadge{}).TriggeredBy(constant.ActQuestionAsked))
+}
+
+func TestBadgeService_EvaluateUser(t *testing.T) {
+	ctx := context.Background()
+	env := &testBadgeEnv{acceptedCount: 1, user: &entity.User{ID: "1", QuestionCount: 1, AnswerCount: 1}}
+	bs := newTestBadgeService(t, env)
+
+	// only the badges triggered by the activity are evaluated
+	awarded, err := bs.EvaluateUser(ctx, "1", constant.ActAnswerAccept, "10020000000000001")
+	assert.NoError(t, err)
+	assert.Equal(t, 1, awarded)
+	assert.Equal(t, "first_accepted_answer", env.badges[0].BadgeName)
+	assert.Equal(t, "0", env.badges[0].ObjectID)
+	assert.Len(t, env.msgs, 1)
+	assert.Equal(t, constant.BadgeObjectType, env.msgs[0].ObjectType)
+	assert.Equal(t, "1", env.msgs[0].ObjectID)
+
+	// the badge is awarded once
+	awarded, err = bs.EvaluateUser(ctx, "1", constant.ActAnswerAccept, "10020000000000002")
+	assert.NoError(t, err)
+	assert.Equal(t, 0, awarded)
+
+	// deleted or missing users are ignored
+	awarded, err = bs.EvaluateUser(ctx, "2", "", "")
+	assert.NoError(t, err)
+	assert.Equal(t, 0, awarded)
+}
+
+func TestBadgeService_BackfillCron(t *testing.T) {
+	ctx := context.Background()
+	env := &testBadgeEnv{activeDays: 30, tagStat: []*entity.UserBadgeTagStat{
+		{TagID: "101", AnswerAmount: 10},
+		{TagID: "102", AnswerAmount: 9},
+		{TagID: "103", AnswerAmount: 50},
+	}, user: &entity.User{ID: "1", QuestionCount: 1}}
+	bs := newTestBadgeService(t, env)
+
+	bs.BackfillCron(ctx)
+	progress := bs.GetBackfillStatus(ctx)
+	assert.False(t, progress.Running)
+	assert.Equal(t, 1, progress.Users)
+	assert.Equal(t, 5, progress.Awarded)
+	assert.Len(t, env.msgs, 5)
+
+	count, badges, err := bs.GetUserBadges(ctx, "1")
+	assert.NoError(t, err)
+	assert.Equal(t, &schema.UserBadgeCount{Silver: 1, Bronze: 4}, count)
+	names := make([]string, 0)
+	for _, badge := range badges {
+		names = append(names, badge.Name+"/"+badge.ObjectName)
+	}
+	assert.ElementsMatch(t, []string{"first_question/", "tag_bronze/tag-101", "tag_bronze/tag-103",
+		"tag_silver/tag-103", "active_30_days/"}, names)
+}
+
+func TestBadgeService_PluginBadge(t *testing.T) {
+	ctx := context.Background()
+	plugin.Register(&testBadgePlugin{})
+	plugin.StatusManager.Enable("mock_badge", true)
+	defer plugin.StatusManager.Enable("mock_badge", false)
+
+	env := &testBadgeEnv{user: &entity.User{ID: "1", Rank: 1000}}
+	bs := newTestBadgeService(t, env)
+	awarded, err := bs.EvaluateUser(ctx, "1", constant.ActQuestionAsked, "10010000000000001")
+	assert.NoError(t, err)
+	assert.Equal(t, 1, awarded)
+	assert.Equal(t, "mock_badge:high_rank", env.badges[0].BadgeName)
+	assert.Equal(t, entity.BadgeLevelGold, env.badges[0].Level)
+
+	badgeList, err := bs.GetBadgeList(ctx)
+	assert.NoError(t, err)
+	assert.Len(t, badgeList, len(builtinBadges)+1)
+	assert.Equal(t, plugin.BadgeLevelGold, badgeList[len(badgeList)-1].Level)
+}
@@ -0,0 +1,65 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package badge_queue
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/apache/incubator-answer/internal/service/job_queue"
+	"github.com/segmentfault/pacman/log"
+)
+
+// BadgeEventQueueName the name of the persisted queue
+const BadgeEventQueueName = "badge_event"
+
+//go:generate mockgen -source=./badge_queue.go -destination=../mock/badge_queue_service_mock.go -package=mock
+type BadgeQueueService interface {
+	Send(ctx context.Context, msg *schema.BadgeEventMsg)
+	RegisterHandler(handler func(ctx context.Context, msg *schema.BadgeEventMsg) error)
+}
+
+type badgeQueueService struct {
+	jobQueueService *job_queue.JobQueueService
+}
+
+func (ns *badgeQueueService) Send(ctx context.Context, msg *schema.BadgeEventMsg) {
+	if err := ns.jobQueueService.Enqueue(ctx, BadgeEventQueueName, msg); err != nil {
+		log.Errorf("send badge event failed: %v", err)
+	}
+}
+
+func (ns *badgeQueueService) RegisterHandler(
+	handler func(ctx context.Context, msg *schema.BadgeEventMsg) error) {
+	ns.jobQueueService.RegisterHandler(BadgeEventQueueName, func(ctx context.Context, payload []byte) error {
+		msg := &schema.BadgeEventMsg{}
+		if err := json.Unmarshal(payload, msg); err != nil {
+			return job_queue.Permanent(err)
+		}
+		log.Debugf("received badge event %+v", msg)
+		return handler(ctx, msg)
+	})
+}
+
+// NewBadgeQueueService create a new badge event queue service
+func NewBadgeQueueService(jobQueueService *job_queue.JobQueueService) BadgeQueueService {
+	return &badgeQueueService{jobQueueService: jobQueueService}
+}
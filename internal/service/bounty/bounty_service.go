@@ -0,0 +1,247 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package bounty
+
+import (
+	"context"
+	"time"
+
+	"github.com/apache/incubator-answer/internal/base/constant"
+	"github.com/apache/incubator-answer/internal/base/handler"
+	"github.com/apache/incubator-answer/internal/base/reason"
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/apache/incubator-answer/internal/service/activity"
+	answercommon "github.com/apache/incubator-answer/internal/service/answer_common"
+	"github.com/apache/incubator-answer/internal/service/notice_queue"
+	questioncommon "github.com/apache/incubator-answer/internal/service/question_common"
+	usercommon "github.com/apache/incubator-answer/internal/service/user_common"
+	"github.com/apache/incubator-answer/pkg/converter"
+	"github.com/apache/incubator-answer/pkg/uid"
+	"github.com/segmentfault/pacman/errors"
+	"github.com/segmentfault/pacman/log"
+)
+
+//go:generate mockgen -source=./bounty_service.go -destination=../mock/bounty_repo_mock.go -package=mock
+
+// BountyRepo question bounty repository
+type BountyRepo interface {
+	GetActiveBounty(ctx context.Context, questionID string) (bounty *entity.QuestionBounty, exist bool, err error)
+	GetActiveBountyListByQuestionIDs(ctx context.Context, questionIDs []string) (bountyList []*entity.QuestionBounty, err error)
+	GetBountyListByQuestionID(ctx context.Context, questionID string) (bountyList []*entity.QuestionBounty, err error)
+	GetExpiredBountyList(ctx context.Context, deadline time.Time, limit int) (bountyList []*entity.QuestionBounty, err error)
+}
+
+// BountyService question bounty service
+type BountyService struct {
+	bountyRepo               BountyRepo
+	bountyActivityRepo       activity.BountyActivityRepo
+	questionRepo             questioncommon.QuestionRepo
+	answerRepo               answercommon.AnswerRepo
+	userCommon               *usercommon.UserCommon
+	notificationQueueService notice_queue.NotificationQueueService
+}
+
+// NewBountyService new bounty service
+func NewBountyService(
+	bountyRepo BountyRepo,
+	bountyActivityRepo activity.BountyActivityRepo,
+	questionRepo questioncommon.QuestionRepo,
+	answerRepo answercommon.AnswerRepo,
+	userCommon *usercommon.UserCommon,
+	notificationQueueService notice_queue.NotificationQueueService,
+) *BountyService {
+	return &BountyService{
+		bountyRepo:               bountyRepo,
+		bountyActivityRepo:       bountyActivityRepo,
+		questionRepo:             questionRepo,
+		answerRepo:               answerRepo,
+		userCommon:               userCommon,
+		notificationQueueService: notificationQueueService,
+	}
+}
+
+// OfferBounty offer a bounty on the question, the amount is deducted from the user's reputation
+func (bs *BountyService) OfferBounty(ctx context.Context, req *schema.OfferBountyReq) (err error) {
+	if req.Amount < schema.BountyMinAmount || req.Amount > schema.BountyMaxAmount {
+		return errors.BadRequest(reason.BountyAmountInvalid)
+	}
+	questionID := uid.DeShortID(req.QuestionID)
+	questionInfo, exist, err := bs.questionRepo.GetQuestion(ctx, questionID)
+	if err != nil {
+		return err
+	}
+	if !exist {
+		return errors.BadRequest(reason.QuestionNotFound)
+	}
+	if questionInfo.Status != entity.QuestionStatusAvailable || questionInfo.Show != entity.QuestionShow {
+		return errors.BadRequest(reason.BountyQuestionNotOpen)
+	}
+
+	return bs.bountyActivityRepo.OfferBounty(ctx, &entity.QuestionBounty{
+		QuestionID:      questionID,
+		UserID:          req.UserID,
+		Amount:          req.Amount,
+		ExpiresAt:       time.Now().Add(schema.BountyDuration),
+		AwardedAnswerID: "0",
+		AwardedUserID:   "0",
+	})
+}
+
+// AwardBounty award the active bounty of the question to an answer, only the offering user can award it
+func (bs *BountyService) AwardBounty(ctx context.Context, req *schema.AwardBountyReq) (err error) {
+	questionID := uid.DeShortID(req.QuestionID)
+	answerID := uid.DeShortID(req.AnswerID)
+	bounty, exist, err := bs.bountyRepo.GetActiveBounty(ctx, questionID)
+	if err != nil {
+		return err
+	}
+	if !exist || bounty.ExpiresAt.Before(time.Now()) {
+		return errors.BadRequest(reason.BountyNotFound)
+	}
+	if bounty.UserID != req.UserID {
+		return errors.Forbidden(reason.ForbiddenError)
+	}
+
+	answerInfo, exist, err := bs.answerRepo.GetAnswer(ctx, answerID)
+	if err != nil {
+		return err
+	}
+	if !exist || answerInfo.Status == entity.AnswerStatusDeleted ||
+		uid.DeShortID(answerInfo.QuestionID) != questionID {
+		return errors.BadRequest(reason.AnswerNotFound)
+	}
+	if answerInfo.UserID == req.UserID {
+		return errors.BadRequest(reason.BountyCannotAwardOwnAnswer)
+	}
+
+	questionInfo, exist, err := bs.questionRepo.GetQuestion(ctx, questionID)
+	if err != nil {
+		return err
+	}
+	if !exist {
+		return errors.BadRequest(reason.QuestionNotFound)
+	}
+
+	bounty.AwardedAnswerID = answerID
+	bounty.AwardedUserID = answerInfo.UserID
+	if err = bs.bountyActivityRepo.AwardBounty(ctx, bounty); err != nil {
+		return err
+	}
+
+	// the reputation is counted in the achievement of the answer, the award itself goes to the inbox
+	bs.notificationQueueService.Send(ctx, &schema.NotificationMsg{
+		TriggerUserID:      req.UserID,
+		ReceiverUserID:     answerInfo.UserID,
+		Type:               schema.NotificationTypeInbox,
+		Title:              questionInfo.Title,
+		ObjectID:           answerID,
+		ObjectType:         constant.AnswerObjectType,
+		NotificationAction: constant.NotificationBountyAwarded,
+	})
+	return nil
+}
+
+// GetQuestionBounty get the active bounty and the bounty history of the question
+func (bs *BountyService) GetQuestionBounty(ctx context.Context, req *schema.GetQuestionBountyReq) (
+	resp *schema.GetQuestionBountyResp, err error) {
+	bountyList, err := bs.bountyRepo.GetBountyListByQuestionID(ctx, uid.DeShortID(req.QuestionID))
+	if err != nil {
+		return nil, err
+	}
+
+	userIDs := make([]string, 0)
+	for _, bounty := range bountyList {
+		userIDs = append(userIDs, bounty.UserID)
+		if bounty.Status == entity.QuestionBountyStatusAwarded {
+			userIDs = append(userIDs, bounty.AwardedUserID)
+		}
+	}
+	userInfoMapping, err := bs.userCommon.BatchUserBasicInfoByID(ctx, userIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	resp = &schema.GetQuestionBountyResp{History: make([]*schema.QuestionBountyInfo, 0)}
+	for _, bounty := range bountyList {
+		info := &schema.QuestionBountyInfo{
+			ID:        converter.IntToString(bounty.ID),
+			Amount:    bounty.Amount,
+			CreatedAt: bounty.CreatedAt.Unix(),
+			ExpiresAt: bounty.ExpiresAt.Unix(),
+			UserInfo:  userInfoMapping[bounty.UserID],
+		}
+		switch bounty.Status {
+		case entity.QuestionBountyStatusActive:
+			info.Status = schema.BountyStatusActive
+			resp.Active = info
+		case entity.QuestionBountyStatusAwarded:
+			info.Status = schema.BountyStatusAwarded
+			info.AwardedAnswerID = bounty.AwardedAnswerID
+			if handler.GetEnableShortID(ctx) {
+				info.AwardedAnswerID = uid.EnShortID(info.AwardedAnswerID)
+			}
+			info.AwardedUserInfo = userInfoMapping[bounty.AwardedUserID]
+			info.AwardedAt = bounty.AwardedAt.Unix()
+		case entity.QuestionBountyStatusExpired:
+			info.Status = schema.BountyStatusExpired
+		}
+		resp.History = append(resp.History, info)
+	}
+	return resp, nil
+}
+
+// GetActiveBountyAmountMapping get the active bounty amount of the questions, the key is question id
+func (bs *BountyService) GetActiveBountyAmountMapping(ctx context.Context, questionIDs []string) (
+	mapping map[string]int, err error) {
+	mapping = make(map[string]int, len(questionIDs))
+	ids := make([]string, 0, len(questionIDs))
+	for _, id := range questionIDs {
+		ids = append(ids, uid.DeShortID(id))
+	}
+	bountyList, err := bs.bountyRepo.GetActiveBountyListByQuestionIDs(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	for _, bounty := range bountyList {
+		mapping[bounty.QuestionID] = bounty.Amount
+	}
+	return mapping, nil
+}
+
+// ExpireBountyCron expire the bounties that were not awarded in time
+func (bs *BountyService) ExpireBountyCron(ctx context.Context) {
+	for {
+		bountyList, err := bs.bountyRepo.GetExpiredBountyList(ctx, time.Now(), schema.BountyExpireBatchSize)
+		if err != nil {
+			log.Error(err)
+			return
+		}
+		for _, bounty := range bountyList {
+			if err = bs.bountyActivityRepo.ExpireBounty(ctx, bounty); err != nil {
+				log.Errorf("expire bounty %d failed: %v", bounty.ID, err)
+				return
+			}
+		}
+		if len(bountyList) < schema.BountyExpireBatchSize {
+			return
+		}
+	}
+}
@@ -0,0 +1,240 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package bounty
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/apache/incubator-answer/internal/base/constant"
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/apache/incubator-answer/internal/service/mock"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	testQuestionID    = "10010000000000001"
+	testAnswerID      = "10020000000000001"
+	testOwnAnswerID   = "10020000000000002"
+	testOtherAnswerID = "10020000000000003"
+)
+
+// testBountyEnv keeps the bounties, the question and the notifications of the mocked dependencies in memory
+type testBountyEnv struct {
+	bountyList []*entity.QuestionBounty
+	question   *entity.Question
+	msgList    []*schema.NotificationMsg
+}
+
+func (e *testBountyEnv) getActiveBounty(ctx context.Context, questionID string) (*entity.QuestionBounty, bool, error) {
+	for _, bounty := range e.bountyList {
+		if bounty.QuestionID == questionID && bounty.Status == entity.QuestionBountyStatusActive {
+			return bounty, true, nil
+		}
+	}
+	return nil, false, nil
+}
+
+func (e *testBountyEnv) getExpiredBountyList(ctx context.Context, deadline time.Time, limit int) (
+	bountyList []*entity.QuestionBounty, err error) {
+	for _, bounty := range e.bountyList {
+		if bounty.Status == entity.QuestionBountyStatusActive && bounty.ExpiresAt.Before(deadline) && len(bountyList) < limit {
+			bountyList = append(bountyList, bounty)
+		}
+	}
+	return bountyList, nil
+}
+
+func (e *testBountyEnv) offerBounty(ctx context.Context, bounty *entity.QuestionBounty) error {
+	bounty.ID = int64(len(e.bountyList) + 1)
+	bounty.Status = entity.QuestionBountyStatusActive
+	e.bountyList = append(e.bountyList, bounty)
+	return nil
+}
+
+func (e *testBountyEnv) getQuestion(ctx context.Context, id string) (*entity.Question, bool, error) {
+	if e.question.ID != id {
+		return nil, false, nil
+	}
+	return e.question, true, nil
+}
+
+func getTestAnswer(ctx context.Context, id string) (*entity.Answer, bool, error) {
+	switch id {
+	case testAnswerID:
+		return &entity.Answer{ID: id, QuestionID: testQuestionID, UserID: "2", Status: entity.AnswerStatusAvailable}, true, nil
+	case testOwnAnswerID:
+		return &entity.Answer{ID: id, QuestionID: testQuestionID, UserID: "1", Status: entity.AnswerStatusAvailable}, true, nil
+	case testOtherAnswerID:
+		return &entity.Answer{ID: id, QuestionID: "10010000000000009", UserID: "3", Status: entity.AnswerStatusAvailable}, true, nil
+	}
+	return nil, false, nil
+}
+
+func newTestBountyService(t *testing.T) (*BountyService, *testBountyEnv) {
+	ctl := gomock.NewController(t)
+	env := &testBountyEnv{question: &entity.Question{
+		ID:     testQuestionID,
+		UserID: "1",
+		Title:  "How to offer a bounty?",
+		Status: entity.QuestionStatusAvailable,
+		Show:   entity.QuestionShow,
+	}}
+
+	bountyRepo := mock.NewMockBountyRepo(ctl)
+	bountyRepo.EXPECT().GetActiveBounty(gomock.Any(), gomock.Any()).DoAndReturn(env.getActiveBounty).AnyTimes()
+	bountyRepo.EXPECT().GetExpiredBountyList(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(env.getExpiredBountyList).AnyTimes()
+
+	bountyActivityRepo := mock.NewMockBountyActivityRepo(ctl)
+	bountyActivityRepo.EXPECT().OfferBounty(gomock.Any(), gomock.Any()).DoAndReturn(env.offerBounty).AnyTimes()
+	bountyActivityRepo.EXPECT().AwardBounty(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, bounty *entity.QuestionBounty) error {
+			bounty.Status = entity.QuestionBountyStatusAwarded
+			bounty.AwardedAt = time.Now()
+			return nil
+		}).AnyTimes()
+	bountyActivityRepo.EXPECT().ExpireBounty(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, bounty *entity.QuestionBounty) error {
+			bounty.Status = entity.QuestionBountyStatusExpired
+			return nil
+		}).AnyTimes()
+
+	questionRepo := mock.NewMockQuestionRepo(ctl)
+	questionRepo.EXPECT().GetQuestion(gomock.Any(), gomock.Any()).DoAndReturn(env.getQuestion).AnyTimes()
+
+	answerRepo := mock.NewMockAnswerRepo(ctl)
+	answerRepo.EXPECT().GetAnswer(gomock.Any(), gomock.Any()).DoAndReturn(getTestAnswer).AnyTimes()
+
+	notificationQueue := mock.NewMockNotificationQueueService(ctl)
+	notificationQueue.EXPECT().Send(gomock.Any(), gomock.Any()).Do(
+		func(ctx context.Context, msg *schema.NotificationMsg) {
+			env.msgList = append(env.msgList, msg)
+		}).AnyTimes()
+
+	bs := NewBountyService(bountyRepo, bountyActivityRepo, questionRepo, answerRepo, nil, notificationQueue)
+	return bs, env
+}
+
+func TestBountyService_OfferBounty(t *testing.T) {
+	ctx := context.TODO()
+	bs, env := newTestBountyService(t)
+
+	err := bs.OfferBounty(ctx, &schema.OfferBountyReq{QuestionID: testQuestionID, Amount: schema.BountyMinAmount - 1, UserID: "1"})
+	assert.Error(t, err)
+	err = bs.OfferBounty(ctx, &schema.OfferBountyReq{QuestionID: testQuestionID, Amount: schema.BountyMaxAmount + 1, UserID: "1"})
+	assert.Error(t, err)
+	err = bs.OfferBounty(ctx, &schema.OfferBountyReq{QuestionID: "10010000000000009", Amount: 100, UserID: "1"})
+	assert.Error(t, err)
+
+	env.question.Status = entity.QuestionStatusClosed
+	err = bs.OfferBounty(ctx, &schema.OfferBountyReq{QuestionID: testQuestionID, Amount: 100, UserID: "1"})
+	assert.Error(t, err)
+	assert.Empty(t, env.bountyList)
+
+	env.question.Status = entity.QuestionStatusAvailable
+	err = bs.OfferBounty(ctx, &schema.OfferBountyReq{QuestionID: testQuestionID, Amount: 100, UserID: "1"})
+	assert.NoError(t, err)
+	assert.Len(t, env.bountyList, 1)
+	bounty := env.bountyList[0]
+	assert.Equal(t, testQuestionID, bounty.QuestionID)
+	assert.Equal(t, 100, bounty.Amount)
+	assert.WithinDuration(t, time.Now().Add(schema.BountyDuration), bounty.ExpiresAt, time.Minute)
+}
+
+func TestBountyService_AwardBounty(t *testing.T) {
+	ctx := context.TODO()
+	bs, env := newTestBountyService(t)
+
+	// no active bounty
+	err := bs.AwardBounty(ctx, &schema.AwardBountyReq{QuestionID: testQuestionID, AnswerID: testAnswerID, UserID: "1"})
+	assert.Error(t, err)
+
+	assert.NoError(t, bs.OfferBounty(ctx, &schema.OfferBountyReq{QuestionID: testQuestionID, Amount: 100, UserID: "1"}))
+	// only the offering user can award it
+	err = bs.AwardBounty(ctx, &schema.AwardBountyReq{QuestionID: testQuestionID, AnswerID: testAnswerID, UserID: "2"})
+	assert.Error(t, err)
+	// the answer of another question
+	err = bs.AwardBounty(ctx, &schema.AwardBountyReq{QuestionID: testQuestionID, AnswerID: testOtherAnswerID, UserID: "1"})
+	assert.Error(t, err)
+	// the own answer
+	err = bs.AwardBounty(ctx, &schema.AwardBountyReq{QuestionID: testQuestionID, AnswerID: testOwnAnswerID, UserID: "1"})
+	assert.Error(t, err)
+	assert.Equal(t, entity.QuestionBountyStatusActive, env.bountyList[0].Status)
+	assert.Empty(t, env.msgList)
+
+	err = bs.AwardBounty(ctx, &schema.AwardBountyReq{QuestionID: testQuestionID, AnswerID: testAnswerID, UserID: "1"})
+	assert.NoError(t, err)
+	bounty := env.bountyList[0]
+	assert.Equal(t, entity.QuestionBountyStatusAwarded, bounty.Status)
+	assert.Equal(t, testAnswerID, bounty.AwardedAnswerID)
+	assert.Equal(t, "2", bounty.AwardedUserID)
+
+	assert.Len(t, env.msgList, 1)
+	msg := env.msgList[0]
+	assert.Equal(t, "2", msg.ReceiverUserID)
+	assert.Equal(t, constant.NotificationBountyAwarded, msg.NotificationAction)
+	assert.Equal(t, "How to offer a bounty?", msg.Title)
+
+	// the bounty can only be awarded once
+	err = bs.AwardBounty(ctx, &schema.AwardBountyReq{QuestionID: testQuestionID, AnswerID: testAnswerID, UserID: "1"})
+	assert.Error(t, err)
+}
+
+func TestBountyService_ExpireBounty(t *testing.T) {
+	ctx := context.TODO()
+	bs, env := newTestBountyService(t)
+
+	// more expired bounties than one batch
+	for i := 0; i < schema.BountyExpireBatchSize+1; i++ {
+		env.bountyList = append(env.bountyList, &entity.QuestionBounty{
+			ID:         int64(i + 1),
+			QuestionID: testQuestionID,
+			Status:     entity.QuestionBountyStatusActive,
+			ExpiresAt:  time.Now().Add(-time.Hour),
+		})
+	}
+	env.bountyList = append(env.bountyList, &entity.QuestionBounty{
+		ID:         int64(schema.BountyExpireBatchSize + 2),
+		QuestionID: testQuestionID,
+		Status:     entity.QuestionBountyStatusActive,
+		ExpiresAt:  time.Now().Add(time.Hour),
+	})
+
+	bs.ExpireBountyCron(ctx)
+	for _, bounty := range env.bountyList[:schema.BountyExpireBatchSize+1] {
+		assert.Equal(t, entity.QuestionBountyStatusExpired, bounty.Status)
+	}
+	assert.Equal(t, entity.QuestionBountyStatusActive, env.bountyList[schema.BountyExpireBatchSize+1].Status)
+
+	// the expired but not yet processed bounty can not be awarded
+	env.bountyList = []*entity.QuestionBounty{{
+		ID:         1,
+		QuestionID: testQuestionID,
+		UserID:     "1",
+		Status:     entity.QuestionBountyStatusActive,
+		ExpiresAt:  time.Now().Add(-time.Minute),
+	}}
+	err := bs.AwardBounty(ctx, &schema.AwardBountyReq{QuestionID: testQuestionID, AnswerID: testAnswerID, UserID: "1"})
+	assert.Error(t, err)
+}
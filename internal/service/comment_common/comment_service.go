@@ -28,6 +28,8 @@ import (
 	"github.com/segmentfault/pacman/errors"
 )
 
+//go:generate mockgen -source=./comment_service.go -destination=../mock/comment_common_repo_mock.go -package=mock
+
 // CommentCommonRepo comment repository
 type CommentCommonRepo interface {
 	GetComment(ctx context.Context, commentID string) (comment *entity.Comment, exist bool, err error)
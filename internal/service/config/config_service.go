@@ -27,6 +27,8 @@ import (
 	"github.com/apache/incubator-answer/internal/entity"
 )
 
+//go:generate mockgen -source=./config_service.go -destination=../mock/config_repo_mock.go -package=mock
+
 // ConfigRepo config repository
 type ConfigRepo interface {
 	GetConfigByID(ctx context.Context, id int) (c *entity.Config, err error)
@@ -36,6 +36,7 @@ import (
 	"github.com/apache/incubator-answer/internal/schema"
 	"github.com/apache/incubator-answer/internal/service/activity"
 	"github.com/apache/incubator-answer/internal/service/activity_queue"
+	"github.com/apache/incubator-answer/internal/service/bounty"
 	collectioncommon "github.com/apache/incubator-answer/internal/service/collection_common"
 	"github.com/apache/incubator-answer/internal/service/config"
 	"github.com/apache/incubator-answer/internal/service/export"
@@ -84,6 +85,7 @@ type QuestionService struct {
 	newQuestionNotificationService   *notification.ExternalNotificationService
 	reviewService                    *review.ReviewService
 	configService                    *config.ConfigService
+	bountyService                    *bounty.BountyService
 }
 
 func NewQuestionService(
@@ -106,6 +108,7 @@ func NewQuestionService(
 	newQuestionNotificationService *notification.ExternalNotificationService,
 	reviewService *review.ReviewService,
 	configService *config.ConfigService,
+	bountyService *bounty.BountyService,
 ) *QuestionService {
 	return &QuestionService{
 		questionRepo:                     questionRepo,
@@ -127,6 +130,7 @@ func NewQuestionService(
 		newQuestionNotificationService:   newQuestionNotificationService,
 		reviewService:                    reviewService,
 		configService:                    configService,
+		bountyService:                    bountyService,
 	}
 }
 
@@ -1312,11 +1316,10 @@ func (qs *QuestionService) GetQuestionPage(ctx context.Context, req *schema.Ques
 			return nil, 0, err
 		}
 		if exist {
-			synTagIds, err := qs.tagCommon.GetTagIDsByMainTagID(ctx, tagInfo.ID)
+			tagIDs, err = qs.tagCommon.GetTagIDsWithDescendants(ctx, tagInfo.ID)
 			if err != nil {
 				return nil, 0, err
 			}
-			tagIDs = append(synTagIds, tagInfo.ID)
 		}
 	}
 
@@ -1345,6 +1348,18 @@ func (qs *QuestionService) GetQuestionPage(ctx context.Context, req *schema.Ques
 	if err != nil {
 		return nil, 0, err
 	}
+
+	questionIDs := make([]string, 0, len(questions))
+	for _, item := range questions {
+		questionIDs = append(questionIDs, item.ID)
+	}
+	bountyMapping, err := qs.bountyService.GetActiveBountyAmountMapping(ctx, questionIDs)
+	if err != nil {
+		return nil, 0, err
+	}
+	for _, item := range questions {
+		item.BountyAmount = bountyMapping[uid.DeShortID(item.ID)]
+	}
 	return questions, total, nil
 }
 
@@ -0,0 +1,459 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package content
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/apache/incubator-answer/internal/base/constant"
+	"github.com/apache/incubator-answer/internal/base/reason"
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/apache/incubator-answer/pkg/converter"
+	"github.com/apache/incubator-answer/pkg/diff"
+	"github.com/apache/incubator-answer/pkg/obj"
+	"github.com/apache/incubator-answer/pkg/uid"
+	"github.com/jinzhu/copier"
+	"github.com/segmentfault/pacman/errors"
+	"github.com/segmentfault/pacman/log"
+)
+
+// revisionSnapshot the comparable fields of a revision content
+type revisionSnapshot struct {
+	Title   string
+	Content string
+	Tags    []string
+}
+
+// GetRevisionDiff get the diff between two revisions of the same object
+func (rs *RevisionService) GetRevisionDiff(ctx context.Context, req *schema.GetRevisionDiffReq) (
+	resp *schema.GetRevisionDiffResp, err error) {
+	objectID := uid.DeShortID(req.ObjectID)
+	objectType, err := obj.GetObjectTypeStrByObjectID(objectID)
+	if err != nil {
+		return nil, errors.BadRequest(reason.ObjectNotFound)
+	}
+	fromRevision, err := rs.getPublishedRevision(ctx, req.FromRevisionID)
+	if err != nil {
+		return nil, err
+	}
+	toRevision, err := rs.getPublishedRevision(ctx, req.ToRevisionID)
+	if err != nil {
+		return nil, err
+	}
+	if fromRevision.ObjectID != objectID || toRevision.ObjectID != objectID {
+		return nil, errors.BadRequest(reason.RevisionNotFound)
+	}
+
+	fromSnapshot, err := parseRevisionSnapshot(objectType, fromRevision.Content)
+	if err != nil {
+		return nil, err
+	}
+	toSnapshot, err := parseRevisionSnapshot(objectType, toRevision.Content)
+	if err != nil {
+		return nil, err
+	}
+
+	resp = &schema.GetRevisionDiffResp{
+		ObjectID:   req.ObjectID,
+		ObjectType: objectType,
+		From:       rs.formatRevisionDiffSide(ctx, fromRevision),
+		To:         rs.formatRevisionDiffSide(ctx, toRevision),
+		Title:      diff.Words(fromSnapshot.Title, toSnapshot.Title),
+		Content:    diff.Lines(fromSnapshot.Content, toSnapshot.Content),
+	}
+	if objectType == constant.QuestionObjectType {
+		resp.Tags = diffRevisionTags(fromSnapshot.Tags, toSnapshot.Tags)
+	}
+	return resp, nil
+}
+
+// RollbackRevision roll the object back to an old revision, the old content is saved as a new revision
+func (rs *RevisionService) RollbackRevision(ctx context.Context, req *schema.RollbackRevisionReq) (
+	resp *schema.RollbackRevisionResp, err error) {
+	revisionInfo, err := rs.getPublishedRevision(ctx, req.RevisionID)
+	if err != nil {
+		return nil, err
+	}
+	if revisionInfo.ObjectID != uid.DeShortID(req.ObjectID) {
+		return nil, errors.BadRequest(reason.RevisionNotFound)
+	}
+	_, existUnreviewed, err := rs.revisionRepo.ExistUnreviewedByObjectID(ctx, revisionInfo.ObjectID)
+	if err != nil {
+		return nil, err
+	}
+	if existUnreviewed {
+		return nil, errors.BadRequest(reason.RevisionReviewUnderway)
+	}
+	objectType, err := obj.GetObjectTypeStrByObjectID(revisionInfo.ObjectID)
+	if err != nil {
+		return nil, errors.BadRequest(reason.ObjectNotFound)
+	}
+
+	revisionDTO := &schema.AddRevisionDTO{
+		UserID:   req.UserID,
+		ObjectID: revisionInfo.ObjectID,
+		Title:    revisionInfo.Title,
+		Log:      req.EditSummary,
+		Status:   entity.RevisionReviewPassStatus,
+	}
+	var activityTypeKey constant.ActivityTypeKey
+	switch objectType {
+	case constant.QuestionObjectType:
+		revisionDTO.Content, err = rs.rollbackQuestion(ctx, revisionInfo, req.UserID)
+		activityTypeKey = constant.ActQuestionRollback
+	case constant.AnswerObjectType:
+		revisionDTO.Content, err = rs.rollbackAnswer(ctx, revisionInfo, req.UserID)
+		activityTypeKey = constant.ActAnswerRollback
+	case constant.TagObjectType:
+		revisionDTO.Content, err = rs.rollbackTag(ctx, revisionInfo, req.UserID)
+		activityTypeKey = constant.ActTagRollback
+	default:
+		return nil, errors.BadRequest(reason.ObjectNotFound)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	revisionID, err := rs.revisionCommonService.AddRevision(ctx, revisionDTO, true)
+	if err != nil {
+		return nil, err
+	}
+	rs.activityQueueService.Send(ctx, &schema.ActivityMsg{
+		UserID:           req.UserID,
+		ObjectID:         revisionInfo.ObjectID,
+		OriginalObjectID: revisionInfo.ObjectID,
+		ActivityTypeKey:  activityTypeKey,
+		RevisionID:       revisionID,
+	})
+	return &schema.RollbackRevisionResp{RevisionID: revisionID}, nil
+}
+
+func (rs *RevisionService) rollbackQuestion(ctx context.Context, revisionInfo *entity.Revision, userID string) (
+	content string, err error) {
+	snapshot := &entity.QuestionWithTagsRevision{}
+	if err = json.Unmarshal([]byte(revisionInfo.Content), snapshot); err != nil {
+		return "", errors.InternalServer(reason.UnknownError).WithError(err).WithStack()
+	}
+	question, exist, err := rs.questionRepo.GetQuestion(ctx, revisionInfo.ObjectID)
+	if err != nil {
+		return "", err
+	}
+	if !exist {
+		return "", errors.BadRequest(reason.QuestionNotFound)
+	}
+	if question.Status == entity.QuestionStatusDeleted {
+		return "", errors.BadRequest(reason.QuestionCannotUpdate)
+	}
+	question.ID = revisionInfo.ObjectID
+
+	// tags deleted since the old revision are not created again
+	tagNames := make([]string, 0, len(snapshot.Tags))
+	for _, tag := range snapshot.Tags {
+		tagNames = append(tagNames, tag.SlugName)
+	}
+	tags, err := rs.tagCommon.GetTagListByNames(ctx, tagNames)
+	if err != nil {
+		return "", err
+	}
+	oldTags, err := rs.tagCommon.GetObjectEntityTag(ctx, question.ID)
+	if err != nil {
+		return "", err
+	}
+	newTagNames := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		newTagNames = append(newTagNames, tag.SlugName)
+	}
+	oldTagNames := make([]string, 0, len(oldTags))
+	for _, tag := range oldTags {
+		oldTagNames = append(oldTagNames, tag.SlugName)
+	}
+	tagsChanged := rs.tagCommon.CheckTagsIsChange(ctx, newTagNames, oldTagNames)
+	if question.Title == snapshot.Title && question.OriginalText == snapshot.OriginalText && !tagsChanged {
+		return "", errors.BadRequest(reason.RevisionRollbackNoChange)
+	}
+
+	now := time.Now()
+	question.Title = snapshot.Title
+	question.OriginalText = snapshot.OriginalText
+	question.ParsedText = snapshot.ParsedText
+	question.UpdatedAt = now
+	question.PostUpdateTime = now
+	question.LastEditUserID = userID
+	err = rs.questionRepo.UpdateQuestion(ctx, question, []string{"title", "original_text", "parsed_text", "updated_at", "post_update_time", "last_edit_user_id"})
+	if err != nil {
+		return "", err
+	}
+	if tagsChanged {
+		objectTagData := &schema.TagChange{ObjectID: question.ID, UserID: userID}
+		for _, tag := range tags {
+			objectTagData.Tags = append(objectTagData.Tags, &schema.TagItem{SlugName: tag.SlugName})
+		}
+		if err = rs.tagCommon.ObjectChangeTag(ctx, objectTagData); err != nil {
+			return "", err
+		}
+	}
+
+	questionRevision := &entity.QuestionWithTagsRevision{Question: *question}
+	for _, tag := range tags {
+		item := &entity.TagSimpleInfoForRevision{}
+		_ = copier.Copy(item, tag)
+		questionRevision.Tags = append(questionRevision.Tags, item)
+	}
+	infoJSON, _ := json.Marshal(questionRevision)
+	return string(infoJSON), nil
+}
+
+func (rs *RevisionService) rollbackAnswer(ctx context.Context, revisionInfo *entity.Revision, userID string) (
+	content string, err error) {
+	snapshot := &entity.Answer{}
+	if err = json.Unmarshal([]byte(revisionInfo.Content), snapshot); err != nil {
+		return "", errors.InternalServer(reason.UnknownError).WithError(err).WithStack()
+	}
+	answer, exist, err := rs.answerRepo.GetAnswer(ctx, revisionInfo.ObjectID)
+	if err != nil {
+		return "", err
+	}
+	if !exist {
+		return "", errors.BadRequest(reason.AnswerNotFound)
+	}
+	if answer.Status == entity.AnswerStatusDeleted {
+		return "", errors.BadRequest(reason.AnswerCannotUpdate)
+	}
+	answer.ID = revisionInfo.ObjectID
+	answer.QuestionID = uid.DeShortID(answer.QuestionID)
+	if answer.OriginalText == snapshot.OriginalText {
+		return "", errors.BadRequest(reason.RevisionRollbackNoChange)
+	}
+
+	now := time.Now()
+	answer.OriginalText = snapshot.OriginalText
+	answer.ParsedText = snapshot.ParsedText
+	answer.UpdatedAt = now
+	answer.LastEditUserID = userID
+	err = rs.answerRepo.UpdateAnswer(ctx, answer, []string{"original_text", "parsed_text", "updated_at", "last_edit_user_id"})
+	if err != nil {
+		return "", err
+	}
+	if err = rs.questionCommon.UpdatePostSetTime(ctx, answer.QuestionID, now); err != nil {
+		return "", err
+	}
+
+	questionInfo, exist, err := rs.questionRepo.GetQuestion(ctx, answer.QuestionID)
+	if err != nil {
+		log.Error(err)
+	} else if exist {
+		msg := &schema.NotificationMsg{
+			TriggerUserID:      userID,
+			ReceiverUserID:     questionInfo.UserID,
+			Type:               schema.NotificationTypeInbox,
+			ObjectID:           answer.ID,
+			ObjectType:         constant.AnswerObjectType,
+			NotificationAction: constant.NotificationUpdateAnswer,
+		}
+		rs.notificationQueueService.Send(ctx, msg)
+	}
+
+	infoJSON, _ := json.Marshal(answer)
+	return string(infoJSON), nil
+}
+
+func (rs *RevisionService) rollbackTag(ctx context.Context, revisionInfo *entity.Revision, userID string) (
+	content string, err error) {
+	snapshot := &entity.Tag{}
+	if err = json.Unmarshal([]byte(revisionInfo.Content), snapshot); err != nil {
+		return "", errors.InternalServer(reason.UnknownError).WithError(err).WithStack()
+	}
+	tagInfo, exist, err := rs.tagCommon.GetTagByID(ctx, revisionInfo.ObjectID)
+	if err != nil {
+		return "", err
+	}
+	if !exist {
+		return "", errors.BadRequest(reason.TagNotFound)
+	}
+	structureChanged := tagInfo.ParentTagID != snapshot.ParentTagID || tagInfo.TagGroupID != snapshot.TagGroupID
+	contentChanged := tagInfo.SlugName != snapshot.SlugName ||
+		tagInfo.DisplayName != snapshot.DisplayName ||
+		tagInfo.OriginalText != snapshot.OriginalText
+	if !structureChanged && !contentChanged {
+		return "", errors.BadRequest(reason.RevisionRollbackNoChange)
+	}
+	if !strings.EqualFold(tagInfo.SlugName, snapshot.SlugName) {
+		_, slugExist, err := rs.tagCommon.GetTagBySlugName(ctx, snapshot.SlugName)
+		if err != nil {
+			return "", err
+		}
+		if slugExist {
+			return "", errors.BadRequest(reason.TagAlreadyExist)
+		}
+	}
+	// the tree may have changed since the revision, the old parent and group are checked again
+	if structureChanged {
+		if err = rs.checkTagStructure(ctx, tagInfo, snapshot); err != nil {
+			return "", err
+		}
+	}
+
+	if contentChanged {
+		tagInfo.SlugName = snapshot.SlugName
+		tagInfo.DisplayName = snapshot.DisplayName
+		tagInfo.OriginalText = snapshot.OriginalText
+		tagInfo.ParsedText = snapshot.ParsedText
+		if err = rs.tagRepo.UpdateTag(ctx, tagInfo); err != nil {
+			return "", err
+		}
+	}
+	if structureChanged {
+		err = rs.tagRepo.UpdateTagStructure(ctx, tagInfo.ID, snapshot.ParentTagID, snapshot.TagGroupID)
+		if err != nil {
+			return "", err
+		}
+		tagInfo.ParentTagID = snapshot.ParentTagID
+		tagInfo.TagGroupID = snapshot.TagGroupID
+	}
+	if tagInfo.MainTagID == 0 {
+		tagList, err := rs.tagRepo.GetTagList(ctx, &entity.Tag{MainTagID: converter.StringToInt64(tagInfo.ID)})
+		if err != nil {
+			return "", err
+		}
+		if len(tagList) > 0 {
+			synonymSlugNames := make([]string, 0, len(tagList))
+			for _, tag := range tagList {
+				synonymSlugNames = append(synonymSlugNames, tag.SlugName)
+			}
+			err = rs.tagRepo.UpdateTagSynonym(ctx, synonymSlugNames, converter.StringToInt64(tagInfo.ID), tagInfo.SlugName)
+			if err != nil {
+				return "", err
+			}
+		}
+	}
+
+	infoJSON, _ := json.Marshal(tagInfo)
+	return string(infoJSON), nil
+}
+
+// checkTagStructure check the tag can be moved back to the parent and group in the snapshot
+func (rs *RevisionService) checkTagStructure(ctx context.Context, tagInfo, snapshot *entity.Tag) (err error) {
+	if snapshot.ParentTagID > 0 {
+		if tagInfo.MainTagID > 0 {
+			return errors.BadRequest(reason.TagParentInvalid)
+		}
+		if _, err = rs.tagCommon.CheckTagParent(ctx, tagInfo, converter.IntToString(snapshot.ParentTagID)); err != nil {
+			return err
+		}
+	}
+	if snapshot.TagGroupID > 0 {
+		_, exist, err := rs.tagGroupRepo.GetTagGroup(ctx, snapshot.TagGroupID)
+		if err != nil {
+			return err
+		}
+		if !exist {
+			return errors.BadRequest(reason.TagGroupNotFound)
+		}
+	}
+	return nil
+}
+
+// getPublishedRevision get a revision that is visible in the revision history
+func (rs *RevisionService) getPublishedRevision(ctx context.Context, revisionID string) (
+	revisionInfo *entity.Revision, err error) {
+	revisionInfo, exist, err := rs.revisionRepo.GetRevisionByID(ctx, revisionID)
+	if err != nil {
+		return nil, err
+	}
+	if !exist || (revisionInfo.Status != entity.RevisioNnormalStatus &&
+		revisionInfo.Status != entity.RevisionReviewPassStatus) {
+		return nil, errors.BadRequest(reason.RevisionNotFound)
+	}
+	return revisionInfo, nil
+}
+
+func (rs *RevisionService) formatRevisionDiffSide(ctx context.Context, revisionInfo *entity.Revision) *schema.RevisionDiffSide {
+	side := &schema.RevisionDiffSide{
+		ID:              revisionInfo.ID,
+		CreatedAtParsed: revisionInfo.CreatedAt.Unix(),
+		Log:             revisionInfo.Log,
+	}
+	userInfo, exist, err := rs.userCommon.GetUserBasicInfoByID(ctx, revisionInfo.UserID)
+	if err != nil {
+		log.Error(err)
+	} else if exist {
+		side.UserInfo = *userInfo
+	}
+	return side
+}
+
+// parseRevisionSnapshot get title, markdown content and tags from the revision content
+func parseRevisionSnapshot(objectType, content string) (snapshot *revisionSnapshot, err error) {
+	snapshot = &revisionSnapshot{Tags: make([]string, 0)}
+	switch objectType {
+	case constant.QuestionObjectType:
+		question := &entity.QuestionWithTagsRevision{}
+		err = json.Unmarshal([]byte(content), question)
+		snapshot.Title = question.Title
+		snapshot.Content = question.OriginalText
+		for _, tag := range question.Tags {
+			snapshot.Tags = append(snapshot.Tags, tag.SlugName)
+		}
+	case constant.AnswerObjectType:
+		answer := &entity.Answer{}
+		err = json.Unmarshal([]byte(content), answer)
+		snapshot.Content = answer.OriginalText
+	case constant.TagObjectType:
+		tag := &entity.Tag{}
+		err = json.Unmarshal([]byte(content), tag)
+		snapshot.Title = tag.DisplayName
+		snapshot.Content = tag.OriginalText
+	}
+	if err != nil {
+		return nil, errors.InternalServer(reason.UnknownError).WithError(err).WithStack()
+	}
+	return snapshot, nil
+}
+
+func diffRevisionTags(from, to []string) *schema.RevisionTagsDiff {
+	tagsDiff := &schema.RevisionTagsDiff{
+		Added:     make([]string, 0),
+		Removed:   make([]string, 0),
+		Unchanged: make([]string, 0),
+	}
+	fromMapping := make(map[string]bool, len(from))
+	for _, slugName := range from {
+		fromMapping[slugName] = true
+	}
+	toMapping := make(map[string]bool, len(to))
+	for _, slugName := range to {
+		toMapping[slugName] = true
+		if fromMapping[slugName] {
+			tagsDiff.Unchanged = append(tagsDiff.Unchanged, slugName)
+		} else {
+			tagsDiff.Added = append(tagsDiff.Added, slugName)
+		}
+	}
+	for _, slugName := range from {
+		if !toMapping[slugName] {
+			tagsDiff.Removed = append(tagsDiff.Removed, slugName)
+		}
+	}
+	return tagsDiff
+}
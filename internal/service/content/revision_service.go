@@ -40,6 +40,7 @@ import (
 	"github.com/apache/incubator-answer/internal/service/report_common"
 	"github.com/apache/incubator-answer/internal/service/review"
 	"github.com/apache/incubator-answer/internal/service/revision"
+	"github.com/apache/incubator-answer/internal/service/revision_common"
 	"github.com/apache/incubator-answer/internal/service/tag_common"
 	tagcommon "github.com/apache/incubator-answer/internal/service/tag_common"
 	usercommon "github.com/apache/incubator-answer/internal/service/user_common"
@@ -63,11 +64,13 @@ type RevisionService struct {
 	answerRepo               answercommon.AnswerRepo
 	tagRepo                  tag_common.TagRepo
 	tagCommon                *tagcommon.TagCommonService
+	tagGroupRepo             tag_common.TagGroupRepo
 	notificationQueueService notice_queue.NotificationQueueService
 	activityQueueService     activity_queue.ActivityQueueService
 	reportRepo               report_common.ReportRepo
 	reviewService            *review.ReviewService
 	reviewActivity           activity.ReviewActivityRepo
+	revisionCommonService    *revision_common.RevisionService
 }
 
 func NewRevisionService(
@@ -80,11 +83,13 @@ func NewRevisionService(
 	answerRepo answercommon.AnswerRepo,
 	tagRepo tag_common.TagRepo,
 	tagCommon *tagcommon.TagCommonService,
+	tagGroupRepo tag_common.TagGroupRepo,
 	notificationQueueService notice_queue.NotificationQueueService,
 	activityQueueService activity_queue.ActivityQueueService,
 	reportRepo report_common.ReportRepo,
 	reviewService *review.ReviewService,
 	reviewActivity activity.ReviewActivityRepo,
+	revisionCommonService *revision_common.RevisionService,
 ) *RevisionService {
 	return &RevisionService{
 		revisionRepo:             revisionRepo,
@@ -96,11 +101,13 @@ func NewRevisionService(
 		answerRepo:               answerRepo,
 		tagRepo:                  tagRepo,
 		tagCommon:                tagCommon,
+		tagGroupRepo:             tagGroupRepo,
 		notificationQueueService: notificationQueueService,
 		activityQueueService:     activityQueueService,
 		reportRepo:               reportRepo,
 		reviewService:            reviewService,
 		reviewActivity:           reviewActivity,
+		revisionCommonService:    revisionCommonService,
 	}
 }
 
@@ -38,12 +38,14 @@ import (
 	"github.com/apache/incubator-answer/internal/service/activity"
 	"github.com/apache/incubator-answer/internal/service/activity_common"
 	"github.com/apache/incubator-answer/internal/service/auth"
+	"github.com/apache/incubator-answer/internal/service/badge"
 	"github.com/apache/incubator-answer/internal/service/export"
 	"github.com/apache/incubator-answer/internal/service/role"
 	"github.com/apache/incubator-answer/internal/service/siteinfo_common"
 	usercommon "github.com/apache/incubator-answer/internal/service/user_common"
 	"github.com/apache/incubator-answer/internal/service/user_external_login"
 	"github.com/apache/incubator-answer/pkg/checker"
+	"github.com/apache/incubator-answer/pkg/uid"
 	"github.com/apache/incubator-answer/plugin"
 	"github.com/google/uuid"
 	"github.com/segmentfault/pacman/errors"
@@ -65,6 +67,8 @@ type UserService struct {
 	userNotificationConfigRepo    user_notification_config.UserNotificationConfigRepo
 	userNotificationConfigService *user_notification_config.UserNotificationConfigService
 	questionService               *questioncommon.QuestionCommon
+	notificationMuteRepo          user_notification_config.NotificationMuteRepo
+	badgeService                  *badge.BadgeService
 }
 
 func NewUserService(userRepo usercommon.UserRepo,
@@ -79,6 +83,8 @@ func NewUserService(userRepo usercommon.UserRepo,
 	userNotificationConfigRepo user_notification_config.UserNotificationConfigRepo,
 	userNotificationConfigService *user_notification_config.UserNotificationConfigService,
 	questionService *questioncommon.QuestionCommon,
+	notificationMuteRepo user_notification_config.NotificationMuteRepo,
+	badgeService *badge.BadgeService,
 ) *UserService {
 	return &UserService{
 		userCommonService:             userCommonService,
@@ -93,6 +99,8 @@ func NewUserService(userRepo usercommon.UserRepo,
 		userNotificationConfigRepo:    userNotificationConfigRepo,
 		userNotificationConfigService: userNotificationConfigService,
 		questionService:               questionService,
+		notificationMuteRepo:          notificationMuteRepo,
+		badgeService:                  badgeService,
 	}
 }
 
@@ -141,6 +149,11 @@ func (us *UserService) GetOtherUserInfoByUsername(ctx context.Context, req *sche
 		return nil, err
 	}
 	resp.QuestionCount = int(questionCount)
+
+	resp.BadgeCount, resp.Badges, err = us.badgeService.GetUserBadges(ctx, userInfo.ID)
+	if err != nil {
+		return nil, err
+	}
 	return resp, nil
 }
 
@@ -768,6 +781,14 @@ func (us *UserService) UserUnsubscribeNotification(
 		return errors.BadRequest(reason.EmailVerifyURLExpired)
 	}
 
+	// mute the question only, other notifications are still sent
+	if data.SourceType == schema.MuteQuestionSourceType {
+		if len(data.ObjectID) == 0 {
+			return errors.BadRequest(reason.EmailVerifyURLExpired)
+		}
+		return us.notificationMuteRepo.AddMute(ctx, data.UserID, uid.DeShortID(data.ObjectID))
+	}
+
 	for _, source := range data.NotificationSources {
 		notificationConfig, exist, err := us.userNotificationConfigRepo.GetByUserIDAndSource(
 			ctx, data.UserID, source)
@@ -31,9 +31,11 @@ import (
 	"github.com/apache/incubator-answer/internal/base/translator"
 	"github.com/apache/incubator-answer/internal/entity"
 	"github.com/apache/incubator-answer/internal/service/activity_type"
+	"github.com/apache/incubator-answer/internal/service/badge_queue"
 	"github.com/apache/incubator-answer/internal/service/comment_common"
 	"github.com/apache/incubator-answer/internal/service/config"
 	"github.com/apache/incubator-answer/internal/service/object_info"
+	"github.com/apache/incubator-answer/internal/service/webhook_queue"
 	"github.com/apache/incubator-answer/pkg/htmltext"
 	"github.com/segmentfault/pacman/log"
 
@@ -55,13 +57,15 @@ type VoteRepo interface {
 
 // VoteService user service
 type VoteService struct {
-	voteRepo          VoteRepo
-	configService     *config.ConfigService
-	questionRepo      questioncommon.QuestionRepo
-	answerRepo        answercommon.AnswerRepo
-	commentCommonRepo comment_common.CommentCommonRepo
-	objectService     *object_info.ObjService
-	activityRepo      activity_common.ActivityRepo
+	voteRepo            VoteRepo
+	configService       *config.ConfigService
+	questionRepo        questioncommon.QuestionRepo
+	answerRepo          answercommon.AnswerRepo
+	commentCommonRepo   comment_common.CommentCommonRepo
+	objectService       *object_info.ObjService
+	activityRepo        activity_common.ActivityRepo
+	webhookQueueService webhook_queue.WebhookQueueService
+	badgeQueueService   badge_queue.BadgeQueueService
 }
 
 func NewVoteService(
@@ -71,14 +75,18 @@ func NewVoteService(
 	answerRepo answercommon.AnswerRepo,
 	commentCommonRepo comment_common.CommentCommonRepo,
 	objectService *object_info.ObjService,
+	webhookQueueService webhook_queue.WebhookQueueService,
+	badgeQueueService badge_queue.BadgeQueueService,
 ) *VoteService {
 	return &VoteService{
-		voteRepo:          voteRepo,
-		configService:     configService,
-		questionRepo:      questionRepo,
-		answerRepo:        answerRepo,
-		commentCommonRepo: commentCommonRepo,
-		objectService:     objectService,
+		voteRepo:            voteRepo,
+		configService:       configService,
+		questionRepo:        questionRepo,
+		answerRepo:          answerRepo,
+		commentCommonRepo:   commentCommonRepo,
+		objectService:       objectService,
+		webhookQueueService: webhookQueueService,
+		badgeQueueService:   badgeQueueService,
 	}
 }
 
@@ -125,6 +133,8 @@ func (vs *VoteService) VoteUp(ctx context.Context, req *schema.VoteReq) (resp *s
 	resp.Votes = resp.UpVotes - resp.DownVotes
 	if !req.IsCancel {
 		resp.VoteStatus = constant.ActVoteUp
+		vs.dispatchVoteWebhook(ctx, req.UserID, objectInfo, "upvote")
+		vs.dispatchVoteBadgeEvent(ctx, objectInfo)
 	}
 	return resp, nil
 }
@@ -173,10 +183,37 @@ func (vs *VoteService) VoteDown(ctx context.Context, req *schema.VoteReq) (resp
 	resp.Votes = resp.UpVotes - resp.DownVotes
 	if !req.IsCancel {
 		resp.VoteStatus = constant.ActVoteDown
+		vs.dispatchVoteWebhook(ctx, req.UserID, objectInfo, "downvote")
 	}
 	return resp, nil
 }
 
+// dispatchVoteWebhook only votes of questions and answers can be subscribed by webhook, eg: question.upvote
+func (vs *VoteService) dispatchVoteWebhook(ctx context.Context, userID string,
+	objectInfo *schema.SimpleObjectInfo, action string) {
+	if objectInfo.ObjectType != constant.QuestionObjectType && objectInfo.ObjectType != constant.AnswerObjectType {
+		return
+	}
+	vs.webhookQueueService.Send(ctx, &schema.WebhookEventMsg{
+		Event:         constant.ActivityTypeKey(objectInfo.ObjectType + "." + action),
+		ObjectID:      objectInfo.ObjectID,
+		UserID:        objectInfo.ObjectCreatorUserID,
+		TriggerUserID: userID,
+	})
+}
+
+// dispatchVoteBadgeEvent the upvotes of answers make the tag badges of the answer author evaluated
+func (vs *VoteService) dispatchVoteBadgeEvent(ctx context.Context, objectInfo *schema.SimpleObjectInfo) {
+	if objectInfo.ObjectType != constant.AnswerObjectType {
+		return
+	}
+	vs.badgeQueueService.Send(ctx, &schema.BadgeEventMsg{
+		UserID:          objectInfo.ObjectCreatorUserID,
+		ActivityTypeKey: constant.ActAnswerUpvote,
+		ObjectID:        objectInfo.ObjectID,
+	})
+}
+
 // ListUserVotes list user's votes
 func (vs *VoteService) ListUserVotes(ctx context.Context, req schema.GetVoteWithPageReq) (resp *pager.PageModel, err error) {
 	typeKeys := []string{
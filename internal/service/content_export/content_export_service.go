@@ -0,0 +1,136 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package content_export
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/apache/incubator-answer/internal/base/reason"
+	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/apache/incubator-answer/internal/service/job_queue"
+	"github.com/apache/incubator-answer/internal/service/service_config"
+	"github.com/segmentfault/pacman/errors"
+	"github.com/segmentfault/pacman/log"
+)
+
+// ContentExportQueueName the name of the persisted queue which runs the content export
+const ContentExportQueueName = "content_export"
+
+// ContentExportService run the content export as admin job and manage the exported archives
+type ContentExportService struct {
+	contentExporter *ContentExporter
+	jobQueueService *job_queue.JobQueueService
+	serviceConfig   *service_config.ServiceConfig
+}
+
+// NewContentExportService new content export service
+func NewContentExportService(
+	contentExporter *ContentExporter,
+	jobQueueService *job_queue.JobQueueService,
+	serviceConfig *service_config.ServiceConfig,
+) *ContentExportService {
+	es := &ContentExportService{
+		contentExporter: contentExporter,
+		jobQueueService: jobQueueService,
+		serviceConfig:   serviceConfig,
+	}
+	jobQueueService.RegisterHandler(ContentExportQueueName, es.handleContentExportJob)
+	return es
+}
+
+// exportDir the exported archives are saved beside the upload directory, so they are not public
+func (es *ContentExportService) exportDir() string {
+	return filepath.Join(filepath.Dir(filepath.Clean(es.serviceConfig.UploadPath)), "exports")
+}
+
+// StartContentExport add the content export job to queue
+func (es *ContentExportService) StartContentExport(ctx context.Context, req *schema.ContentExportReq) (err error) {
+	return es.jobQueueService.Enqueue(ctx, ContentExportQueueName, req)
+}
+
+// GetContentExportFiles get the finished export archives, the newest one first
+func (es *ContentExportService) GetContentExportFiles(ctx context.Context) (
+	resp []*schema.ContentExportFileResp, err error) {
+	resp = make([]*schema.ContentExportFileResp, 0)
+	entries, err := os.ReadDir(es.exportDir())
+	if err != nil {
+		if os.IsNotExist(err) {
+			return resp, nil
+		}
+		return nil, errors.InternalServer(reason.UnknownError).WithError(err).WithStack()
+	}
+	for _, entry := range entries {
+		if entry.IsDir() || !isExportFileName(entry.Name()) {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil {
+			continue
+		}
+		resp = append(resp, &schema.ContentExportFileResp{
+			FileName:  entry.Name(),
+			Size:      info.Size(),
+			CreatedAt: info.ModTime().Unix(),
+		})
+	}
+	sort.Slice(resp, func(i, j int) bool {
+		return resp[i].FileName > resp[j].FileName
+	})
+	return resp, nil
+}
+
+// GetContentExportFilePath get the path of export archive for downloading
+func (es *ContentExportService) GetContentExportFilePath(ctx context.Context, req *schema.ContentExportDownloadReq) (
+	filePath string, err error) {
+	if !isExportFileName(req.FileName) {
+		return "", errors.BadRequest(reason.ContentExportFileNotFound)
+	}
+	filePath = filepath.Join(es.exportDir(), req.FileName)
+	info, err := os.Stat(filePath)
+	if err != nil || info.IsDir() {
+		return "", errors.BadRequest(reason.ContentExportFileNotFound)
+	}
+	return filePath, nil
+}
+
+// isExportFileName check the file name is an export archive and not a path
+func isExportFileName(fileName string) bool {
+	return filepath.Base(fileName) == fileName &&
+		strings.HasPrefix(fileName, ExportFilePrefix) &&
+		strings.HasSuffix(fileName, ExportFileSuffix)
+}
+
+func (es *ContentExportService) handleContentExportJob(ctx context.Context, payload []byte) error {
+	req := &schema.ContentExportReq{}
+	if err := json.Unmarshal(payload, req); err != nil {
+		return job_queue.Permanent(err)
+	}
+	archivePath, err := es.contentExporter.Export(ctx, req, es.exportDir())
+	if err != nil {
+		return err
+	}
+	log.Infof("[content export] done: %s", archivePath)
+	return nil
+}
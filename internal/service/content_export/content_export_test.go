@@ -0,0 +1,76 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package content_export
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/apache/incubator-answer/internal/service/job_queue"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsExportFileName(t *testing.T) {
+	assert.True(t, isExportFileName("answer_export_20240101120000.tar.gz"))
+	assert.False(t, isExportFileName("answer_export_20240101120000.tar.gz.tmp"))
+	assert.False(t, isExportFileName("../answer_export_20240101120000.tar.gz"))
+	assert.False(t, isExportFileName("answer_dump_20240101120000.tar.gz"))
+}
+
+func TestExportAnswer(t *testing.T) {
+	req := &schema.ContentExportReq{IncludePending: true}
+	assert.True(t, exportAnswer(req, &entity.Answer{Status: entity.AnswerStatusAvailable}))
+	assert.True(t, exportAnswer(req, &entity.Answer{Status: entity.AnswerStatusPending}))
+	assert.False(t, exportAnswer(req, &entity.Answer{Status: entity.AnswerStatusDeleted}))
+}
+
+func TestRenderMarkdown(t *testing.T) {
+	createdAt := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	doc := &schema.ContentExportQuestion{
+		Title:     "How to export?",
+		Author:    &schema.ContentExportUser{ID: "1", Username: "alice", DisplayName: "Alice"},
+		Content:   "question body",
+		Status:    "available",
+		Tags:      []*schema.ContentExportTag{{SlugName: "go"}},
+		CreatedAt: createdAt,
+		Comments: []*schema.ContentExportComment{
+			{Author: &schema.ContentExportUser{Username: "bob"}, Content: "first\nline", CreatedAt: createdAt},
+		},
+		Answers: []*schema.ContentExportAnswer{
+			{Author: &schema.ContentExportUser{}, Content: "answer body", Status: "available", Accepted: true, CreatedAt: createdAt},
+		},
+	}
+	md := renderMarkdown(doc)
+	assert.Contains(t, md, "# How to export?\n")
+	assert.Contains(t, md, "- Author: Alice (@alice)\n")
+	assert.Contains(t, md, "- Tags: `go`\n")
+	assert.Contains(t, md, "- @bob (2024-01-02 03:04:05): first line\n")
+	assert.Contains(t, md, "### Answer by unknown (accepted)\n")
+	assert.Contains(t, md, "answer body\n")
+}
+
+func TestContentExportServiceHandleContentExportJob(t *testing.T) {
+	err := (&ContentExportService{}).handleContentExportJob(context.TODO(), []byte("{"))
+	assert.Error(t, err)
+	assert.True(t, job_queue.IsPermanent(err))
+}
@@ -0,0 +1,412 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package content_export
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/apache/incubator-answer/internal/base/pager"
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/schema"
+	answercommon "github.com/apache/incubator-answer/internal/service/answer_common"
+	"github.com/apache/incubator-answer/internal/service/comment"
+	questioncommon "github.com/apache/incubator-answer/internal/service/question_common"
+	"github.com/apache/incubator-answer/internal/service/revision"
+	tagcommon "github.com/apache/incubator-answer/internal/service/tag_common"
+	usercommon "github.com/apache/incubator-answer/internal/service/user_common"
+	"github.com/apache/incubator-answer/pkg/dir"
+	"github.com/segmentfault/pacman/log"
+)
+
+const (
+	// ExportFormatVersion the version of exported archive format
+	ExportFormatVersion = 1
+	// ExportFilePrefix the prefix of exported archive file name
+	ExportFilePrefix = "answer_export_"
+	// ExportFileSuffix the suffix of exported archive file name
+	ExportFileSuffix = ".tar.gz"
+
+	exportPageSize = 100
+)
+
+var answerStatusIntToString = map[int]string{
+	entity.AnswerStatusAvailable: "available",
+	entity.AnswerStatusDeleted:   "deleted",
+	entity.AnswerStatusPending:   "pending",
+}
+
+// ContentExporter write all questions with their answers, comments, tags and revisions to an archive
+type ContentExporter struct {
+	questionRepo  questioncommon.QuestionRepo
+	answerCommon  *answercommon.AnswerCommon
+	commentRepo   comment.CommentRepo
+	tagCommonRepo tagcommon.TagCommonRepo
+	tagRelRepo    tagcommon.TagRelRepo
+	revisionRepo  revision.RevisionRepo
+	userRepo      usercommon.UserRepo
+}
+
+// NewContentExporter new content exporter
+func NewContentExporter(
+	questionRepo questioncommon.QuestionRepo,
+	answerCommon *answercommon.AnswerCommon,
+	commentRepo comment.CommentRepo,
+	tagCommonRepo tagcommon.TagCommonRepo,
+	tagRelRepo tagcommon.TagRelRepo,
+	revisionRepo revision.RevisionRepo,
+	userRepo usercommon.UserRepo,
+) *ContentExporter {
+	return &ContentExporter{
+		questionRepo:  questionRepo,
+		answerCommon:  answerCommon,
+		commentRepo:   commentRepo,
+		tagCommonRepo: tagCommonRepo,
+		tagRelRepo:    tagRelRepo,
+		revisionRepo:  revisionRepo,
+		userRepo:      userRepo,
+	}
+}
+
+// Export write the contents to a new archive in output directory, every question is written as
+// questions/<id>.json and questions/<id>.md. The archive is renamed after all contents are written,
+// so an unfinished archive will never be listed.
+func (ce *ContentExporter) Export(ctx context.Context, req *schema.ContentExportReq, outputDir string) (
+	archivePath string, err error) {
+	if err = dir.CreateDirIfNotExist(outputDir); err != nil {
+		return "", err
+	}
+	archivePath = filepath.Join(outputDir,
+		fmt.Sprintf("%s%s%s", ExportFilePrefix, time.Now().Format("20060102150405"), ExportFileSuffix))
+	tmpPath := archivePath + ".tmp"
+
+	manifest := &schema.ContentExportManifest{
+		FormatVersion:  ExportFormatVersion,
+		CreatedAt:      time.Now(),
+		IncludeDeleted: req.IncludeDeleted,
+		IncludePending: req.IncludePending,
+		IncludeHidden:  req.IncludeHidden,
+	}
+	if err = ce.writeArchive(ctx, req, tmpPath, manifest); err != nil {
+		_ = os.Remove(tmpPath)
+		return "", err
+	}
+	if err = os.Rename(tmpPath, archivePath); err != nil {
+		_ = os.Remove(tmpPath)
+		return "", err
+	}
+	log.Infof("exported %d questions, %d answers, %d comments to %s",
+		manifest.Questions, manifest.Answers, manifest.Comments, archivePath)
+	return archivePath, nil
+}
+
+func (ce *ContentExporter) writeArchive(ctx context.Context, req *schema.ContentExportReq, archivePath string,
+	manifest *schema.ContentExportManifest) (err error) {
+	file, err := os.Create(archivePath)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	gzipWriter := gzip.NewWriter(file)
+	tarWriter := tar.NewWriter(gzipWriter)
+
+	for _, status := range exportQuestionStatus(req) {
+		for page := 1; ; page++ {
+			questionList, _, err := ce.questionRepo.AdminQuestionPage(ctx, &schema.AdminQuestionPageReq{
+				Page:     page,
+				PageSize: exportPageSize,
+				Status:   status,
+			})
+			if err != nil {
+				return err
+			}
+			for _, question := range questionList {
+				if question.Show == entity.QuestionHide && !req.IncludeHidden {
+					continue
+				}
+				doc, err := ce.buildQuestion(ctx, req, question)
+				if err != nil {
+					return err
+				}
+				if err = writeQuestion(tarWriter, doc); err != nil {
+					return err
+				}
+				manifest.Questions++
+				manifest.Answers += len(doc.Answers)
+				manifest.Comments += len(doc.Comments)
+				for _, answer := range doc.Answers {
+					manifest.Comments += len(answer.Comments)
+				}
+			}
+			if len(questionList) < exportPageSize {
+				break
+			}
+		}
+	}
+
+	content, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err = writeArchiveFile(tarWriter, "manifest.json", content); err != nil {
+		return err
+	}
+	if err = tarWriter.Close(); err != nil {
+		return err
+	}
+	return gzipWriter.Close()
+}
+
+// exportQuestionStatus the question status should be exported
+func exportQuestionStatus(req *schema.ContentExportReq) []int {
+	status := []int{entity.QuestionStatusAvailable, entity.QuestionStatusClosed}
+	if req.IncludePending {
+		status = append(status, entity.QuestionStatusPending)
+	}
+	if req.IncludeDeleted {
+		status = append(status, entity.QuestionStatusDeleted)
+	}
+	return status
+}
+
+// exportAnswer check the answer should be exported or not
+func exportAnswer(req *schema.ContentExportReq, answer *entity.Answer) bool {
+	switch answer.Status {
+	case entity.AnswerStatusAvailable:
+		return true
+	case entity.AnswerStatusPending:
+		return req.IncludePending
+	case entity.AnswerStatusDeleted:
+		return req.IncludeDeleted
+	default:
+		return false
+	}
+}
+
+func (ce *ContentExporter) buildQuestion(ctx context.Context, req *schema.ContentExportReq,
+	question *entity.Question) (doc *schema.ContentExportQuestion, err error) {
+	users := newExportUserCache(ce.userRepo)
+	doc = &schema.ContentExportQuestion{
+		ID:        question.ID,
+		Title:     question.Title,
+		Content:   question.OriginalText,
+		Status:    entity.AdminQuestionSearchStatusIntToString[question.Status],
+		Hidden:    question.Show == entity.QuestionHide,
+		VoteCount: question.VoteCount,
+		ViewCount: question.ViewCount,
+		CreatedAt: question.CreatedAt,
+		UpdatedAt: question.UpdatedAt,
+	}
+	if question.AcceptedAnswerID != "0" {
+		doc.AcceptedAnswerID = question.AcceptedAnswerID
+	}
+	if doc.Author, err = users.get(ctx, question.UserID); err != nil {
+		return nil, err
+	}
+	if doc.Tags, err = ce.getTags(ctx, question.ID); err != nil {
+		return nil, err
+	}
+	if doc.Comments, err = ce.getComments(ctx, users, question.ID); err != nil {
+		return nil, err
+	}
+	if doc.Revisions, err = ce.getRevisions(ctx, users, question.ID); err != nil {
+		return nil, err
+	}
+
+	doc.Answers = make([]*schema.ContentExportAnswer, 0)
+	for page := 1; ; page++ {
+		answerList, _, err := ce.answerCommon.Search(ctx, &entity.AnswerSearch{
+			Answer:         entity.Answer{QuestionID: question.ID},
+			IncludeDeleted: true,
+			Order:          entity.AnswerSearchOrderByTimeAsc,
+			Page:           page,
+			PageSize:       exportPageSize,
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, answer := range answerList {
+			if !exportAnswer(req, answer) {
+				continue
+			}
+			item := &schema.ContentExportAnswer{
+				ID:        answer.ID,
+				Content:   answer.OriginalText,
+				Status:    answerStatusIntToString[answer.Status],
+				Accepted:  answer.ID == question.AcceptedAnswerID,
+				VoteCount: answer.VoteCount,
+				CreatedAt: answer.CreatedAt,
+				UpdatedAt: answer.UpdatedAt,
+			}
+			if item.Author, err = users.get(ctx, answer.UserID); err != nil {
+				return nil, err
+			}
+			if item.Comments, err = ce.getComments(ctx, users, answer.ID); err != nil {
+				return nil, err
+			}
+			if item.Revisions, err = ce.getRevisions(ctx, users, answer.ID); err != nil {
+				return nil, err
+			}
+			doc.Answers = append(doc.Answers, item)
+		}
+		if len(answerList) < exportPageSize {
+			break
+		}
+	}
+	return doc, nil
+}
+
+func (ce *ContentExporter) getTags(ctx context.Context, objectID string) (tags []*schema.ContentExportTag, err error) {
+	tags = make([]*schema.ContentExportTag, 0)
+	tagRelList, err := ce.tagRelRepo.GetObjectTagRelList(ctx, objectID)
+	if err != nil || len(tagRelList) == 0 {
+		return tags, err
+	}
+	tagIDs := make([]string, 0, len(tagRelList))
+	for _, rel := range tagRelList {
+		tagIDs = append(tagIDs, rel.TagID)
+	}
+	tagList, err := ce.tagCommonRepo.GetTagListByIDs(ctx, tagIDs)
+	if err != nil {
+		return nil, err
+	}
+	for _, tag := range tagList {
+		tags = append(tags, &schema.ContentExportTag{SlugName: tag.SlugName, DisplayName: tag.DisplayName})
+	}
+	return tags, nil
+}
+
+// getComments get the available comments of object
+func (ce *ContentExporter) getComments(ctx context.Context, users *exportUserCache, objectID string) (
+	comments []*schema.ContentExportComment, err error) {
+	comments = make([]*schema.ContentExportComment, 0)
+	for page := 1; ; page++ {
+		commentList, _, err := ce.commentRepo.GetCommentPage(ctx, &comment.CommentQuery{
+			PageCond: pager.PageCond{Page: page, PageSize: exportPageSize},
+			ObjectID: objectID,
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, c := range commentList {
+			item := &schema.ContentExportComment{
+				ID:        c.ID,
+				Content:   c.OriginalText,
+				VoteCount: c.VoteCount,
+				CreatedAt: c.CreatedAt,
+			}
+			if c.ReplyCommentID.Valid {
+				item.ReplyCommentID = fmt.Sprintf("%d", c.ReplyCommentID.Int64)
+			}
+			if item.Author, err = users.get(ctx, c.UserID); err != nil {
+				return nil, err
+			}
+			comments = append(comments, item)
+		}
+		if len(commentList) < exportPageSize {
+			break
+		}
+	}
+	return comments, nil
+}
+
+// getRevisions get the revision history of object, the oldest one first
+func (ce *ContentExporter) getRevisions(ctx context.Context, users *exportUserCache, objectID string) (
+	revisions []*schema.ContentExportRevision, err error) {
+	revisionList, err := ce.revisionRepo.GetRevisionList(ctx, &entity.Revision{ObjectID: objectID})
+	if err != nil {
+		return nil, err
+	}
+	revisions = make([]*schema.ContentExportRevision, 0, len(revisionList))
+	for i := len(revisionList) - 1; i >= 0; i-- {
+		r := revisionList[i]
+		item := &schema.ContentExportRevision{
+			ID:        r.ID,
+			Title:     r.Title,
+			Log:       r.Log,
+			Status:    r.Status,
+			Content:   r.Content,
+			CreatedAt: r.CreatedAt,
+		}
+		if item.Author, err = users.get(ctx, r.UserID); err != nil {
+			return nil, err
+		}
+		revisions = append(revisions, item)
+	}
+	return revisions, nil
+}
+
+// exportUserCache cache the public fields of authors while exporting one question
+type exportUserCache struct {
+	userRepo usercommon.UserRepo
+	users    map[string]*schema.ContentExportUser
+}
+
+func newExportUserCache(userRepo usercommon.UserRepo) *exportUserCache {
+	return &exportUserCache{userRepo: userRepo, users: make(map[string]*schema.ContentExportUser)}
+}
+
+func (uc *exportUserCache) get(ctx context.Context, userID string) (*schema.ContentExportUser, error) {
+	if user, ok := uc.users[userID]; ok {
+		return user, nil
+	}
+	user := &schema.ContentExportUser{ID: userID}
+	userList, err := uc.userRepo.BatchGetByID(ctx, []string{userID})
+	if err != nil {
+		return nil, err
+	}
+	if len(userList) > 0 {
+		user.Username = userList[0].Username
+		user.DisplayName = userList[0].DisplayName
+	}
+	uc.users[userID] = user
+	return user, nil
+}
+
+func writeQuestion(tarWriter *tar.Writer, doc *schema.ContentExportQuestion) error {
+	content, err := json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err = writeArchiveFile(tarWriter, fmt.Sprintf("questions/%s.json", doc.ID), content); err != nil {
+		return err
+	}
+	return writeArchiveFile(tarWriter, fmt.Sprintf("questions/%s.md", doc.ID), []byte(renderMarkdown(doc)))
+}
+
+func writeArchiveFile(tarWriter *tar.Writer, name string, content []byte) error {
+	header := &tar.Header{
+		Name:    name,
+		Mode:    0o644,
+		Size:    int64(len(content)),
+		ModTime: time.Now(),
+	}
+	if err := tarWriter.WriteHeader(header); err != nil {
+		return err
+	}
+	_, err := tarWriter.Write(content)
+	return err
+}
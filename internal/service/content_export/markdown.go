@@ -0,0 +1,89 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package content_export
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/apache/incubator-answer/internal/schema"
+)
+
+const markdownTimeFormat = "2006-01-02 15:04:05"
+
+// renderMarkdown render the exported question as a readable markdown document
+func renderMarkdown(doc *schema.ContentExportQuestion) string {
+	b := &strings.Builder{}
+	fmt.Fprintf(b, "# %s\n\n", doc.Title)
+	fmt.Fprintf(b, "- Author: %s\n", markdownUser(doc.Author))
+	fmt.Fprintf(b, "- Created: %s\n", doc.CreatedAt.UTC().Format(markdownTimeFormat))
+	fmt.Fprintf(b, "- Status: %s\n", doc.Status)
+	if doc.Hidden {
+		b.WriteString("- Hidden: true\n")
+	}
+	if len(doc.Tags) > 0 {
+		tags := make([]string, 0, len(doc.Tags))
+		for _, tag := range doc.Tags {
+			tags = append(tags, "`"+tag.SlugName+"`")
+		}
+		fmt.Fprintf(b, "- Tags: %s\n", strings.Join(tags, ", "))
+	}
+	fmt.Fprintf(b, "- Votes: %d\n", doc.VoteCount)
+	fmt.Fprintf(b, "\n%s\n", strings.TrimSpace(doc.Content))
+	renderMarkdownComments(b, doc.Comments)
+
+	if len(doc.Answers) > 0 {
+		fmt.Fprintf(b, "\n## Answers (%d)\n", len(doc.Answers))
+	}
+	for _, answer := range doc.Answers {
+		fmt.Fprintf(b, "\n---\n\n### Answer by %s", markdownUser(answer.Author))
+		if answer.Accepted {
+			b.WriteString(" (accepted)")
+		}
+		b.WriteString("\n\n")
+		fmt.Fprintf(b, "- Created: %s\n", answer.CreatedAt.UTC().Format(markdownTimeFormat))
+		fmt.Fprintf(b, "- Status: %s\n", answer.Status)
+		fmt.Fprintf(b, "- Votes: %d\n", answer.VoteCount)
+		fmt.Fprintf(b, "\n%s\n", strings.TrimSpace(answer.Content))
+		renderMarkdownComments(b, answer.Comments)
+	}
+	return b.String()
+}
+
+func renderMarkdownComments(b *strings.Builder, comments []*schema.ContentExportComment) {
+	if len(comments) == 0 {
+		return
+	}
+	b.WriteString("\n#### Comments\n\n")
+	for _, c := range comments {
+		content := strings.Join(strings.Fields(c.Content), " ")
+		fmt.Fprintf(b, "- %s (%s): %s\n", markdownUser(c.Author), c.CreatedAt.UTC().Format(markdownTimeFormat), content)
+	}
+}
+
+func markdownUser(user *schema.ContentExportUser) string {
+	if user == nil || len(user.Username) == 0 {
+		return "unknown"
+	}
+	if len(user.DisplayName) == 0 {
+		return "@" + user.Username
+	}
+	return fmt.Sprintf("%s (@%s)", user.DisplayName, user.Username)
+}
@@ -26,6 +26,7 @@ import (
 	"io"
 	"net/http"
 	"net/url"
+	"sort"
 	"time"
 
 	"github.com/apache/incubator-answer/internal/service/review"
@@ -35,6 +36,7 @@ import (
 
 	"github.com/apache/incubator-answer/internal/base/constant"
 	"github.com/apache/incubator-answer/internal/base/data"
+	"github.com/apache/incubator-answer/internal/entity"
 	"github.com/apache/incubator-answer/internal/schema"
 	"github.com/apache/incubator-answer/internal/service/activity_common"
 	answercommon "github.com/apache/incubator-answer/internal/service/answer_common"
@@ -119,6 +121,7 @@ func (ds *dashboardService) Statistical(ctx context.Context) (*schema.DashboardI
 		}
 		dashboardInfo.DatabaseVersion = ds.getDatabaseInfo()
 		dashboardInfo.DatabaseSize = ds.GetDatabaseSize()
+		dashboardInfo.ReportModeratorStats = ds.reportModeratorStats(ctx)
 	}
 
 	dashboardInfo.ReportCount = ds.reportCount(ctx)
@@ -215,6 +218,56 @@ func (ds *dashboardService) reportCount(ctx context.Context) int64 {
 }
 
 // count vote
+func (ds *dashboardService) reportModeratorStats(ctx context.Context) []*schema.ReportModeratorStat {
+	stats := make([]*schema.ReportModeratorStat, 0)
+	startTime := time.Now().AddDate(0, 0, -schema.ReportModeratorStatDays)
+	reports, err := ds.reportRepo.GetHandledReports(ctx, startTime)
+	if err != nil {
+		log.Errorf("get handled reports failed: %s", err)
+		return stats
+	}
+
+	statMapping := make(map[string]*schema.ReportModeratorStat)
+	totalSeconds := make(map[string]int64)
+	for _, report := range reports {
+		stat, ok := statMapping[report.HandledUserID]
+		if !ok {
+			stat = &schema.ReportModeratorStat{UserID: report.HandledUserID}
+			statMapping[report.HandledUserID] = stat
+			stats = append(stats, stat)
+		}
+		if report.Status == entity.ReportStatusIgnore {
+			stat.IgnoredCount++
+		} else {
+			stat.HandledCount++
+		}
+		totalSeconds[report.HandledUserID] += int64(report.HandledAt.Sub(report.CreatedAt).Seconds())
+	}
+	if len(stats) == 0 {
+		return stats
+	}
+
+	userIDs := make([]string, 0, len(stats))
+	for _, stat := range stats {
+		stat.AvgResolutionSeconds = totalSeconds[stat.UserID] / int64(stat.HandledCount+stat.IgnoredCount)
+		userIDs = append(userIDs, stat.UserID)
+	}
+	users, err := ds.userRepo.BatchGetByID(ctx, userIDs)
+	if err != nil {
+		log.Errorf("get users failed: %s", err)
+	}
+	for _, user := range users {
+		if stat, ok := statMapping[user.ID]; ok {
+			stat.Username = user.Username
+			stat.DisplayName = user.DisplayName
+		}
+	}
+	sort.Slice(stats, func(i, j int) bool {
+		return stats[i].HandledCount+stats[i].IgnoredCount > stats[j].HandledCount+stats[j].IgnoredCount
+	})
+	return stats
+}
+
 func (ds *dashboardService) voteCount(ctx context.Context) int64 {
 	typeKeys := []string{
 		"question.vote_up",
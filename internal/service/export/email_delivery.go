@@ -0,0 +1,209 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package export
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"mime"
+	"time"
+
+	"github.com/apache/incubator-answer/internal/base/pager"
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/apache/incubator-answer/internal/service/job_queue"
+	"github.com/apache/incubator-answer/pkg/htmltext"
+	"github.com/segmentfault/pacman/log"
+	"gopkg.in/gomail.v2"
+)
+
+const (
+	// EmailQueueName the name of the persisted queue which delivers emails
+	EmailQueueName = "email"
+	// maxDeliveryAttempts the email is marked as failed and not retried after these attempts
+	maxDeliveryAttempts = 6
+	// defaultDeliveryRetentionDays the done delivery logs are kept for these days by default
+	defaultDeliveryRetentionDays = 30
+)
+
+// EmailDeliveryRepo email delivery repository
+type EmailDeliveryRepo interface {
+	AddDelivery(ctx context.Context, delivery *entity.EmailDelivery) (err error)
+	UpdateDelivery(ctx context.Context, delivery *entity.EmailDelivery) (err error)
+	GetDelivery(ctx context.Context, deliveryID int64) (delivery *entity.EmailDelivery, exist bool, err error)
+	GetDeliveryPage(ctx context.Context, page, pageSize int, status int) (
+		deliveries []*entity.EmailDelivery, total int64, err error)
+	RemoveDoneDeliveries(ctx context.Context, updatedBefore time.Time) (removed int64, err error)
+}
+
+// SendMessage put the email message into the queue, it will be sent and retried in the background
+func (es *EmailService) SendMessage(ctx context.Context, msg *EmailMessage) {
+	ec, err := es.GetEmailConfig(ctx)
+	if err != nil {
+		log.Errorf("get email config failed: %s", err)
+		return
+	}
+	if !es.transport.Configured(ec) {
+		log.Warnf("smtp host is empty, skip send email")
+		return
+	}
+
+	delivery := &entity.EmailDelivery{
+		ToEmail:            msg.To,
+		Subject:            msg.Subject,
+		Body:               msg.Body,
+		ListUnsubscribeURL: msg.ListUnsubscribeURL,
+		ReplyTo:            msg.ReplyTo,
+		Status:             entity.EmailDeliveryStatusQueued,
+	}
+	if err = es.emailDeliveryRepo.AddDelivery(ctx, delivery); err != nil {
+		log.Error(err)
+		return
+	}
+	err = es.jobQueueService.Enqueue(ctx, EmailQueueName, &schema.EmailDeliveryJob{DeliveryID: delivery.ID})
+	if err != nil {
+		log.Error(err)
+	}
+}
+
+func (es *EmailService) handleDeliveryJob(ctx context.Context, payload []byte) error {
+	job := &schema.EmailDeliveryJob{}
+	if err := json.Unmarshal(payload, job); err != nil {
+		return job_queue.Permanent(err)
+	}
+	delivery, exist, err := es.emailDeliveryRepo.GetDelivery(ctx, job.DeliveryID)
+	if err != nil {
+		return err
+	}
+	if !exist || delivery.Status == entity.EmailDeliveryStatusSent {
+		return nil
+	}
+	ec, err := es.GetEmailConfig(ctx)
+	if err != nil {
+		return err
+	}
+	return es.deliver(ctx, ec, delivery)
+}
+
+// deliver send the email and record the result, returns error if it should be retried
+func (es *EmailService) deliver(ctx context.Context, ec *EmailConfig, delivery *entity.EmailDelivery) error {
+	log.Infof("try to send email to %s", delivery.ToEmail)
+	sendErr := es.transport.Send(ctx, ec, delivery.ToEmail, es.composeMessage(ec, delivery))
+	delivery.Attempts++
+	switch {
+	case sendErr == nil:
+		log.Infof("send email to %s success", delivery.ToEmail)
+		delivery.Status = entity.EmailDeliveryStatusSent
+		delivery.Error = ""
+		delivery.SentAt = time.Now()
+	case isPermanentSendError(sendErr):
+		log.Errorf("send email to %s bounced: %s", delivery.ToEmail, sendErr)
+		delivery.Status = entity.EmailDeliveryStatusBounced
+		delivery.Error = sendErr.Error()
+	default:
+		log.Errorf("send email to %s failed: %s", delivery.ToEmail, sendErr)
+		delivery.Status = entity.EmailDeliveryStatusFailed
+		delivery.Error = sendErr.Error()
+	}
+
+	retry := delivery.Status == entity.EmailDeliveryStatusFailed && delivery.Attempts < maxDeliveryAttempts
+	// the body may contain the password reset or email verification link, do not keep it when it's done
+	if !retry {
+		delivery.Body = ""
+	}
+	if err := es.emailDeliveryRepo.UpdateDelivery(ctx, delivery); err != nil {
+		log.Error(err)
+	}
+	if retry {
+		return sendErr
+	}
+	return nil
+}
+
+func (es *EmailService) composeMessage(ec *EmailConfig, delivery *entity.EmailDelivery) *gomail.Message {
+	m := gomail.NewMessage()
+	fromName := mime.QEncoding.Encode("utf-8", ec.FromName)
+	m.SetHeader("From", fmt.Sprintf("%s <%s>", fromName, ec.FromEmail))
+	m.SetHeader("To", delivery.ToEmail)
+	m.SetHeader("Subject", delivery.Subject)
+	if len(delivery.ReplyTo) > 0 {
+		m.SetHeader("Reply-To", delivery.ReplyTo)
+	}
+	if len(delivery.ListUnsubscribeURL) > 0 {
+		// RFC 8058 one-click unsubscribe, mail client will POST "List-Unsubscribe=One-Click" to the url
+		m.SetHeader("List-Unsubscribe", fmt.Sprintf("<%s>", delivery.ListUnsubscribeURL))
+		m.SetHeader("List-Unsubscribe-Post", "List-Unsubscribe=One-Click")
+	}
+	m.SetBody("text/plain", htmltext.PlainText(delivery.Body))
+	m.AddAlternative("text/html", delivery.Body)
+	return m
+}
+
+// PurgeDeliveriesCron delete the sent, bounced and failed delivery logs after the retention days,
+// the logs contain the email addresses and the subjects with question titles and user names
+func (es *EmailService) PurgeDeliveriesCron(ctx context.Context) {
+	retentionDays := defaultDeliveryRetentionDays
+	if es.serviceConfig != nil && es.serviceConfig.EmailDeliveryRetentionDays > 0 {
+		retentionDays = es.serviceConfig.EmailDeliveryRetentionDays
+	}
+	removed, err := es.emailDeliveryRepo.RemoveDoneDeliveries(ctx, time.Now().AddDate(0, 0, -retentionDays))
+	if err != nil {
+		log.Errorf("purge email delivery logs failed: %v", err)
+		return
+	}
+	log.Infof("purged %d email delivery logs older than %d days", removed, retentionDays)
+}
+
+// GetDeliveryPage get email delivery logs
+func (es *EmailService) GetDeliveryPage(ctx context.Context, req *schema.GetEmailDeliveryPageReq) (
+	pageModel *pager.PageModel, err error) {
+	deliveries, total, err := es.emailDeliveryRepo.GetDeliveryPage(ctx, req.Page, req.PageSize,
+		entity.EmailDeliveryStatus[req.Status])
+	if err != nil {
+		return nil, err
+	}
+	resp := make([]*schema.GetEmailDeliveryResp, 0, len(deliveries))
+	for _, delivery := range deliveries {
+		resp = append(resp, formatEmailDelivery(delivery))
+	}
+	return pager.NewPageModel(total, resp), nil
+}
+
+func formatEmailDelivery(delivery *entity.EmailDelivery) *schema.GetEmailDeliveryResp {
+	resp := &schema.GetEmailDeliveryResp{
+		DeliveryID: delivery.ID,
+		ToEmail:    delivery.ToEmail,
+		Subject:    delivery.Subject,
+		Attempts:   delivery.Attempts,
+		Error:      delivery.Error,
+		CreatedAt:  delivery.CreatedAt.Unix(),
+		UpdatedAt:  delivery.UpdatedAt.Unix(),
+	}
+	for name, status := range entity.EmailDeliveryStatus {
+		if status == delivery.Status {
+			resp.Status = name
+		}
+	}
+	if !delivery.SentAt.IsZero() {
+		resp.SentAt = delivery.SentAt.Unix()
+	}
+	return resp
+}
@@ -0,0 +1,234 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package export
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/textproto"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/service/job_queue"
+	"github.com/apache/incubator-answer/internal/service/service_config"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/gomail.v2"
+)
+
+type mockEmailTransport struct {
+	err error
+}
+
+func (t *mockEmailTransport) Configured(ec *EmailConfig) bool {
+	return true
+}
+
+func (t *mockEmailTransport) Send(ctx context.Context, ec *EmailConfig, to string, msg io.WriterTo) error {
+	return t.err
+}
+
+type mockEmailDeliveryRepo struct {
+	EmailDeliveryRepo
+	updated       *entity.EmailDelivery
+	removedBefore time.Time
+}
+
+func (r *mockEmailDeliveryRepo) UpdateDelivery(ctx context.Context, delivery *entity.EmailDelivery) error {
+	r.updated = delivery
+	return nil
+}
+
+func (r *mockEmailDeliveryRepo) RemoveDoneDeliveries(ctx context.Context, updatedBefore time.Time) (int64, error) {
+	r.removedBefore = updatedBefore
+	return 1, nil
+}
+
+func TestFileEmailTransport(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "mail.mbox")
+	transport := NewFileEmailTransport(path)
+	ec := &EmailConfig{FromEmail: "answer@example.com"}
+
+	for i := 0; i < 2; i++ {
+		m := gomail.NewMessage()
+		m.SetHeader("From", ec.FromEmail)
+		m.SetHeader("To", "user@example.com")
+		m.SetBody("text/plain", "hello\nFrom the answer site")
+		assert.NoError(t, transport.Send(context.TODO(), ec, "user@example.com", m))
+	}
+
+	content, err := os.ReadFile(path)
+	assert.NoError(t, err)
+	separators := 0
+	for _, line := range strings.Split(string(content), "\n") {
+		if strings.HasPrefix(line, "From ") {
+			assert.True(t, strings.HasPrefix(line, "From answer@example.com "))
+			separators++
+		}
+	}
+	assert.Equal(t, 2, separators)
+	assert.Contains(t, string(content), "\n>From the answer site\n")
+}
+
+func TestEmailServiceDeliver(t *testing.T) {
+	ec := &EmailConfig{FromEmail: "answer@example.com"}
+	repo := &mockEmailDeliveryRepo{}
+	transport := &mockEmailTransport{}
+	es := &EmailService{emailDeliveryRepo: repo, transport: transport}
+
+	// temporary failure is retried and the body is kept
+	transport.err = errors.New("connection refused")
+	delivery := &entity.EmailDelivery{ToEmail: "user@example.com", Body: "<p>hi</p>"}
+	assert.Error(t, es.deliver(context.TODO(), ec, delivery))
+	assert.Equal(t, entity.EmailDeliveryStatusFailed, repo.updated.Status)
+	assert.Equal(t, "<p>hi</p>", repo.updated.Body)
+
+	// authentication failed, it's the problem of the site and retried
+	transport.err = &textproto.Error{Code: 535, Msg: "authentication failed"}
+	assert.Error(t, es.deliver(context.TODO(), ec, delivery))
+	assert.Equal(t, entity.EmailDeliveryStatusFailed, repo.updated.Status)
+
+	// the recipient is rejected by the smtp server, not retried
+	transport.err = &rcptError{err: &textproto.Error{Code: 550, Msg: "mailbox unavailable"}}
+	assert.NoError(t, es.deliver(context.TODO(), ec, delivery))
+	assert.Equal(t, entity.EmailDeliveryStatusBounced, repo.updated.Status)
+	assert.Empty(t, repo.updated.Body)
+
+	// give up after the max attempts
+	transport.err = errors.New("connection refused")
+	delivery = &entity.EmailDelivery{ToEmail: "user@example.com", Body: "<p>hi</p>", Attempts: maxDeliveryAttempts - 1}
+	assert.NoError(t, es.deliver(context.TODO(), ec, delivery))
+	assert.Equal(t, entity.EmailDeliveryStatusFailed, repo.updated.Status)
+	assert.Empty(t, repo.updated.Body)
+
+	transport.err = nil
+	delivery = &entity.EmailDelivery{ToEmail: "user@example.com", Body: "<p>hi</p>"}
+	assert.NoError(t, es.deliver(context.TODO(), ec, delivery))
+	assert.Equal(t, entity.EmailDeliveryStatusSent, repo.updated.Status)
+	assert.False(t, repo.updated.SentAt.IsZero())
+}
+
+func TestEmailServiceHandleDeliveryJob(t *testing.T) {
+	es := &EmailService{emailDeliveryRepo: &mockEmailDeliveryRepo{}, transport: &mockEmailTransport{}}
+	err := es.handleDeliveryJob(context.TODO(), []byte("{"))
+	assert.True(t, job_queue.IsPermanent(err))
+}
+
+func TestEmailServicePurgeDeliveriesCron(t *testing.T) {
+	repo := &mockEmailDeliveryRepo{}
+	es := &EmailService{emailDeliveryRepo: repo, serviceConfig: &service_config.ServiceConfig{}}
+	es.PurgeDeliveriesCron(context.TODO())
+	assert.WithinDuration(t, time.Now().AddDate(0, 0, -defaultDeliveryRetentionDays), repo.removedBefore, time.Minute)
+
+	es.serviceConfig.EmailDeliveryRetentionDays = 7
+	es.PurgeDeliveriesCron(context.TODO())
+	assert.WithinDuration(t, time.Now().AddDate(0, 0, -7), repo.removedBefore, time.Minute)
+}
+
+func TestIsPermanentSendError(t *testing.T) {
+	rcpt := func(code int, msg string) error {
+		return &rcptError{err: &textproto.Error{Code: code, Msg: msg}}
+	}
+	assert.True(t, isPermanentSendError(rcpt(550, "mailbox unavailable")))
+	assert.True(t, isPermanentSendError(rcpt(551, "user not local")))
+	assert.True(t, isPermanentSendError(rcpt(553, "mailbox name not allowed")))
+	assert.True(t, isPermanentSendError(fmt.Errorf("send: %w", rcpt(550, "no such user"))))
+
+	assert.False(t, isPermanentSendError(rcpt(550, "relay access denied")))
+	assert.False(t, isPermanentSendError(rcpt(452, "too many recipients")))
+	assert.False(t, isPermanentSendError(rcpt(554, "transaction failed")))
+	assert.False(t, isPermanentSendError(&textproto.Error{Code: 550, Msg: "sender rejected"}))
+	assert.False(t, isPermanentSendError(&textproto.Error{Code: 530, Msg: "authentication required"}))
+	assert.False(t, isPermanentSendError(&textproto.Error{Code: 535, Msg: "authentication failed"}))
+	assert.False(t, isPermanentSendError(errors.New("connection refused")))
+}
+
+// serveSMTP a minimal smtp server which replies the rejection to the command
+func serveSMTP(t *testing.T, rejectCommand, rejectReply string) (host string, port int) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+	t.Cleanup(func() { _ = listener.Close() })
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		text := textproto.NewConn(conn)
+		_ = text.PrintfLine("220 localhost ESMTP")
+		for {
+			line, err := text.ReadLine()
+			if err != nil {
+				return
+			}
+			command := strings.ToUpper(strings.SplitN(line, " ", 2)[0])
+			switch {
+			case command == rejectCommand:
+				_ = text.PrintfLine(rejectReply)
+			case command == "EHLO":
+				_ = text.PrintfLine("250 localhost")
+			case command == "DATA":
+				_ = text.PrintfLine("354 go ahead")
+				_, _ = text.ReadDotBytes()
+				_ = text.PrintfLine("250 ok")
+			case command == "QUIT":
+				_ = text.PrintfLine("221 bye")
+				return
+			default:
+				_ = text.PrintfLine("250 ok")
+			}
+		}
+	}()
+	addr := listener.Addr().(*net.TCPAddr)
+	return addr.IP.String(), addr.Port
+}
+
+func TestSMTPEmailTransportSend(t *testing.T) {
+	send := func(rejectCommand, rejectReply string) error {
+		host, port := serveSMTP(t, rejectCommand, rejectReply)
+		ec := &EmailConfig{FromEmail: "answer@example.com", SMTPHost: host, SMTPPort: port}
+		m := gomail.NewMessage()
+		m.SetHeader("From", ec.FromEmail)
+		m.SetHeader("To", "user@example.com")
+		m.SetBody("text/plain", "hello")
+		return (&smtpEmailTransport{}).Send(context.TODO(), ec, "user@example.com", m)
+	}
+
+	assert.NoError(t, send("", ""))
+
+	err := send("RCPT", "550 no such user")
+	assert.Error(t, err)
+	assert.True(t, isPermanentSendError(err))
+
+	// the same reply to the sender is not a bounce of the recipient
+	err = send("MAIL", "550 sender rejected")
+	assert.Error(t, err)
+	assert.False(t, isPermanentSendError(err))
+
+	err = send("MAIL", "530 authentication required")
+	assert.Error(t, err)
+	assert.False(t, isPermanentSendError(err))
+}
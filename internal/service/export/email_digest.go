@@ -0,0 +1,121 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package export
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/apache/incubator-answer/internal/base/constant"
+	"github.com/apache/incubator-answer/internal/base/handler"
+	"github.com/apache/incubator-answer/internal/base/translator"
+	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/apache/incubator-answer/pkg/display"
+	"golang.org/x/net/context"
+)
+
+// NewAnswerDigestItem the new answer notification in digest email
+func (es *EmailService) NewAnswerDigestItem(ctx context.Context, raw *schema.NewAnswerTemplateRawData) (
+	item *schema.DigestItemTemplateData, err error) {
+	title, _, err := es.NewAnswerTemplate(ctx, raw)
+	if err != nil {
+		return nil, err
+	}
+	return es.newDigestItem(ctx, title, raw.AnswerSummary, func(permalink int, siteURL string) string {
+		return display.AnswerURL(permalink, siteURL, raw.QuestionID, raw.QuestionTitle, raw.AnswerID)
+	})
+}
+
+// NewInviteAnswerDigestItem the invite answer notification in digest email
+func (es *EmailService) NewInviteAnswerDigestItem(ctx context.Context, raw *schema.NewInviteAnswerTemplateRawData) (
+	item *schema.DigestItemTemplateData, err error) {
+	title, _, err := es.NewInviteAnswerTemplate(ctx, raw)
+	if err != nil {
+		return nil, err
+	}
+	return es.newDigestItem(ctx, title, raw.QuestionTitle, func(permalink int, siteURL string) string {
+		return display.QuestionURL(permalink, siteURL, raw.QuestionID, raw.QuestionTitle)
+	})
+}
+
+// NewCommentDigestItem the new comment notification in digest email
+func (es *EmailService) NewCommentDigestItem(ctx context.Context, raw *schema.NewCommentTemplateRawData) (
+	item *schema.DigestItemTemplateData, err error) {
+	title, _, err := es.NewCommentTemplate(ctx, raw)
+	if err != nil {
+		return nil, err
+	}
+	return es.newDigestItem(ctx, title, raw.CommentSummary, func(permalink int, siteURL string) string {
+		return display.CommentURL(permalink, siteURL, raw.QuestionID, raw.QuestionTitle, raw.AnswerID, raw.CommentID)
+	})
+}
+
+// NewQuestionDigestItem the new question notification in digest email
+func (es *EmailService) NewQuestionDigestItem(ctx context.Context, raw *schema.NewQuestionTemplateRawData) (
+	item *schema.DigestItemTemplateData, err error) {
+	title, _, err := es.NewQuestionTemplate(ctx, raw)
+	if err != nil {
+		return nil, err
+	}
+	return es.newDigestItem(ctx, title, strings.Join(raw.Tags, ", "), func(permalink int, siteURL string) string {
+		return display.QuestionURL(permalink, siteURL, raw.QuestionID, raw.QuestionTitle)
+	})
+}
+
+func (es *EmailService) newDigestItem(ctx context.Context, title, summary string,
+	buildURL func(permalink int, siteURL string) string) (item *schema.DigestItemTemplateData, err error) {
+	siteInfo, err := es.siteInfoService.GetSiteGeneral(ctx)
+	if err != nil {
+		return nil, err
+	}
+	seoInfo, err := es.siteInfoService.GetSiteSeo(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &schema.DigestItemTemplateData{
+		// the site name is shown in the title of digest email, so remove it from every item
+		Title:   strings.TrimPrefix(title, fmt.Sprintf("[%s] ", siteInfo.Name)),
+		Url:     buildURL(seoInfo.Permalink, siteInfo.SiteUrl),
+		Summary: summary,
+	}, nil
+}
+
+// DigestTemplate the digest email of several notifications
+func (es *EmailService) DigestTemplate(ctx context.Context, items []*schema.DigestItemTemplateData,
+	unsubscribeCode string) (title, body string, err error) {
+	siteInfo, err := es.siteInfoService.GetSiteGeneral(ctx)
+	if err != nil {
+		return
+	}
+	lang := handler.GetLangByCtx(ctx)
+	itemsContent := &strings.Builder{}
+	for _, item := range items {
+		itemsContent.WriteString(translator.TrWithData(lang, constant.EmailTplKeyDigestItem, item))
+	}
+	templateData := &schema.DigestTemplateData{
+		SiteName:       siteInfo.Name,
+		Count:          len(items),
+		Items:          itemsContent.String(),
+		UnsubscribeUrl: fmt.Sprintf("%s/users/unsubscribe?code=%s", siteInfo.SiteUrl, unsubscribeCode),
+	}
+	title = translator.TrWithData(lang, constant.EmailTplKeyDigestTitle, templateData)
+	body = translator.TrWithData(lang, constant.EmailTplKeyDigestBody, templateData)
+	return title, body, nil
+}
@@ -0,0 +1,139 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package export
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base32"
+	"strconv"
+	"strings"
+
+	"github.com/apache/incubator-answer/internal/service/service_config"
+)
+
+const (
+	ReplyTokenKindComment = "c"
+	ReplyTokenKindAnswer  = "a"
+
+	// replyTokenSignatureSize the signature bytes in the token, the local part of address is limited to 64 characters
+	replyTokenSignatureSize = 10
+)
+
+var replyTokenEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// ReplyToken the target which the reply-by-email is posted to, it is signed and carried in the reply-to address
+type ReplyToken struct {
+	Kind           string
+	UserID         string
+	ObjectID       string
+	ReplyCommentID string
+}
+
+// ReplyAddress the signed reply-to address of the token, empty if reply-by-email is disabled
+func (es *EmailService) ReplyAddress(token *ReplyToken) string {
+	if es.serviceConfig == nil || !es.serviceConfig.InboundMail.Enabled() {
+		return ""
+	}
+	return EncodeReplyAddress(es.serviceConfig.InboundMail, token)
+}
+
+// ParseReplyAddress get the token from the reply-to address, false if the address is not a valid reply address
+func (es *EmailService) ParseReplyAddress(address string) (token *ReplyToken, ok bool) {
+	if es.serviceConfig == nil || !es.serviceConfig.InboundMail.Enabled() {
+		return nil, false
+	}
+	return DecodeReplyAddress(es.serviceConfig.InboundMail, address)
+}
+
+// EncodeReplyAddress encode the token as "local+token@domain" of the reply address.
+// The ids are in base36 and lowercase, as some MTA do not keep the case of the local part.
+func EncodeReplyAddress(conf *service_config.InboundMailConfig, token *ReplyToken) string {
+	local, domain, found := strings.Cut(conf.ReplyAddress, "@")
+	if !found {
+		return ""
+	}
+	fields := []string{token.Kind}
+	for _, id := range []string{token.UserID, token.ObjectID, token.ReplyCommentID} {
+		if len(id) == 0 {
+			continue
+		}
+		num, err := strconv.ParseInt(id, 10, 64)
+		if err != nil {
+			return ""
+		}
+		fields = append(fields, strconv.FormatInt(num, 36))
+	}
+	data := strings.Join(fields, "-")
+	return local + "+" + data + "-" + signReplyToken(conf.Secret, data) + "@" + domain
+}
+
+// DecodeReplyAddress decode and verify the token in the reply address
+func DecodeReplyAddress(conf *service_config.InboundMailConfig, address string) (token *ReplyToken, ok bool) {
+	local, domain, found := strings.Cut(conf.ReplyAddress, "@")
+	if !found {
+		return nil, false
+	}
+	addrLocal, addrDomain, found := strings.Cut(strings.ToLower(address), "@")
+	if !found || addrDomain != strings.ToLower(domain) {
+		return nil, false
+	}
+	prefix := strings.ToLower(local) + "+"
+	if !strings.HasPrefix(addrLocal, prefix) {
+		return nil, false
+	}
+	data := strings.TrimPrefix(addrLocal, prefix)
+	sepIndex := strings.LastIndex(data, "-")
+	if sepIndex < 0 {
+		return nil, false
+	}
+	data, signature := data[:sepIndex], data[sepIndex+1:]
+	if subtle.ConstantTimeCompare([]byte(signature), []byte(signReplyToken(conf.Secret, data))) != 1 {
+		return nil, false
+	}
+
+	fields := strings.Split(data, "-")
+	if len(fields) < 3 || len(fields) > 4 {
+		return nil, false
+	}
+	ids := make([]string, 0, 3)
+	for _, field := range fields[1:] {
+		num, err := strconv.ParseInt(field, 36, 64)
+		if err != nil {
+			return nil, false
+		}
+		ids = append(ids, strconv.FormatInt(num, 10))
+	}
+	token = &ReplyToken{Kind: fields[0], UserID: ids[0], ObjectID: ids[1]}
+	if len(ids) > 2 {
+		token.ReplyCommentID = ids[2]
+	}
+	if token.Kind != ReplyTokenKindComment && token.Kind != ReplyTokenKindAnswer {
+		return nil, false
+	}
+	return token, true
+}
+
+func signReplyToken(secret, data string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(data))
+	return strings.ToLower(replyTokenEncoding.EncodeToString(mac.Sum(nil)[:replyTokenSignatureSize]))
+}
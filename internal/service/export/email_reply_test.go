@@ -0,0 +1,54 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package export
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/apache/incubator-answer/internal/service/service_config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReplyAddress(t *testing.T) {
+	conf := &service_config.InboundMailConfig{ReplyAddress: "reply@example.com", Secret: "secret"}
+	token := &ReplyToken{
+		Kind:           ReplyTokenKindComment,
+		UserID:         "1",
+		ObjectID:       "10020000000000001",
+		ReplyCommentID: "10040000000000002",
+	}
+	address := EncodeReplyAddress(conf, token)
+	local, _, _ := strings.Cut(address, "@")
+	assert.LessOrEqual(t, len(local), 64)
+	assert.True(t, strings.HasSuffix(address, "@example.com"))
+
+	decoded, ok := DecodeReplyAddress(conf, strings.ToUpper(address))
+	assert.True(t, ok)
+	assert.Equal(t, token, decoded)
+
+	// tampered token or another secret
+	_, ok = DecodeReplyAddress(conf, strings.Replace(address, "c-1-", "c-2-", 1))
+	assert.False(t, ok)
+	_, ok = DecodeReplyAddress(&service_config.InboundMailConfig{ReplyAddress: "reply@example.com", Secret: "another"}, address)
+	assert.False(t, ok)
+	_, ok = DecodeReplyAddress(conf, "someone@example.com")
+	assert.False(t, ok)
+}
@@ -20,12 +20,9 @@
 package export
 
 import (
-	"crypto/tls"
 	"encoding/json"
 	"fmt"
 	"github.com/apache/incubator-answer/pkg/display"
-	"mime"
-	"os"
 	"strings"
 	"time"
 
@@ -35,18 +32,23 @@ import (
 	"github.com/apache/incubator-answer/internal/base/translator"
 	"github.com/apache/incubator-answer/internal/schema"
 	"github.com/apache/incubator-answer/internal/service/config"
+	"github.com/apache/incubator-answer/internal/service/job_queue"
+	"github.com/apache/incubator-answer/internal/service/service_config"
 	"github.com/apache/incubator-answer/internal/service/siteinfo_common"
 	"github.com/segmentfault/pacman/errors"
 	"github.com/segmentfault/pacman/log"
 	"golang.org/x/net/context"
-	"gopkg.in/gomail.v2"
 )
 
 // EmailService kit service
 type EmailService struct {
-	configService   *config.ConfigService
-	emailRepo       EmailRepo
-	siteInfoService siteinfo_common.SiteInfoCommonService
+	configService     *config.ConfigService
+	emailRepo         EmailRepo
+	emailDeliveryRepo EmailDeliveryRepo
+	siteInfoService   siteinfo_common.SiteInfoCommonService
+	jobQueueService   *job_queue.JobQueueService
+	serviceConfig     *service_config.ServiceConfig
+	transport         EmailTransport
 }
 
 // EmailRepo email repository
@@ -59,13 +61,37 @@ type EmailRepo interface {
 func NewEmailService(
 	configService *config.ConfigService,
 	emailRepo EmailRepo,
+	emailDeliveryRepo EmailDeliveryRepo,
 	siteInfoService siteinfo_common.SiteInfoCommonService,
+	jobQueueService *job_queue.JobQueueService,
+	serviceConfig *service_config.ServiceConfig,
 ) *EmailService {
-	return &EmailService{
-		configService:   configService,
-		emailRepo:       emailRepo,
-		siteInfoService: siteInfoService,
+	es := &EmailService{
+		configService:     configService,
+		emailRepo:         emailRepo,
+		emailDeliveryRepo: emailDeliveryRepo,
+		siteInfoService:   siteInfoService,
+		jobQueueService:   jobQueueService,
+		serviceConfig:     serviceConfig,
+		transport:         NewEmailTransport(),
 	}
+	jobQueueService.RegisterHandler(EmailQueueName, es.handleDeliveryJob)
+	return es
+}
+
+// OneClickUnsubscribePath the api path for RFC 8058 one-click unsubscribe
+const OneClickUnsubscribePath = "/answer/api/v1/user/notification/unsubscribe/one-click"
+
+// EmailMessage the email to be sent
+type EmailMessage struct {
+	To      string
+	Subject string
+	// Body the html body, the plain text alternative is generated from it
+	Body string
+	// ListUnsubscribeURL the one-click unsubscribe url, only notification emails have it
+	ListUnsubscribeURL string
+	// ReplyTo the signed reply address, the reply will be posted as answer or comment
+	ReplyTo string
 }
 
 // EmailConfig email config
@@ -106,52 +132,40 @@ func (es *EmailService) SendAndSaveCode(ctx context.Context, userID, toEmailAddr
 	es.Send(ctx, toEmailAddr, subject, body)
 }
 
-// SendAndSaveCodeWithTime send email and save code
-func (es *EmailService) SendAndSaveCodeWithTime(
-	ctx context.Context, userID, toEmailAddr, subject, body, code, codeContent string, duration time.Duration) {
+// SaveCodeWithTime save code with the duration
+func (es *EmailService) SaveCodeWithTime(ctx context.Context, userID, code, codeContent string, duration time.Duration) {
 	err := es.emailRepo.SetCode(ctx, userID, code, codeContent, duration)
 	if err != nil {
 		log.Error(err)
-		return
 	}
-	es.Send(ctx, toEmailAddr, subject, body)
 }
 
-// Send email send
-func (es *EmailService) Send(ctx context.Context, toEmailAddr, subject, body string) {
-	log.Infof("try to send email to %s", toEmailAddr)
-	ec, err := es.GetEmailConfig(ctx)
+// SendNotificationAndSaveCode send notification email and save the unsubscribe code of the links in the body,
+// the one-click unsubscribe header of the email has a long-lived signed token instead of the code.
+func (es *EmailService) SendNotificationAndSaveCode(ctx context.Context, userID string, msg *EmailMessage,
+	code string, codeContent *schema.EmailCodeContent, duration time.Duration) {
+	err := es.emailRepo.SetCode(ctx, userID, code, codeContent.ToJSONString(), duration)
 	if err != nil {
-		log.Errorf("get email config failed: %s", err)
+		log.Error(err)
 		return
 	}
-	if len(ec.SMTPHost) == 0 {
-		log.Warnf("smtp host is empty, skip send email")
+	siteInfo, err := es.siteInfoService.GetSiteGeneral(ctx)
+	if err != nil {
+		log.Error(err)
 		return
 	}
-
-	m := gomail.NewMessage()
-	fromName := mime.QEncoding.Encode("utf-8", ec.FromName)
-	m.SetHeader("From", fmt.Sprintf("%s <%s>", fromName, ec.FromEmail))
-	m.SetHeader("To", toEmailAddr)
-	m.SetHeader("Subject", subject)
-	m.SetBody("text/html", body)
-
-	d := gomail.NewDialer(ec.SMTPHost, ec.SMTPPort, ec.SMTPUsername, ec.SMTPPassword)
-	if ec.IsSSL() {
-		d.SSL = true
-	}
-	if ec.IsTLS() {
-		d.SSL = false
-	}
-	if len(os.Getenv("SKIP_SMTP_TLS_VERIFY")) > 0 {
-		d.TLSConfig = &tls.Config{ServerName: d.Host, InsecureSkipVerify: true}
-	}
-	if err := d.DialAndSend(m); err != nil {
-		log.Errorf("send email to %s failed: %s", toEmailAddr, err)
-	} else {
-		log.Infof("send email to %s success", toEmailAddr)
+	unsubscribeToken, err := es.UnsubscribeToken(ctx, codeContent.UserID, codeContent.NotificationSources)
+	if err != nil {
+		log.Error(err)
+		return
 	}
+	msg.ListUnsubscribeURL = fmt.Sprintf("%s%s?token=%s", siteInfo.SiteUrl, OneClickUnsubscribePath, unsubscribeToken)
+	es.SendMessage(ctx, msg)
+}
+
+// Send email send
+func (es *EmailService) Send(ctx context.Context, toEmailAddr, subject, body string) {
+	es.SendMessage(ctx, &EmailMessage{To: toEmailAddr, Subject: subject, Body: body})
 }
 
 // VerifyUrlExpired email send
@@ -241,6 +255,7 @@ func (es *EmailService) NewAnswerTemplate(ctx context.Context, raw *schema.NewAn
 		AnswerUrl:      display.AnswerURL(seoInfo.Permalink, siteInfo.SiteUrl, raw.QuestionID, raw.QuestionTitle, raw.AnswerID),
 		AnswerSummary:  raw.AnswerSummary,
 		UnsubscribeUrl: fmt.Sprintf("%s/users/unsubscribe?code=%s", siteInfo.SiteUrl, raw.UnsubscribeCode),
+		MuteUrl:        fmt.Sprintf("%s/users/unsubscribe?code=%s&type=mute", siteInfo.SiteUrl, raw.MuteCode),
 	}
 
 	lang := handler.GetLangByCtx(ctx)
@@ -291,6 +306,7 @@ func (es *EmailService) NewCommentTemplate(ctx context.Context, raw *schema.NewC
 		QuestionTitle:  raw.QuestionTitle,
 		CommentSummary: raw.CommentSummary,
 		UnsubscribeUrl: fmt.Sprintf("%s/users/unsubscribe?code=%s", siteInfo.SiteUrl, raw.UnsubscribeCode),
+		MuteUrl:        fmt.Sprintf("%s/users/unsubscribe?code=%s&type=mute", siteInfo.SiteUrl, raw.MuteCode),
 	}
 	templateData.CommentUrl = display.CommentURL(seoInfo.Permalink,
 		siteInfo.SiteUrl, raw.QuestionID, raw.QuestionTitle, raw.AnswerID, raw.CommentID)
@@ -0,0 +1,272 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package export
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/smtp"
+	"net/textproto"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// EmailTransport deliver the composed email message. It is SMTP by default, tests and development setups
+// can set EMAIL_FILE_SINK to write all messages to a local mbox file instead.
+type EmailTransport interface {
+	// Configured whether the transport is able to send with the email config
+	Configured(ec *EmailConfig) bool
+	Send(ctx context.Context, ec *EmailConfig, to string, msg io.WriterTo) error
+}
+
+// NewEmailTransport new email transport according to the environment
+func NewEmailTransport() EmailTransport {
+	if path := os.Getenv("EMAIL_FILE_SINK"); len(path) > 0 {
+		return NewFileEmailTransport(path)
+	}
+	return &smtpEmailTransport{}
+}
+
+// smtpEmailTransport send the message by the smtp server in email config
+type smtpEmailTransport struct{}
+
+func (t *smtpEmailTransport) Configured(ec *EmailConfig) bool {
+	return len(ec.SMTPHost) > 0
+}
+
+func (t *smtpEmailTransport) Send(ctx context.Context, ec *EmailConfig, to string, msg io.WriterTo) error {
+	c, err := t.dial(ec)
+	if err != nil {
+		return err
+	}
+	defer c.Close()
+	if err = c.Mail(ec.FromEmail); err != nil {
+		return err
+	}
+	// only the rejection of the recipient tells the address is bad, keep the stage in the error
+	if err = c.Rcpt(to); err != nil {
+		return &rcptError{err: err}
+	}
+	w, err := c.Data()
+	if err != nil {
+		return err
+	}
+	if _, err = msg.WriteTo(w); err != nil {
+		_ = w.Close()
+		return err
+	}
+	if err = w.Close(); err != nil {
+		return err
+	}
+	return c.Quit()
+}
+
+// dial connect and authenticate to the smtp server, the same way as gomail does
+func (t *smtpEmailTransport) dial(ec *EmailConfig) (*smtp.Client, error) {
+	ssl := ec.SMTPPort == 465
+	if ec.IsSSL() {
+		ssl = true
+	}
+	if ec.IsTLS() {
+		ssl = false
+	}
+	tlsConfig := &tls.Config{ServerName: ec.SMTPHost}
+	if len(os.Getenv("SKIP_SMTP_TLS_VERIFY")) > 0 {
+		tlsConfig.InsecureSkipVerify = true
+	}
+
+	conn, err := net.DialTimeout("tcp", net.JoinHostPort(ec.SMTPHost, strconv.Itoa(ec.SMTPPort)), 10*time.Second)
+	if err != nil {
+		return nil, err
+	}
+	if ssl {
+		conn = tls.Client(conn, tlsConfig)
+	}
+	c, err := smtp.NewClient(conn, ec.SMTPHost)
+	if err != nil {
+		_ = conn.Close()
+		return nil, err
+	}
+	if !ssl {
+		if ok, _ := c.Extension("STARTTLS"); ok {
+			if err = c.StartTLS(tlsConfig); err != nil {
+				_ = c.Close()
+				return nil, err
+			}
+		}
+	}
+	if len(ec.SMTPUsername) == 0 {
+		return c, nil
+	}
+	ok, auths := c.Extension("AUTH")
+	if !ok {
+		return c, nil
+	}
+	var auth smtp.Auth
+	switch {
+	case strings.Contains(auths, "CRAM-MD5"):
+		auth = smtp.CRAMMD5Auth(ec.SMTPUsername, ec.SMTPPassword)
+	case strings.Contains(auths, "LOGIN") && !strings.Contains(auths, "PLAIN"):
+		auth = &loginAuth{username: ec.SMTPUsername, password: ec.SMTPPassword, host: ec.SMTPHost}
+	default:
+		auth = smtp.PlainAuth("", ec.SMTPUsername, ec.SMTPPassword, ec.SMTPHost)
+	}
+	if err = c.Auth(auth); err != nil {
+		_ = c.Close()
+		return nil, err
+	}
+	return c, nil
+}
+
+// loginAuth the LOGIN authentication mechanism, which is not in net/smtp
+type loginAuth struct {
+	username string
+	password string
+	host     string
+}
+
+func (a *loginAuth) Start(server *smtp.ServerInfo) (string, []byte, error) {
+	if !server.TLS {
+		advertised := false
+		for _, mechanism := range server.Auth {
+			if mechanism == "LOGIN" {
+				advertised = true
+				break
+			}
+		}
+		if !advertised {
+			return "", nil, errors.New("unencrypted connection")
+		}
+	}
+	if server.Name != a.host {
+		return "", nil, errors.New("wrong host name")
+	}
+	return "LOGIN", nil, nil
+}
+
+func (a *loginAuth) Next(fromServer []byte, more bool) ([]byte, error) {
+	if !more {
+		return nil, nil
+	}
+	switch {
+	case bytes.Equal(fromServer, []byte("Username:")):
+		return []byte(a.username), nil
+	case bytes.Equal(fromServer, []byte("Password:")):
+		return []byte(a.password), nil
+	default:
+		return nil, fmt.Errorf("unexpected server challenge: %s", fromServer)
+	}
+}
+
+// rcptError the smtp server rejected the recipient
+type rcptError struct {
+	err error
+}
+
+func (e *rcptError) Error() string {
+	return e.err.Error()
+}
+
+func (e *rcptError) Unwrap() error {
+	return e.err
+}
+
+// fileEmailTransport append the message to a local mbox file
+type fileEmailTransport struct {
+	path string
+	lock sync.Mutex
+}
+
+// NewFileEmailTransport new email transport which writes messages to the mbox file
+func NewFileEmailTransport(path string) EmailTransport {
+	return &fileEmailTransport{path: path}
+}
+
+func (t *fileEmailTransport) Configured(ec *EmailConfig) bool {
+	return true
+}
+
+func (t *fileEmailTransport) Send(ctx context.Context, ec *EmailConfig, to string, msg io.WriterTo) error {
+	buf := &bytes.Buffer{}
+	if _, err := msg.WriteTo(buf); err != nil {
+		return err
+	}
+	from := ec.FromEmail
+	if len(from) == 0 {
+		from = "MAILER-DAEMON"
+	}
+
+	out := &bytes.Buffer{}
+	out.WriteString(fmt.Sprintf("From %s %s\n", from, time.Now().UTC().Format(time.ANSIC)))
+	scanner := bufio.NewScanner(buf)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := strings.TrimSuffix(scanner.Text(), "\r")
+		// mboxrd quoting, the lines starting with "From " are separators
+		if strings.HasPrefix(strings.TrimLeft(line, ">"), "From ") {
+			line = ">" + line
+		}
+		out.WriteString(line + "\n")
+	}
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+	out.WriteString("\n")
+
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	f, err := os.OpenFile(t.path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o600)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	_, err = f.Write(out.Bytes())
+	return err
+}
+
+// isPermanentSendError whether the smtp server rejected the recipient permanently, the message is bounced
+// and should not be retried. The other errors, such as authentication, relay denied or connection errors,
+// are the problem of the site or the server, they are retried.
+func isPermanentSendError(err error) bool {
+	var rcptErr *rcptError
+	if !errors.As(err, &rcptErr) {
+		return false
+	}
+	var smtpErr *textproto.Error
+	if !errors.As(rcptErr.err, &smtpErr) {
+		return false
+	}
+	switch smtpErr.Code {
+	case 550, 551, 553:
+		// 550 may also be the relay denied, the mailbox is fine in that case
+		return !strings.Contains(strings.ToLower(smtpErr.Msg), "relay")
+	default:
+		return false
+	}
+}
@@ -0,0 +1,105 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package export
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/subtle"
+	"strings"
+
+	"github.com/apache/incubator-answer/internal/base/constant"
+	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/segmentfault/pacman/log"
+)
+
+// unsubscribeTokenSignatureSize the signature bytes in the one-click unsubscribe token
+const unsubscribeTokenSignatureSize = 16
+
+// UnsubscribeToken the signed one-click unsubscribe token of the List-Unsubscribe header.
+// Unlike the email code it never expires, as the mail client may post it long after the email is sent.
+func (es *EmailService) UnsubscribeToken(ctx context.Context,
+	userID string, sources []constant.NotificationSource) (token string, err error) {
+	secret, err := es.configService.GetStringValue(ctx, constant.EmailUnsubscribeSecretKey)
+	if err != nil {
+		return "", err
+	}
+	return EncodeUnsubscribeToken(secret, userID, sources), nil
+}
+
+// VerifyUnsubscribeToken verify the one-click unsubscribe token,
+// return the unsubscribe code content, empty if the token is invalid
+func (es *EmailService) VerifyUnsubscribeToken(ctx context.Context, token string) (content string) {
+	secret, err := es.configService.GetStringValue(ctx, constant.EmailUnsubscribeSecretKey)
+	if err != nil {
+		log.Error(err)
+		return ""
+	}
+	userID, sources, ok := DecodeUnsubscribeToken(secret, token)
+	if !ok {
+		return ""
+	}
+	codeContent := &schema.EmailCodeContent{
+		SourceType:          schema.UnsubscribeSourceType,
+		UserID:              userID,
+		NotificationSources: sources,
+	}
+	return codeContent.ToJSONString()
+}
+
+// EncodeUnsubscribeToken encode the token as "userID.source,source.signature"
+func EncodeUnsubscribeToken(secret, userID string, sources []constant.NotificationSource) string {
+	fields := make([]string, 0, len(sources))
+	for _, source := range sources {
+		fields = append(fields, string(source))
+	}
+	data := userID + "." + strings.Join(fields, ",")
+	return data + "." + signUnsubscribeToken(secret, data)
+}
+
+// DecodeUnsubscribeToken decode and verify the one-click unsubscribe token
+func DecodeUnsubscribeToken(secret, token string) (userID string, sources []constant.NotificationSource, ok bool) {
+	if len(secret) == 0 {
+		return "", nil, false
+	}
+	sepIndex := strings.LastIndex(token, ".")
+	if sepIndex < 0 {
+		return "", nil, false
+	}
+	data, signature := token[:sepIndex], token[sepIndex+1:]
+	if subtle.ConstantTimeCompare([]byte(signature), []byte(signUnsubscribeToken(secret, data))) != 1 {
+		return "", nil, false
+	}
+	userID, sourceData, found := strings.Cut(data, ".")
+	if !found || len(userID) == 0 || len(sourceData) == 0 {
+		return "", nil, false
+	}
+	for _, source := range strings.Split(sourceData, ",") {
+		sources = append(sources, constant.NotificationSource(source))
+	}
+	return userID, sources, true
+}
+
+func signUnsubscribeToken(secret, data string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte("unsubscribe:" + data))
+	return strings.ToLower(replyTokenEncoding.EncodeToString(mac.Sum(nil)[:unsubscribeTokenSignatureSize]))
+}
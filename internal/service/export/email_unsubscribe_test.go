@@ -0,0 +1,48 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package export
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/apache/incubator-answer/internal/base/constant"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUnsubscribeToken(t *testing.T) {
+	sources := []constant.NotificationSource{constant.InboxSource, constant.AllNewQuestionSource}
+	token := EncodeUnsubscribeToken("secret", "1", sources)
+
+	userID, decoded, ok := DecodeUnsubscribeToken("secret", token)
+	assert.True(t, ok)
+	assert.Equal(t, "1", userID)
+	assert.Equal(t, sources, decoded)
+
+	// tampered token, another secret or no secret
+	_, _, ok = DecodeUnsubscribeToken("secret", strings.Replace(token, "1.", "2.", 1))
+	assert.False(t, ok)
+	_, _, ok = DecodeUnsubscribeToken("another", token)
+	assert.False(t, ok)
+	_, _, ok = DecodeUnsubscribeToken("", EncodeUnsubscribeToken("", "1", sources))
+	assert.False(t, ok)
+	_, _, ok = DecodeUnsubscribeToken("secret", "1")
+	assert.False(t, ok)
+}
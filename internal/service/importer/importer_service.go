@@ -0,0 +1,85 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package importer
+
+import (
+	"context"
+	"encoding/json"
+	"path/filepath"
+
+	"github.com/apache/incubator-answer/internal/base/reason"
+	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/apache/incubator-answer/internal/service/job_queue"
+	"github.com/apache/incubator-answer/pkg/dir"
+	"github.com/segmentfault/pacman/errors"
+	"github.com/segmentfault/pacman/log"
+)
+
+// StackExchangeImportQueueName the name of the persisted queue which runs the Stack Exchange import
+const StackExchangeImportQueueName = "stackexchange_import"
+
+// stackExchangeImportJob the payload of import job
+type stackExchangeImportJob struct {
+	Dir    string `json:"dir"`
+	UserID string `json:"user_id"`
+}
+
+// ImporterService run the imports as admin jobs
+type ImporterService struct {
+	stackExchangeImporter *StackExchangeImporter
+	jobQueueService       *job_queue.JobQueueService
+}
+
+// NewImporterService new importer service
+func NewImporterService(
+	stackExchangeImporter *StackExchangeImporter,
+	jobQueueService *job_queue.JobQueueService,
+) *ImporterService {
+	is := &ImporterService{
+		stackExchangeImporter: stackExchangeImporter,
+		jobQueueService:       jobQueueService,
+	}
+	jobQueueService.RegisterHandler(StackExchangeImportQueueName, is.handleStackExchangeImportJob)
+	return is
+}
+
+// StartStackExchangeImport check the dump and add the import job to queue
+func (is *ImporterService) StartStackExchangeImport(ctx context.Context, req *schema.StackExchangeImportReq) (err error) {
+	if !dir.CheckFileExist(filepath.Join(req.Dir, sePostsFile)) {
+		return errors.BadRequest(reason.ImportSourceNotFound)
+	}
+	return is.jobQueueService.Enqueue(ctx, StackExchangeImportQueueName, &stackExchangeImportJob{
+		Dir:    req.Dir,
+		UserID: req.UserID,
+	})
+}
+
+func (is *ImporterService) handleStackExchangeImportJob(ctx context.Context, payload []byte) error {
+	job := &stackExchangeImportJob{}
+	if err := json.Unmarshal(payload, job); err != nil {
+		return job_queue.Permanent(err)
+	}
+	result, err := is.stackExchangeImporter.Import(ctx, &schema.StackExchangeImportReq{Dir: job.Dir, UserID: job.UserID})
+	if err != nil {
+		return err
+	}
+	log.Infof("[stackexchange import] %s done: %+v", job.Dir, *result)
+	return nil
+}
@@ -0,0 +1,34 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package importer
+
+import (
+	"context"
+	"testing"
+
+	"github.com/apache/incubator-answer/internal/service/job_queue"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestImporterServiceHandleStackExchangeImportJob(t *testing.T) {
+	err := (&ImporterService{}).handleStackExchangeImportJob(context.TODO(), []byte("{"))
+	assert.Error(t, err)
+	assert.True(t, job_queue.IsPermanent(err))
+}
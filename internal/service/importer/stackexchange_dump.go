@@ -0,0 +1,234 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package importer
+
+import (
+	"encoding/xml"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// The files in the Stack Exchange data dump
+const (
+	seUsersFile       = "Users.xml"
+	seTagsFile        = "Tags.xml"
+	sePostsFile       = "Posts.xml"
+	sePostHistoryFile = "PostHistory.xml"
+	seCommentsFile    = "Comments.xml"
+	seVotesFile       = "Votes.xml"
+)
+
+// The types of posts and post history rows which can be imported
+const (
+	sePostTypeQuestion = 1
+	sePostTypeAnswer   = 2
+
+	sePostHistoryInitialTitle  = 1
+	sePostHistoryInitialBody   = 2
+	sePostHistoryInitialTags   = 3
+	sePostHistoryEditTitle     = 4
+	sePostHistoryEditBody      = 5
+	sePostHistoryEditTags      = 6
+	sePostHistoryRollbackTitle = 7
+	sePostHistoryRollbackBody  = 8
+	sePostHistoryRollbackTags  = 9
+
+	seVoteTypeUpMod   = 2
+	seVoteTypeDownMod = 3
+)
+
+// seTimeLayout the time in dump has no time zone, it is UTC
+const seTimeLayout = "2006-01-02T15:04:05.999"
+
+type seUser struct {
+	ID             string `xml:"Id,attr"`
+	Reputation     int    `xml:"Reputation,attr"`
+	CreationDate   string `xml:"CreationDate,attr"`
+	DisplayName    string `xml:"DisplayName,attr"`
+	LastAccessDate string `xml:"LastAccessDate,attr"`
+	WebsiteURL     string `xml:"WebsiteUrl,attr"`
+	Location       string `xml:"Location,attr"`
+	AboutMe        string `xml:"AboutMe,attr"`
+	// Email only exists in the dump of Stack Overflow for Teams
+	Email string `xml:"Email,attr"`
+}
+
+type seTag struct {
+	ID      string `xml:"Id,attr"`
+	TagName string `xml:"TagName,attr"`
+}
+
+type sePost struct {
+	ID               string `xml:"Id,attr"`
+	PostTypeID       int    `xml:"PostTypeId,attr"`
+	ParentID         string `xml:"ParentId,attr"`
+	AcceptedAnswerID string `xml:"AcceptedAnswerId,attr"`
+	CreationDate     string `xml:"CreationDate,attr"`
+	Score            int    `xml:"Score,attr"`
+	ViewCount        int    `xml:"ViewCount,attr"`
+	Body             string `xml:"Body,attr"`
+	OwnerUserID      string `xml:"OwnerUserId,attr"`
+	LastEditorUserID string `xml:"LastEditorUserId,attr"`
+	LastEditDate     string `xml:"LastEditDate,attr"`
+	LastActivityDate string `xml:"LastActivityDate,attr"`
+	Title            string `xml:"Title,attr"`
+	Tags             string `xml:"Tags,attr"`
+	CommentCount     int    `xml:"CommentCount,attr"`
+	ClosedDate       string `xml:"ClosedDate,attr"`
+}
+
+type sePostHistory struct {
+	ID                string `xml:"Id,attr"`
+	PostHistoryTypeID int    `xml:"PostHistoryTypeId,attr"`
+	PostID            string `xml:"PostId,attr"`
+	RevisionGUID      string `xml:"RevisionGUID,attr"`
+	CreationDate      string `xml:"CreationDate,attr"`
+	UserID            string `xml:"UserId,attr"`
+	Comment           string `xml:"Comment,attr"`
+	Text              string `xml:"Text,attr"`
+}
+
+type seComment struct {
+	ID           string `xml:"Id,attr"`
+	PostID       string `xml:"PostId,attr"`
+	Score        int    `xml:"Score,attr"`
+	Text         string `xml:"Text,attr"`
+	CreationDate string `xml:"CreationDate,attr"`
+	UserID       string `xml:"UserId,attr"`
+}
+
+type seVote struct {
+	ID           string `xml:"Id,attr"`
+	PostID       string `xml:"PostId,attr"`
+	VoteTypeID   int    `xml:"VoteTypeId,attr"`
+	UserID       string `xml:"UserId,attr"`
+	CreationDate string `xml:"CreationDate,attr"`
+}
+
+// readStackExchangeRows reads the <row> elements of the file one by one,
+// exist is false if the file is not in the dump.
+func readStackExchangeRows[T any](dir, fileName string, fn func(row *T) error) (exist bool, err error) {
+	file, err := os.Open(filepath.Join(dir, fileName))
+	if errors.Is(err, os.ErrNotExist) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	defer file.Close()
+
+	decoder := xml.NewDecoder(file)
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			return true, nil
+		}
+		if err != nil {
+			return true, err
+		}
+		start, ok := token.(xml.StartElement)
+		if !ok || start.Name.Local != "row" {
+			continue
+		}
+		row := new(T)
+		if err = decoder.DecodeElement(row, &start); err != nil {
+			return true, err
+		}
+		if err = fn(row); err != nil {
+			return true, err
+		}
+	}
+}
+
+// parseStackExchangeTime parses the time in dump, zero time is returned if it is empty or invalid
+func parseStackExchangeTime(value string) time.Time {
+	t, err := time.ParseInLocation(seTimeLayout, value, time.UTC)
+	if err != nil {
+		return time.Time{}
+	}
+	return t
+}
+
+// parseStackExchangeTags parses the tags of post, both `<a><b>` and `|a|b|` formats are used in dumps
+func parseStackExchangeTags(value string) (tags []string) {
+	value = strings.NewReplacer("><", "|", "<", "|", ">", "|").Replace(value)
+	for _, tag := range strings.Split(value, "|") {
+		tag = strings.TrimSpace(tag)
+		if len(tag) > 0 {
+			tags = append(tags, tag)
+		}
+	}
+	return tags
+}
+
+// seRevisionState the content of a post after one revision in PostHistory.xml
+type seRevisionState struct {
+	GUID      string
+	UserID    string
+	CreatedAt time.Time
+	Comment   string
+	Title     string
+	Body      string
+	Tags      []string
+}
+
+// applyPostHistory applies the history row to the revisions of post,
+// the rows with the same RevisionGUID are merged into one revision.
+func applyPostHistory(revisions []*seRevisionState, row *sePostHistory) []*seRevisionState {
+	var field string
+	switch row.PostHistoryTypeID {
+	case sePostHistoryInitialTitle, sePostHistoryEditTitle, sePostHistoryRollbackTitle:
+		field = "title"
+	case sePostHistoryInitialBody, sePostHistoryEditBody, sePostHistoryRollbackBody:
+		field = "body"
+	case sePostHistoryInitialTags, sePostHistoryEditTags, sePostHistoryRollbackTags:
+		field = "tags"
+	default:
+		return revisions
+	}
+
+	var current *seRevisionState
+	if len(revisions) > 0 && revisions[len(revisions)-1].GUID == row.RevisionGUID {
+		current = revisions[len(revisions)-1]
+	} else {
+		current = &seRevisionState{GUID: row.RevisionGUID}
+		if len(revisions) > 0 {
+			last := revisions[len(revisions)-1]
+			current.Title, current.Body, current.Tags = last.Title, last.Body, last.Tags
+		}
+		current.UserID = row.UserID
+		current.CreatedAt = parseStackExchangeTime(row.CreationDate)
+		current.Comment = row.Comment
+		revisions = append(revisions, current)
+	}
+	switch field {
+	case "title":
+		current.Title = row.Text
+	case "body":
+		current.Body = row.Text
+	case "tags":
+		current.Tags = parseStackExchangeTags(row.Text)
+	}
+	return revisions
+}
@@ -0,0 +1,86 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package importer
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseStackExchangeTags(t *testing.T) {
+	assert.Equal(t, []string{"python", "c#"}, parseStackExchangeTags("<python><c#>"))
+	assert.Equal(t, []string{"python", "go"}, parseStackExchangeTags("|python|go|"))
+	assert.Empty(t, parseStackExchangeTags(""))
+}
+
+func TestParseStackExchangeTime(t *testing.T) {
+	assert.Equal(t, time.Date(2010, 7, 19, 19, 12, 12, 510000000, time.UTC),
+		parseStackExchangeTime("2010-07-19T19:12:12.510"))
+	assert.True(t, parseStackExchangeTime("").IsZero())
+}
+
+func TestApplyPostHistory(t *testing.T) {
+	rows := []*sePostHistory{
+		{PostHistoryTypeID: sePostHistoryInitialTitle, RevisionGUID: "a", UserID: "1", Text: "title"},
+		{PostHistoryTypeID: sePostHistoryInitialBody, RevisionGUID: "a", UserID: "1", Text: "body"},
+		{PostHistoryTypeID: sePostHistoryInitialTags, RevisionGUID: "a", UserID: "1", Text: "<go>"},
+		{PostHistoryTypeID: 10, RevisionGUID: "b", UserID: "2", Text: "closed"},
+		{PostHistoryTypeID: sePostHistoryEditBody, RevisionGUID: "c", UserID: "2", Comment: "fix", Text: "new body"},
+	}
+	var revisions []*seRevisionState
+	for _, row := range rows {
+		revisions = applyPostHistory(revisions, row)
+	}
+	assert.Len(t, revisions, 2)
+	assert.Equal(t, "title", revisions[0].Title)
+	assert.Equal(t, "body", revisions[0].Body)
+	assert.Equal(t, "title", revisions[1].Title)
+	assert.Equal(t, "new body", revisions[1].Body)
+	assert.Equal(t, []string{"go"}, revisions[1].Tags)
+	assert.Equal(t, "2", revisions[1].UserID)
+	assert.Equal(t, "fix", revisions[1].Comment)
+}
+
+func TestReadStackExchangeRows(t *testing.T) {
+	dir := t.TempDir()
+	content := `<?xml version="1.0" encoding="utf-8"?>
+<tags>
+  <row Id="1" TagName="python" />
+  <row Id="2" TagName="go" />
+</tags>`
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, seTagsFile), []byte(content), 0o644))
+
+	var tags []*seTag
+	exist, err := readStackExchangeRows(dir, seTagsFile, func(row *seTag) error {
+		tags = append(tags, row)
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.True(t, exist)
+	assert.Equal(t, []*seTag{{ID: "1", TagName: "python"}, {ID: "2", TagName: "go"}}, tags)
+
+	exist, err = readStackExchangeRows(dir, seVotesFile, func(row *seVote) error { return nil })
+	assert.NoError(t, err)
+	assert.False(t, exist)
+}
@@ -0,0 +1,805 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package importer
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/apache/incubator-answer/internal/base/constant"
+	"github.com/apache/incubator-answer/internal/base/reason"
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/apache/incubator-answer/internal/service/activity_type"
+	"github.com/apache/incubator-answer/internal/service/config"
+	"github.com/apache/incubator-answer/internal/service/unique"
+	"github.com/apache/incubator-answer/pkg/checker"
+	"github.com/apache/incubator-answer/pkg/converter"
+	"github.com/apache/incubator-answer/pkg/dir"
+	"github.com/apache/incubator-answer/pkg/obj"
+	"github.com/apache/incubator-answer/pkg/random"
+	"github.com/segmentfault/pacman/errors"
+	"github.com/segmentfault/pacman/log"
+)
+
+// The object types recorded in import id mapping
+const (
+	importObjectTypeUser     = "user"
+	importObjectTypeTag      = "tag"
+	importObjectTypeQuestion = "question"
+	importObjectTypeAnswer   = "answer"
+	importObjectTypeComment  = "comment"
+	importObjectTypeVote     = "vote"
+)
+
+// ImportRepo import repository
+type ImportRepo interface {
+	GetIDMapping(ctx context.Context, source, objectType string) (mapping map[string]string, err error)
+	GetUserByEmail(ctx context.Context, email string) (user *entity.User, exist bool, err error)
+	CheckUsernameExist(ctx context.Context, username string) (exist bool, err error)
+	GetFirstAdminUserID(ctx context.Context) (userID string, exist bool, err error)
+	GetTagsBySlugNames(ctx context.Context, slugNames []string) (tags []*entity.Tag, err error)
+	AddUser(ctx context.Context, user *entity.User, mapping *entity.ImportIDMapping) (err error)
+	AddQuestion(ctx context.Context, question *entity.Question, tagRels []*entity.TagRel,
+		revisions []*entity.Revision, mapping *entity.ImportIDMapping) (err error)
+	AddAnswer(ctx context.Context, answer *entity.Answer,
+		revisions []*entity.Revision, mapping *entity.ImportIDMapping) (err error)
+	AddObjects(ctx context.Context, mapping *entity.ImportIDMapping, objects ...any) (err error)
+	UpdateTagQuestionCount(ctx context.Context, tagIDs []string) (err error)
+	UpdateUserContentCount(ctx context.Context, userIDs []string) (err error)
+}
+
+// StackExchangeImporter import the Stack Exchange data dump
+type StackExchangeImporter struct {
+	importRepo    ImportRepo
+	uniqueIDRepo  unique.UniqueIDRepo
+	configService *config.ConfigService
+}
+
+// NewStackExchangeImporter new Stack Exchange importer
+func NewStackExchangeImporter(
+	importRepo ImportRepo,
+	uniqueIDRepo unique.UniqueIDRepo,
+	configService *config.ConfigService,
+) *StackExchangeImporter {
+	return &StackExchangeImporter{
+		importRepo:    importRepo,
+		uniqueIDRepo:  uniqueIDRepo,
+		configService: configService,
+	}
+}
+
+// sePostIndex the information of post which is needed before importing the post itself
+type sePostIndex struct {
+	PostTypeID       int
+	ParentID         string
+	NewID            string
+	Imported         bool
+	AnswerCount      int
+	LastAnswerID     string
+	LastAnswerAt     time.Time
+	AcceptedAnswerID string
+}
+
+// stackExchangeImport the state of one import
+type stackExchangeImport struct {
+	*StackExchangeImporter
+	dir            string
+	fallbackUserID string
+	result         *schema.StackExchangeImportResult
+	userMapping    map[string]string
+	tagMapping     map[string]*entity.Tag
+	posts          map[string]*sePostIndex
+	histories      map[string][]*seRevisionState
+	changedUserIDs map[string]bool
+	changedTagIDs  map[string]bool
+}
+
+// Import imports all the files in the dump directory, the imported objects are skipped,
+// so it can be run again after a failure.
+func (si *StackExchangeImporter) Import(ctx context.Context, req *schema.StackExchangeImportReq) (
+	result *schema.StackExchangeImportResult, err error) {
+	if !dir.CheckFileExist(filepath.Join(req.Dir, sePostsFile)) {
+		return nil, errors.BadRequest(reason.ImportSourceNotFound)
+	}
+	fallbackUserID := req.UserID
+	if len(fallbackUserID) == 0 {
+		userID, exist, err := si.importRepo.GetFirstAdminUserID(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if !exist {
+			return nil, errors.BadRequest(reason.ImportFallbackUserNotFound)
+		}
+		fallbackUserID = userID
+	}
+
+	im := &stackExchangeImport{
+		StackExchangeImporter: si,
+		dir:                   req.Dir,
+		fallbackUserID:        fallbackUserID,
+		result:                &schema.StackExchangeImportResult{},
+		tagMapping:            make(map[string]*entity.Tag),
+		posts:                 make(map[string]*sePostIndex),
+		histories:             make(map[string][]*seRevisionState),
+		changedUserIDs:        make(map[string]bool),
+		changedTagIDs:         make(map[string]bool),
+	}
+	steps := []struct {
+		name string
+		fn   func(ctx context.Context) error
+	}{
+		{seUsersFile, im.importUsers},
+		{seTagsFile, im.importTags},
+		{sePostsFile, im.indexPosts},
+		{sePostHistoryFile, im.readPostHistories},
+		{sePostsFile, im.importPosts},
+		{seCommentsFile, im.importComments},
+		{seVotesFile, im.importVotes},
+	}
+	for _, step := range steps {
+		log.Infof("[stackexchange import] processing %s", step.name)
+		if err = step.fn(ctx); err != nil {
+			return im.result, fmt.Errorf("import %s failed: %w", step.name, err)
+		}
+	}
+
+	if err = si.importRepo.UpdateTagQuestionCount(ctx, mapKeys(im.changedTagIDs)); err != nil {
+		return im.result, err
+	}
+	if err = si.importRepo.UpdateUserContentCount(ctx, mapKeys(im.changedUserIDs)); err != nil {
+		return im.result, err
+	}
+	return im.result, nil
+}
+
+func (im *stackExchangeImport) newMapping(objectType, oldID, newID string) *entity.ImportIDMapping {
+	return &entity.ImportIDMapping{
+		Source:     entity.ImportSourceStackExchange,
+		ObjectType: objectType,
+		OldID:      oldID,
+		NewID:      newID,
+	}
+}
+
+// getUserID returns the new id of the user in dump, or the fallback user if it is not imported
+func (im *stackExchangeImport) getUserID(oldUserID string) string {
+	if userID, ok := im.userMapping[oldUserID]; ok {
+		return userID
+	}
+	return im.fallbackUserID
+}
+
+func (im *stackExchangeImport) importUsers(ctx context.Context) (err error) {
+	im.userMapping, err = im.importRepo.GetIDMapping(ctx, entity.ImportSourceStackExchange, importObjectTypeUser)
+	if err != nil {
+		return err
+	}
+	_, err = readStackExchangeRows(im.dir, seUsersFile, func(row *seUser) error {
+		if _, ok := im.userMapping[row.ID]; ok {
+			im.result.Skipped++
+			return nil
+		}
+		// the existing user with the same email is used
+		if email := strings.TrimSpace(row.Email); len(email) > 0 {
+			user, exist, err := im.importRepo.GetUserByEmail(ctx, email)
+			if err != nil {
+				return err
+			}
+			if exist {
+				im.userMapping[row.ID] = user.ID
+				return im.importRepo.AddObjects(ctx, im.newMapping(importObjectTypeUser, row.ID, user.ID))
+			}
+		}
+
+		user, err := im.newUser(ctx, row)
+		if err != nil {
+			return err
+		}
+		if err = im.importRepo.AddUser(ctx, user, im.newMapping(importObjectTypeUser, row.ID, "")); err != nil {
+			return err
+		}
+		im.userMapping[row.ID] = user.ID
+		im.result.Users++
+		return nil
+	})
+	return err
+}
+
+func (im *stackExchangeImport) newUser(ctx context.Context, row *seUser) (user *entity.User, err error) {
+	username, err := im.makeUsername(ctx, row)
+	if err != nil {
+		return nil, err
+	}
+	email := strings.TrimSpace(row.Email)
+	if len(email) == 0 {
+		// the public dumps have no email, the reserved domain makes sure no mail is sent
+		email = fmt.Sprintf("stackexchange-user-%s@import.invalid", row.ID)
+	}
+	createdAt := parseStackExchangeTime(row.CreationDate)
+	rank := row.Reputation
+	if rank < 1 {
+		rank = 1
+	}
+	return &entity.User{
+		CreatedAt:     createdAt,
+		UpdatedAt:     createdAt,
+		LastLoginDate: parseStackExchangeTime(row.LastAccessDate),
+		Username:      username,
+		EMail:         email,
+		MailStatus:    entity.EmailStatusToBeVerified,
+		NoticeStatus:  schema.NoticeStatusOff,
+		Rank:          rank,
+		Status:        entity.UserStatusAvailable,
+		DisplayName:   truncate(row.DisplayName, 30),
+		Bio:           row.AboutMe,
+		BioHTML:       converter.Markdown2HTML(row.AboutMe),
+		Website:       truncate(row.WebsiteURL, 255),
+		Location:      truncate(row.Location, 100),
+	}, nil
+}
+
+// makeUsername makes an unused username from display name, the user id in dump is used if the name is invalid
+func (im *stackExchangeImport) makeUsername(ctx context.Context, row *seUser) (username string, err error) {
+	username = strings.ToLower(strings.ReplaceAll(strings.TrimSpace(row.DisplayName), " ", "-"))
+	if checker.IsInvalidUsername(username) || checker.IsReservedUsername(username) {
+		username = "user-" + row.ID
+	}
+	username = truncate(username, 40)
+	suffix := ""
+	for {
+		exist, err := im.importRepo.CheckUsernameExist(ctx, username+suffix)
+		if err != nil {
+			return "", err
+		}
+		if !exist {
+			return username + suffix, nil
+		}
+		suffix = random.UsernameSuffix()
+	}
+}
+
+func (im *stackExchangeImport) importTags(ctx context.Context) (err error) {
+	tagMapping, err := im.importRepo.GetIDMapping(ctx, entity.ImportSourceStackExchange, importObjectTypeTag)
+	if err != nil {
+		return err
+	}
+	_, err = readStackExchangeRows(im.dir, seTagsFile, func(row *seTag) error {
+		if _, ok := tagMapping[row.ID]; ok {
+			im.result.Skipped++
+			return nil
+		}
+		tag, created, err := im.getOrCreateTag(ctx, row.TagName, row.ID)
+		if err != nil || tag == nil {
+			return err
+		}
+		tagMapping[row.ID] = tag.ID
+		if created {
+			return nil
+		}
+		// the existing tag with the same slug name is used
+		return im.importRepo.AddObjects(ctx, im.newMapping(importObjectTypeTag, row.ID, tag.ID))
+	})
+	return err
+}
+
+// getTag returns the tag with the slug name of the tag in dump, it will be created if not exist
+func (im *stackExchangeImport) getTag(ctx context.Context, tagName string) (tag *entity.Tag, err error) {
+	tag, _, err = im.getOrCreateTag(ctx, tagName, "name:"+tagName)
+	return tag, err
+}
+
+// getOrCreateTag returns the tag with the slug name of the tag in dump,
+// if it does not exist, it will be created with the id mapping of oldID.
+func (im *stackExchangeImport) getOrCreateTag(ctx context.Context, tagName, oldID string) (
+	tag *entity.Tag, created bool, err error) {
+	slugName := truncate(strings.ToLower(strings.ReplaceAll(strings.TrimSpace(tagName), " ", "-")), 35)
+	if len(slugName) == 0 {
+		return nil, false, nil
+	}
+	if tag, ok := im.tagMapping[slugName]; ok {
+		return tag, false, nil
+	}
+	tags, err := im.importRepo.GetTagsBySlugNames(ctx, []string{slugName})
+	if err != nil {
+		return nil, false, err
+	}
+	if len(tags) > 0 {
+		im.tagMapping[slugName] = tags[0]
+		return tags[0], false, nil
+	}
+
+	tag = &entity.Tag{
+		SlugName:     slugName,
+		DisplayName:  truncate(tagName, 35),
+		OriginalText: "",
+		ParsedText:   "",
+		Status:       entity.TagStatusAvailable,
+		RevisionID:   "0",
+		UserID:       im.fallbackUserID,
+		CreatedAt:    time.Now(),
+		UpdatedAt:    time.Now(),
+	}
+	tag.ID, err = im.uniqueIDRepo.GenUniqueIDStr(ctx, tag.TableName())
+	if err != nil {
+		return nil, false, err
+	}
+	if err = im.importRepo.AddObjects(ctx, im.newMapping(importObjectTypeTag, oldID, tag.ID), tag); err != nil {
+		return nil, false, err
+	}
+	im.tagMapping[slugName] = tag
+	im.result.Tags++
+	return tag, true, nil
+}
+
+// indexPosts reads the relations between posts and generates the ids of posts which are not imported
+func (im *stackExchangeImport) indexPosts(ctx context.Context) (err error) {
+	questionMapping, err := im.importRepo.GetIDMapping(ctx, entity.ImportSourceStackExchange, importObjectTypeQuestion)
+	if err != nil {
+		return err
+	}
+	answerMapping, err := im.importRepo.GetIDMapping(ctx, entity.ImportSourceStackExchange, importObjectTypeAnswer)
+	if err != nil {
+		return err
+	}
+	_, err = readStackExchangeRows(im.dir, sePostsFile, func(row *sePost) error {
+		var objectType, newID string
+		var imported bool
+		switch row.PostTypeID {
+		case sePostTypeQuestion:
+			objectType = constant.QuestionObjectType
+			newID, imported = questionMapping[row.ID]
+		case sePostTypeAnswer:
+			objectType = constant.AnswerObjectType
+			newID, imported = answerMapping[row.ID]
+		default:
+			return nil
+		}
+		if !imported {
+			if newID, err = im.uniqueIDRepo.GenUniqueIDStr(ctx, objectType); err != nil {
+				return err
+			}
+		}
+		post := im.getPostIndex(row.ID)
+		post.PostTypeID, post.ParentID, post.NewID, post.Imported = row.PostTypeID, row.ParentID, newID, imported
+		if row.PostTypeID == sePostTypeQuestion {
+			post.AcceptedAnswerID = row.AcceptedAnswerID
+			return nil
+		}
+
+		question := im.getPostIndex(row.ParentID)
+		question.AnswerCount++
+		if createdAt := parseStackExchangeTime(row.CreationDate); !createdAt.Before(question.LastAnswerAt) {
+			question.LastAnswerID, question.LastAnswerAt = row.ID, createdAt
+		}
+		return nil
+	})
+	return err
+}
+
+func (im *stackExchangeImport) getPostIndex(postID string) *sePostIndex {
+	post, ok := im.posts[postID]
+	if !ok {
+		post = &sePostIndex{}
+		im.posts[postID] = post
+	}
+	return post
+}
+
+// getPostNewID returns the new id of question or answer in dump, empty if the post is not in dump
+func (im *stackExchangeImport) getPostNewID(postID string) string {
+	if post, ok := im.posts[postID]; ok {
+		return post.NewID
+	}
+	return ""
+}
+
+// readPostHistories reads the revisions of the posts which are not imported
+func (im *stackExchangeImport) readPostHistories(ctx context.Context) (err error) {
+	_, err = readStackExchangeRows(im.dir, sePostHistoryFile, func(row *sePostHistory) error {
+		post, ok := im.posts[row.PostID]
+		if !ok || post.Imported || len(post.NewID) == 0 {
+			return nil
+		}
+		im.histories[row.PostID] = applyPostHistory(im.histories[row.PostID], row)
+		return nil
+	})
+	return err
+}
+
+func (im *stackExchangeImport) importPosts(ctx context.Context) (err error) {
+	_, err = readStackExchangeRows(im.dir, sePostsFile, func(row *sePost) error {
+		post, ok := im.posts[row.ID]
+		if !ok || len(post.NewID) == 0 {
+			return nil
+		}
+		if post.Imported {
+			im.result.Skipped++
+			return nil
+		}
+		switch row.PostTypeID {
+		case sePostTypeQuestion:
+			err = im.importQuestion(ctx, row, post)
+		case sePostTypeAnswer:
+			err = im.importAnswer(ctx, row, post)
+		}
+		if err != nil {
+			return err
+		}
+		post.Imported = true
+		delete(im.histories, row.ID)
+		return nil
+	})
+	return err
+}
+
+func (im *stackExchangeImport) importQuestion(ctx context.Context, row *sePost, post *sePostIndex) (err error) {
+	createdAt := parseStackExchangeTime(row.CreationDate)
+	question := &entity.Question{
+		ID:               post.NewID,
+		CreatedAt:        createdAt,
+		UpdatedAt:        latestTime(createdAt, parseStackExchangeTime(row.LastEditDate)),
+		UserID:           im.getUserID(row.OwnerUserID),
+		LastEditUserID:   "0",
+		Title:            truncate(row.Title, 150),
+		Pin:              entity.QuestionUnPin,
+		Show:             entity.QuestionShow,
+		Status:           entity.QuestionStatusAvailable,
+		ViewCount:        row.ViewCount,
+		UniqueViewCount:  row.ViewCount,
+		VoteCount:        row.Score,
+		AnswerCount:      post.AnswerCount,
+		AcceptedAnswerID: "0",
+		LastAnswerID:     "0",
+		PostUpdateTime:   latestTime(createdAt, parseStackExchangeTime(row.LastActivityDate)),
+	}
+	if len(row.LastEditorUserID) > 0 {
+		question.LastEditUserID = im.getUserID(row.LastEditorUserID)
+	}
+	if len(row.ClosedDate) > 0 {
+		question.Status = entity.QuestionStatusClosed
+	}
+	if newID := im.getPostNewID(post.AcceptedAnswerID); len(newID) > 0 {
+		question.AcceptedAnswerID = newID
+	}
+	if newID := im.getPostNewID(post.LastAnswerID); len(newID) > 0 {
+		question.LastAnswerID = newID
+	}
+	histories := im.postHistories(row)
+	last := histories[len(histories)-1]
+	question.OriginalText = last.Body
+	question.ParsedText = converter.Markdown2HTML(last.Body)
+
+	tags, err := im.getTags(ctx, last.Tags)
+	if err != nil {
+		return err
+	}
+	tagRels := make([]*entity.TagRel, 0, len(tags))
+	for _, tag := range tags {
+		tagRels = append(tagRels, &entity.TagRel{
+			CreatedAt: createdAt,
+			UpdatedAt: createdAt,
+			ObjectID:  question.ID,
+			TagID:     tag.ID,
+			Status:    entity.TagRelStatusAvailable,
+		})
+		im.changedTagIDs[tag.ID] = true
+	}
+
+	revisions := make([]*entity.Revision, 0, len(histories))
+	for _, history := range histories {
+		snapshot := &entity.QuestionWithTagsRevision{Question: *question}
+		snapshot.Title = truncate(history.Title, 150)
+		snapshot.OriginalText = history.Body
+		snapshot.ParsedText = converter.Markdown2HTML(history.Body)
+		historyTags, err := im.getTags(ctx, history.Tags)
+		if err != nil {
+			return err
+		}
+		for _, tag := range historyTags {
+			snapshot.Tags = append(snapshot.Tags, &entity.TagSimpleInfoForRevision{
+				ID:              tag.ID,
+				MainTagID:       tag.MainTagID,
+				MainTagSlugName: tag.MainTagSlugName,
+				SlugName:        tag.SlugName,
+				DisplayName:     tag.DisplayName,
+				Recommend:       tag.Recommend,
+				Reserved:        tag.Reserved,
+				RevisionID:      tag.RevisionID,
+			})
+		}
+		revisions = append(revisions, im.newRevision(question.ID, snapshot.Title, snapshot, history))
+	}
+
+	if err = im.importRepo.AddQuestion(ctx, question, tagRels, revisions,
+		im.newMapping(importObjectTypeQuestion, row.ID, question.ID)); err != nil {
+		return err
+	}
+	im.changedUserIDs[question.UserID] = true
+	im.result.Questions++
+	im.result.Revisions += len(revisions)
+	return nil
+}
+
+func (im *stackExchangeImport) importAnswer(ctx context.Context, row *sePost, post *sePostIndex) (err error) {
+	question, ok := im.posts[post.ParentID]
+	if !ok || len(question.NewID) == 0 {
+		im.result.Skipped++
+		return nil
+	}
+	createdAt := parseStackExchangeTime(row.CreationDate)
+	answer := &entity.Answer{
+		ID:             post.NewID,
+		CreatedAt:      createdAt,
+		UpdatedAt:      latestTime(createdAt, parseStackExchangeTime(row.LastEditDate)),
+		QuestionID:     question.NewID,
+		UserID:         im.getUserID(row.OwnerUserID),
+		LastEditUserID: "0",
+		Status:         entity.AnswerStatusAvailable,
+		Accepted:       schema.AnswerAcceptedFailed,
+		CommentCount:   row.CommentCount,
+		VoteCount:      row.Score,
+	}
+	if len(row.LastEditorUserID) > 0 {
+		answer.LastEditUserID = im.getUserID(row.LastEditorUserID)
+	}
+	if question.AcceptedAnswerID == row.ID {
+		answer.Accepted = schema.AnswerAcceptedEnable
+	}
+	histories := im.postHistories(row)
+	last := histories[len(histories)-1]
+	answer.OriginalText = last.Body
+	answer.ParsedText = converter.Markdown2HTML(last.Body)
+
+	revisions := make([]*entity.Revision, 0, len(histories))
+	for _, history := range histories {
+		snapshot := *answer
+		snapshot.OriginalText = history.Body
+		snapshot.ParsedText = converter.Markdown2HTML(history.Body)
+		revisions = append(revisions, im.newRevision(answer.ID, "", snapshot, history))
+	}
+
+	if err = im.importRepo.AddAnswer(ctx, answer, revisions,
+		im.newMapping(importObjectTypeAnswer, row.ID, answer.ID)); err != nil {
+		return err
+	}
+	im.changedUserIDs[answer.UserID] = true
+	im.result.Answers++
+	im.result.Revisions += len(revisions)
+	return nil
+}
+
+// postHistories returns the revisions of post in PostHistory.xml,
+// if there is none, the post itself is used as the only revision.
+func (im *stackExchangeImport) postHistories(row *sePost) []*seRevisionState {
+	histories := im.histories[row.ID]
+	if len(histories) > 0 && len(histories[len(histories)-1].Body) > 0 {
+		return histories
+	}
+	// the html body is kept as it is, markdown allows raw html
+	return []*seRevisionState{{
+		UserID:    row.OwnerUserID,
+		CreatedAt: parseStackExchangeTime(row.CreationDate),
+		Title:     row.Title,
+		Body:      row.Body,
+		Tags:      parseStackExchangeTags(row.Tags),
+	}}
+}
+
+func (im *stackExchangeImport) getTags(ctx context.Context, tagNames []string) (tags []*entity.Tag, err error) {
+	added := make(map[string]bool, len(tagNames))
+	for _, tagName := range tagNames {
+		tag, err := im.getTag(ctx, tagName)
+		if err != nil {
+			return nil, err
+		}
+		if tag == nil || added[tag.ID] {
+			continue
+		}
+		added[tag.ID] = true
+		tags = append(tags, tag)
+	}
+	return tags, nil
+}
+
+func (im *stackExchangeImport) newRevision(objectID, title string, content any,
+	history *seRevisionState) *entity.Revision {
+	contentJSON, _ := json.Marshal(content)
+	objectType, _ := obj.GetObjectTypeNumberByObjectID(objectID)
+	return &entity.Revision{
+		CreatedAt:  history.CreatedAt,
+		UpdatedAt:  history.CreatedAt,
+		UserID:     im.getUserID(history.UserID),
+		ObjectType: objectType,
+		ObjectID:   objectID,
+		Title:      title,
+		Content:    string(contentJSON),
+		Log:        truncate(history.Comment, 255),
+		Status:     entity.RevisionReviewPassStatus,
+	}
+}
+
+func (im *stackExchangeImport) importComments(ctx context.Context) (err error) {
+	commentMapping, err := im.importRepo.GetIDMapping(ctx, entity.ImportSourceStackExchange, importObjectTypeComment)
+	if err != nil {
+		return err
+	}
+	_, err = readStackExchangeRows(im.dir, seCommentsFile, func(row *seComment) error {
+		if _, ok := commentMapping[row.ID]; ok {
+			im.result.Skipped++
+			return nil
+		}
+		post, ok := im.posts[row.PostID]
+		if !ok || !post.Imported {
+			im.result.Skipped++
+			return nil
+		}
+		questionID := post.NewID
+		if post.PostTypeID == sePostTypeAnswer {
+			questionID = im.getPostNewID(post.ParentID)
+		}
+		createdAt := parseStackExchangeTime(row.CreationDate)
+		comment := &entity.Comment{
+			CreatedAt:    createdAt,
+			UpdatedAt:    createdAt,
+			UserID:       im.getUserID(row.UserID),
+			ObjectID:     post.NewID,
+			QuestionID:   questionID,
+			VoteCount:    row.Score,
+			Status:       entity.CommentStatusAvailable,
+			OriginalText: row.Text,
+			ParsedText:   converter.Markdown2BasicHTML(row.Text),
+		}
+		comment.ID, err = im.uniqueIDRepo.GenUniqueIDStr(ctx, comment.TableName())
+		if err != nil {
+			return err
+		}
+		if err = im.importRepo.AddObjects(ctx, im.newMapping(importObjectTypeComment, row.ID, comment.ID), comment); err != nil {
+			return err
+		}
+		commentMapping[row.ID] = comment.ID
+		im.result.Comments++
+		return nil
+	})
+	return err
+}
+
+// importVotes imports the up and down votes as vote activities, only the dump of
+// Stack Overflow for Teams has the voter of these votes, the others are skipped.
+// The vote count of posts is already imported from their score.
+func (im *stackExchangeImport) importVotes(ctx context.Context) (err error) {
+	voteMapping, err := im.importRepo.GetIDMapping(ctx, entity.ImportSourceStackExchange, importObjectTypeVote)
+	if err != nil {
+		return err
+	}
+	postOwners, err := im.getPostOwners()
+	if err != nil {
+		return err
+	}
+	_, err = readStackExchangeRows(im.dir, seVotesFile, func(row *seVote) error {
+		if row.VoteTypeID != seVoteTypeUpMod && row.VoteTypeID != seVoteTypeDownMod {
+			return nil
+		}
+		if _, ok := voteMapping[row.ID]; ok {
+			im.result.Skipped++
+			return nil
+		}
+		post, ok := im.posts[row.PostID]
+		voterID, voterExist := im.userMapping[row.UserID]
+		if !ok || !post.Imported || !voterExist {
+			im.result.Skipped++
+			return nil
+		}
+		activities, err := im.newVoteActivities(ctx, post, postOwners[post.NewID], voterID,
+			row.VoteTypeID == seVoteTypeUpMod, parseStackExchangeTime(row.CreationDate))
+		if err != nil {
+			return err
+		}
+		if err = im.importRepo.AddObjects(ctx, im.newMapping(importObjectTypeVote, row.ID, post.NewID), activities...); err != nil {
+			return err
+		}
+		voteMapping[row.ID] = post.NewID
+		im.result.Votes++
+		return nil
+	})
+	return err
+}
+
+// getPostOwners reads the owner of posts again, the owner of post is needed by the voted activity
+func (im *stackExchangeImport) getPostOwners() (owners map[string]string, err error) {
+	owners = make(map[string]string, len(im.posts))
+	_, err = readStackExchangeRows(im.dir, sePostsFile, func(row *sePost) error {
+		if newID := im.getPostNewID(row.ID); len(newID) > 0 {
+			owners[newID] = im.getUserID(row.OwnerUserID)
+		}
+		return nil
+	})
+	return owners, err
+}
+
+// newVoteActivities creates the activities of voter and the owner of post, like the vote service does
+func (im *stackExchangeImport) newVoteActivities(ctx context.Context, post *sePostIndex, ownerID, voterID string,
+	voteUp bool, votedAt time.Time) (activities []any, err error) {
+	var actions []string
+	switch {
+	case post.PostTypeID == sePostTypeQuestion && voteUp:
+		actions = []string{activity_type.QuestionVoteUp, activity_type.QuestionVotedUp}
+	case post.PostTypeID == sePostTypeQuestion:
+		actions = []string{activity_type.QuestionVoteDown, activity_type.QuestionVotedDown}
+	case voteUp:
+		actions = []string{activity_type.AnswerVoteUp, activity_type.AnswerVotedUp}
+	default:
+		actions = []string{activity_type.AnswerVoteDown, activity_type.AnswerVotedDown}
+	}
+	for _, action := range actions {
+		cfg, err := im.configService.GetConfigByKey(ctx, action)
+		if err != nil {
+			return nil, err
+		}
+		activity := &entity.Activity{
+			CreatedAt:        votedAt,
+			UpdatedAt:        votedAt,
+			UserID:           voterID,
+			ObjectID:         post.NewID,
+			OriginalObjectID: post.NewID,
+			ActivityType:     cfg.ID,
+			Cancelled:        entity.ActivityAvailable,
+			Rank:             cfg.GetIntValue(),
+		}
+		if strings.Contains(action, "voted") {
+			activity.UserID = ownerID
+			activity.TriggerUserID = converter.StringToInt64(voterID)
+		}
+		if activity.Rank != 0 {
+			activity.HasRank = 1
+		}
+		activities = append(activities, activity)
+	}
+	return activities, nil
+}
+
+func latestTime(times ...time.Time) (latest time.Time) {
+	for _, t := range times {
+		if t.After(latest) {
+			latest = t
+		}
+	}
+	return latest
+}
+
+// truncate truncates the string to the max number of runes
+func truncate(s string, maxLength int) string {
+	runes := []rune(s)
+	if len(runes) <= maxLength {
+		return s
+	}
+	return string(runes[:maxLength])
+}
+
+func mapKeys(m map[string]bool) (keys []string) {
+	for key := range m {
+		keys = append(keys, key)
+	}
+	return keys
+}
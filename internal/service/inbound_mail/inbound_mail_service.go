@@ -0,0 +1,312 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package inbound_mail
+
+import (
+	"context"
+	"crypto/subtle"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/apache/incubator-answer/internal/base/constant"
+	"github.com/apache/incubator-answer/internal/base/handler"
+	"github.com/apache/incubator-answer/internal/base/reason"
+	"github.com/apache/incubator-answer/internal/base/validator"
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/apache/incubator-answer/internal/service/action"
+	"github.com/apache/incubator-answer/internal/service/comment"
+	"github.com/apache/incubator-answer/internal/service/content"
+	"github.com/apache/incubator-answer/internal/service/export"
+	"github.com/apache/incubator-answer/internal/service/permission"
+	"github.com/apache/incubator-answer/internal/service/rank"
+	"github.com/apache/incubator-answer/internal/service/role"
+	"github.com/apache/incubator-answer/internal/service/service_config"
+	"github.com/apache/incubator-answer/internal/service/siteinfo_common"
+	usercommon "github.com/apache/incubator-answer/internal/service/user_common"
+	"github.com/segmentfault/pacman/errors"
+	"github.com/segmentfault/pacman/i18n"
+	"github.com/segmentfault/pacman/log"
+)
+
+// inboundMailUserAgent the user agent of the answers posted by email, it is used by the review
+const inboundMailUserAgent = "Answer-Inbound-Mail"
+
+// InboundMailService reply-by-email service, the replies of notification emails are posted as answers or comments
+type InboundMailService struct {
+	serviceConfig         *service_config.ServiceConfig
+	emailService          *export.EmailService
+	userRepo              usercommon.UserRepo
+	userRoleService       *role.UserRoleRelService
+	rankService           *rank.RankService
+	actionService         *action.CaptchaService
+	commentService        *comment.CommentService
+	answerService         *content.AnswerService
+	siteInfoCommonService siteinfo_common.SiteInfoCommonService
+	pollLock              sync.Mutex
+}
+
+// NewInboundMailService new inbound mail service
+func NewInboundMailService(
+	serviceConfig *service_config.ServiceConfig,
+	emailService *export.EmailService,
+	userRepo usercommon.UserRepo,
+	userRoleService *role.UserRoleRelService,
+	rankService *rank.RankService,
+	actionService *action.CaptchaService,
+	commentService *comment.CommentService,
+	answerService *content.AnswerService,
+	siteInfoCommonService siteinfo_common.SiteInfoCommonService,
+) *InboundMailService {
+	return &InboundMailService{
+		serviceConfig:         serviceConfig,
+		emailService:          emailService,
+		userRepo:              userRepo,
+		userRoleService:       userRoleService,
+		rankService:           rankService,
+		actionService:         actionService,
+		commentService:        commentService,
+		answerService:         answerService,
+		siteInfoCommonService: siteInfoCommonService,
+	}
+}
+
+// VerifySecret verify the secret sent by the MTA pipe
+func (is *InboundMailService) VerifySecret(secret string) bool {
+	if !is.serviceConfig.InboundMail.Enabled() || len(secret) == 0 {
+		return false
+	}
+	return subtle.ConstantTimeCompare([]byte(secret), []byte(is.serviceConfig.InboundMail.Secret)) == 1
+}
+
+// MaxMessageSize the max size of the inbound mail in bytes
+func (is *InboundMailService) MaxMessageSize() int64 {
+	return is.serviceConfig.InboundMail.MaxMessageBytes()
+}
+
+// HandleMessage post the reply in the raw message as the answer or comment
+func (is *InboundMailService) HandleMessage(ctx context.Context, r io.Reader) (err error) {
+	if !is.serviceConfig.InboundMail.Enabled() {
+		return errors.BadRequest(reason.InboundMailDisabled)
+	}
+	msg, err := ParseMessage(r, is.MaxMessageSize())
+	if err != nil {
+		return errors.BadRequest(reason.InboundMailInvalidMessage).WithError(err)
+	}
+	var token *export.ReplyToken
+	for _, recipient := range msg.Recipients {
+		if t, ok := is.emailService.ParseReplyAddress(recipient); ok {
+			token = t
+			break
+		}
+	}
+	if token == nil {
+		return errors.BadRequest(reason.InboundMailInvalidToken)
+	}
+
+	userInfo, exist, err := is.userRepo.GetByUserID(ctx, token.UserID)
+	if err != nil {
+		return err
+	}
+	// the token is issued to the receiver of the notification, it can not be used by others who got the email
+	if !exist || userInfo.Status != entity.UserStatusAvailable || userInfo.MailStatus != entity.EmailStatusAvailable ||
+		!strings.EqualFold(userInfo.EMail, msg.From) {
+		return errors.Forbidden(reason.InboundMailSenderNotAllowed)
+	}
+	text := StripReply(msg.Text)
+	if len(text) == 0 {
+		return errors.BadRequest(reason.InboundMailEmptyContent)
+	}
+	if len(userInfo.Language) > 0 {
+		ctx = context.WithValue(ctx, constant.AcceptLanguageFlag, i18n.Language(userInfo.Language))
+	}
+
+	log.Infof("user %s reply by email %s to %s %s", userInfo.ID, msg.MessageID, token.Kind, token.ObjectID)
+	if token.Kind == export.ReplyTokenKindAnswer {
+		return is.addAnswer(ctx, userInfo, token, text)
+	}
+	return is.addComment(ctx, userInfo, token, text)
+}
+
+// addComment add comment with the same checks as the comment controller
+func (is *InboundMailService) addComment(ctx context.Context, userInfo *entity.User, token *export.ReplyToken,
+	text string) (err error) {
+	req := &schema.AddCommentReq{
+		ObjectID:       token.ObjectID,
+		ReplyCommentID: token.ReplyCommentID,
+		OriginalText:   text,
+		UserID:         userInfo.ID,
+	}
+	if _, err = validator.GetValidatorByLang(handler.GetLangByCtx(ctx)).Check(req); err != nil {
+		return err
+	}
+
+	canList, err := is.rankService.CheckOperationPermissions(ctx, req.UserID, []string{
+		permission.CommentAdd,
+		permission.CommentEdit,
+		permission.CommentDelete,
+		permission.LinkUrlLimit,
+	})
+	if err != nil {
+		return err
+	}
+	linkUrlLimitUser := canList[3]
+	isAdmin := is.isAdminModerator(ctx, req.UserID)
+	if !isAdmin || !linkUrlLimitUser {
+		if !is.actionService.ValidationStrategy(ctx, req.UserID, entity.CaptchaActionComment) {
+			return errors.BadRequest(reason.InboundMailCaptchaRequired)
+		}
+	}
+	req.CanAdd = canList[0]
+	req.CanEdit = canList[1]
+	req.CanDelete = canList[2]
+	if !req.CanAdd {
+		return errors.Forbidden(reason.RankFailToMeetTheCondition)
+	}
+
+	if _, err = is.commentService.AddComment(ctx, req); err != nil {
+		return err
+	}
+	if !isAdmin || !linkUrlLimitUser {
+		_, _ = is.actionService.ActionRecordAdd(ctx, entity.CaptchaActionComment, req.UserID)
+	}
+	return nil
+}
+
+// addAnswer add answer with the same checks as the answer controller
+func (is *InboundMailService) addAnswer(ctx context.Context, userInfo *entity.User, token *export.ReplyToken,
+	text string) (err error) {
+	req := &schema.AnswerAddReq{
+		QuestionID: token.ObjectID,
+		Content:    text,
+		UserID:     userInfo.ID,
+		UserAgent:  inboundMailUserAgent,
+	}
+	if _, err = validator.GetValidatorByLang(handler.GetLangByCtx(ctx)).Check(req); err != nil {
+		return err
+	}
+
+	canList, err := is.rankService.CheckOperationPermissions(ctx, req.UserID, []string{
+		permission.AnswerEdit,
+		permission.AnswerDelete,
+		permission.LinkUrlLimit,
+	})
+	if err != nil {
+		return err
+	}
+	linkUrlLimitUser := canList[2]
+	isAdmin := is.isAdminModerator(ctx, req.UserID)
+	if !isAdmin || !linkUrlLimitUser {
+		if !is.actionService.ValidationStrategy(ctx, req.UserID, entity.CaptchaActionAnswer) {
+			return errors.BadRequest(reason.InboundMailCaptchaRequired)
+		}
+	}
+	can, err := is.rankService.CheckOperationPermission(ctx, req.UserID, permission.AnswerAdd, "")
+	if err != nil {
+		return err
+	}
+	if !can {
+		return errors.Forbidden(reason.RankFailToMeetTheCondition)
+	}
+
+	write, err := is.siteInfoCommonService.GetSiteWrite(ctx)
+	if err != nil {
+		return err
+	}
+	if write.RestrictAnswer {
+		ids, err := is.answerService.GetCountByUserIDQuestionID(ctx, req.UserID, req.QuestionID)
+		if err != nil {
+			return err
+		}
+		if len(ids) >= 1 {
+			return errors.Forbidden(reason.AnswerRestrictAnswer)
+		}
+	}
+
+	if _, err = is.answerService.Insert(ctx, req); err != nil {
+		return err
+	}
+	if !isAdmin || !linkUrlLimitUser {
+		_, _ = is.actionService.ActionRecordAdd(ctx, entity.CaptchaActionAnswer, req.UserID)
+	}
+	return nil
+}
+
+func (is *InboundMailService) isAdminModerator(ctx context.Context, userID string) bool {
+	roleID, err := is.userRoleService.GetUserRole(ctx, userID)
+	if err != nil {
+		log.Error(err)
+		return false
+	}
+	return roleID == role.RoleAdminID || roleID == role.RoleModeratorID
+}
+
+// PollMaildir handle the new mails in the maildir, the mails are moved to "cur" whether they are posted
+// or not, so a broken mail will not be handled again and again.
+func (is *InboundMailService) PollMaildir(ctx context.Context) {
+	conf := is.serviceConfig.InboundMail
+	if !conf.Enabled() || len(conf.Maildir) == 0 {
+		return
+	}
+	if !is.pollLock.TryLock() {
+		return
+	}
+	defer is.pollLock.Unlock()
+
+	newDir, curDir := filepath.Join(conf.Maildir, "new"), filepath.Join(conf.Maildir, "cur")
+	entries, err := os.ReadDir(newDir)
+	if err != nil {
+		log.Errorf("read maildir %s failed: %v", newDir, err)
+		return
+	}
+	names := make([]string, 0, len(entries))
+	for _, e := range entries {
+		if !e.IsDir() && !strings.HasPrefix(e.Name(), ".") {
+			names = append(names, e.Name())
+		}
+	}
+	// the maildir file names start with the delivery time
+	sort.Strings(names)
+	for _, name := range names {
+		// move it before handling, the mail is never posted twice even if the moving fails
+		curPath := filepath.Join(curDir, name+":2,S")
+		if err = os.Rename(filepath.Join(newDir, name), curPath); err != nil {
+			log.Errorf("move mail %s to cur failed: %v", name, err)
+			continue
+		}
+		is.handleMaildirFile(ctx, curPath)
+	}
+}
+
+func (is *InboundMailService) handleMaildirFile(ctx context.Context, path string) {
+	f, err := os.Open(path)
+	if err != nil {
+		log.Error(err)
+		return
+	}
+	defer f.Close()
+	if err = is.HandleMessage(ctx, f); err != nil {
+		log.Warnf("handle inbound mail %s failed: %v", path, err)
+	}
+}
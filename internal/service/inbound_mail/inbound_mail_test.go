@@ -0,0 +1,94 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package inbound_mail
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseMessage(t *testing.T) {
+	raw := strings.Join([]string{
+		"From: Someone <someone@example.com>",
+		"To: reply+token@example.com",
+		"Cc: other@example.com",
+		"Message-ID: <1@example.com>",
+		"Content-Type: multipart/alternative; boundary=\"b1\"",
+		"",
+		"--b1",
+		"Content-Type: text/plain; charset=utf-8",
+		"Content-Transfer-Encoding: quoted-printable",
+		"",
+		"Thanks, it works =E2=9C=93",
+		"",
+		"--b1",
+		"Content-Type: text/html; charset=utf-8",
+		"",
+		"<p>Thanks, it works</p>",
+		"--b1--",
+		"",
+	}, "\r\n")
+	msg, err := ParseMessage(strings.NewReader(raw), 1024*1024)
+	assert.NoError(t, err)
+	assert.Equal(t, "someone@example.com", msg.From)
+	assert.Equal(t, []string{"reply+token@example.com", "other@example.com"}, msg.Recipients)
+	assert.Equal(t, "<1@example.com>", msg.MessageID)
+	assert.Equal(t, "Thanks, it works ✓", strings.TrimSpace(msg.Text))
+
+	// html only and base64 encoded
+	raw = strings.Join([]string{
+		"From: someone@example.com",
+		"To: reply+token@example.com",
+		"Content-Type: text/html",
+		"Content-Transfer-Encoding: base64",
+		"",
+		"PHA+SGVsbG88YnI+d29ybGQ8L3A+",
+		"",
+	}, "\r\n")
+	msg, err = ParseMessage(strings.NewReader(raw), 1024*1024)
+	assert.NoError(t, err)
+	assert.Equal(t, "Hello\nworld", msg.Text)
+
+	// larger than the max size
+	_, err = ParseMessage(strings.NewReader(raw), int64(len(raw)-1))
+	assert.Error(t, err)
+}
+
+func TestStripReply(t *testing.T) {
+	text := "Good point, I will try it.\n\nOn Mon, Jan 1, 2024 at 10:00 AM Answer <reply@example.com> wrote:\n> the answer\n"
+	assert.Equal(t, "Good point, I will try it.", StripReply(text))
+
+	text = "Good point.\nOn Mon, Jan 1, 2024 at 10:00 AM Answer\n<reply@example.com> wrote:\n> the answer"
+	assert.Equal(t, "Good point.", StripReply(text))
+
+	text = "Inline reply\n> quoted\nmore text\n\n-- \nJohn Doe\nEngineer"
+	assert.Equal(t, "Inline reply\nmore text", StripReply(text))
+
+	text = "Yes.\n\nSent from my iPhone"
+	assert.Equal(t, "Yes.", StripReply(text))
+
+	text = "Agreed\n-----Original Message-----\nFrom: Answer"
+	assert.Equal(t, "Agreed", StripReply(text))
+
+	text = "Here is what the doc wrote:\nsomething"
+	assert.Equal(t, text, StripReply(text))
+}
@@ -0,0 +1,172 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package inbound_mail
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net/mail"
+	"strings"
+
+	"github.com/apache/incubator-answer/pkg/htmltext"
+)
+
+// maxMultipartDepth the nested multipart deeper than it is ignored
+const maxMultipartDepth = 5
+
+// recipientHeaders the headers which may contain the reply address
+var recipientHeaders = []string{"To", "Cc", "Delivered-To", "X-Original-To"}
+
+// Message the parsed inbound mail
+type Message struct {
+	MessageID  string
+	From       string
+	Recipients []string
+	// Text the plain text body, the html body is converted when there is no plain text
+	Text string
+}
+
+// ParseMessage parse the raw RFC 822 message, the message larger than max size is rejected
+func ParseMessage(r io.Reader, maxMessageSize int64) (msg *Message, err error) {
+	raw, err := io.ReadAll(io.LimitReader(r, maxMessageSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(raw)) > maxMessageSize {
+		return nil, fmt.Errorf("message is larger than %d bytes", maxMessageSize)
+	}
+	m, err := mail.ReadMessage(bytes.NewReader(raw))
+	if err != nil {
+		return nil, err
+	}
+
+	msg = &Message{MessageID: m.Header.Get("Message-Id")}
+	from, err := mail.ParseAddress(m.Header.Get("From"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid from address: %w", err)
+	}
+	msg.From = from.Address
+	for _, header := range recipientHeaders {
+		addresses, err := m.Header.AddressList(header)
+		if err != nil {
+			continue
+		}
+		for _, address := range addresses {
+			msg.Recipients = append(msg.Recipients, address.Address)
+		}
+	}
+
+	plain, html, err := readBody(m.Header.Get("Content-Type"), m.Header.Get("Content-Transfer-Encoding"), m.Body, 0)
+	if err != nil {
+		return nil, err
+	}
+	if len(strings.TrimSpace(plain)) > 0 {
+		msg.Text = plain
+	} else {
+		msg.Text = htmltext.PlainText(html)
+	}
+	msg.Text = strings.ReplaceAll(msg.Text, "\r\n", "\n")
+	return msg, nil
+}
+
+// readBody returns the first text/plain and text/html parts of the body, attachments are ignored
+func readBody(contentType, transferEncoding string, body io.Reader, depth int) (plain, html string, err error) {
+	mediaType, params, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		// no or broken content type is plain text by RFC 2045
+		mediaType, params = "text/plain", map[string]string{}
+	}
+
+	if strings.HasPrefix(mediaType, "multipart/") {
+		if depth >= maxMultipartDepth {
+			return "", "", nil
+		}
+		mr := multipart.NewReader(body, params["boundary"])
+		for {
+			part, err := mr.NextRawPart()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				return "", "", err
+			}
+			if disposition, _, _ := mime.ParseMediaType(part.Header.Get("Content-Disposition")); disposition == "attachment" {
+				continue
+			}
+			partPlain, partHTML, err := readBody(part.Header.Get("Content-Type"),
+				part.Header.Get("Content-Transfer-Encoding"), part, depth+1)
+			if err != nil {
+				return "", "", err
+			}
+			if len(plain) == 0 {
+				plain = partPlain
+			}
+			if len(html) == 0 {
+				html = partHTML
+			}
+		}
+		return plain, html, nil
+	}
+
+	if mediaType != "text/plain" && mediaType != "text/html" {
+		return "", "", nil
+	}
+	content, err := io.ReadAll(decodeTransfer(transferEncoding, body))
+	if err != nil {
+		return "", "", err
+	}
+	if mediaType == "text/html" {
+		return "", string(content), nil
+	}
+	return string(content), "", nil
+}
+
+func decodeTransfer(encoding string, body io.Reader) io.Reader {
+	switch strings.ToLower(strings.TrimSpace(encoding)) {
+	case "quoted-printable":
+		return quotedprintable.NewReader(body)
+	case "base64":
+		return base64.NewDecoder(base64.StdEncoding, &newlineSkipper{r: body})
+	default:
+		return body
+	}
+}
+
+// newlineSkipper drop the line breaks of the base64 body
+type newlineSkipper struct {
+	r io.Reader
+}
+
+func (s *newlineSkipper) Read(p []byte) (n int, err error) {
+	n, err = s.r.Read(p)
+	j := 0
+	for i := 0; i < n; i++ {
+		if p[i] != '\r' && p[i] != '\n' {
+			p[j] = p[i]
+			j++
+		}
+	}
+	return j, err
+}
@@ -0,0 +1,73 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package inbound_mail
+
+import (
+	"regexp"
+	"strings"
+)
+
+var (
+	// the line before the quoted mail, like "On Mon, Jan 1, 2024 at 10:00 AM Someone <a@b.com> wrote:"
+	quoteHeaderReg = regexp.MustCompile(`(?i)^(on\s.+wrote:|.*<[^>\s]+@[^>\s]+>\s*(wrote|writes):)$`)
+	// the quote header is wrapped to two lines by some clients
+	quoteHeaderStartReg = regexp.MustCompile(`(?i)^on\s.+`)
+	quoteHeaderEndReg   = regexp.MustCompile(`(?i)^.*wrote:$`)
+	// outlook and other clients separate the original mail
+	originalMessageReg = regexp.MustCompile(`(?i)^(-{2,}\s*original message\s*-{2,}|_{10,})$`)
+	// "Sent from my iPhone" like signatures of mobile clients
+	mobileSignatureReg = regexp.MustCompile(`(?i)^sent from my\s.+$`)
+)
+
+// StripReply keep only the new text of the reply, the quoted mail and signature are removed
+func StripReply(text string) string {
+	lines := strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n")
+	kept := make([]string, 0, len(lines))
+	for i := 0; i < len(lines); i++ {
+		line := strings.TrimRight(lines[i], " \t")
+		trimmed := strings.TrimSpace(line)
+
+		// signature delimiter "-- " and everything after it
+		if line == "--" || lines[i] == "-- " {
+			break
+		}
+		if quoteHeaderReg.MatchString(trimmed) || originalMessageReg.MatchString(trimmed) {
+			break
+		}
+		if i+1 < len(lines) && quoteHeaderStartReg.MatchString(trimmed) &&
+			quoteHeaderEndReg.MatchString(strings.TrimSpace(lines[i+1])) {
+			break
+		}
+		if strings.HasPrefix(trimmed, ">") {
+			continue
+		}
+		kept = append(kept, line)
+	}
+
+	// remove the mobile signature at the end
+	for len(kept) > 0 {
+		last := strings.TrimSpace(kept[len(kept)-1])
+		if len(last) > 0 && !mobileSignatureReg.MatchString(last) {
+			break
+		}
+		kept = kept[:len(kept)-1]
+	}
+	return strings.TrimSpace(strings.Join(kept, "\n"))
+}
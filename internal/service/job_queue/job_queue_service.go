@@ -0,0 +1,349 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package job_queue
+
+import (
+	"context"
+	"encoding/json"
+	stderrors "errors"
+	"sync"
+	"time"
+
+	"github.com/apache/incubator-answer/internal/base/pager"
+	"github.com/apache/incubator-answer/internal/base/reason"
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/segmentfault/pacman/errors"
+	"github.com/segmentfault/pacman/log"
+)
+
+const (
+	// defaultMaxAttempts is the number of attempts before a job is moved to the dead-letter state
+	defaultMaxAttempts = 8
+	// retryBaseDelay is the delay before the first retry, it doubles on every failed attempt
+	retryBaseDelay = 10 * time.Second
+	// retryMaxDelay caps the exponential backoff
+	retryMaxDelay = time.Hour
+	// pollInterval is how often a worker looks for jobs whose retry time has come
+	pollInterval = 5 * time.Second
+	batchSize    = 100
+	// leaseTimeout a running job is considered interrupted by a crash or restart if it is not finished in this time,
+	// then it is put back to pending and run by any instance
+	leaseTimeout = 10 * time.Minute
+)
+
+// JobQueueRepo job queue repository
+type JobQueueRepo interface {
+	AddJob(ctx context.Context, job *entity.QueueJob) (err error)
+	GetJob(ctx context.Context, jobID int64) (job *entity.QueueJob, exist bool, err error)
+	GetDueJobs(ctx context.Context, queueName string, now time.Time, limit int) (jobs []*entity.QueueJob, err error)
+	GetJobPage(ctx context.Context, page, pageSize int, cond *entity.QueueJob) (jobs []*entity.QueueJob, total int64, err error)
+	ClaimJob(ctx context.Context, jobID int64, now time.Time) (claimed bool, err error)
+	UpdateJob(ctx context.Context, job *entity.QueueJob, cols ...string) (err error)
+	RemoveJob(ctx context.Context, jobID int64) (err error)
+	ResetExpiredJobs(ctx context.Context, queueName string, claimedBefore time.Time) (affected int64, err error)
+}
+
+// JobHandler handles the payload of one job, a returned error makes the job retried later
+// unless it is marked by Permanent
+type JobHandler func(ctx context.Context, payload []byte) error
+
+// permanentError the error of job that will never succeed
+type permanentError struct {
+	err error
+}
+
+func (e *permanentError) Error() string {
+	return e.err.Error()
+}
+
+func (e *permanentError) Unwrap() error {
+	return e.err
+}
+
+// Permanent marks the error returned by handler as not retryable, the job is moved to the dead-letter state at once
+func Permanent(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &permanentError{err: err}
+}
+
+// IsPermanent whether the error is marked by Permanent
+func IsPermanent(err error) bool {
+	var pe *permanentError
+	return stderrors.As(err, &pe)
+}
+
+// JobQueueService persisted job queue service
+type JobQueueService struct {
+	jobQueueRepo JobQueueRepo
+	lock         sync.Mutex
+	handlers     map[string]JobHandler
+	wakeups      map[string]chan struct{}
+}
+
+// NewJobQueueService new job queue service
+func NewJobQueueService(jobQueueRepo JobQueueRepo) *JobQueueService {
+	return &JobQueueService{
+		jobQueueRepo: jobQueueRepo,
+		handlers:     make(map[string]JobHandler),
+		wakeups:      make(map[string]chan struct{}),
+	}
+}
+
+// Enqueue persist a message into the queue, it will be handled by the handler registered for the queue
+func (js *JobQueueService) Enqueue(ctx context.Context, queueName string, msg any) (err error) {
+	payload, err := json.Marshal(msg)
+	if err != nil {
+		return err
+	}
+	job := &entity.QueueJob{
+		QueueName:   queueName,
+		Payload:     string(payload),
+		Status:      entity.QueueJobStatusPending,
+		MaxAttempts: defaultMaxAttempts,
+		NextRunAt:   time.Now(),
+	}
+	if err = js.jobQueueRepo.AddJob(ctx, job); err != nil {
+		return err
+	}
+	js.wakeup(queueName)
+	return nil
+}
+
+// RegisterHandler register the handler of the queue and start a worker for it
+func (js *JobQueueService) RegisterHandler(queueName string, handler JobHandler) {
+	js.lock.Lock()
+	defer js.lock.Unlock()
+	_, started := js.handlers[queueName]
+	js.handlers[queueName] = handler
+	if started {
+		return
+	}
+	wakeup := make(chan struct{}, 1)
+	js.wakeups[queueName] = wakeup
+	go js.working(queueName, wakeup)
+}
+
+func (js *JobQueueService) wakeup(queueName string) {
+	js.lock.Lock()
+	wakeup, ok := js.wakeups[queueName]
+	js.lock.Unlock()
+	if !ok {
+		return
+	}
+	select {
+	case wakeup <- struct{}{}:
+	default:
+	}
+}
+
+func (js *JobQueueService) getHandler(queueName string) JobHandler {
+	js.lock.Lock()
+	defer js.lock.Unlock()
+	return js.handlers[queueName]
+}
+
+func (js *JobQueueService) working(queueName string, wakeup chan struct{}) {
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+	for {
+		js.runDueJobs(queueName)
+		select {
+		case <-wakeup:
+		case <-ticker.C:
+		}
+	}
+}
+
+func (js *JobQueueService) runDueJobs(queueName string) {
+	ctx := context.Background()
+	// the jobs whose lease expired were interrupted, other instances may still be running the jobs claimed recently
+	affected, err := js.jobQueueRepo.ResetExpiredJobs(ctx, queueName, time.Now().Add(-leaseTimeout))
+	if err != nil {
+		log.Error(err)
+	} else if affected > 0 {
+		log.Infof("reset %d interrupted jobs of queue %s", affected, queueName)
+	}
+	for {
+		jobs, err := js.jobQueueRepo.GetDueJobs(ctx, queueName, time.Now(), batchSize)
+		if err != nil {
+			log.Error(err)
+			return
+		}
+		for _, job := range jobs {
+			js.runJob(ctx, job)
+		}
+		if len(jobs) < batchSize {
+			return
+		}
+	}
+}
+
+func (js *JobQueueService) runJob(ctx context.Context, job *entity.QueueJob) {
+	// look up the handler before claiming, so the job without handler stays pending instead of running forever
+	handler := js.getHandler(job.QueueName)
+	if handler == nil {
+		log.Warnf("no handler for queue %s", job.QueueName)
+		return
+	}
+	claimed, err := js.jobQueueRepo.ClaimJob(ctx, job.ID, time.Now())
+	if err != nil {
+		log.Error(err)
+		return
+	}
+	if !claimed {
+		return
+	}
+
+	stopRenewing := js.renewClaim(ctx, job)
+	err = handler(ctx, []byte(job.Payload))
+	stopRenewing()
+	if err == nil {
+		if err = js.jobQueueRepo.RemoveJob(ctx, job.ID); err != nil {
+			log.Error(err)
+		}
+		return
+	}
+
+	job.Attempts++
+	job.LastError = err.Error()
+	if job.Attempts >= job.MaxAttempts || IsPermanent(err) {
+		job.Status = entity.QueueJobStatusDead
+		log.Errorf("queue %s job %d is dead after %d attempts: %s", job.QueueName, job.ID, job.Attempts, err)
+	} else {
+		job.Status = entity.QueueJobStatusPending
+		job.NextRunAt = time.Now().Add(retryBackoff(job.Attempts))
+		log.Warnf("queue %s job %d failed, retry at %s: %s", job.QueueName, job.ID, job.NextRunAt, err)
+	}
+	if err = js.jobQueueRepo.UpdateJob(ctx, job, "status", "attempts", "last_error", "next_run_at"); err != nil {
+		log.Error(err)
+	}
+}
+
+// renewClaim keeps the lease of the running job until the returned function is called,
+// so the long jobs such as imports are not taken over by other instances
+func (js *JobQueueService) renewClaim(ctx context.Context, job *entity.QueueJob) (stop func()) {
+	done := make(chan struct{})
+	go func() {
+		ticker := time.NewTicker(leaseTimeout / 3)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-done:
+				return
+			case now := <-ticker.C:
+				err := js.jobQueueRepo.UpdateJob(ctx, &entity.QueueJob{ID: job.ID, ClaimedAt: now}, "claimed_at")
+				if err != nil {
+					log.Error(err)
+				}
+			}
+		}
+	}()
+	return func() { close(done) }
+}
+
+// retryBackoff returns the delay before the next attempt after the given number of failed attempts
+func retryBackoff(attempts int) time.Duration {
+	delay := retryBaseDelay
+	for i := 1; i < attempts; i++ {
+		delay *= 2
+		if delay >= retryMaxDelay {
+			return retryMaxDelay
+		}
+	}
+	return delay
+}
+
+// GetJobPage get queue job page
+func (js *JobQueueService) GetJobPage(ctx context.Context, req *schema.GetQueueJobPageReq) (
+	pageModel *pager.PageModel, err error) {
+	cond := &entity.QueueJob{QueueName: req.QueueName}
+	if len(req.Status) > 0 {
+		cond.Status = entity.QueueJobStatus[req.Status]
+	}
+	jobs, total, err := js.jobQueueRepo.GetJobPage(ctx, req.Page, req.PageSize, cond)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := make([]*schema.GetQueueJobPageResp, 0, len(jobs))
+	for _, job := range jobs {
+		resp = append(resp, &schema.GetQueueJobPageResp{
+			JobID:       job.ID,
+			QueueName:   job.QueueName,
+			Payload:     job.Payload,
+			Status:      queueJobStatusName(job.Status),
+			Attempts:    job.Attempts,
+			MaxAttempts: job.MaxAttempts,
+			LastError:   job.LastError,
+			CreatedAt:   job.CreatedAt.Unix(),
+			NextRunAt:   job.NextRunAt.Unix(),
+		})
+	}
+	return pager.NewPageModel(total, resp), nil
+}
+
+func queueJobStatusName(status int) string {
+	for name, s := range entity.QueueJobStatus {
+		if s == status {
+			return name
+		}
+	}
+	return ""
+}
+
+// RetryJob replay a job immediately, its attempts will be reset
+func (js *JobQueueService) RetryJob(ctx context.Context, req *schema.RetryQueueJobReq) (err error) {
+	job, exist, err := js.jobQueueRepo.GetJob(ctx, req.JobID)
+	if err != nil {
+		return err
+	}
+	if !exist {
+		return errors.BadRequest(reason.QueueJobNotFound)
+	}
+	if job.Status == entity.QueueJobStatusRunning {
+		return errors.BadRequest(reason.QueueJobIsRunning)
+	}
+	job.Status = entity.QueueJobStatusPending
+	job.Attempts = 0
+	job.NextRunAt = time.Now()
+	if err = js.jobQueueRepo.UpdateJob(ctx, job, "status", "attempts", "next_run_at"); err != nil {
+		return err
+	}
+	js.wakeup(job.QueueName)
+	return nil
+}
+
+// RemoveJob remove a job from the queue
+func (js *JobQueueService) RemoveJob(ctx context.Context, req *schema.RemoveQueueJobReq) (err error) {
+	job, exist, err := js.jobQueueRepo.GetJob(ctx, req.JobID)
+	if err != nil {
+		return err
+	}
+	if !exist {
+		return errors.BadRequest(reason.QueueJobNotFound)
+	}
+	if job.Status == entity.QueueJobStatusRunning {
+		return errors.BadRequest(reason.QueueJobIsRunning)
+	}
+	return js.jobQueueRepo.RemoveJob(ctx, job.ID)
+}
@@ -0,0 +1,119 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package job_queue
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/stretchr/testify/assert"
+)
+
+type mockJobQueueRepo struct {
+	jobs map[int64]*entity.QueueJob
+}
+
+func (m *mockJobQueueRepo) AddJob(ctx context.Context, job *entity.QueueJob) error {
+	job.ID = int64(len(m.jobs) + 1)
+	m.jobs[job.ID] = job
+	return nil
+}
+func (m *mockJobQueueRepo) GetJob(ctx context.Context, jobID int64) (*entity.QueueJob, bool, error) {
+	job, ok := m.jobs[jobID]
+	return job, ok, nil
+}
+func (m *mockJobQueueRepo) GetDueJobs(ctx context.Context, queueName string, now time.Time, limit int) (
+	[]*entity.QueueJob, error) {
+	return nil, nil
+}
+func (m *mockJobQueueRepo) GetJobPage(ctx context.Context, page, pageSize int, cond *entity.QueueJob) (
+	[]*entity.QueueJob, int64, error) {
+	return nil, 0, nil
+}
+func (m *mockJobQueueRepo) ClaimJob(ctx context.Context, jobID int64, now time.Time) (bool, error) {
+	job := m.jobs[jobID]
+	if job.Status != entity.QueueJobStatusPending {
+		return false, nil
+	}
+	job.Status, job.ClaimedAt = entity.QueueJobStatusRunning, now
+	return true, nil
+}
+func (m *mockJobQueueRepo) UpdateJob(ctx context.Context, job *entity.QueueJob, cols ...string) error {
+	return nil
+}
+func (m *mockJobQueueRepo) RemoveJob(ctx context.Context, jobID int64) error {
+	delete(m.jobs, jobID)
+	return nil
+}
+func (m *mockJobQueueRepo) ResetExpiredJobs(ctx context.Context, queueName string, claimedBefore time.Time) (
+	int64, error) {
+	return 0, nil
+}
+
+func TestRetryBackoff(t *testing.T) {
+	assert.Equal(t, 10*time.Second, retryBackoff(1))
+	assert.Equal(t, 20*time.Second, retryBackoff(2))
+	assert.Equal(t, 80*time.Second, retryBackoff(4))
+	assert.Equal(t, time.Hour, retryBackoff(20))
+}
+
+func TestRunJob(t *testing.T) {
+	repo := &mockJobQueueRepo{jobs: make(map[int64]*entity.QueueJob)}
+	js := NewJobQueueService(repo)
+	ctx := context.Background()
+	handlerErrors := map[string]error{
+		"ok":        nil,
+		"retry":     fmt.Errorf("connection refused"),
+		"permanent": Permanent(fmt.Errorf("user not exist")),
+	}
+	for name, handlerErr := range handlerErrors {
+		handlerErr := handlerErr
+		js.handlers[name] = func(ctx context.Context, payload []byte) error { return handlerErr }
+		_ = repo.AddJob(ctx, &entity.QueueJob{QueueName: name, Status: entity.QueueJobStatusPending, MaxAttempts: 8})
+	}
+	for _, job := range []*entity.QueueJob{repo.jobs[1], repo.jobs[2], repo.jobs[3]} {
+		js.runJob(ctx, job)
+	}
+
+	for _, job := range repo.jobs {
+		switch job.QueueName {
+		case "retry":
+			assert.Equal(t, entity.QueueJobStatusPending, job.Status)
+			assert.Equal(t, 1, job.Attempts)
+		case "permanent":
+			assert.Equal(t, entity.QueueJobStatusDead, job.Status)
+			assert.Equal(t, "user not exist", job.LastError)
+		default:
+			t.Errorf("job of queue %s should be removed", job.QueueName)
+		}
+	}
+	assert.Len(t, repo.jobs, 2)
+
+	unknown := &entity.QueueJob{ID: 10, QueueName: "unknown", Status: entity.QueueJobStatusPending, MaxAttempts: 8}
+	repo.jobs[unknown.ID] = unknown
+	js.runJob(ctx, unknown)
+	assert.Equal(t, entity.QueueJobStatusPending, unknown.Status)
+	assert.True(t, IsPermanent(fmt.Errorf("wrapped: %w", Permanent(fmt.Errorf("x")))))
+	assert.False(t, IsPermanent(fmt.Errorf("x")))
+	assert.Nil(t, Permanent(nil))
+}
@@ -0,0 +1,321 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./answer.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	entity "github.com/apache/incubator-answer/internal/entity"
+	schema "github.com/apache/incubator-answer/internal/schema"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockAnswerRepo is a mock of AnswerRepo interface.
+type MockAnswerRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockAnswerRepoMockRecorder
+}
+
+// MockAnswerRepoMockRecorder is the mock recorder for MockAnswerRepo.
+type MockAnswerRepoMockRecorder struct {
+	mock *MockAnswerRepo
+}
+
+// NewMockAnswerRepo creates a new mock instance.
+func NewMockAnswerRepo(ctrl *gomock.Controller) *MockAnswerRepo {
+	mock := &MockAnswerRepo{ctrl: ctrl}
+	mock.recorder = &MockAnswerRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAnswerRepo) EXPECT() *MockAnswerRepoMockRecorder {
+	return m.recorder
+}
+
+// AddAnswer mocks base method.
+func (m *MockAnswerRepo) AddAnswer(ctx context.Context, answer *entity.Answer) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddAnswer", ctx, answer)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddAnswer indicates an expected call of AddAnswer.
+func (mr *MockAnswerRepoMockRecorder) AddAnswer(ctx, answer interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddAnswer", reflect.TypeOf((*MockAnswerRepo)(nil).AddAnswer), ctx, answer)
+}
+
+// AdminSearchList mocks base method.
+func (m *MockAnswerRepo) AdminSearchList(ctx context.Context, search *schema.AdminAnswerPageReq) ([]*entity.Answer, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AdminSearchList", ctx, search)
+	ret0, _ := ret[0].([]*entity.Answer)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// AdminSearchList indicates an expected call of AdminSearchList.
+func (mr *MockAnswerRepoMockRecorder) AdminSearchList(ctx, search interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AdminSearchList", reflect.TypeOf((*MockAnswerRepo)(nil).AdminSearchList), ctx, search)
+}
+
+// GetAnswer mocks base method.
+func (m *MockAnswerRepo) GetAnswer(ctx context.Context, id string) (*entity.Answer, bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAnswer", ctx, id)
+	ret0, _ := ret[0].(*entity.Answer)
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetAnswer indicates an expected call of GetAnswer.
+func (mr *MockAnswerRepoMockRecorder) GetAnswer(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAnswer", reflect.TypeOf((*MockAnswerRepo)(nil).GetAnswer), ctx, id)
+}
+
+// GetAnswerCount mocks base method.
+func (m *MockAnswerRepo) GetAnswerCount(ctx context.Context) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAnswerCount", ctx)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAnswerCount indicates an expected call of GetAnswerCount.
+func (mr *MockAnswerRepoMockRecorder) GetAnswerCount(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAnswerCount", reflect.TypeOf((*MockAnswerRepo)(nil).GetAnswerCount), ctx)
+}
+
+// GetAnswerList mocks base method.
+func (m *MockAnswerRepo) GetAnswerList(ctx context.Context, answer *entity.Answer) ([]*entity.Answer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAnswerList", ctx, answer)
+	ret0, _ := ret[0].([]*entity.Answer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAnswerList indicates an expected call of GetAnswerList.
+func (mr *MockAnswerRepoMockRecorder) GetAnswerList(ctx, answer interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAnswerList", reflect.TypeOf((*MockAnswerRepo)(nil).GetAnswerList), ctx, answer)
+}
+
+// GetAnswerPage mocks base method.
+func (m *MockAnswerRepo) GetAnswerPage(ctx context.Context, page, pageSize int, answer *entity.Answer) ([]*entity.Answer, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAnswerPage", ctx, page, pageSize, answer)
+	ret0, _ := ret[0].([]*entity.Answer)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetAnswerPage indicates an expected call of GetAnswerPage.
+func (mr *MockAnswerRepoMockRecorder) GetAnswerPage(ctx, page, pageSize, answer interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAnswerPage", reflect.TypeOf((*MockAnswerRepo)(nil).GetAnswerPage), ctx, page, pageSize, answer)
+}
+
+// GetByID mocks base method.
+func (m *MockAnswerRepo) GetByID(ctx context.Context, answerID string) (*entity.Answer, bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, answerID)
+	ret0, _ := ret[0].(*entity.Answer)
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockAnswerRepoMockRecorder) GetByID(ctx, answerID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockAnswerRepo)(nil).GetByID), ctx, answerID)
+}
+
+// GetCountByQuestionID mocks base method.
+func (m *MockAnswerRepo) GetCountByQuestionID(ctx context.Context, questionID string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCountByQuestionID", ctx, questionID)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCountByQuestionID indicates an expected call of GetCountByQuestionID.
+func (mr *MockAnswerRepoMockRecorder) GetCountByQuestionID(ctx, questionID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCountByQuestionID", reflect.TypeOf((*MockAnswerRepo)(nil).GetCountByQuestionID), ctx, questionID)
+}
+
+// GetCountByUserID mocks base method.
+func (m *MockAnswerRepo) GetCountByUserID(ctx context.Context, userID string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCountByUserID", ctx, userID)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCountByUserID indicates an expected call of GetCountByUserID.
+func (mr *MockAnswerRepoMockRecorder) GetCountByUserID(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCountByUserID", reflect.TypeOf((*MockAnswerRepo)(nil).GetCountByUserID), ctx, userID)
+}
+
+// GetIDsByUserIDAndQuestionID mocks base method.
+func (m *MockAnswerRepo) GetIDsByUserIDAndQuestionID(ctx context.Context, userID, questionID string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetIDsByUserIDAndQuestionID", ctx, userID, questionID)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetIDsByUserIDAndQuestionID indicates an expected call of GetIDsByUserIDAndQuestionID.
+func (mr *MockAnswerRepoMockRecorder) GetIDsByUserIDAndQuestionID(ctx, userID, questionID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetIDsByUserIDAndQuestionID", reflect.TypeOf((*MockAnswerRepo)(nil).GetIDsByUserIDAndQuestionID), ctx, userID, questionID)
+}
+
+// GetPersonalAnswerPage mocks base method.
+func (m *MockAnswerRepo) GetPersonalAnswerPage(ctx context.Context, cond *entity.PersonalAnswerPageQueryCond) ([]*entity.Answer, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPersonalAnswerPage", ctx, cond)
+	ret0, _ := ret[0].([]*entity.Answer)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetPersonalAnswerPage indicates an expected call of GetPersonalAnswerPage.
+func (mr *MockAnswerRepoMockRecorder) GetPersonalAnswerPage(ctx, cond interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPersonalAnswerPage", reflect.TypeOf((*MockAnswerRepo)(nil).GetPersonalAnswerPage), ctx, cond)
+}
+
+// RecoverAnswer mocks base method.
+func (m *MockAnswerRepo) RecoverAnswer(ctx context.Context, answerID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecoverAnswer", ctx, answerID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RecoverAnswer indicates an expected call of RecoverAnswer.
+func (mr *MockAnswerRepoMockRecorder) RecoverAnswer(ctx, answerID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecoverAnswer", reflect.TypeOf((*MockAnswerRepo)(nil).RecoverAnswer), ctx, answerID)
+}
+
+// RemoveAllUserAnswer mocks base method.
+func (m *MockAnswerRepo) RemoveAllUserAnswer(ctx context.Context, userID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveAllUserAnswer", ctx, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveAllUserAnswer indicates an expected call of RemoveAllUserAnswer.
+func (mr *MockAnswerRepoMockRecorder) RemoveAllUserAnswer(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveAllUserAnswer", reflect.TypeOf((*MockAnswerRepo)(nil).RemoveAllUserAnswer), ctx, userID)
+}
+
+// RemoveAnswer mocks base method.
+func (m *MockAnswerRepo) RemoveAnswer(ctx context.Context, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveAnswer", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveAnswer indicates an expected call of RemoveAnswer.
+func (mr *MockAnswerRepoMockRecorder) RemoveAnswer(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveAnswer", reflect.TypeOf((*MockAnswerRepo)(nil).RemoveAnswer), ctx, id)
+}
+
+// SearchList mocks base method.
+func (m *MockAnswerRepo) SearchList(ctx context.Context, search *entity.AnswerSearch) ([]*entity.Answer, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SearchList", ctx, search)
+	ret0, _ := ret[0].([]*entity.Answer)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// SearchList indicates an expected call of SearchList.
+func (mr *MockAnswerRepoMockRecorder) SearchList(ctx, search interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchList", reflect.TypeOf((*MockAnswerRepo)(nil).SearchList), ctx, search)
+}
+
+// SumVotesByQuestionID mocks base method.
+func (m *MockAnswerRepo) SumVotesByQuestionID(ctx context.Context, questionID string) (float64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SumVotesByQuestionID", ctx, questionID)
+	ret0, _ := ret[0].(float64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SumVotesByQuestionID indicates an expected call of SumVotesByQuestionID.
+func (mr *MockAnswerRepoMockRecorder) SumVotesByQuestionID(ctx, questionID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SumVotesByQuestionID", reflect.TypeOf((*MockAnswerRepo)(nil).SumVotesByQuestionID), ctx, questionID)
+}
+
+// UpdateAcceptedStatus mocks base method.
+func (m *MockAnswerRepo) UpdateAcceptedStatus(ctx context.Context, acceptedAnswerID, questionID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateAcceptedStatus", ctx, acceptedAnswerID, questionID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateAcceptedStatus indicates an expected call of UpdateAcceptedStatus.
+func (mr *MockAnswerRepoMockRecorder) UpdateAcceptedStatus(ctx, acceptedAnswerID, questionID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateAcceptedStatus", reflect.TypeOf((*MockAnswerRepo)(nil).UpdateAcceptedStatus), ctx, acceptedAnswerID, questionID)
+}
+
+// UpdateAnswer mocks base method.
+func (m *MockAnswerRepo) UpdateAnswer(ctx context.Context, answer *entity.Answer, cols []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateAnswer", ctx, answer, cols)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateAnswer indicates an expected call of UpdateAnswer.
+func (mr *MockAnswerRepoMockRecorder) UpdateAnswer(ctx, answer, cols interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateAnswer", reflect.TypeOf((*MockAnswerRepo)(nil).UpdateAnswer), ctx, answer, cols)
+}
+
+// UpdateAnswerStatus mocks base method.
+func (m *MockAnswerRepo) UpdateAnswerStatus(ctx context.Context, answerID string, status int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateAnswerStatus", ctx, answerID, status)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateAnswerStatus indicates an expected call of UpdateAnswerStatus.
+func (mr *MockAnswerRepoMockRecorder) UpdateAnswerStatus(ctx, answerID, status interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateAnswerStatus", reflect.TypeOf((*MockAnswerRepo)(nil).UpdateAnswerStatus), ctx, answerID, status)
+}
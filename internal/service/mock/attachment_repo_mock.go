@@ -0,0 +1,125 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./attachment_service.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	entity "github.com/apache/incubator-answer/internal/entity"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockAttachmentRepo is a mock of AttachmentRepo interface.
+type MockAttachmentRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockAttachmentRepoMockRecorder
+}
+
+// MockAttachmentRepoMockRecorder is the mock recorder for MockAttachmentRepo.
+type MockAttachmentRepoMockRecorder struct {
+	mock *MockAttachmentRepo
+}
+
+// NewMockAttachmentRepo creates a new mock instance.
+func NewMockAttachmentRepo(ctrl *gomock.Controller) *MockAttachmentRepo {
+	mock := &MockAttachmentRepo{ctrl: ctrl}
+	mock.recorder = &MockAttachmentRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAttachmentRepo) EXPECT() *MockAttachmentRepoMockRecorder {
+	return m.recorder
+}
+
+// AddAttachment mocks base method.
+func (m *MockAttachmentRepo) AddAttachment(ctx context.Context, attachment *entity.Attachment) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddAttachment", ctx, attachment)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddAttachment indicates an expected call of AddAttachment.
+func (mr *MockAttachmentRepoMockRecorder) AddAttachment(ctx, attachment interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddAttachment", reflect.TypeOf((*MockAttachmentRepo)(nil).AddAttachment), ctx, attachment)
+}
+
+// GetAttachmentByFileID mocks base method.
+func (m *MockAttachmentRepo) GetAttachmentByFileID(ctx context.Context, fileID string) (*entity.Attachment, bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAttachmentByFileID", ctx, fileID)
+	ret0, _ := ret[0].(*entity.Attachment)
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetAttachmentByFileID indicates an expected call of GetAttachmentByFileID.
+func (mr *MockAttachmentRepoMockRecorder) GetAttachmentByFileID(ctx, fileID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAttachmentByFileID", reflect.TypeOf((*MockAttachmentRepo)(nil).GetAttachmentByFileID), ctx, fileID)
+}
+
+// GetUnlinkedAttachments mocks base method.
+func (m *MockAttachmentRepo) GetUnlinkedAttachments(ctx context.Context, createdBefore time.Time, lastID int64, limit int) ([]*entity.Attachment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUnlinkedAttachments", ctx, createdBefore, lastID, limit)
+	ret0, _ := ret[0].([]*entity.Attachment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUnlinkedAttachments indicates an expected call of GetUnlinkedAttachments.
+func (mr *MockAttachmentRepoMockRecorder) GetUnlinkedAttachments(ctx, createdBefore, lastID, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUnlinkedAttachments", reflect.TypeOf((*MockAttachmentRepo)(nil).GetUnlinkedAttachments), ctx, createdBefore, lastID, limit)
+}
+
+// IncreaseDownloadCount mocks base method.
+func (m *MockAttachmentRepo) IncreaseDownloadCount(ctx context.Context, id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IncreaseDownloadCount", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// IncreaseDownloadCount indicates an expected call of IncreaseDownloadCount.
+func (mr *MockAttachmentRepoMockRecorder) IncreaseDownloadCount(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IncreaseDownloadCount", reflect.TypeOf((*MockAttachmentRepo)(nil).IncreaseDownloadCount), ctx, id)
+}
+
+// LinkAttachments mocks base method.
+func (m *MockAttachmentRepo) LinkAttachments(ctx context.Context, objectID string, fileIDs []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LinkAttachments", ctx, objectID, fileIDs)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// LinkAttachments indicates an expected call of LinkAttachments.
+func (mr *MockAttachmentRepoMockRecorder) LinkAttachments(ctx, objectID, fileIDs interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LinkAttachments", reflect.TypeOf((*MockAttachmentRepo)(nil).LinkAttachments), ctx, objectID, fileIDs)
+}
+
+// RemoveUnlinkedAttachment mocks base method.
+func (m *MockAttachmentRepo) RemoveUnlinkedAttachment(ctx context.Context, id int64) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveUnlinkedAttachment", ctx, id)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RemoveUnlinkedAttachment indicates an expected call of RemoveUnlinkedAttachment.
+func (mr *MockAttachmentRepoMockRecorder) RemoveUnlinkedAttachment(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveUnlinkedAttachment", reflect.TypeOf((*MockAttachmentRepo)(nil).RemoveUnlinkedAttachment), ctx, id)
+}
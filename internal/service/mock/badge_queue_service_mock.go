@@ -0,0 +1,60 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./badge_queue.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	schema "github.com/apache/incubator-answer/internal/schema"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockBadgeQueueService is a mock of BadgeQueueService interface.
+type MockBadgeQueueService struct {
+	ctrl     *gomock.Controller
+	recorder *MockBadgeQueueServiceMockRecorder
+}
+
+// MockBadgeQueueServiceMockRecorder is the mock recorder for MockBadgeQueueService.
+type MockBadgeQueueServiceMockRecorder struct {
+	mock *MockBadgeQueueService
+}
+
+// NewMockBadgeQueueService creates a new mock instance.
+func NewMockBadgeQueueService(ctrl *gomock.Controller) *MockBadgeQueueService {
+	mock := &MockBadgeQueueService{ctrl: ctrl}
+	mock.recorder = &MockBadgeQueueServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBadgeQueueService) EXPECT() *MockBadgeQueueServiceMockRecorder {
+	return m.recorder
+}
+
+// RegisterHandler mocks base method.
+func (m *MockBadgeQueueService) RegisterHandler(handler func(context.Context, *schema.BadgeEventMsg) error) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "RegisterHandler", handler)
+}
+
+// RegisterHandler indicates an expected call of RegisterHandler.
+func (mr *MockBadgeQueueServiceMockRecorder) RegisterHandler(handler interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RegisterHandler", reflect.TypeOf((*MockBadgeQueueService)(nil).RegisterHandler), handler)
+}
+
+// Send mocks base method.
+func (m *MockBadgeQueueService) Send(ctx context.Context, msg *schema.BadgeEventMsg) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Send", ctx, msg)
+}
+
+// Send indicates an expected call of Send.
+func (mr *MockBadgeQueueServiceMockRecorder) Send(ctx, msg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Send", reflect.TypeOf((*MockBadgeQueueService)(nil).Send), ctx, msg)
+}
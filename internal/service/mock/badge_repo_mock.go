@@ -0,0 +1,126 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./badge_service.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	entity "github.com/apache/incubator-answer/internal/entity"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockBadgeRepo is a mock of BadgeRepo interface.
+type MockBadgeRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockBadgeRepoMockRecorder
+}
+
+// MockBadgeRepoMockRecorder is the mock recorder for MockBadgeRepo.
+type MockBadgeRepoMockRecorder struct {
+	mock *MockBadgeRepo
+}
+
+// NewMockBadgeRepo creates a new mock instance.
+func NewMockBadgeRepo(ctrl *gomock.Controller) *MockBadgeRepo {
+	mock := &MockBadgeRepo{ctrl: ctrl}
+	mock.recorder = &MockBadgeRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBadgeRepo) EXPECT() *MockBadgeRepoMockRecorder {
+	return m.recorder
+}
+
+// AddUserBadge mocks base method.
+func (m *MockBadgeRepo) AddUserBadge(ctx context.Context, userBadge *entity.UserBadge) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddUserBadge", ctx, userBadge)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddUserBadge indicates an expected call of AddUserBadge.
+func (mr *MockBadgeRepoMockRecorder) AddUserBadge(ctx, userBadge interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddUserBadge", reflect.TypeOf((*MockBadgeRepo)(nil).AddUserBadge), ctx, userBadge)
+}
+
+// CountAcceptedAnswer mocks base method.
+func (m *MockBadgeRepo) CountAcceptedAnswer(ctx context.Context, userID string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountAcceptedAnswer", ctx, userID)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountAcceptedAnswer indicates an expected call of CountAcceptedAnswer.
+func (mr *MockBadgeRepoMockRecorder) CountAcceptedAnswer(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountAcceptedAnswer", reflect.TypeOf((*MockBadgeRepo)(nil).CountAcceptedAnswer), ctx, userID)
+}
+
+// CountActiveDays mocks base method.
+func (m *MockBadgeRepo) CountActiveDays(ctx context.Context, userID string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountActiveDays", ctx, userID)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountActiveDays indicates an expected call of CountActiveDays.
+func (mr *MockBadgeRepoMockRecorder) CountActiveDays(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountActiveDays", reflect.TypeOf((*MockBadgeRepo)(nil).CountActiveDays), ctx, userID)
+}
+
+// GetUpvotedAnswerTagStat mocks base method.
+func (m *MockBadgeRepo) GetUpvotedAnswerTagStat(ctx context.Context, userID string) ([]*entity.UserBadgeTagStat, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUpvotedAnswerTagStat", ctx, userID)
+	ret0, _ := ret[0].([]*entity.UserBadgeTagStat)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUpvotedAnswerTagStat indicates an expected call of GetUpvotedAnswerTagStat.
+func (mr *MockBadgeRepoMockRecorder) GetUpvotedAnswerTagStat(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUpvotedAnswerTagStat", reflect.TypeOf((*MockBadgeRepo)(nil).GetUpvotedAnswerTagStat), ctx, userID)
+}
+
+// GetUserBadgeList mocks base method.
+func (m *MockBadgeRepo) GetUserBadgeList(ctx context.Context, userID string) ([]*entity.UserBadge, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserBadgeList", ctx, userID)
+	ret0, _ := ret[0].([]*entity.UserBadge)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserBadgeList indicates an expected call of GetUserBadgeList.
+func (mr *MockBadgeRepoMockRecorder) GetUserBadgeList(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserBadgeList", reflect.TypeOf((*MockBadgeRepo)(nil).GetUserBadgeList), ctx, userID)
+}
+
+// GetUserIDsAfter mocks base method.
+func (m *MockBadgeRepo) GetUserIDsAfter(ctx context.Context, lastUserID string, limit int) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserIDsAfter", ctx, lastUserID, limit)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserIDsAfter indicates an expected call of GetUserIDsAfter.
+func (mr *MockBadgeRepoMockRecorder) GetUserIDsAfter(ctx, lastUserID, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserIDsAfter", reflect.TypeOf((*MockBadgeRepo)(nil).GetUserIDsAfter), ctx, lastUserID, limit)
+}
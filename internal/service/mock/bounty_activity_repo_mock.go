@@ -0,0 +1,78 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./bounty_activity.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	entity "github.com/apache/incubator-answer/internal/entity"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockBountyActivityRepo is a mock of BountyActivityRepo interface.
+type MockBountyActivityRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockBountyActivityRepoMockRecorder
+}
+
+// MockBountyActivityRepoMockRecorder is the mock recorder for MockBountyActivityRepo.
+type MockBountyActivityRepoMockRecorder struct {
+	mock *MockBountyActivityRepo
+}
+
+// NewMockBountyActivityRepo creates a new mock instance.
+func NewMockBountyActivityRepo(ctrl *gomock.Controller) *MockBountyActivityRepo {
+	mock := &MockBountyActivityRepo{ctrl: ctrl}
+	mock.recorder = &MockBountyActivityRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBountyActivityRepo) EXPECT() *MockBountyActivityRepoMockRecorder {
+	return m.recorder
+}
+
+// AwardBounty mocks base method.
+func (m *MockBountyActivityRepo) AwardBounty(ctx context.Context, bounty *entity.QuestionBounty) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AwardBounty", ctx, bounty)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AwardBounty indicates an expected call of AwardBounty.
+func (mr *MockBountyActivityRepoMockRecorder) AwardBounty(ctx, bounty interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AwardBounty", reflect.TypeOf((*MockBountyActivityRepo)(nil).AwardBounty), ctx, bounty)
+}
+
+// ExpireBounty mocks base method.
+func (m *MockBountyActivityRepo) ExpireBounty(ctx context.Context, bounty *entity.QuestionBounty) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExpireBounty", ctx, bounty)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ExpireBounty indicates an expected call of ExpireBounty.
+func (mr *MockBountyActivityRepoMockRecorder) ExpireBounty(ctx, bounty interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExpireBounty", reflect.TypeOf((*MockBountyActivityRepo)(nil).ExpireBounty), ctx, bounty)
+}
+
+// OfferBounty mocks base method.
+func (m *MockBountyActivityRepo) OfferBounty(ctx context.Context, bounty *entity.QuestionBounty) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "OfferBounty", ctx, bounty)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// OfferBounty indicates an expected call of OfferBounty.
+func (mr *MockBountyActivityRepoMockRecorder) OfferBounty(ctx, bounty interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OfferBounty", reflect.TypeOf((*MockBountyActivityRepo)(nil).OfferBounty), ctx, bounty)
+}
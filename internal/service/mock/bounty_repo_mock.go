@@ -0,0 +1,98 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./bounty_service.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	entity "github.com/apache/incubator-answer/internal/entity"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockBountyRepo is a mock of BountyRepo interface.
+type MockBountyRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockBountyRepoMockRecorder
+}
+
+// MockBountyRepoMockRecorder is the mock recorder for MockBountyRepo.
+type MockBountyRepoMockRecorder struct {
+	mock *MockBountyRepo
+}
+
+// NewMockBountyRepo creates a new mock instance.
+func NewMockBountyRepo(ctrl *gomock.Controller) *MockBountyRepo {
+	mock := &MockBountyRepo{ctrl: ctrl}
+	mock.recorder = &MockBountyRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBountyRepo) EXPECT() *MockBountyRepoMockRecorder {
+	return m.recorder
+}
+
+// GetActiveBounty mocks base method.
+func (m *MockBountyRepo) GetActiveBounty(ctx context.Context, questionID string) (*entity.QuestionBounty, bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetActiveBounty", ctx, questionID)
+	ret0, _ := ret[0].(*entity.QuestionBounty)
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetActiveBounty indicates an expected call of GetActiveBounty.
+func (mr *MockBountyRepoMockRecorder) GetActiveBounty(ctx, questionID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetActiveBounty", reflect.TypeOf((*MockBountyRepo)(nil).GetActiveBounty), ctx, questionID)
+}
+
+// GetActiveBountyListByQuestionIDs mocks base method.
+func (m *MockBountyRepo) GetActiveBountyListByQuestionIDs(ctx context.Context, questionIDs []string) ([]*entity.QuestionBounty, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetActiveBountyListByQuestionIDs", ctx, questionIDs)
+	ret0, _ := ret[0].([]*entity.QuestionBounty)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetActiveBountyListByQuestionIDs indicates an expected call of GetActiveBountyListByQuestionIDs.
+func (mr *MockBountyRepoMockRecorder) GetActiveBountyListByQuestionIDs(ctx, questionIDs interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetActiveBountyListByQuestionIDs", reflect.TypeOf((*MockBountyRepo)(nil).GetActiveBountyListByQuestionIDs), ctx, questionIDs)
+}
+
+// GetBountyListByQuestionID mocks base method.
+func (m *MockBountyRepo) GetBountyListByQuestionID(ctx context.Context, questionID string) ([]*entity.QuestionBounty, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBountyListByQuestionID", ctx, questionID)
+	ret0, _ := ret[0].([]*entity.QuestionBounty)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBountyListByQuestionID indicates an expected call of GetBountyListByQuestionID.
+func (mr *MockBountyRepoMockRecorder) GetBountyListByQuestionID(ctx, questionID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBountyListByQuestionID", reflect.TypeOf((*MockBountyRepo)(nil).GetBountyListByQuestionID), ctx, questionID)
+}
+
+// GetExpiredBountyList mocks base method.
+func (m *MockBountyRepo) GetExpiredBountyList(ctx context.Context, deadline time.Time, limit int) ([]*entity.QuestionBounty, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetExpiredBountyList", ctx, deadline, limit)
+	ret0, _ := ret[0].([]*entity.QuestionBounty)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetExpiredBountyList indicates an expected call of GetExpiredBountyList.
+func (mr *MockBountyRepoMockRecorder) GetExpiredBountyList(ctx, deadline, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetExpiredBountyList", reflect.TypeOf((*MockBountyRepo)(nil).GetExpiredBountyList), ctx, deadline, limit)
+}
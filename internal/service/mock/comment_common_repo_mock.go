@@ -0,0 +1,97 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./comment_service.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	entity "github.com/apache/incubator-answer/internal/entity"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockCommentCommonRepo is a mock of CommentCommonRepo interface.
+type MockCommentCommonRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockCommentCommonRepoMockRecorder
+}
+
+// MockCommentCommonRepoMockRecorder is the mock recorder for MockCommentCommonRepo.
+type MockCommentCommonRepoMockRecorder struct {
+	mock *MockCommentCommonRepo
+}
+
+// NewMockCommentCommonRepo creates a new mock instance.
+func NewMockCommentCommonRepo(ctrl *gomock.Controller) *MockCommentCommonRepo {
+	mock := &MockCommentCommonRepo{ctrl: ctrl}
+	mock.recorder = &MockCommentCommonRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCommentCommonRepo) EXPECT() *MockCommentCommonRepoMockRecorder {
+	return m.recorder
+}
+
+// GetComment mocks base method.
+func (m *MockCommentCommonRepo) GetComment(ctx context.Context, commentID string) (*entity.Comment, bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetComment", ctx, commentID)
+	ret0, _ := ret[0].(*entity.Comment)
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetComment indicates an expected call of GetComment.
+func (mr *MockCommentCommonRepoMockRecorder) GetComment(ctx, commentID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetComment", reflect.TypeOf((*MockCommentCommonRepo)(nil).GetComment), ctx, commentID)
+}
+
+// GetCommentCount mocks base method.
+func (m *MockCommentCommonRepo) GetCommentCount(ctx context.Context) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCommentCount", ctx)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCommentCount indicates an expected call of GetCommentCount.
+func (mr *MockCommentCommonRepoMockRecorder) GetCommentCount(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCommentCount", reflect.TypeOf((*MockCommentCommonRepo)(nil).GetCommentCount), ctx)
+}
+
+// GetCommentWithoutStatus mocks base method.
+func (m *MockCommentCommonRepo) GetCommentWithoutStatus(ctx context.Context, commentID string) (*entity.Comment, bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCommentWithoutStatus", ctx, commentID)
+	ret0, _ := ret[0].(*entity.Comment)
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetCommentWithoutStatus indicates an expected call of GetCommentWithoutStatus.
+func (mr *MockCommentCommonRepoMockRecorder) GetCommentWithoutStatus(ctx, commentID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCommentWithoutStatus", reflect.TypeOf((*MockCommentCommonRepo)(nil).GetCommentWithoutStatus), ctx, commentID)
+}
+
+// RemoveAllUserComment mocks base method.
+func (m *MockCommentCommonRepo) RemoveAllUserComment(ctx context.Context, userID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveAllUserComment", ctx, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveAllUserComment indicates an expected call of RemoveAllUserComment.
+func (mr *MockCommentCommonRepoMockRecorder) RemoveAllUserComment(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveAllUserComment", reflect.TypeOf((*MockCommentCommonRepo)(nil).RemoveAllUserComment), ctx, userID)
+}
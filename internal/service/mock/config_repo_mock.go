@@ -0,0 +1,80 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./config_service.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	entity "github.com/apache/incubator-answer/internal/entity"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockConfigRepo is a mock of ConfigRepo interface.
+type MockConfigRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockConfigRepoMockRecorder
+}
+
+// MockConfigRepoMockRecorder is the mock recorder for MockConfigRepo.
+type MockConfigRepoMockRecorder struct {
+	mock *MockConfigRepo
+}
+
+// NewMockConfigRepo creates a new mock instance.
+func NewMockConfigRepo(ctrl *gomock.Controller) *MockConfigRepo {
+	mock := &MockConfigRepo{ctrl: ctrl}
+	mock.recorder = &MockConfigRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockConfigRepo) EXPECT() *MockConfigRepoMockRecorder {
+	return m.recorder
+}
+
+// GetConfigByID mocks base method.
+func (m *MockConfigRepo) GetConfigByID(ctx context.Context, id int) (*entity.Config, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetConfigByID", ctx, id)
+	ret0, _ := ret[0].(*entity.Config)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetConfigByID indicates an expected call of GetConfigByID.
+func (mr *MockConfigRepoMockRecorder) GetConfigByID(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetConfigByID", reflect.TypeOf((*MockConfigRepo)(nil).GetConfigByID), ctx, id)
+}
+
+// GetConfigByKey mocks base method.
+func (m *MockConfigRepo) GetConfigByKey(ctx context.Context, key string) (*entity.Config, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetConfigByKey", ctx, key)
+	ret0, _ := ret[0].(*entity.Config)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetConfigByKey indicates an expected call of GetConfigByKey.
+func (mr *MockConfigRepoMockRecorder) GetConfigByKey(ctx, key interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetConfigByKey", reflect.TypeOf((*MockConfigRepo)(nil).GetConfigByKey), ctx, key)
+}
+
+// UpdateConfig mocks base method.
+func (m *MockConfigRepo) UpdateConfig(ctx context.Context, key, value string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateConfig", ctx, key, value)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateConfig indicates an expected call of UpdateConfig.
+func (mr *MockConfigRepoMockRecorder) UpdateConfig(ctx, key, value interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateConfig", reflect.TypeOf((*MockConfigRepo)(nil).UpdateConfig), ctx, key, value)
+}
@@ -0,0 +1,60 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./notice_queue.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	schema "github.com/apache/incubator-answer/internal/schema"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockNotificationQueueService is a mock of NotificationQueueService interface.
+type MockNotificationQueueService struct {
+	ctrl     *gomock.Controller
+	recorder *MockNotificationQueueServiceMockRecorder
+}
+
+// MockNotificationQueueServiceMockRecorder is the mock recorder for MockNotificationQueueService.
+type MockNotificationQueueServiceMockRecorder struct {
+	mock *MockNotificationQueueService
+}
+
+// NewMockNotificationQueueService creates a new mock instance.
+func NewMockNotificationQueueService(ctrl *gomock.Controller) *MockNotificationQueueService {
+	mock := &MockNotificationQueueService{ctrl: ctrl}
+	mock.recorder = &MockNotificationQueueServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockNotificationQueueService) EXPECT() *MockNotificationQueueServiceMockRecorder {
+	return m.recorder
+}
+
+// RegisterHandler mocks base method.
+func (m *MockNotificationQueueService) RegisterHandler(handler func(context.Context, *schema.NotificationMsg) error) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "RegisterHandler", handler)
+}
+
+// RegisterHandler indicates an expected call of RegisterHandler.
+func (mr *MockNotificationQueueServiceMockRecorder) RegisterHandler(handler interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RegisterHandler", reflect.TypeOf((*MockNotificationQueueService)(nil).RegisterHandler), handler)
+}
+
+// Send mocks base method.
+func (m *MockNotificationQueueService) Send(ctx context.Context, msg *schema.NotificationMsg) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Send", ctx, msg)
+}
+
+// Send indicates an expected call of Send.
+func (mr *MockNotificationQueueServiceMockRecorder) Send(ctx, msg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Send", reflect.TypeOf((*MockNotificationQueueService)(nil).Send), ctx, msg)
+}
@@ -0,0 +1,372 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./question.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	entity "github.com/apache/incubator-answer/internal/entity"
+	schema "github.com/apache/incubator-answer/internal/schema"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockQuestionRepo is a mock of QuestionRepo interface.
+type MockQuestionRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockQuestionRepoMockRecorder
+}
+
+// MockQuestionRepoMockRecorder is the mock recorder for MockQuestionRepo.
+type MockQuestionRepoMockRecorder struct {
+	mock *MockQuestionRepo
+}
+
+// NewMockQuestionRepo creates a new mock instance.
+func NewMockQuestionRepo(ctrl *gomock.Controller) *MockQuestionRepo {
+	mock := &MockQuestionRepo{ctrl: ctrl}
+	mock.recorder = &MockQuestionRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockQuestionRepo) EXPECT() *MockQuestionRepoMockRecorder {
+	return m.recorder
+}
+
+// AddQuestion mocks base method.
+func (m *MockQuestionRepo) AddQuestion(ctx context.Context, question *entity.Question) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddQuestion", ctx, question)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddQuestion indicates an expected call of AddQuestion.
+func (mr *MockQuestionRepoMockRecorder) AddQuestion(ctx, question interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddQuestion", reflect.TypeOf((*MockQuestionRepo)(nil).AddQuestion), ctx, question)
+}
+
+// AdminQuestionPage mocks base method.
+func (m *MockQuestionRepo) AdminQuestionPage(ctx context.Context, search *schema.AdminQuestionPageReq) ([]*entity.Question, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AdminQuestionPage", ctx, search)
+	ret0, _ := ret[0].([]*entity.Question)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// AdminQuestionPage indicates an expected call of AdminQuestionPage.
+func (mr *MockQuestionRepoMockRecorder) AdminQuestionPage(ctx, search interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AdminQuestionPage", reflect.TypeOf((*MockQuestionRepo)(nil).AdminQuestionPage), ctx, search)
+}
+
+// FindByID mocks base method.
+func (m *MockQuestionRepo) FindByID(ctx context.Context, id []string) ([]*entity.Question, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByID", ctx, id)
+	ret0, _ := ret[0].([]*entity.Question)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByID indicates an expected call of FindByID.
+func (mr *MockQuestionRepoMockRecorder) FindByID(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByID", reflect.TypeOf((*MockQuestionRepo)(nil).FindByID), ctx, id)
+}
+
+// GetQuestion mocks base method.
+func (m *MockQuestionRepo) GetQuestion(ctx context.Context, id string) (*entity.Question, bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetQuestion", ctx, id)
+	ret0, _ := ret[0].(*entity.Question)
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetQuestion indicates an expected call of GetQuestion.
+func (mr *MockQuestionRepoMockRecorder) GetQuestion(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetQuestion", reflect.TypeOf((*MockQuestionRepo)(nil).GetQuestion), ctx, id)
+}
+
+// GetQuestionCount mocks base method.
+func (m *MockQuestionRepo) GetQuestionCount(ctx context.Context) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetQuestionCount", ctx)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetQuestionCount indicates an expected call of GetQuestionCount.
+func (mr *MockQuestionRepoMockRecorder) GetQuestionCount(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetQuestionCount", reflect.TypeOf((*MockQuestionRepo)(nil).GetQuestionCount), ctx)
+}
+
+// GetQuestionList mocks base method.
+func (m *MockQuestionRepo) GetQuestionList(ctx context.Context, question *entity.Question) ([]*entity.Question, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetQuestionList", ctx, question)
+	ret0, _ := ret[0].([]*entity.Question)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetQuestionList indicates an expected call of GetQuestionList.
+func (mr *MockQuestionRepoMockRecorder) GetQuestionList(ctx, question interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetQuestionList", reflect.TypeOf((*MockQuestionRepo)(nil).GetQuestionList), ctx, question)
+}
+
+// GetQuestionPage mocks base method.
+func (m *MockQuestionRepo) GetQuestionPage(ctx context.Context, page, pageSize int, tagIDs []string, userID, orderCond string, inDays int, showHidden, showPending bool) ([]*entity.Question, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetQuestionPage", ctx, page, pageSize, tagIDs, userID, orderCond, inDays, showHidden, showPending)
+	ret0, _ := ret[0].([]*entity.Question)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetQuestionPage indicates an expected call of GetQuestionPage.
+func (mr *MockQuestionRepoMockRecorder) GetQuestionPage(ctx, page, pageSize, tagIDs, userID, orderCond, inDays, showHidden, showPending interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetQuestionPage", reflect.TypeOf((*MockQuestionRepo)(nil).GetQuestionPage), ctx, page, pageSize, tagIDs, userID, orderCond, inDays, showHidden, showPending)
+}
+
+// GetQuestionsByTitle mocks base method.
+func (m *MockQuestionRepo) GetQuestionsByTitle(ctx context.Context, title string, pageSize int) ([]*entity.Question, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetQuestionsByTitle", ctx, title, pageSize)
+	ret0, _ := ret[0].([]*entity.Question)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetQuestionsByTitle indicates an expected call of GetQuestionsByTitle.
+func (mr *MockQuestionRepoMockRecorder) GetQuestionsByTitle(ctx, title, pageSize interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetQuestionsByTitle", reflect.TypeOf((*MockQuestionRepo)(nil).GetQuestionsByTitle), ctx, title, pageSize)
+}
+
+// GetUserQuestionCount mocks base method.
+func (m *MockQuestionRepo) GetUserQuestionCount(ctx context.Context, userID string, show int) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserQuestionCount", ctx, userID, show)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserQuestionCount indicates an expected call of GetUserQuestionCount.
+func (mr *MockQuestionRepoMockRecorder) GetUserQuestionCount(ctx, userID, show interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserQuestionCount", reflect.TypeOf((*MockQuestionRepo)(nil).GetUserQuestionCount), ctx, userID, show)
+}
+
+// RecoverQuestion mocks base method.
+func (m *MockQuestionRepo) RecoverQuestion(ctx context.Context, questionID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecoverQuestion", ctx, questionID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RecoverQuestion indicates an expected call of RecoverQuestion.
+func (mr *MockQuestionRepoMockRecorder) RecoverQuestion(ctx, questionID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecoverQuestion", reflect.TypeOf((*MockQuestionRepo)(nil).RecoverQuestion), ctx, questionID)
+}
+
+// RemoveAllUserQuestion mocks base method.
+func (m *MockQuestionRepo) RemoveAllUserQuestion(ctx context.Context, userID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveAllUserQuestion", ctx, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveAllUserQuestion indicates an expected call of RemoveAllUserQuestion.
+func (mr *MockQuestionRepoMockRecorder) RemoveAllUserQuestion(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveAllUserQuestion", reflect.TypeOf((*MockQuestionRepo)(nil).RemoveAllUserQuestion), ctx, userID)
+}
+
+// RemoveQuestion mocks base method.
+func (m *MockQuestionRepo) RemoveQuestion(ctx context.Context, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveQuestion", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveQuestion indicates an expected call of RemoveQuestion.
+func (mr *MockQuestionRepoMockRecorder) RemoveQuestion(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveQuestion", reflect.TypeOf((*MockQuestionRepo)(nil).RemoveQuestion), ctx, id)
+}
+
+// SitemapQuestions mocks base method.
+func (m *MockQuestionRepo) SitemapQuestions(ctx context.Context, page, pageSize int) ([]*schema.SiteMapQuestionInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SitemapQuestions", ctx, page, pageSize)
+	ret0, _ := ret[0].([]*schema.SiteMapQuestionInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SitemapQuestions indicates an expected call of SitemapQuestions.
+func (mr *MockQuestionRepoMockRecorder) SitemapQuestions(ctx, page, pageSize interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SitemapQuestions", reflect.TypeOf((*MockQuestionRepo)(nil).SitemapQuestions), ctx, page, pageSize)
+}
+
+// UpdateAccepted mocks base method.
+func (m *MockQuestionRepo) UpdateAccepted(ctx context.Context, question *entity.Question) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateAccepted", ctx, question)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateAccepted indicates an expected call of UpdateAccepted.
+func (mr *MockQuestionRepoMockRecorder) UpdateAccepted(ctx, question interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateAccepted", reflect.TypeOf((*MockQuestionRepo)(nil).UpdateAccepted), ctx, question)
+}
+
+// UpdateAnswerCount mocks base method.
+func (m *MockQuestionRepo) UpdateAnswerCount(ctx context.Context, questionID string, num int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateAnswerCount", ctx, questionID, num)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateAnswerCount indicates an expected call of UpdateAnswerCount.
+func (mr *MockQuestionRepoMockRecorder) UpdateAnswerCount(ctx, questionID, num interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateAnswerCount", reflect.TypeOf((*MockQuestionRepo)(nil).UpdateAnswerCount), ctx, questionID, num)
+}
+
+// UpdateCollectionCount mocks base method.
+func (m *MockQuestionRepo) UpdateCollectionCount(ctx context.Context, questionID string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateCollectionCount", ctx, questionID)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateCollectionCount indicates an expected call of UpdateCollectionCount.
+func (mr *MockQuestionRepoMockRecorder) UpdateCollectionCount(ctx, questionID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCollectionCount", reflect.TypeOf((*MockQuestionRepo)(nil).UpdateCollectionCount), ctx, questionID)
+}
+
+// UpdateLastAnswer mocks base method.
+func (m *MockQuestionRepo) UpdateLastAnswer(ctx context.Context, question *entity.Question) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateLastAnswer", ctx, question)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateLastAnswer indicates an expected call of UpdateLastAnswer.
+func (mr *MockQuestionRepoMockRecorder) UpdateLastAnswer(ctx, question interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateLastAnswer", reflect.TypeOf((*MockQuestionRepo)(nil).UpdateLastAnswer), ctx, question)
+}
+
+// UpdatePvCount mocks base method.
+func (m *MockQuestionRepo) UpdatePvCount(ctx context.Context, questionID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdatePvCount", ctx, questionID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdatePvCount indicates an expected call of UpdatePvCount.
+func (mr *MockQuestionRepoMockRecorder) UpdatePvCount(ctx, questionID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePvCount", reflect.TypeOf((*MockQuestionRepo)(nil).UpdatePvCount), ctx, questionID)
+}
+
+// UpdateQuestion mocks base method.
+func (m *MockQuestionRepo) UpdateQuestion(ctx context.Context, question *entity.Question, Cols []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateQuestion", ctx, question, Cols)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateQuestion indicates an expected call of UpdateQuestion.
+func (mr *MockQuestionRepoMockRecorder) UpdateQuestion(ctx, question, Cols interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateQuestion", reflect.TypeOf((*MockQuestionRepo)(nil).UpdateQuestion), ctx, question, Cols)
+}
+
+// UpdateQuestionOperation mocks base method.
+func (m *MockQuestionRepo) UpdateQuestionOperation(ctx context.Context, question *entity.Question) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateQuestionOperation", ctx, question)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateQuestionOperation indicates an expected call of UpdateQuestionOperation.
+func (mr *MockQuestionRepoMockRecorder) UpdateQuestionOperation(ctx, question interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateQuestionOperation", reflect.TypeOf((*MockQuestionRepo)(nil).UpdateQuestionOperation), ctx, question)
+}
+
+// UpdateQuestionStatus mocks base method.
+func (m *MockQuestionRepo) UpdateQuestionStatus(ctx context.Context, questionID string, status int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateQuestionStatus", ctx, questionID, status)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateQuestionStatus indicates an expected call of UpdateQuestionStatus.
+func (mr *MockQuestionRepoMockRecorder) UpdateQuestionStatus(ctx, questionID, status interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateQuestionStatus", reflect.TypeOf((*MockQuestionRepo)(nil).UpdateQuestionStatus), ctx, questionID, status)
+}
+
+// UpdateQuestionStatusWithOutUpdateTime mocks base method.
+func (m *MockQuestionRepo) UpdateQuestionStatusWithOutUpdateTime(ctx context.Context, question *entity.Question) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateQuestionStatusWithOutUpdateTime", ctx, question)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateQuestionStatusWithOutUpdateTime indicates an expected call of UpdateQuestionStatusWithOutUpdateTime.
+func (mr *MockQuestionRepoMockRecorder) UpdateQuestionStatusWithOutUpdateTime(ctx, question interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateQuestionStatusWithOutUpdateTime", reflect.TypeOf((*MockQuestionRepo)(nil).UpdateQuestionStatusWithOutUpdateTime), ctx, question)
+}
+
+// UpdateSearch mocks base method.
+func (m *MockQuestionRepo) UpdateSearch(ctx context.Context, questionID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateSearch", ctx, questionID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateSearch indicates an expected call of UpdateSearch.
+func (mr *MockQuestionRepoMockRecorder) UpdateSearch(ctx, questionID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSearch", reflect.TypeOf((*MockQuestionRepo)(nil).UpdateSearch), ctx, questionID)
+}
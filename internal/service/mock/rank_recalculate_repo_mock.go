@@ -0,0 +1,82 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./rank_recalculate_service.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	entity "github.com/apache/incubator-answer/internal/entity"
+	schema "github.com/apache/incubator-answer/internal/schema"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockRankRecalculateRepo is a mock of RankRecalculateRepo interface.
+type MockRankRecalculateRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockRankRecalculateRepoMockRecorder
+}
+
+// MockRankRecalculateRepoMockRecorder is the mock recorder for MockRankRecalculateRepo.
+type MockRankRecalculateRepoMockRecorder struct {
+	mock *MockRankRecalculateRepo
+}
+
+// NewMockRankRecalculateRepo creates a new mock instance.
+func NewMockRankRecalculateRepo(ctrl *gomock.Controller) *MockRankRecalculateRepo {
+	mock := &MockRankRecalculateRepo{ctrl: ctrl}
+	mock.recorder = &MockRankRecalculateRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRankRecalculateRepo) EXPECT() *MockRankRecalculateRepoMockRecorder {
+	return m.recorder
+}
+
+// ApplyRankDiffs mocks base method.
+func (m *MockRankRecalculateRepo) ApplyRankDiffs(ctx context.Context, diffs []*schema.RankRecalculateDiff, operatorID string) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ApplyRankDiffs", ctx, diffs, operatorID)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ApplyRankDiffs indicates an expected call of ApplyRankDiffs.
+func (mr *MockRankRecalculateRepoMockRecorder) ApplyRankDiffs(ctx, diffs, operatorID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApplyRankDiffs", reflect.TypeOf((*MockRankRecalculateRepo)(nil).ApplyRankDiffs), ctx, diffs, operatorID)
+}
+
+// GetRankActivities mocks base method.
+func (m *MockRankRecalculateRepo) GetRankActivities(ctx context.Context, userID string) ([]*entity.Activity, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRankActivities", ctx, userID)
+	ret0, _ := ret[0].([]*entity.Activity)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRankActivities indicates an expected call of GetRankActivities.
+func (mr *MockRankRecalculateRepoMockRecorder) GetRankActivities(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRankActivities", reflect.TypeOf((*MockRankRecalculateRepo)(nil).GetRankActivities), ctx, userID)
+}
+
+// GetUsersAfter mocks base method.
+func (m *MockRankRecalculateRepo) GetUsersAfter(ctx context.Context, lastUserID string, limit int) ([]*entity.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUsersAfter", ctx, lastUserID, limit)
+	ret0, _ := ret[0].([]*entity.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUsersAfter indicates an expected call of GetUsersAfter.
+func (mr *MockRankRecalculateRepoMockRecorder) GetUsersAfter(ctx, lastUserID, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUsersAfter", reflect.TypeOf((*MockRankRecalculateRepo)(nil).GetUsersAfter), ctx, lastUserID, limit)
+}
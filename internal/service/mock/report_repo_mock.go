@@ -0,0 +1,158 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./report_common.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	entity "github.com/apache/incubator-answer/internal/entity"
+	schema "github.com/apache/incubator-answer/internal/schema"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockReportRepo is a mock of ReportRepo interface.
+type MockReportRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockReportRepoMockRecorder
+}
+
+// MockReportRepoMockRecorder is the mock recorder for MockReportRepo.
+type MockReportRepoMockRecorder struct {
+	mock *MockReportRepo
+}
+
+// NewMockReportRepo creates a new mock instance.
+func NewMockReportRepo(ctrl *gomock.Controller) *MockReportRepo {
+	mock := &MockReportRepo{ctrl: ctrl}
+	mock.recorder = &MockReportRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockReportRepo) EXPECT() *MockReportRepoMockRecorder {
+	return m.recorder
+}
+
+// AddReport mocks base method.
+func (m *MockReportRepo) AddReport(ctx context.Context, report *entity.Report) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddReport", ctx, report)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddReport indicates an expected call of AddReport.
+func (mr *MockReportRepoMockRecorder) AddReport(ctx, report interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddReport", reflect.TypeOf((*MockReportRepo)(nil).AddReport), ctx, report)
+}
+
+// GetByID mocks base method.
+func (m *MockReportRepo) GetByID(ctx context.Context, id string) (*entity.Report, bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, id)
+	ret0, _ := ret[0].(*entity.Report)
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockReportRepoMockRecorder) GetByID(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockReportRepo)(nil).GetByID), ctx, id)
+}
+
+// GetHandledReports mocks base method.
+func (m *MockReportRepo) GetHandledReports(ctx context.Context, startTime time.Time) ([]*entity.Report, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetHandledReports", ctx, startTime)
+	ret0, _ := ret[0].([]*entity.Report)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetHandledReports indicates an expected call of GetHandledReports.
+func (mr *MockReportRepoMockRecorder) GetHandledReports(ctx, startTime interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHandledReports", reflect.TypeOf((*MockReportRepo)(nil).GetHandledReports), ctx, startTime)
+}
+
+// GetPendingReportsByObjectIDs mocks base method.
+func (m *MockReportRepo) GetPendingReportsByObjectIDs(ctx context.Context, objectIDs []string) ([]*entity.Report, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPendingReportsByObjectIDs", ctx, objectIDs)
+	ret0, _ := ret[0].([]*entity.Report)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPendingReportsByObjectIDs indicates an expected call of GetPendingReportsByObjectIDs.
+func (mr *MockReportRepoMockRecorder) GetPendingReportsByObjectIDs(ctx, objectIDs interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPendingReportsByObjectIDs", reflect.TypeOf((*MockReportRepo)(nil).GetPendingReportsByObjectIDs), ctx, objectIDs)
+}
+
+// GetReportCasePage mocks base method.
+func (m *MockReportRepo) GetReportCasePage(ctx context.Context, query *schema.GetReportCasePageDTO) ([]*entity.ReportCaseStat, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetReportCasePage", ctx, query)
+	ret0, _ := ret[0].([]*entity.ReportCaseStat)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetReportCasePage indicates an expected call of GetReportCasePage.
+func (mr *MockReportRepoMockRecorder) GetReportCasePage(ctx, query interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReportCasePage", reflect.TypeOf((*MockReportRepo)(nil).GetReportCasePage), ctx, query)
+}
+
+// GetReportCount mocks base method.
+func (m *MockReportRepo) GetReportCount(ctx context.Context) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetReportCount", ctx)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetReportCount indicates an expected call of GetReportCount.
+func (mr *MockReportRepoMockRecorder) GetReportCount(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReportCount", reflect.TypeOf((*MockReportRepo)(nil).GetReportCount), ctx)
+}
+
+// UpdatePendingAssignee mocks base method.
+func (m *MockReportRepo) UpdatePendingAssignee(ctx context.Context, objectID, assigneeUserID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdatePendingAssignee", ctx, objectID, assigneeUserID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdatePendingAssignee indicates an expected call of UpdatePendingAssignee.
+func (mr *MockReportRepoMockRecorder) UpdatePendingAssignee(ctx, objectID, assigneeUserID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePendingAssignee", reflect.TypeOf((*MockReportRepo)(nil).UpdatePendingAssignee), ctx, objectID, assigneeUserID)
+}
+
+// UpdatePendingStatus mocks base method.
+func (m *MockReportRepo) UpdatePendingStatus(ctx context.Context, objectID string, status int, handledUserID string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdatePendingStatus", ctx, objectID, status, handledUserID)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdatePendingStatus indicates an expected call of UpdatePendingStatus.
+func (mr *MockReportRepoMockRecorder) UpdatePendingStatus(ctx, objectID, status, handledUserID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePendingStatus", reflect.TypeOf((*MockReportRepo)(nil).UpdatePendingStatus), ctx, objectID, status, handledUserID)
+}
@@ -0,0 +1,694 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./tag_common.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	entity "github.com/apache/incubator-answer/internal/entity"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockTagCommonRepo is a mock of TagCommonRepo interface.
+type MockTagCommonRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockTagCommonRepoMockRecorder
+}
+
+// MockTagCommonRepoMockRecorder is the mock recorder for MockTagCommonRepo.
+type MockTagCommonRepoMockRecorder struct {
+	mock *MockTagCommonRepo
+}
+
+// NewMockTagCommonRepo creates a new mock instance.
+func NewMockTagCommonRepo(ctrl *gomock.Controller) *MockTagCommonRepo {
+	mock := &MockTagCommonRepo{ctrl: ctrl}
+	mock.recorder = &MockTagCommonRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTagCommonRepo) EXPECT() *MockTagCommonRepoMockRecorder {
+	return m.recorder
+}
+
+// AddTagList mocks base method.
+func (m *MockTagCommonRepo) AddTagList(ctx context.Context, tagList []*entity.Tag) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddTagList", ctx, tagList)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddTagList indicates an expected call of AddTagList.
+func (mr *MockTagCommonRepoMockRecorder) AddTagList(ctx, tagList interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddTagList", reflect.TypeOf((*MockTagCommonRepo)(nil).AddTagList), ctx, tagList)
+}
+
+// GetRecommendTagList mocks base method.
+func (m *MockTagCommonRepo) GetRecommendTagList(ctx context.Context) ([]*entity.Tag, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRecommendTagList", ctx)
+	ret0, _ := ret[0].([]*entity.Tag)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRecommendTagList indicates an expected call of GetRecommendTagList.
+func (mr *MockTagCommonRepoMockRecorder) GetRecommendTagList(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRecommendTagList", reflect.TypeOf((*MockTagCommonRepo)(nil).GetRecommendTagList), ctx)
+}
+
+// GetReservedTagList mocks base method.
+func (m *MockTagCommonRepo) GetReservedTagList(ctx context.Context) ([]*entity.Tag, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetReservedTagList", ctx)
+	ret0, _ := ret[0].([]*entity.Tag)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetReservedTagList indicates an expected call of GetReservedTagList.
+func (mr *MockTagCommonRepoMockRecorder) GetReservedTagList(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReservedTagList", reflect.TypeOf((*MockTagCommonRepo)(nil).GetReservedTagList), ctx)
+}
+
+// GetTagByID mocks base method.
+func (m *MockTagCommonRepo) GetTagByID(ctx context.Context, tagID string, includeDeleted bool) (*entity.Tag, bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTagByID", ctx, tagID, includeDeleted)
+	ret0, _ := ret[0].(*entity.Tag)
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetTagByID indicates an expected call of GetTagByID.
+func (mr *MockTagCommonRepoMockRecorder) GetTagByID(ctx, tagID, includeDeleted interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTagByID", reflect.TypeOf((*MockTagCommonRepo)(nil).GetTagByID), ctx, tagID, includeDeleted)
+}
+
+// GetTagBySlugName mocks base method.
+func (m *MockTagCommonRepo) GetTagBySlugName(ctx context.Context, slugName string) (*entity.Tag, bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTagBySlugName", ctx, slugName)
+	ret0, _ := ret[0].(*entity.Tag)
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetTagBySlugName indicates an expected call of GetTagBySlugName.
+func (mr *MockTagCommonRepoMockRecorder) GetTagBySlugName(ctx, slugName interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTagBySlugName", reflect.TypeOf((*MockTagCommonRepo)(nil).GetTagBySlugName), ctx, slugName)
+}
+
+// GetTagListByIDs mocks base method.
+func (m *MockTagCommonRepo) GetTagListByIDs(ctx context.Context, ids []string) ([]*entity.Tag, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTagListByIDs", ctx, ids)
+	ret0, _ := ret[0].([]*entity.Tag)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTagListByIDs indicates an expected call of GetTagListByIDs.
+func (mr *MockTagCommonRepoMockRecorder) GetTagListByIDs(ctx, ids interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTagListByIDs", reflect.TypeOf((*MockTagCommonRepo)(nil).GetTagListByIDs), ctx, ids)
+}
+
+// GetTagListByName mocks base method.
+func (m *MockTagCommonRepo) GetTagListByName(ctx context.Context, name string, recommend, reserved bool) ([]*entity.Tag, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTagListByName", ctx, name, recommend, reserved)
+	ret0, _ := ret[0].([]*entity.Tag)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTagListByName indicates an expected call of GetTagListByName.
+func (mr *MockTagCommonRepoMockRecorder) GetTagListByName(ctx, name, recommend, reserved interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTagListByName", reflect.TypeOf((*MockTagCommonRepo)(nil).GetTagListByName), ctx, name, recommend, reserved)
+}
+
+// GetTagListByNames mocks base method.
+func (m *MockTagCommonRepo) GetTagListByNames(ctx context.Context, names []string) ([]*entity.Tag, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTagListByNames", ctx, names)
+	ret0, _ := ret[0].([]*entity.Tag)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTagListByNames indicates an expected call of GetTagListByNames.
+func (mr *MockTagCommonRepoMockRecorder) GetTagListByNames(ctx, names interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTagListByNames", reflect.TypeOf((*MockTagCommonRepo)(nil).GetTagListByNames), ctx, names)
+}
+
+// GetTagPage mocks base method.
+func (m *MockTagCommonRepo) GetTagPage(ctx context.Context, page, pageSize int, tag *entity.Tag, queryCond string) ([]*entity.Tag, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTagPage", ctx, page, pageSize, tag, queryCond)
+	ret0, _ := ret[0].([]*entity.Tag)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetTagPage indicates an expected call of GetTagPage.
+func (mr *MockTagCommonRepoMockRecorder) GetTagPage(ctx, page, pageSize, tag, queryCond interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTagPage", reflect.TypeOf((*MockTagCommonRepo)(nil).GetTagPage), ctx, page, pageSize, tag, queryCond)
+}
+
+// UpdateTagQuestionCount mocks base method.
+func (m *MockTagCommonRepo) UpdateTagQuestionCount(ctx context.Context, tagID string, questionCount int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateTagQuestionCount", ctx, tagID, questionCount)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateTagQuestionCount indicates an expected call of UpdateTagQuestionCount.
+func (mr *MockTagCommonRepoMockRecorder) UpdateTagQuestionCount(ctx, tagID, questionCount interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTagQuestionCount", reflect.TypeOf((*MockTagCommonRepo)(nil).UpdateTagQuestionCount), ctx, tagID, questionCount)
+}
+
+// UpdateTagsAttribute mocks base method.
+func (m *MockTagCommonRepo) UpdateTagsAttribute(ctx context.Context, tags []string, attribute string, value bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateTagsAttribute", ctx, tags, attribute, value)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateTagsAttribute indicates an expected call of UpdateTagsAttribute.
+func (mr *MockTagCommonRepoMockRecorder) UpdateTagsAttribute(ctx, tags, attribute, value interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTagsAttribute", reflect.TypeOf((*MockTagCommonRepo)(nil).UpdateTagsAttribute), ctx, tags, attribute, value)
+}
+
+// MockTagRepo is a mock of TagRepo interface.
+type MockTagRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockTagRepoMockRecorder
+}
+
+// MockTagRepoMockRecorder is the mock recorder for MockTagRepo.
+type MockTagRepoMockRecorder struct {
+	mock *MockTagRepo
+}
+
+// NewMockTagRepo creates a new mock instance.
+func NewMockTagRepo(ctrl *gomock.Controller) *MockTagRepo {
+	mock := &MockTagRepo{ctrl: ctrl}
+	mock.recorder = &MockTagRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTagRepo) EXPECT() *MockTagRepoMockRecorder {
+	return m.recorder
+}
+
+// GetHierarchyTagList mocks base method.
+func (m *MockTagRepo) GetHierarchyTagList(ctx context.Context) ([]*entity.Tag, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetHierarchyTagList", ctx)
+	ret0, _ := ret[0].([]*entity.Tag)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetHierarchyTagList indicates an expected call of GetHierarchyTagList.
+func (mr *MockTagRepoMockRecorder) GetHierarchyTagList(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHierarchyTagList", reflect.TypeOf((*MockTagRepo)(nil).GetHierarchyTagList), ctx)
+}
+
+// GetIDsByMainTagIDs mocks base method.
+func (m *MockTagRepo) GetIDsByMainTagIDs(ctx context.Context, mainTagIDs []string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetIDsByMainTagIDs", ctx, mainTagIDs)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetIDsByMainTagIDs indicates an expected call of GetIDsByMainTagIDs.
+func (mr *MockTagRepoMockRecorder) GetIDsByMainTagIDs(ctx, mainTagIDs interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetIDsByMainTagIDs", reflect.TypeOf((*MockTagRepo)(nil).GetIDsByMainTagIDs), ctx, mainTagIDs)
+}
+
+// GetIDsByMainTagId mocks base method.
+func (m *MockTagRepo) GetIDsByMainTagId(ctx context.Context, mainTagID string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetIDsByMainTagId", ctx, mainTagID)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetIDsByMainTagId indicates an expected call of GetIDsByMainTagId.
+func (mr *MockTagRepoMockRecorder) GetIDsByMainTagId(ctx, mainTagID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetIDsByMainTagId", reflect.TypeOf((*MockTagRepo)(nil).GetIDsByMainTagId), ctx, mainTagID)
+}
+
+// GetTagList mocks base method.
+func (m *MockTagRepo) GetTagList(ctx context.Context, tag *entity.Tag) ([]*entity.Tag, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTagList", ctx, tag)
+	ret0, _ := ret[0].([]*entity.Tag)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTagList indicates an expected call of GetTagList.
+func (mr *MockTagRepoMockRecorder) GetTagList(ctx, tag interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTagList", reflect.TypeOf((*MockTagRepo)(nil).GetTagList), ctx, tag)
+}
+
+// GetTagListByParentIDs mocks base method.
+func (m *MockTagRepo) GetTagListByParentIDs(ctx context.Context, parentTagIDs []string) ([]*entity.Tag, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTagListByParentIDs", ctx, parentTagIDs)
+	ret0, _ := ret[0].([]*entity.Tag)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTagListByParentIDs indicates an expected call of GetTagListByParentIDs.
+func (mr *MockTagRepoMockRecorder) GetTagListByParentIDs(ctx, parentTagIDs interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTagListByParentIDs", reflect.TypeOf((*MockTagRepo)(nil).GetTagListByParentIDs), ctx, parentTagIDs)
+}
+
+// GetTagSynonymCount mocks base method.
+func (m *MockTagRepo) GetTagSynonymCount(ctx context.Context, tagID string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTagSynonymCount", ctx, tagID)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTagSynonymCount indicates an expected call of GetTagSynonymCount.
+func (mr *MockTagRepoMockRecorder) GetTagSynonymCount(ctx, tagID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTagSynonymCount", reflect.TypeOf((*MockTagRepo)(nil).GetTagSynonymCount), ctx, tagID)
+}
+
+// MustGetTagByNameOrID mocks base method.
+func (m *MockTagRepo) MustGetTagByNameOrID(ctx context.Context, tagID, slugName string) (*entity.Tag, bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MustGetTagByNameOrID", ctx, tagID, slugName)
+	ret0, _ := ret[0].(*entity.Tag)
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// MustGetTagByNameOrID indicates an expected call of MustGetTagByNameOrID.
+func (mr *MockTagRepoMockRecorder) MustGetTagByNameOrID(ctx, tagID, slugName interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MustGetTagByNameOrID", reflect.TypeOf((*MockTagRepo)(nil).MustGetTagByNameOrID), ctx, tagID, slugName)
+}
+
+// RecoverTag mocks base method.
+func (m *MockTagRepo) RecoverTag(ctx context.Context, tagID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecoverTag", ctx, tagID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RecoverTag indicates an expected call of RecoverTag.
+func (mr *MockTagRepoMockRecorder) RecoverTag(ctx, tagID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecoverTag", reflect.TypeOf((*MockTagRepo)(nil).RecoverTag), ctx, tagID)
+}
+
+// RemoveTag mocks base method.
+func (m *MockTagRepo) RemoveTag(ctx context.Context, tagID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveTag", ctx, tagID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveTag indicates an expected call of RemoveTag.
+func (mr *MockTagRepoMockRecorder) RemoveTag(ctx, tagID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveTag", reflect.TypeOf((*MockTagRepo)(nil).RemoveTag), ctx, tagID)
+}
+
+// UpdateTag mocks base method.
+func (m *MockTagRepo) UpdateTag(ctx context.Context, tag *entity.Tag) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateTag", ctx, tag)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateTag indicates an expected call of UpdateTag.
+func (mr *MockTagRepoMockRecorder) UpdateTag(ctx, tag interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTag", reflect.TypeOf((*MockTagRepo)(nil).UpdateTag), ctx, tag)
+}
+
+// UpdateTagStructure mocks base method.
+func (m *MockTagRepo) UpdateTagStructure(ctx context.Context, tagID string, parentTagID, tagGroupID int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateTagStructure", ctx, tagID, parentTagID, tagGroupID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateTagStructure indicates an expected call of UpdateTagStructure.
+func (mr *MockTagRepoMockRecorder) UpdateTagStructure(ctx, tagID, parentTagID, tagGroupID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTagStructure", reflect.TypeOf((*MockTagRepo)(nil).UpdateTagStructure), ctx, tagID, parentTagID, tagGroupID)
+}
+
+// UpdateTagSynonym mocks base method.
+func (m *MockTagRepo) UpdateTagSynonym(ctx context.Context, tagSlugNameList []string, mainTagID int64, mainTagSlugName string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateTagSynonym", ctx, tagSlugNameList, mainTagID, mainTagSlugName)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateTagSynonym indicates an expected call of UpdateTagSynonym.
+func (mr *MockTagRepoMockRecorder) UpdateTagSynonym(ctx, tagSlugNameList, mainTagID, mainTagSlugName interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTagSynonym", reflect.TypeOf((*MockTagRepo)(nil).UpdateTagSynonym), ctx, tagSlugNameList, mainTagID, mainTagSlugName)
+}
+
+// MockTagGroupRepo is a mock of TagGroupRepo interface.
+type MockTagGroupRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockTagGroupRepoMockRecorder
+}
+
+// MockTagGroupRepoMockRecorder is the mock recorder for MockTagGroupRepo.
+type MockTagGroupRepoMockRecorder struct {
+	mock *MockTagGroupRepo
+}
+
+// NewMockTagGroupRepo creates a new mock instance.
+func NewMockTagGroupRepo(ctrl *gomock.Controller) *MockTagGroupRepo {
+	mock := &MockTagGroupRepo{ctrl: ctrl}
+	mock.recorder = &MockTagGroupRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTagGroupRepo) EXPECT() *MockTagGroupRepoMockRecorder {
+	return m.recorder
+}
+
+// AddTagGroup mocks base method.
+func (m *MockTagGroupRepo) AddTagGroup(ctx context.Context, tagGroup *entity.TagGroup) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddTagGroup", ctx, tagGroup)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddTagGroup indicates an expected call of AddTagGroup.
+func (mr *MockTagGroupRepoMockRecorder) AddTagGroup(ctx, tagGroup interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddTagGroup", reflect.TypeOf((*MockTagGroupRepo)(nil).AddTagGroup), ctx, tagGroup)
+}
+
+// GetTagGroup mocks base method.
+func (m *MockTagGroupRepo) GetTagGroup(ctx context.Context, tagGroupID int64) (*entity.TagGroup, bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTagGroup", ctx, tagGroupID)
+	ret0, _ := ret[0].(*entity.TagGroup)
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetTagGroup indicates an expected call of GetTagGroup.
+func (mr *MockTagGroupRepoMockRecorder) GetTagGroup(ctx, tagGroupID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTagGroup", reflect.TypeOf((*MockTagGroupRepo)(nil).GetTagGroup), ctx, tagGroupID)
+}
+
+// GetTagGroupByName mocks base method.
+func (m *MockTagGroupRepo) GetTagGroupByName(ctx context.Context, name string) (*entity.TagGroup, bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTagGroupByName", ctx, name)
+	ret0, _ := ret[0].(*entity.TagGroup)
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetTagGroupByName indicates an expected call of GetTagGroupByName.
+func (mr *MockTagGroupRepoMockRecorder) GetTagGroupByName(ctx, name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTagGroupByName", reflect.TypeOf((*MockTagGroupRepo)(nil).GetTagGroupByName), ctx, name)
+}
+
+// GetTagGroupList mocks base method.
+func (m *MockTagGroupRepo) GetTagGroupList(ctx context.Context) ([]*entity.TagGroup, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTagGroupList", ctx)
+	ret0, _ := ret[0].([]*entity.TagGroup)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTagGroupList indicates an expected call of GetTagGroupList.
+func (mr *MockTagGroupRepoMockRecorder) GetTagGroupList(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTagGroupList", reflect.TypeOf((*MockTagGroupRepo)(nil).GetTagGroupList), ctx)
+}
+
+// RemoveTagGroup mocks base method.
+func (m *MockTagGroupRepo) RemoveTagGroup(ctx context.Context, tagGroupID int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveTagGroup", ctx, tagGroupID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveTagGroup indicates an expected call of RemoveTagGroup.
+func (mr *MockTagGroupRepoMockRecorder) RemoveTagGroup(ctx, tagGroupID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveTagGroup", reflect.TypeOf((*MockTagGroupRepo)(nil).RemoveTagGroup), ctx, tagGroupID)
+}
+
+// UpdateTagGroup mocks base method.
+func (m *MockTagGroupRepo) UpdateTagGroup(ctx context.Context, tagGroup *entity.TagGroup) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateTagGroup", ctx, tagGroup)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateTagGroup indicates an expected call of UpdateTagGroup.
+func (mr *MockTagGroupRepoMockRecorder) UpdateTagGroup(ctx, tagGroup interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTagGroup", reflect.TypeOf((*MockTagGroupRepo)(nil).UpdateTagGroup), ctx, tagGroup)
+}
+
+// MockTagRelRepo is a mock of TagRelRepo interface.
+type MockTagRelRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockTagRelRepoMockRecorder
+}
+
+// MockTagRelRepoMockRecorder is the mock recorder for MockTagRelRepo.
+type MockTagRelRepoMockRecorder struct {
+	mock *MockTagRelRepo
+}
+
+// NewMockTagRelRepo creates a new mock instance.
+func NewMockTagRelRepo(ctrl *gomock.Controller) *MockTagRelRepo {
+	mock := &MockTagRelRepo{ctrl: ctrl}
+	mock.recorder = &MockTagRelRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTagRelRepo) EXPECT() *MockTagRelRepoMockRecorder {
+	return m.recorder
+}
+
+// AddTagRelList mocks base method.
+func (m *MockTagRelRepo) AddTagRelList(ctx context.Context, tagList []*entity.TagRel) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddTagRelList", ctx, tagList)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddTagRelList indicates an expected call of AddTagRelList.
+func (mr *MockTagRelRepoMockRecorder) AddTagRelList(ctx, tagList interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddTagRelList", reflect.TypeOf((*MockTagRelRepo)(nil).AddTagRelList), ctx, tagList)
+}
+
+// BatchGetObjectTagRelList mocks base method.
+func (m *MockTagRelRepo) BatchGetObjectTagRelList(ctx context.Context, objectIds []string) ([]*entity.TagRel, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BatchGetObjectTagRelList", ctx, objectIds)
+	ret0, _ := ret[0].([]*entity.TagRel)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BatchGetObjectTagRelList indicates an expected call of BatchGetObjectTagRelList.
+func (mr *MockTagRelRepoMockRecorder) BatchGetObjectTagRelList(ctx, objectIds interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BatchGetObjectTagRelList", reflect.TypeOf((*MockTagRelRepo)(nil).BatchGetObjectTagRelList), ctx, objectIds)
+}
+
+// CountTagRelByTagID mocks base method.
+func (m *MockTagRelRepo) CountTagRelByTagID(ctx context.Context, tagID string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountTagRelByTagID", ctx, tagID)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountTagRelByTagID indicates an expected call of CountTagRelByTagID.
+func (mr *MockTagRelRepoMockRecorder) CountTagRelByTagID(ctx, tagID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountTagRelByTagID", reflect.TypeOf((*MockTagRelRepo)(nil).CountTagRelByTagID), ctx, tagID)
+}
+
+// EnableTagRelByIDs mocks base method.
+func (m *MockTagRelRepo) EnableTagRelByIDs(ctx context.Context, ids []int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EnableTagRelByIDs", ctx, ids)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// EnableTagRelByIDs indicates an expected call of EnableTagRelByIDs.
+func (mr *MockTagRelRepoMockRecorder) EnableTagRelByIDs(ctx, ids interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnableTagRelByIDs", reflect.TypeOf((*MockTagRelRepo)(nil).EnableTagRelByIDs), ctx, ids)
+}
+
+// GetObjectTagRelList mocks base method.
+func (m *MockTagRelRepo) GetObjectTagRelList(ctx context.Context, objectId string) ([]*entity.TagRel, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetObjectTagRelList", ctx, objectId)
+	ret0, _ := ret[0].([]*entity.TagRel)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetObjectTagRelList indicates an expected call of GetObjectTagRelList.
+func (mr *MockTagRelRepoMockRecorder) GetObjectTagRelList(ctx, objectId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetObjectTagRelList", reflect.TypeOf((*MockTagRelRepo)(nil).GetObjectTagRelList), ctx, objectId)
+}
+
+// GetObjectTagRelWithoutStatus mocks base method.
+func (m *MockTagRelRepo) GetObjectTagRelWithoutStatus(ctx context.Context, objectId, tagID string) (*entity.TagRel, bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetObjectTagRelWithoutStatus", ctx, objectId, tagID)
+	ret0, _ := ret[0].(*entity.TagRel)
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetObjectTagRelWithoutStatus indicates an expected call of GetObjectTagRelWithoutStatus.
+func (mr *MockTagRelRepoMockRecorder) GetObjectTagRelWithoutStatus(ctx, objectId, tagID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetObjectTagRelWithoutStatus", reflect.TypeOf((*MockTagRelRepo)(nil).GetObjectTagRelWithoutStatus), ctx, objectId, tagID)
+}
+
+// HideTagRelListByObjectID mocks base method.
+func (m *MockTagRelRepo) HideTagRelListByObjectID(ctx context.Context, objectID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HideTagRelListByObjectID", ctx, objectID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// HideTagRelListByObjectID indicates an expected call of HideTagRelListByObjectID.
+func (mr *MockTagRelRepoMockRecorder) HideTagRelListByObjectID(ctx, objectID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HideTagRelListByObjectID", reflect.TypeOf((*MockTagRelRepo)(nil).HideTagRelListByObjectID), ctx, objectID)
+}
+
+// RecoverTagRelListByObjectID mocks base method.
+func (m *MockTagRelRepo) RecoverTagRelListByObjectID(ctx context.Context, objectID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecoverTagRelListByObjectID", ctx, objectID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RecoverTagRelListByObjectID indicates an expected call of RecoverTagRelListByObjectID.
+func (mr *MockTagRelRepoMockRecorder) RecoverTagRelListByObjectID(ctx, objectID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecoverTagRelListByObjectID", reflect.TypeOf((*MockTagRelRepo)(nil).RecoverTagRelListByObjectID), ctx, objectID)
+}
+
+// RemoveTagRelListByIDs mocks base method.
+func (m *MockTagRelRepo) RemoveTagRelListByIDs(ctx context.Context, ids []int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveTagRelListByIDs", ctx, ids)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveTagRelListByIDs indicates an expected call of RemoveTagRelListByIDs.
+func (mr *MockTagRelRepoMockRecorder) RemoveTagRelListByIDs(ctx, ids interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveTagRelListByIDs", reflect.TypeOf((*MockTagRelRepo)(nil).RemoveTagRelListByIDs), ctx, ids)
+}
+
+// RemoveTagRelListByObjectID mocks base method.
+func (m *MockTagRelRepo) RemoveTagRelListByObjectID(ctx context.Context, objectID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveTagRelListByObjectID", ctx, objectID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveTagRelListByObjectID indicates an expected call of RemoveTagRelListByObjectID.
+func (mr *MockTagRelRepoMockRecorder) RemoveTagRelListByObjectID(ctx, objectID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveTagRelListByObjectID", reflect.TypeOf((*MockTagRelRepo)(nil).RemoveTagRelListByObjectID), ctx, objectID)
+}
+
+// ShowTagRelListByObjectID mocks base method.
+func (m *MockTagRelRepo) ShowTagRelListByObjectID(ctx context.Context, objectID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ShowTagRelListByObjectID", ctx, objectID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ShowTagRelListByObjectID indicates an expected call of ShowTagRelListByObjectID.
+func (mr *MockTagRelRepoMockRecorder) ShowTagRelListByObjectID(ctx, objectID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ShowTagRelListByObjectID", reflect.TypeOf((*MockTagRelRepo)(nil).ShowTagRelListByObjectID), ctx, objectID)
+}
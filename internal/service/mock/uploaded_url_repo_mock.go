@@ -0,0 +1,79 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./storage_migrate.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockUploadedURLRepo is a mock of UploadedURLRepo interface.
+type MockUploadedURLRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockUploadedURLRepoMockRecorder
+}
+
+// MockUploadedURLRepoMockRecorder is the mock recorder for MockUploadedURLRepo.
+type MockUploadedURLRepoMockRecorder struct {
+	mock *MockUploadedURLRepo
+}
+
+// NewMockUploadedURLRepo creates a new mock instance.
+func NewMockUploadedURLRepo(ctrl *gomock.Controller) *MockUploadedURLRepo {
+	mock := &MockUploadedURLRepo{ctrl: ctrl}
+	mock.recorder = &MockUploadedURLRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUploadedURLRepo) EXPECT() *MockUploadedURLRepoMockRecorder {
+	return m.recorder
+}
+
+// CountURLPrefix mocks base method.
+func (m *MockUploadedURLRepo) CountURLPrefix(ctx context.Context, urlPrefix string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountURLPrefix", ctx, urlPrefix)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountURLPrefix indicates an expected call of CountURLPrefix.
+func (mr *MockUploadedURLRepoMockRecorder) CountURLPrefix(ctx, urlPrefix interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountURLPrefix", reflect.TypeOf((*MockUploadedURLRepo)(nil).CountURLPrefix), ctx, urlPrefix)
+}
+
+// ReplaceURLPrefix mocks base method.
+func (m *MockUploadedURLRepo) ReplaceURLPrefix(ctx context.Context, oldURLPrefix, newURLPrefix string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReplaceURLPrefix", ctx, oldURLPrefix, newURLPrefix)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReplaceURLPrefix indicates an expected call of ReplaceURLPrefix.
+func (mr *MockUploadedURLRepoMockRecorder) ReplaceURLPrefix(ctx, oldURLPrefix, newURLPrefix interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReplaceURLPrefix", reflect.TypeOf((*MockUploadedURLRepo)(nil).ReplaceURLPrefix), ctx, oldURLPrefix, newURLPrefix)
+}
+
+// ScanContents mocks base method.
+func (m *MockUploadedURLRepo) ScanContents(ctx context.Context, fn func(string)) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ScanContents", ctx, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ScanContents indicates an expected call of ScanContents.
+func (mr *MockUploadedURLRepoMockRecorder) ScanContents(ctx, fn interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ScanContents", reflect.TypeOf((*MockUploadedURLRepo)(nil).ScanContents), ctx, fn)
+}
@@ -0,0 +1,96 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./user_role_rel_service.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	entity "github.com/apache/incubator-answer/internal/entity"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockUserRoleRelRepo is a mock of UserRoleRelRepo interface.
+type MockUserRoleRelRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockUserRoleRelRepoMockRecorder
+}
+
+// MockUserRoleRelRepoMockRecorder is the mock recorder for MockUserRoleRelRepo.
+type MockUserRoleRelRepoMockRecorder struct {
+	mock *MockUserRoleRelRepo
+}
+
+// NewMockUserRoleRelRepo creates a new mock instance.
+func NewMockUserRoleRelRepo(ctrl *gomock.Controller) *MockUserRoleRelRepo {
+	mock := &MockUserRoleRelRepo{ctrl: ctrl}
+	mock.recorder = &MockUserRoleRelRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUserRoleRelRepo) EXPECT() *MockUserRoleRelRepoMockRecorder {
+	return m.recorder
+}
+
+// GetUserRoleRel mocks base method.
+func (m *MockUserRoleRelRepo) GetUserRoleRel(ctx context.Context, userID string) (*entity.UserRoleRel, bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserRoleRel", ctx, userID)
+	ret0, _ := ret[0].(*entity.UserRoleRel)
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetUserRoleRel indicates an expected call of GetUserRoleRel.
+func (mr *MockUserRoleRelRepoMockRecorder) GetUserRoleRel(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserRoleRel", reflect.TypeOf((*MockUserRoleRelRepo)(nil).GetUserRoleRel), ctx, userID)
+}
+
+// GetUserRoleRelList mocks base method.
+func (m *MockUserRoleRelRepo) GetUserRoleRelList(ctx context.Context, userIDs []string) ([]*entity.UserRoleRel, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserRoleRelList", ctx, userIDs)
+	ret0, _ := ret[0].([]*entity.UserRoleRel)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserRoleRelList indicates an expected call of GetUserRoleRelList.
+func (mr *MockUserRoleRelRepoMockRecorder) GetUserRoleRelList(ctx, userIDs interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserRoleRelList", reflect.TypeOf((*MockUserRoleRelRepo)(nil).GetUserRoleRelList), ctx, userIDs)
+}
+
+// GetUserRoleRelListByRoleID mocks base method.
+func (m *MockUserRoleRelRepo) GetUserRoleRelListByRoleID(ctx context.Context, roleIDs []int) ([]*entity.UserRoleRel, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserRoleRelListByRoleID", ctx, roleIDs)
+	ret0, _ := ret[0].([]*entity.UserRoleRel)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserRoleRelListByRoleID indicates an expected call of GetUserRoleRelListByRoleID.
+func (mr *MockUserRoleRelRepoMockRecorder) GetUserRoleRelListByRoleID(ctx, roleIDs interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserRoleRelListByRoleID", reflect.TypeOf((*MockUserRoleRelRepo)(nil).GetUserRoleRelListByRoleID), ctx, roleIDs)
+}
+
+// SaveUserRoleRel mocks base method.
+func (m *MockUserRoleRelRepo) SaveUserRoleRel(ctx context.Context, userID string, roleID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveUserRoleRel", ctx, userID, roleID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveUserRoleRel indicates an expected call of SaveUserRoleRel.
+func (mr *MockUserRoleRelRepoMockRecorder) SaveUserRoleRel(ctx, userID, roleID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveUserRoleRel", reflect.TypeOf((*MockUserRoleRelRepo)(nil).SaveUserRoleRel), ctx, userID, roleID)
+}
@@ -0,0 +1,60 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./webhook_queue.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	schema "github.com/apache/incubator-answer/internal/schema"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockWebhookQueueService is a mock of WebhookQueueService interface.
+type MockWebhookQueueService struct {
+	ctrl     *gomock.Controller
+	recorder *MockWebhookQueueServiceMockRecorder
+}
+
+// MockWebhookQueueServiceMockRecorder is the mock recorder for MockWebhookQueueService.
+type MockWebhookQueueServiceMockRecorder struct {
+	mock *MockWebhookQueueService
+}
+
+// NewMockWebhookQueueService creates a new mock instance.
+func NewMockWebhookQueueService(ctrl *gomock.Controller) *MockWebhookQueueService {
+	mock := &MockWebhookQueueService{ctrl: ctrl}
+	mock.recorder = &MockWebhookQueueServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockWebhookQueueService) EXPECT() *MockWebhookQueueServiceMockRecorder {
+	return m.recorder
+}
+
+// RegisterHandler mocks base method.
+func (m *MockWebhookQueueService) RegisterHandler(handler func(context.Context, *schema.WebhookEventMsg) error) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "RegisterHandler", handler)
+}
+
+// RegisterHandler indicates an expected call of RegisterHandler.
+func (mr *MockWebhookQueueServiceMockRecorder) RegisterHandler(handler interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RegisterHandler", reflect.TypeOf((*MockWebhookQueueService)(nil).RegisterHandler), handler)
+}
+
+// Send mocks base method.
+func (m *MockWebhookQueueService) Send(ctx context.Context, msg *schema.WebhookEventMsg) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Send", ctx, msg)
+}
+
+// Send indicates an expected call of Send.
+func (mr *MockWebhookQueueServiceMockRecorder) Send(ctx, msg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Send", reflect.TypeOf((*MockWebhookQueueService)(nil).Send), ctx, msg)
+}
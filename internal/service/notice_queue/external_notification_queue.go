@@ -21,49 +21,44 @@ package notice_queue
 
 import (
 	"context"
+	"encoding/json"
 
 	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/apache/incubator-answer/internal/service/job_queue"
 	"github.com/segmentfault/pacman/log"
 )
 
+// ExternalNotificationQueueName the name of the persisted queue
+const ExternalNotificationQueueName = "external_notification"
+
 type ExternalNotificationQueueService interface {
 	Send(ctx context.Context, msg *schema.ExternalNotificationMsg)
 	RegisterHandler(handler func(ctx context.Context, msg *schema.ExternalNotificationMsg) error)
 }
 
 type externalNotificationQueueService struct {
-	Queue   chan *schema.ExternalNotificationMsg
-	Handler func(ctx context.Context, msg *schema.ExternalNotificationMsg) error
+	jobQueueService *job_queue.JobQueueService
 }
 
 func (ns *externalNotificationQueueService) Send(ctx context.Context, msg *schema.ExternalNotificationMsg) {
-	ns.Queue <- msg
+	if err := ns.jobQueueService.Enqueue(ctx, ExternalNotificationQueueName, msg); err != nil {
+		log.Errorf("send external notification failed: %v", err)
+	}
 }
 
 func (ns *externalNotificationQueueService) RegisterHandler(
 	handler func(ctx context.Context, msg *schema.ExternalNotificationMsg) error) {
-	ns.Handler = handler
-}
-
-func (ns *externalNotificationQueueService) working() {
-	go func() {
-		for msg := range ns.Queue {
-			log.Debugf("received notification %+v", msg)
-			if ns.Handler == nil {
-				log.Warnf("no handler for notification")
-				continue
-			}
-			if err := ns.Handler(context.Background(), msg); err != nil {
-				log.Error(err)
-			}
+	ns.jobQueueService.RegisterHandler(ExternalNotificationQueueName, func(ctx context.Context, payload []byte) error {
+		msg := &schema.ExternalNotificationMsg{}
+		if err := json.Unmarshal(payload, msg); err != nil {
+			return job_queue.Permanent(err)
 		}
-	}()
+		log.Debugf("received notification %+v", msg)
+		return handler(ctx, msg)
+	})
 }
 
 // NewNewQuestionNotificationQueueService create a new notification queue service
-func NewNewQuestionNotificationQueueService() ExternalNotificationQueueService {
-	ns := &externalNotificationQueueService{}
-	ns.Queue = make(chan *schema.ExternalNotificationMsg, 128)
-	ns.working()
-	return ns
+func NewNewQuestionNotificationQueueService(jobQueueService *job_queue.JobQueueService) ExternalNotificationQueueService {
+	return &externalNotificationQueueService{jobQueueService: jobQueueService}
 }
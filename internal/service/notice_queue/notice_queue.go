@@ -21,49 +21,45 @@ package notice_queue
 
 import (
 	"context"
+	"encoding/json"
 
 	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/apache/incubator-answer/internal/service/job_queue"
 	"github.com/segmentfault/pacman/log"
 )
 
+// NotificationQueueName the name of the persisted queue
+const NotificationQueueName = "notification"
+
+//go:generate mockgen -source=./notice_queue.go -destination=../mock/notification_queue_service_mock.go -package=mock
 type NotificationQueueService interface {
 	Send(ctx context.Context, msg *schema.NotificationMsg)
 	RegisterHandler(handler func(ctx context.Context, msg *schema.NotificationMsg) error)
 }
 
 type notificationQueueService struct {
-	Queue   chan *schema.NotificationMsg
-	Handler func(ctx context.Context, msg *schema.NotificationMsg) error
+	jobQueueService *job_queue.JobQueueService
 }
 
 func (ns *notificationQueueService) Send(ctx context.Context, msg *schema.NotificationMsg) {
-	ns.Queue <- msg
+	if err := ns.jobQueueService.Enqueue(ctx, NotificationQueueName, msg); err != nil {
+		log.Errorf("send notification failed: %v", err)
+	}
 }
 
 func (ns *notificationQueueService) RegisterHandler(
 	handler func(ctx context.Context, msg *schema.NotificationMsg) error) {
-	ns.Handler = handler
-}
-
-func (ns *notificationQueueService) working() {
-	go func() {
-		for msg := range ns.Queue {
-			log.Debugf("received notification %+v", msg)
-			if ns.Handler == nil {
-				log.Warnf("no handler for notification")
-				continue
-			}
-			if err := ns.Handler(context.Background(), msg); err != nil {
-				log.Error(err)
-			}
+	ns.jobQueueService.RegisterHandler(NotificationQueueName, func(ctx context.Context, payload []byte) error {
+		msg := &schema.NotificationMsg{}
+		if err := json.Unmarshal(payload, msg); err != nil {
+			return job_queue.Permanent(err)
 		}
-	}()
+		log.Debugf("received notification %+v", msg)
+		return handler(ctx, msg)
+	})
 }
 
 // NewNotificationQueueService create a new notification queue service
-func NewNotificationQueueService() NotificationQueueService {
-	ns := &notificationQueueService{}
-	ns.Queue = make(chan *schema.NotificationMsg, 128)
-	ns.working()
-	return ns
+func NewNotificationQueueService(jobQueueService *job_queue.JobQueueService) NotificationQueueService {
+	return &notificationQueueService{jobQueueService: jobQueueService}
 }
@@ -29,6 +29,7 @@ import (
 	"github.com/apache/incubator-answer/internal/service/export"
 	"github.com/apache/incubator-answer/internal/service/notice_queue"
 	"github.com/apache/incubator-answer/internal/service/siteinfo_common"
+	"github.com/apache/incubator-answer/internal/service/tag_common"
 	usercommon "github.com/apache/incubator-answer/internal/service/user_common"
 	"github.com/apache/incubator-answer/internal/service/user_external_login"
 	"github.com/apache/incubator-answer/internal/service/user_notification_config"
@@ -44,6 +45,9 @@ type ExternalNotificationService struct {
 	notificationQueueService   notice_queue.ExternalNotificationQueueService
 	userExternalLoginRepo      user_external_login.UserExternalLoginRepo
 	siteInfoService            siteinfo_common.SiteInfoCommonService
+	notificationDigestRepo     NotificationDigestRepo
+	notificationMuteRepo       user_notification_config.NotificationMuteRepo
+	tagCommonService           *tag_common.TagCommonService
 }
 
 func NewExternalNotificationService(
@@ -55,6 +59,9 @@ func NewExternalNotificationService(
 	notificationQueueService notice_queue.ExternalNotificationQueueService,
 	userExternalLoginRepo user_external_login.UserExternalLoginRepo,
 	siteInfoService siteinfo_common.SiteInfoCommonService,
+	notificationDigestRepo NotificationDigestRepo,
+	notificationMuteRepo user_notification_config.NotificationMuteRepo,
+	tagCommonService *tag_common.TagCommonService,
 ) *ExternalNotificationService {
 	n := &ExternalNotificationService{
 		data:                       data,
@@ -65,6 +72,9 @@ func NewExternalNotificationService(
 		notificationQueueService:   notificationQueueService,
 		userExternalLoginRepo:      userExternalLoginRepo,
 		siteInfoService:            siteInfoService,
+		notificationDigestRepo:     notificationDigestRepo,
+		notificationMuteRepo:       notificationMuteRepo,
+		tagCommonService:           tagCommonService,
 	}
 	notificationQueueService.RegisterHandler(n.Handler)
 	return n
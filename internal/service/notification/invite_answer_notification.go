@@ -23,6 +23,8 @@ import (
 	"context"
 	"github.com/apache/incubator-answer/internal/base/constant"
 	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/apache/incubator-answer/internal/service/export"
+	"github.com/apache/incubator-answer/pkg/uid"
 	"github.com/segmentfault/pacman/i18n"
 	"github.com/segmentfault/pacman/log"
 	"time"
@@ -46,6 +48,13 @@ func (ns *ExternalNotificationService) handleInviteAnswerNotification(ctx contex
 		}
 		switch channel.Key {
 		case constant.EmailChannel:
+			if channel.IsDigest() {
+				ns.addDigestItem(ctx, msg.ReceiverUserID, msg.ReceiverLang, constant.InboxSource, channel.Frequency,
+					func(ctx context.Context) (*schema.DigestItemTemplateData, error) {
+						return ns.emailService.NewInviteAnswerDigestItem(ctx, msg.NewInviteAnswerTemplateRawData)
+					})
+				continue
+			}
 			ns.sendInviteAnswerNotificationEmail(ctx, msg.ReceiverUserID, msg.ReceiverEmail, msg.ReceiverLang, msg.NewInviteAnswerTemplateRawData)
 		}
 	}
@@ -74,6 +83,13 @@ func (ns *ExternalNotificationService) sendInviteAnswerNotificationEmail(ctx con
 		return
 	}
 
-	ns.emailService.SendAndSaveCodeWithTime(
-		ctx, userID, email, title, body, rawData.UnsubscribeCode, codeContent.ToJSONString(), 1*24*time.Hour)
+	// the reply is posted as an answer to the question
+	replyTo := ns.emailService.ReplyAddress(&export.ReplyToken{
+		Kind:     export.ReplyTokenKindAnswer,
+		UserID:   userID,
+		ObjectID: uid.DeShortID(rawData.QuestionID),
+	})
+	msg := &export.EmailMessage{To: email, Subject: title, Body: body, ReplyTo: replyTo}
+	ns.emailService.SendNotificationAndSaveCode(
+		ctx, userID, msg, rawData.UnsubscribeCode, codeContent, 1*24*time.Hour)
 }
@@ -23,6 +23,8 @@ import (
 	"context"
 	"github.com/apache/incubator-answer/internal/base/constant"
 	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/apache/incubator-answer/internal/service/export"
+	"github.com/apache/incubator-answer/pkg/uid"
 	"github.com/segmentfault/pacman/i18n"
 	"github.com/segmentfault/pacman/log"
 	"time"
@@ -31,6 +33,9 @@ import (
 func (ns *ExternalNotificationService) handleNewAnswerNotification(ctx context.Context,
 	msg *schema.ExternalNotificationMsg) error {
 	log.Debugf("try to send new comment notification %+v", msg)
+	if ns.isQuestionMuted(ctx, msg.ReceiverUserID, msg.NewAnswerTemplateRawData.QuestionID) {
+		return nil
+	}
 
 	notificationConfig, exist, err := ns.userNotificationConfigRepo.GetByUserIDAndSource(ctx, msg.ReceiverUserID, constant.InboxSource)
 	if err != nil {
@@ -46,6 +51,13 @@ func (ns *ExternalNotificationService) handleNewAnswerNotification(ctx context.C
 		}
 		switch channel.Key {
 		case constant.EmailChannel:
+			if channel.IsDigest() {
+				ns.addDigestItem(ctx, msg.ReceiverUserID, msg.ReceiverLang, constant.InboxSource, channel.Frequency,
+					func(ctx context.Context) (*schema.DigestItemTemplateData, error) {
+						return ns.emailService.NewAnswerDigestItem(ctx, msg.NewAnswerTemplateRawData)
+					})
+				continue
+			}
 			ns.sendNewAnswerNotificationEmail(ctx, msg.ReceiverUserID, msg.ReceiverEmail, msg.ReceiverLang, msg.NewAnswerTemplateRawData)
 		}
 	}
@@ -68,12 +80,20 @@ func (ns *ExternalNotificationService) sendNewAnswerNotificationEmail(ctx contex
 	if len(lang) > 0 {
 		ctx = context.WithValue(ctx, constant.AcceptLanguageFlag, i18n.Language(lang))
 	}
+	rawData.MuteCode = ns.saveMuteQuestionCode(ctx, userID, email, rawData.QuestionID)
 	title, body, err := ns.emailService.NewAnswerTemplate(ctx, rawData)
 	if err != nil {
 		log.Error(err)
 		return
 	}
 
-	ns.emailService.SendAndSaveCodeWithTime(
-		ctx, userID, email, title, body, rawData.UnsubscribeCode, codeContent.ToJSONString(), 1*24*time.Hour)
+	// the reply is posted as a comment on the new answer
+	replyTo := ns.emailService.ReplyAddress(&export.ReplyToken{
+		Kind:     export.ReplyTokenKindComment,
+		UserID:   userID,
+		ObjectID: uid.DeShortID(rawData.AnswerID),
+	})
+	msg := &export.EmailMessage{To: email, Subject: title, Body: body, ReplyTo: replyTo}
+	ns.emailService.SendNotificationAndSaveCode(
+		ctx, userID, msg, rawData.UnsubscribeCode, codeContent, 1*24*time.Hour)
 }
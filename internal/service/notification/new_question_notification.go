@@ -27,6 +27,7 @@ import (
 	"github.com/apache/incubator-answer/internal/base/constant"
 	"github.com/apache/incubator-answer/internal/base/translator"
 	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/apache/incubator-answer/internal/service/export"
 	"github.com/apache/incubator-answer/pkg/display"
 	"github.com/apache/incubator-answer/pkg/token"
 	"github.com/apache/incubator-answer/plugin"
@@ -57,13 +58,18 @@ func (ns *ExternalNotificationService) handleNewQuestionNotification(ctx context
 			}
 			switch channel.Key {
 			case constant.EmailChannel:
-				ns.sendNewQuestionNotificationEmail(ctx, subscriber.UserID, &schema.NewQuestionTemplateRawData{
+				rawData := &schema.NewQuestionTemplateRawData{
 					QuestionTitle:   msg.NewQuestionTemplateRawData.QuestionTitle,
 					QuestionID:      msg.NewQuestionTemplateRawData.QuestionID,
 					UnsubscribeCode: token.GenerateToken(),
 					Tags:            msg.NewQuestionTemplateRawData.Tags,
 					TagIDs:          msg.NewQuestionTemplateRawData.TagIDs,
-				})
+				}
+				if channel.IsDigest() {
+					ns.addNewQuestionDigestItem(ctx, subscriber, channel.Frequency, rawData)
+					continue
+				}
+				ns.sendNewQuestionNotificationEmail(ctx, subscriber.UserID, rawData)
 			}
 		}
 	}
@@ -76,10 +82,10 @@ func (ns *ExternalNotificationService) getNewQuestionSubscribers(ctx context.Con
 	subscribers []*NewQuestionSubscriber, err error) {
 	subscribersMapping := make(map[string]*NewQuestionSubscriber)
 
-	// 1. get all this new question's tags followers
+	// 1. get all this new question's tags followers, following a parent tag covers its descendants
 	tagsFollowerIDs := make([]string, 0)
 	followerMapping := make(map[string]bool)
-	for _, tagID := range msg.NewQuestionTemplateRawData.TagIDs {
+	for _, tagID := range ns.getFollowedTagIDs(ctx, msg) {
 		userIDs, err := ns.followRepo.GetFollowUserIDs(ctx, tagID)
 		if err != nil {
 			log.Error(err)
@@ -138,6 +144,16 @@ func (ns *ExternalNotificationService) getNewQuestionSubscribers(ctx context.Con
 	return subscribers, nil
 }
 
+// getFollowedTagIDs get the tags of the question and all their ancestors
+func (ns *ExternalNotificationService) getFollowedTagIDs(ctx context.Context, msg *schema.ExternalNotificationMsg) []string {
+	tagIDs, err := ns.tagCommonService.GetTagIDsWithAncestors(ctx, msg.NewQuestionTemplateRawData.TagIDs)
+	if err != nil {
+		log.Error(err)
+		return msg.NewQuestionTemplateRawData.TagIDs
+	}
+	return tagIDs
+}
+
 func (ns *ExternalNotificationService) checkSendNewQuestionNotificationEmailLimit(ctx context.Context, userID string) bool {
 	key := constant.NewQuestionNotificationLimitCacheKeyPrefix + userID
 	old, exist, err := ns.data.Cache.GetInt64(ctx, key)
@@ -191,8 +207,26 @@ func (ns *ExternalNotificationService) sendNewQuestionNotificationEmail(ctx cont
 		},
 		SkipValidationLatestCode: true,
 	}
-	ns.emailService.SendAndSaveCodeWithTime(
-		ctx, userInfo.ID, userInfo.EMail, title, body, rawData.UnsubscribeCode, codeContent.ToJSONString(), 1*24*time.Hour)
+	msg := &export.EmailMessage{To: userInfo.EMail, Subject: title, Body: body}
+	ns.emailService.SendNotificationAndSaveCode(
+		ctx, userInfo.ID, msg, rawData.UnsubscribeCode, codeContent, 1*24*time.Hour)
+}
+
+func (ns *ExternalNotificationService) addNewQuestionDigestItem(ctx context.Context,
+	subscriber *NewQuestionSubscriber, frequency constant.NotificationFrequency, rawData *schema.NewQuestionTemplateRawData) {
+	userInfo, exist, err := ns.userRepo.GetByUserID(ctx, subscriber.UserID)
+	if err != nil {
+		log.Error(err)
+		return
+	}
+	if !exist {
+		log.Errorf("user %s not exist", subscriber.UserID)
+		return
+	}
+	ns.addDigestItem(ctx, userInfo.ID, userInfo.Language, subscriber.NotificationSource, frequency,
+		func(ctx context.Context) (*schema.DigestItemTemplateData, error) {
+			return ns.emailService.NewQuestionDigestItem(ctx, rawData)
+		})
 }
 
 func (ns *ExternalNotificationService) syncNewQuestionNotificationToPlugin(ctx context.Context,
@@ -200,7 +234,7 @@ func (ns *ExternalNotificationService) syncNewQuestionNotificationToPlugin(ctx c
 	_ = plugin.CallNotification(func(fn plugin.Notification) error {
 		// 1. get all this new question's tags followers
 		subscribersMapping := make(map[string]plugin.NotificationType)
-		for _, tagID := range msg.NewQuestionTemplateRawData.TagIDs {
+		for _, tagID := range ns.getFollowedTagIDs(ctx, msg) {
 			userIDs, err := ns.followRepo.GetFollowUserIDs(ctx, tagID)
 			if err != nil {
 				log.Error(err)
@@ -0,0 +1,182 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package notification
+
+import (
+	"context"
+	"time"
+
+	"github.com/apache/incubator-answer/internal/base/constant"
+	"github.com/apache/incubator-answer/internal/base/translator"
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/apache/incubator-answer/internal/service/export"
+	"github.com/apache/incubator-answer/pkg/token"
+	"github.com/segmentfault/pacman/i18n"
+	"github.com/segmentfault/pacman/log"
+)
+
+// digestUnsubscribeCodeTime the unsubscribe link of digest email must be valid until the next weekly digest
+const digestUnsubscribeCodeTime = 7 * 24 * time.Hour
+
+// NotificationDigestRepo notification digest repository
+type NotificationDigestRepo interface {
+	AddDigestItem(ctx context.Context, item *entity.NotificationDigest) (err error)
+	GetDigestUserIDs(ctx context.Context, frequency string, before time.Time) (userIDs []string, err error)
+	GetDigestItems(ctx context.Context, userID, frequency string, before time.Time) (
+		items []*entity.NotificationDigest, err error)
+	RemoveDigestItems(ctx context.Context, ids []int64) (err error)
+}
+
+// addDigestItem add the notification to the digest queue of receiver instead of sending email immediately
+func (ns *ExternalNotificationService) addDigestItem(ctx context.Context, userID, lang string,
+	source constant.NotificationSource, frequency constant.NotificationFrequency,
+	buildItem func(ctx context.Context) (*schema.DigestItemTemplateData, error)) {
+	if len(lang) > 0 {
+		ctx = context.WithValue(ctx, constant.AcceptLanguageFlag, i18n.Language(lang))
+	}
+	item, err := buildItem(ctx)
+	if err != nil {
+		log.Error(err)
+		return
+	}
+	err = ns.notificationDigestRepo.AddDigestItem(ctx, &entity.NotificationDigest{
+		UserID:    userID,
+		Source:    string(source),
+		Frequency: string(frequency),
+		Title:     item.Title,
+		URL:       item.Url,
+		Summary:   item.Summary,
+	})
+	if err != nil {
+		log.Error(err)
+	}
+}
+
+// SendDigestCron send one digest email to every user who has queued notifications of the frequency
+func (ns *ExternalNotificationService) SendDigestCron(ctx context.Context, frequency constant.NotificationFrequency) {
+	before := time.Now()
+	userIDs, err := ns.notificationDigestRepo.GetDigestUserIDs(ctx, string(frequency), before)
+	if err != nil {
+		log.Error(err)
+		return
+	}
+	log.Debugf("send %s digest to %d users", frequency, len(userIDs))
+	for _, userID := range userIDs {
+		if err := ns.sendDigest(ctx, userID, frequency, before); err != nil {
+			log.Errorf("send %s digest to user %s failed: %v", frequency, userID, err)
+		}
+	}
+}
+
+func (ns *ExternalNotificationService) sendDigest(ctx context.Context, userID string,
+	frequency constant.NotificationFrequency, before time.Time) (err error) {
+	items, err := ns.notificationDigestRepo.GetDigestItems(ctx, userID, string(frequency), before)
+	if err != nil {
+		return err
+	}
+	itemIDs := make([]int64, 0, len(items))
+	for _, item := range items {
+		itemIDs = append(itemIDs, item.ID)
+	}
+	// the queued notifications are removed even if the email can not be sent, so they won't be sent again and again.
+	defer func() {
+		if removeErr := ns.notificationDigestRepo.RemoveDigestItems(ctx, itemIDs); removeErr != nil {
+			log.Error(removeErr)
+		}
+	}()
+
+	userInfo, exist, err := ns.userRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if !exist || userInfo.Status == entity.UserStatusDeleted || userInfo.MailStatus != entity.EmailStatusAvailable {
+		return nil
+	}
+
+	// the user may unsubscribe the source after the notification is queued
+	templateItems := make([]*schema.DigestItemTemplateData, 0, len(items))
+	sources := make([]constant.NotificationSource, 0)
+	sourceEnabled := make(map[string]bool)
+	for _, item := range items {
+		enabled, ok := sourceEnabled[item.Source]
+		if !ok {
+			enabled, err = ns.isEmailChannelEnabled(ctx, userID, constant.NotificationSource(item.Source))
+			if err != nil {
+				return err
+			}
+			sourceEnabled[item.Source] = enabled
+			if enabled {
+				sources = append(sources, constant.NotificationSource(item.Source))
+			}
+		}
+		if !enabled {
+			continue
+		}
+		templateItems = append(templateItems, &schema.DigestItemTemplateData{
+			Title:   item.Title,
+			Url:     item.URL,
+			Summary: item.Summary,
+		})
+	}
+	if len(templateItems) == 0 {
+		return nil
+	}
+
+	lang := userInfo.Language
+	if len(lang) == 0 || lang == translator.DefaultLangOption {
+		if interfaceInfo, _ := ns.siteInfoService.GetSiteInterface(ctx); interfaceInfo != nil {
+			lang = interfaceInfo.Language
+		}
+	}
+	if len(lang) > 0 {
+		ctx = context.WithValue(ctx, constant.AcceptLanguageFlag, i18n.Language(lang))
+	}
+	unsubscribeCode := token.GenerateToken()
+	title, body, err := ns.emailService.DigestTemplate(ctx, templateItems, unsubscribeCode)
+	if err != nil {
+		return err
+	}
+	codeContent := &schema.EmailCodeContent{
+		SourceType:               schema.UnsubscribeSourceType,
+		Email:                    userInfo.EMail,
+		UserID:                   userInfo.ID,
+		NotificationSources:      sources,
+		SkipValidationLatestCode: true,
+	}
+	msg := &export.EmailMessage{To: userInfo.EMail, Subject: title, Body: body}
+	ns.emailService.SendNotificationAndSaveCode(ctx, userInfo.ID, msg,
+		unsubscribeCode, codeContent, digestUnsubscribeCodeTime)
+	return nil
+}
+
+func (ns *ExternalNotificationService) isEmailChannelEnabled(ctx context.Context, userID string,
+	source constant.NotificationSource) (enabled bool, err error) {
+	notificationConfig, exist, err := ns.userNotificationConfigRepo.GetByUserIDAndSource(ctx, userID, source)
+	if err != nil || !exist {
+		return false, err
+	}
+	for _, channel := range schema.NewNotificationChannelsFormJson(notificationConfig.Channels) {
+		if channel.Key == constant.EmailChannel && channel.Enable {
+			return true, nil
+		}
+	}
+	return false, nil
+}
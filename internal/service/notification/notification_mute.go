@@ -0,0 +1,55 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package notification
+
+import (
+	"context"
+	"time"
+
+	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/apache/incubator-answer/pkg/token"
+	"github.com/apache/incubator-answer/pkg/uid"
+	"github.com/segmentfault/pacman/log"
+)
+
+// isQuestionMuted check whether the receiver has muted the question from the email
+func (ns *ExternalNotificationService) isQuestionMuted(ctx context.Context, userID, questionID string) bool {
+	muted, err := ns.notificationMuteRepo.IsMuted(ctx, userID, uid.DeShortID(questionID))
+	if err != nil {
+		log.Error(err)
+		return false
+	}
+	return muted
+}
+
+// saveMuteQuestionCode save the code of the "mute this question" link in the email
+func (ns *ExternalNotificationService) saveMuteQuestionCode(ctx context.Context, userID, email, questionID string) (
+	code string) {
+	code = token.GenerateToken()
+	codeContent := &schema.EmailCodeContent{
+		SourceType:               schema.MuteQuestionSourceType,
+		Email:                    email,
+		UserID:                   userID,
+		ObjectID:                 questionID,
+		SkipValidationLatestCode: true,
+	}
+	ns.emailService.SaveCodeWithTime(ctx, userID, code, codeContent.ToJSONString(), 1*24*time.Hour)
+	return code
+}
@@ -35,6 +35,7 @@ import (
 	"github.com/apache/incubator-answer/internal/entity"
 	"github.com/apache/incubator-answer/internal/schema"
 	"github.com/apache/incubator-answer/internal/service/activity_common"
+	"github.com/apache/incubator-answer/internal/service/job_queue"
 	"github.com/apache/incubator-answer/internal/service/notice_queue"
 	"github.com/apache/incubator-answer/internal/service/object_info"
 	usercommon "github.com/apache/incubator-answer/internal/service/user_common"
@@ -119,10 +120,15 @@ func (ns *NotificationCommon) AddNotification(ctx context.Context, msg *schema.N
 		Type:               msg.Type,
 	}
 	var questionID string // just for notify all followers
-	objInfo, err := ns.objectInfoService.GetInfo(ctx, req.ObjectInfo.ObjectID)
+	var objInfo *schema.SimpleObjectInfo
+	var err error
+	// the badge is not a content object, the title of its notification is the badge name
+	if msg.ObjectType != constant.BadgeObjectType {
+		objInfo, err = ns.objectInfoService.GetInfo(ctx, req.ObjectInfo.ObjectID)
+	}
 	if err != nil {
 		log.Error(err)
-	} else {
+	} else if objInfo != nil {
 		req.ObjectInfo.Title = objInfo.Title
 		questionID = objInfo.QuestionID
 		objectMap := make(map[string]string)
@@ -132,7 +138,9 @@ func (ns *NotificationCommon) AddNotification(ctx context.Context, msg *schema.N
 		req.ObjectInfo.ObjectMap = objectMap
 	}
 
-	if msg.Type == schema.NotificationTypeAchievement {
+	// the achievement of the content sums up the reputation changes of the object,
+	// every earned badge is an achievement of its own and has no reputation change
+	if msg.Type == schema.NotificationTypeAchievement && msg.ObjectType != constant.BadgeObjectType {
 		notificationInfo, exist, err := ns.notificationRepo.GetByUserIdObjectIdTypeId(ctx, req.ReceiverUserID, req.ObjectInfo.ObjectID, req.Type)
 		if err != nil {
 			return fmt.Errorf("get by user id object id type id error: %w", err)
@@ -175,7 +183,7 @@ func (ns *NotificationCommon) AddNotification(ctx context.Context, msg *schema.N
 		return fmt.Errorf("get user basic info error: %w", err)
 	}
 	if !exist {
-		return fmt.Errorf("user not exist: %s", req.TriggerUserID)
+		return job_queue.Permanent(fmt.Errorf("user not exist: %s", req.TriggerUserID))
 	}
 	req.UserInfo = userBasicInfo
 	content, _ := json.Marshal(req)
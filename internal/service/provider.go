@@ -25,16 +25,25 @@ import (
 	"github.com/apache/incubator-answer/internal/service/activity_common"
 	"github.com/apache/incubator-answer/internal/service/activity_queue"
 	answercommon "github.com/apache/incubator-answer/internal/service/answer_common"
+	"github.com/apache/incubator-answer/internal/service/api_token"
+	"github.com/apache/incubator-answer/internal/service/attachment"
 	"github.com/apache/incubator-answer/internal/service/auth"
+	"github.com/apache/incubator-answer/internal/service/badge"
+	"github.com/apache/incubator-answer/internal/service/badge_queue"
+	"github.com/apache/incubator-answer/internal/service/bounty"
 	"github.com/apache/incubator-answer/internal/service/collection"
 	collectioncommon "github.com/apache/incubator-answer/internal/service/collection_common"
 	"github.com/apache/incubator-answer/internal/service/comment"
 	"github.com/apache/incubator-answer/internal/service/comment_common"
 	"github.com/apache/incubator-answer/internal/service/config"
 	"github.com/apache/incubator-answer/internal/service/content"
+	"github.com/apache/incubator-answer/internal/service/content_export"
 	"github.com/apache/incubator-answer/internal/service/dashboard"
 	"github.com/apache/incubator-answer/internal/service/export"
 	"github.com/apache/incubator-answer/internal/service/follow"
+	"github.com/apache/incubator-answer/internal/service/importer"
+	"github.com/apache/incubator-answer/internal/service/inbound_mail"
+	"github.com/apache/incubator-answer/internal/service/job_queue"
 	"github.com/apache/incubator-answer/internal/service/meta"
 	"github.com/apache/incubator-answer/internal/service/meta_common"
 	"github.com/apache/incubator-answer/internal/service/notice_queue"
@@ -51,6 +60,7 @@ import (
 	"github.com/apache/incubator-answer/internal/service/revision_common"
 	"github.com/apache/incubator-answer/internal/service/role"
 	"github.com/apache/incubator-answer/internal/service/search_parser"
+	"github.com/apache/incubator-answer/internal/service/search_sync"
 	"github.com/apache/incubator-answer/internal/service/siteinfo"
 	"github.com/apache/incubator-answer/internal/service/siteinfo_common"
 	"github.com/apache/incubator-answer/internal/service/tag"
@@ -58,8 +68,11 @@ import (
 	"github.com/apache/incubator-answer/internal/service/uploader"
 	"github.com/apache/incubator-answer/internal/service/user_admin"
 	usercommon "github.com/apache/incubator-answer/internal/service/user_common"
+	"github.com/apache/incubator-answer/internal/service/user_data"
 	"github.com/apache/incubator-answer/internal/service/user_external_login"
 	"github.com/apache/incubator-answer/internal/service/user_notification_config"
+	"github.com/apache/incubator-answer/internal/service/webhook"
+	"github.com/apache/incubator-answer/internal/service/webhook_queue"
 	"github.com/google/wire"
 )
 
@@ -117,4 +130,20 @@ var ProviderSetService = wire.NewSet(
 	notice_queue.NewNewQuestionNotificationQueueService,
 	review.NewReviewService,
 	meta.NewMetaService,
+	job_queue.NewJobQueueService,
+	webhook_queue.NewWebhookQueueService,
+	webhook.NewWebhookService,
+	api_token.NewAPITokenService,
+	search_sync.NewSearchSyncService,
+	importer.NewStackExchangeImporter,
+	importer.NewImporterService,
+	content_export.NewContentExporter,
+	content_export.NewContentExportService,
+	user_data.NewUserDataService,
+	inbound_mail.NewInboundMailService,
+	bounty.NewBountyService,
+	badge_queue.NewBadgeQueueService,
+	badge.NewBadgeService,
+	rank.NewRankRecalculateService,
+	attachment.NewAttachmentService,
 )
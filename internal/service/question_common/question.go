@@ -48,6 +48,8 @@ import (
 	"github.com/segmentfault/pacman/log"
 )
 
+//go:generate mockgen -source=./question.go -destination=../mock/question_repo_mock.go -package=mock
+
 // QuestionRepo question repository
 type QuestionRepo interface {
 	AddQuestion(ctx context.Context, question *entity.Question) (err error)
@@ -0,0 +1,317 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package rank
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/apache/incubator-answer/internal/base/constant"
+	"github.com/apache/incubator-answer/internal/base/reason"
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/apache/incubator-answer/internal/service/activity_type"
+	"github.com/apache/incubator-answer/internal/service/config"
+	"github.com/apache/incubator-answer/plugin"
+	"github.com/segmentfault/pacman/errors"
+	"github.com/segmentfault/pacman/log"
+)
+
+// dynamicRankActivities the rank of these activities is decided when they happen, not by the config
+var dynamicRankActivities = map[string]bool{
+	string(constant.ActQuestionBountyOffered): true,
+	string(constant.ActQuestionBountyExpired): true,
+	string(constant.ActAnswerBountyAwarded):   true,
+}
+
+// noRankActivities these activities never change the reputation whatever the config is
+var noRankActivities = map[string]bool{
+	"user.follow":     true,
+	"question.follow": true,
+	"tag.follow":      true,
+}
+
+// rankRules the current rank rules, the rules of activity types are loaded when they are used
+type rankRules struct {
+	activities   map[int]*entity.Config
+	maxDailyRank int
+	// dailyExclude the activity types whose rank is not limited by the daily rank limit
+	dailyExclude map[int]bool
+}
+
+//go:generate mockgen -source=./rank_recalculate_service.go -destination=../mock/rank_recalculate_repo_mock.go -package=mock
+
+// RankRecalculateRepo reputation recalculation repository
+type RankRecalculateRepo interface {
+	GetUsersAfter(ctx context.Context, lastUserID string, limit int) (users []*entity.User, err error)
+	GetRankActivities(ctx context.Context, userID string) (activities []*entity.Activity, err error)
+	ApplyRankDiffs(ctx context.Context, diffs []*schema.RankRecalculateDiff, operatorID string) (applied int, err error)
+}
+
+// RankRecalculateService recalculate the reputation of users from their activities under the current rank rules
+type RankRecalculateService struct {
+	rankRecalculateRepo RankRecalculateRepo
+	configService       *config.ConfigService
+	lock                sync.Mutex
+	progress            *schema.RankRecalculateProgress
+}
+
+// NewRankRecalculateService new reputation recalculation service
+func NewRankRecalculateService(
+	rankRecalculateRepo RankRecalculateRepo,
+	configService *config.ConfigService,
+) *RankRecalculateService {
+	return &RankRecalculateService{
+		rankRecalculateRepo: rankRecalculateRepo,
+		configService:       configService,
+	}
+}
+
+// StartRecalculate recalculate the reputation of all users in background
+func (rs *RankRecalculateService) StartRecalculate(ctx context.Context, req *schema.RankRecalculateReq) (
+	resp *schema.RankRecalculateProgress, err error) {
+	if plugin.RankAgentEnabled() {
+		return nil, errors.BadRequest(reason.RankRecalculateAgentEnabled)
+	}
+	if progress := rs.GetRecalculateStatus(ctx); progress.Running {
+		return nil, errors.BadRequest(reason.RankRecalculateIsRunning)
+	}
+	go func() {
+		if _, err := rs.Recalculate(context.Background(), req, nil); err != nil {
+			log.Errorf("recalculate reputation failed: %v", err)
+		}
+	}()
+	return &schema.RankRecalculateProgress{DryRun: req.DryRun, Running: true}, nil
+}
+
+// GetRecalculateStatus get the progress of the running recalculation, or the result of the last one
+func (rs *RankRecalculateService) GetRecalculateStatus(ctx context.Context) (resp *schema.RankRecalculateProgress) {
+	rs.lock.Lock()
+	defer rs.lock.Unlock()
+	if rs.progress == nil {
+		return &schema.RankRecalculateProgress{}
+	}
+	progress := *rs.progress
+	progress.Diffs = append([]*schema.RankRecalculateDiff{}, rs.progress.Diffs...)
+	return &progress
+}
+
+// Recalculate recalculate the reputation of all users batch by batch, every batch is applied in one transaction.
+// Nothing is changed in dry run. The report will be called for every difference if it is not nil.
+func (rs *RankRecalculateService) Recalculate(ctx context.Context, req *schema.RankRecalculateReq,
+	report func(diff *schema.RankRecalculateDiff)) (resp *schema.RankRecalculateProgress, err error) {
+	if plugin.RankAgentEnabled() {
+		return nil, errors.BadRequest(reason.RankRecalculateAgentEnabled)
+	}
+	rs.lock.Lock()
+	if rs.progress != nil && rs.progress.Running {
+		rs.lock.Unlock()
+		return nil, errors.BadRequest(reason.RankRecalculateIsRunning)
+	}
+	rs.progress = &schema.RankRecalculateProgress{
+		DryRun:    req.DryRun,
+		Running:   true,
+		Diffs:     make([]*schema.RankRecalculateDiff, 0),
+		StartedAt: time.Now().Unix(),
+	}
+	rs.lock.Unlock()
+	defer func() {
+		rs.lock.Lock()
+		rs.progress.Running = false
+		rs.progress.FinishedAt = time.Now().Unix()
+		if err != nil {
+			rs.progress.Error = err.Error()
+		}
+		rs.lock.Unlock()
+		resp = rs.GetRecalculateStatus(ctx)
+	}()
+
+	batchSize := req.BatchSize
+	if batchSize <= 0 {
+		batchSize = schema.RankRecalculateDefaultBatchSize
+	}
+	rules, err := rs.getRankRules(ctx)
+	if err != nil {
+		return nil, err
+	}
+	lastUserID := "0"
+	for {
+		users, err := rs.rankRecalculateRepo.GetUsersAfter(ctx, lastUserID, batchSize)
+		if err != nil {
+			return nil, err
+		}
+		diffs := make([]*schema.RankRecalculateDiff, 0)
+		for _, user := range users {
+			diff, err := rs.recalculateUser(ctx, user, rules)
+			if err != nil {
+				return nil, err
+			}
+			if diff != nil {
+				diffs = append(diffs, diff)
+				if report != nil {
+					report(diff)
+				}
+			}
+		}
+
+		applied := 0
+		if !req.DryRun && len(diffs) > 0 {
+			applied, err = rs.rankRecalculateRepo.ApplyRankDiffs(ctx, diffs, req.UserID)
+			if err != nil {
+				return nil, err
+			}
+		}
+		rs.lock.Lock()
+		rs.progress.Users += len(users)
+		rs.progress.Changed += len(diffs)
+		rs.progress.Applied += applied
+		for _, diff := range diffs {
+			if len(rs.progress.Diffs) >= schema.RankRecalculateMaxReportDiffs {
+				break
+			}
+			rs.progress.Diffs = append(rs.progress.Diffs, diff)
+		}
+		rs.lock.Unlock()
+
+		if len(users) < batchSize {
+			return nil, nil
+		}
+		lastUserID = users[len(users)-1].ID
+	}
+}
+
+// recalculateUser replay the activities of the user under the current rules, it returns nil if nothing is different.
+// The activities are replayed day by day like they happened, so the rank rules work the same as the live path:
+//   - the rank is the current value of the rule, except the bounties, the removed rules and the self acceptance
+//   - the positive rank is 0 once the user has earned the daily rank limit on that day, unless the type is excluded
+//   - the deduction never drops the reputation below 1
+//
+// The activities with 0 rank are replayed too, because the rank is 0 both when the rule was 0 and when the daily
+// rank limit was reached, the replay decides it again under the current rules.
+func (rs *RankRecalculateService) recalculateUser(ctx context.Context, user *entity.User,
+	rules *rankRules) (diff *schema.RankRecalculateDiff, err error) {
+	activities, err := rs.rankRecalculateRepo.GetRankActivities(ctx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+	diff = &schema.RankRecalculateDiff{
+		UserID:     user.ID,
+		Username:   user.Username,
+		OldRank:    user.Rank,
+		Activities: make([]*entity.Activity, 0),
+	}
+	actRules := make([]*entity.Config, len(activities))
+	acceptedAnswers := make(map[string]int)
+	for i, act := range activities {
+		if actRules[i], err = rs.getRule(ctx, act.ActivityType, rules); err != nil {
+			return nil, err
+		}
+		if actRules[i] != nil &&
+			(actRules[i].Key == activity_type.AnswerAccept || actRules[i].Key == activity_type.AnswerAccepted) {
+			acceptedAnswers[act.ObjectID]++
+		}
+	}
+
+	dailyEarned := make(map[string]int)
+	for i, act := range activities {
+		delta := act.Rank
+		if rule := actRules[i]; rule != nil && !dynamicRankActivities[rule.Key] {
+			switch {
+			case noRankActivities[rule.Key]:
+				delta = 0
+			case (rule.Key == activity_type.AnswerAccept || rule.Key == activity_type.AnswerAccepted) &&
+				acceptedAnswers[act.ObjectID] > 1:
+				// the user accepted the answer of their own question
+				delta = 0
+			default:
+				delta = rule.GetIntValue()
+			}
+		}
+		day := act.UpdatedAt.Format("2006-01-02")
+		if delta > 0 && !rules.dailyExclude[act.ActivityType] && dailyEarned[day] >= rules.maxDailyRank {
+			delta = 0
+		}
+		if delta < 0 && diff.NewRank+delta < 1 {
+			delta = 1 - diff.NewRank
+			if delta > 0 {
+				delta = 0
+			}
+		}
+		dailyEarned[day] += delta
+		diff.NewRank += delta
+		if delta != act.Rank {
+			hasRank := 0
+			if delta != 0 {
+				hasRank = 1
+			}
+			diff.Activities = append(diff.Activities, &entity.Activity{ID: act.ID, Rank: delta, HasRank: hasRank})
+		}
+	}
+	// the users activated by admin have no activation activity
+	if user.MailStatus == entity.EmailStatusAvailable && diff.NewRank < 1 {
+		diff.NewRank = 1
+	}
+	diff.ChangedActivities = len(diff.Activities)
+	if diff.NewRank == diff.OldRank && diff.ChangedActivities == 0 {
+		return nil, nil
+	}
+	return diff, nil
+}
+
+// getRankRules get the daily rank limit, the rules of activity types are loaded by getRule
+func (rs *RankRecalculateService) getRankRules(ctx context.Context) (rules *rankRules, err error) {
+	rules = &rankRules{
+		activities:   make(map[int]*entity.Config),
+		dailyExclude: make(map[int]bool),
+	}
+	rules.maxDailyRank, err = rs.configService.GetIntValue(ctx, "daily_rank_limit")
+	if err != nil {
+		return nil, err
+	}
+	exclude, _ := rs.configService.GetArrayStringValue(ctx, "daily_rank_limit.exclude")
+	for _, key := range exclude {
+		cfg, err := rs.configService.GetConfigByKey(ctx, key)
+		if err != nil {
+			return nil, err
+		}
+		rules.dailyExclude[cfg.ID] = true
+	}
+	return rules, nil
+}
+
+// getRule get the config of the activity type, nil if the activity type is removed
+func (rs *RankRecalculateService) getRule(ctx context.Context, activityType int,
+	rules *rankRules) (rule *entity.Config, err error) {
+	if rule, ok := rules.activities[activityType]; ok {
+		return rule, nil
+	}
+	rule, err = rs.configService.GetConfigByID(ctx, activityType)
+	if err != nil {
+		// the database errors are wrapped, a plain error means the config is not found
+		if _, ok := err.(*errors.Error); ok {
+			return nil, err
+		}
+		rules.activities[activityType] = nil
+		return nil, nil
+	}
+	rules.activities[activityType] = rule
+	return rule, nil
+}
@@ -0,0 +1,194 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package rank
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/apache/incubator-answer/internal/base/constant"
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/apache/incubator-answer/internal/service/config"
+	"github.com/apache/incubator-answer/internal/service/mock"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+var testRankConfigs = []*entity.Config{
+	{ID: 1, Key: "user.activated", Value: "1"},
+	{ID: 2, Key: "question.voted_up", Value: "10"},
+	{ID: 3, Key: "question.voted_down", Value: "-5"},
+	{ID: 4, Key: string(constant.ActQuestionBountyOffered), Value: "0"},
+	{ID: 5, Key: "answer.accepted", Value: "15"},
+	{ID: 6, Key: "answer.accept", Value: "2"},
+	{ID: 7, Key: "question.follow", Value: "3"},
+	{ID: 22, Key: "daily_rank_limit", Value: "30"},
+	{ID: 23, Key: "daily_rank_limit.exclude", Value: `["answer.accepted"]`},
+}
+
+func newTestRankRecalculateService(ctl *gomock.Controller, users []*entity.User,
+	activities map[string][]*entity.Activity) (*RankRecalculateService, *mock.MockRankRecalculateRepo) {
+	configRepo := mock.NewMockConfigRepo(ctl)
+	configRepo.EXPECT().GetConfigByID(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, id int) (*entity.Config, error) {
+			for _, c := range testRankConfigs {
+				if c.ID == id {
+					return c, nil
+				}
+			}
+			return nil, fmt.Errorf("config not found by id: %d", id)
+		}).AnyTimes()
+	configRepo.EXPECT().GetConfigByKey(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, key string) (*entity.Config, error) {
+			for _, c := range testRankConfigs {
+				if c.Key == key {
+					return c, nil
+				}
+			}
+			return nil, fmt.Errorf("config not found by key: %s", key)
+		}).AnyTimes()
+
+	repo := mock.NewMockRankRecalculateRepo(ctl)
+	repo.EXPECT().GetUsersAfter(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, lastUserID string, limit int) ([]*entity.User, error) {
+			page := make([]*entity.User, 0)
+			for _, u := range users {
+				if u.ID > lastUserID && len(page) < limit {
+					page = append(page, u)
+				}
+			}
+			return page, nil
+		}).AnyTimes()
+	repo.EXPECT().GetRankActivities(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, userID string) ([]*entity.Activity, error) {
+			return activities[userID], nil
+		}).AnyTimes()
+	return NewRankRecalculateService(repo, config.NewConfigService(configRepo)), repo
+}
+
+func TestRankRecalculateService_Recalculate(t *testing.T) {
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+	rs, repo := newTestRankRecalculateService(ctl, []*entity.User{
+		{ID: "1", Username: "alice", Rank: 11, MailStatus: entity.EmailStatusAvailable},
+		{ID: "2", Username: "bob", Rank: 50, MailStatus: entity.EmailStatusAvailable},
+		{ID: "3", Username: "carol", Rank: 1, MailStatus: entity.EmailStatusAvailable},
+	}, map[string][]*entity.Activity{
+		// up to date
+		"1": {{ID: "11", ActivityType: 1, Rank: 1}, {ID: "12", ActivityType: 2, Rank: 10}},
+		// the vote up was worth 5 before, the bounty keeps its own rank, the removed config keeps the old rank
+		"2": {{ID: "21", ActivityType: 1, Rank: 1}, {ID: "22", ActivityType: 2, Rank: 5},
+			{ID: "23", ActivityType: 4, Rank: -50}, {ID: "24", ActivityType: 9, Rank: 2}},
+		// the deduction never drops the reputation below 1
+		"3": {{ID: "31", ActivityType: 1, Rank: 1}, {ID: "32", ActivityType: 3, Rank: -2}},
+	})
+
+	resp, err := rs.Recalculate(context.TODO(), &schema.RankRecalculateReq{DryRun: true, BatchSize: 2}, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, resp.Users)
+	assert.Equal(t, 2, resp.Changed)
+	assert.Equal(t, 0, resp.Applied)
+
+	bob := resp.Diffs[0]
+	assert.Equal(t, "2", bob.UserID)
+	assert.Equal(t, 50, bob.OldRank)
+	assert.Equal(t, 3, bob.NewRank)
+	assert.Equal(t, 2, bob.ChangedActivities)
+
+	carol := resp.Diffs[1]
+	assert.Equal(t, "3", carol.UserID)
+	assert.Equal(t, 1, carol.NewRank)
+	assert.Equal(t, []*entity.Activity{{ID: "32", Rank: 0, HasRank: 0}}, carol.Activities)
+
+	applied := make([]*schema.RankRecalculateDiff, 0)
+	repo.EXPECT().ApplyRankDiffs(gomock.Any(), gomock.Any(), "0").DoAndReturn(
+		func(ctx context.Context, diffs []*schema.RankRecalculateDiff, operatorID string) (int, error) {
+			applied = append(applied, diffs...)
+			return len(diffs), nil
+		}).Times(2)
+	resp, err = rs.Recalculate(context.TODO(), &schema.RankRecalculateReq{BatchSize: 2, UserID: "0"}, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, resp.Applied)
+	assert.Len(t, applied, 2)
+	assert.False(t, rs.GetRecalculateStatus(context.TODO()).Running)
+}
+
+func TestRankRecalculateService_RecalculateZeroRankActivities(t *testing.T) {
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+	day := time.Date(2024, 5, 1, 10, 0, 0, 0, time.Local)
+	rs, _ := newTestRankRecalculateService(ctl, []*entity.User{
+		{ID: "1", Username: "alice", Rank: 1, MailStatus: entity.EmailStatusAvailable},
+	}, map[string][]*entity.Activity{
+		"1": {
+			{ID: "11", ActivityType: 1, Rank: 1, HasRank: 1, UpdatedAt: day},
+			// the vote up was worth 0 when it happened
+			{ID: "12", ActivityType: 2, Rank: 0, UpdatedAt: day},
+			// the follow and the acceptance of own answer never earn reputation
+			{ID: "13", ActivityType: 7, Rank: 0, UpdatedAt: day},
+			{ID: "14", ObjectID: "10020000000000001", ActivityType: 6, Rank: 0, UpdatedAt: day},
+			{ID: "15", ObjectID: "10020000000000001", ActivityType: 5, Rank: 0, UpdatedAt: day},
+		},
+	})
+
+	resp, err := rs.Recalculate(context.TODO(), &schema.RankRecalculateReq{DryRun: true}, nil)
+	assert.NoError(t, err)
+	assert.Len(t, resp.Diffs, 1)
+	assert.Equal(t, 11, resp.Diffs[0].NewRank)
+	assert.Equal(t, []*entity.Activity{{ID: "12", Rank: 10, HasRank: 1}}, resp.Diffs[0].Activities)
+}
+
+func TestRankRecalculateService_RecalculateDailyRankLimit(t *testing.T) {
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+	day := time.Date(2024, 5, 1, 10, 0, 0, 0, time.Local)
+	nextDay := day.AddDate(0, 0, 1)
+	rs, _ := newTestRankRecalculateService(ctl, []*entity.User{
+		{ID: "1", Username: "alice", Rank: 46, MailStatus: entity.EmailStatusAvailable},
+	}, map[string][]*entity.Activity{
+		// the vote up was worth 5 before, it is 10 now and the daily limit is 30
+		"1": {
+			{ID: "11", ActivityType: 1, Rank: 1, HasRank: 1, UpdatedAt: day},
+			{ID: "12", ActivityType: 2, Rank: 5, HasRank: 1, UpdatedAt: day},
+			{ID: "13", ActivityType: 2, Rank: 5, HasRank: 1, UpdatedAt: day},
+			{ID: "14", ActivityType: 2, Rank: 5, HasRank: 1, UpdatedAt: day},
+			// the limit is reached, the rank of the vote up is 0 and the excluded acceptance is not limited
+			{ID: "15", ActivityType: 2, Rank: 5, HasRank: 1, UpdatedAt: day},
+			{ID: "16", ActivityType: 5, Rank: 15, HasRank: 1, UpdatedAt: day},
+			// the limit was reached when it happened, it is counted on a new day
+			{ID: "17", ActivityType: 2, Rank: 0, UpdatedAt: nextDay},
+		},
+	})
+
+	resp, err := rs.Recalculate(context.TODO(), &schema.RankRecalculateReq{DryRun: true}, nil)
+	assert.NoError(t, err)
+	assert.Len(t, resp.Diffs, 1)
+	assert.Equal(t, 56, resp.Diffs[0].NewRank)
+	assert.Equal(t, []*entity.Activity{
+		{ID: "12", Rank: 10, HasRank: 1},
+		{ID: "13", Rank: 10, HasRank: 1},
+		{ID: "14", Rank: 10, HasRank: 1},
+		{ID: "15", Rank: 0, HasRank: 0},
+		{ID: "17", Rank: 10, HasRank: 1},
+	}, resp.Diffs[0].Activities)
+}
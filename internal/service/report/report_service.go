@@ -21,6 +21,7 @@ package report
 
 import (
 	"encoding/json"
+	"time"
 
 	"github.com/apache/incubator-answer/internal/base/constant"
 	"github.com/apache/incubator-answer/internal/base/handler"
@@ -35,26 +36,35 @@ import (
 	questioncommon "github.com/apache/incubator-answer/internal/service/question_common"
 	"github.com/apache/incubator-answer/internal/service/report_common"
 	"github.com/apache/incubator-answer/internal/service/report_handle"
+	"github.com/apache/incubator-answer/internal/service/role"
+	"github.com/apache/incubator-answer/internal/service/service_config"
 	usercommon "github.com/apache/incubator-answer/internal/service/user_common"
+	"github.com/apache/incubator-answer/internal/service/webhook_queue"
 	"github.com/apache/incubator-answer/pkg/checker"
 	"github.com/apache/incubator-answer/pkg/htmltext"
 	"github.com/apache/incubator-answer/pkg/obj"
-	"github.com/jinzhu/copier"
 	"github.com/segmentfault/pacman/errors"
+	"github.com/segmentfault/pacman/i18n"
 	"github.com/segmentfault/pacman/log"
 	"golang.org/x/net/context"
 )
 
+// defaultReportSLA the report case should be handled in this duration if the sla is not configured
+const defaultReportSLA = 24 * time.Hour
+
 // ReportService user service
 type ReportService struct {
-	reportRepo        report_common.ReportRepo
-	objectInfoService *object_info.ObjService
-	commonUser        *usercommon.UserCommon
-	answerRepo        answercommon.AnswerRepo
-	questionRepo      questioncommon.QuestionRepo
-	commentCommonRepo comment_common.CommentCommonRepo
-	reportHandle      *report_handle.ReportHandle
-	configService     *config.ConfigService
+	reportRepo          report_common.ReportRepo
+	objectInfoService   *object_info.ObjService
+	commonUser          *usercommon.UserCommon
+	answerRepo          answercommon.AnswerRepo
+	questionRepo        questioncommon.QuestionRepo
+	commentCommonRepo   comment_common.CommentCommonRepo
+	reportHandle        *report_handle.ReportHandle
+	configService       *config.ConfigService
+	webhookQueueService webhook_queue.WebhookQueueService
+	userRoleRelService  *role.UserRoleRelService
+	serviceConfig       *service_config.ServiceConfig
 }
 
 // NewReportService new report service
@@ -67,16 +77,22 @@ func NewReportService(
 	commentCommonRepo comment_common.CommentCommonRepo,
 	reportHandle *report_handle.ReportHandle,
 	configService *config.ConfigService,
+	webhookQueueService webhook_queue.WebhookQueueService,
+	userRoleRelService *role.UserRoleRelService,
+	serviceConfig *service_config.ServiceConfig,
 ) *ReportService {
 	return &ReportService{
-		reportRepo:        reportRepo,
-		objectInfoService: objectInfoService,
-		commonUser:        commonUser,
-		answerRepo:        answerRepo,
-		questionRepo:      questionRepo,
-		commentCommonRepo: commentCommonRepo,
-		reportHandle:      reportHandle,
-		configService:     configService,
+		reportRepo:          reportRepo,
+		objectInfoService:   objectInfoService,
+		commonUser:          commonUser,
+		answerRepo:          answerRepo,
+		questionRepo:        questionRepo,
+		commentCommonRepo:   commentCommonRepo,
+		reportHandle:        reportHandle,
+		configService:       configService,
+		webhookQueueService: webhookQueueService,
+		userRoleRelService:  userRoleRelService,
+		serviceConfig:       serviceConfig,
 	}
 }
 
@@ -111,36 +127,117 @@ func (rs *ReportService) AddReport(ctx context.Context, req *schema.AddReportReq
 		ReportType:     req.ReportType,
 		Content:        req.Content,
 		Status:         entity.ReportStatusPending,
+		AssigneeUserID: "0",
+		HandledUserID:  "0",
+	}
+	// the new flag joins the pending case of the object, so it keeps the assignee of the case
+	pendingReports, err := rs.reportRepo.GetPendingReportsByObjectIDs(ctx, []string{req.ObjectID})
+	if err != nil {
+		return err
+	}
+	if len(pendingReports) > 0 {
+		report.AssigneeUserID = pendingReports[0].AssigneeUserID
 	}
-	return rs.reportRepo.AddReport(ctx, report)
+	if err = rs.reportRepo.AddReport(ctx, report); err != nil {
+		return err
+	}
+	rs.webhookQueueService.Send(ctx, &schema.WebhookEventMsg{
+		Event:         constant.WebhookEventReportCreated,
+		ObjectID:      req.ObjectID,
+		UserID:        objInfo.ObjectCreatorUserID,
+		TriggerUserID: req.UserID,
+		ExtraInfo:     map[string]string{"report_type": cf.Key, "content": req.Content},
+	})
+	return nil
 }
 
-// GetUnreviewedReportPostPage get unreviewed report post page
+// GetUnreviewedReportPostPage get unreviewed report post page, one case per page
 func (rs *ReportService) GetUnreviewedReportPostPage(ctx context.Context, req *schema.GetUnreviewedReportPostPageReq) (
 	pageModel *pager.PageModel, err error) {
 	if !req.IsAdmin {
 		return pager.NewPageModel(0, make([]*schema.GetReportListPageResp, 0)), nil
 	}
-	lang := handler.GetLangByCtx(ctx)
-	reports, total, err := rs.reportRepo.GetReportListPage(ctx, &schema.GetReportListPageDTO{
+	return rs.getReportCasePage(ctx, &schema.GetReportCasePageDTO{
 		Page:     req.Page,
 		PageSize: 1,
-		Status:   entity.ReportStatusPending,
+		Sort:     schema.ReportCaseSortNewest,
 	})
-	if err != nil {
-		return
+}
+
+// GetReportCasePage get the queue of the pending report cases
+func (rs *ReportService) GetReportCasePage(ctx context.Context, req *schema.GetReportCasePageReq) (
+	pageModel *pager.PageModel, err error) {
+	dto := &schema.GetReportCasePageDTO{
+		Page:     req.Page,
+		PageSize: req.PageSize,
+		Sort:     req.Sort,
+	}
+	switch req.Assignee {
+	case "":
+	case schema.ReportCaseAssigneeMe:
+		dto.AssigneeUserID = req.UserID
+	case schema.ReportCaseAssigneeUnassigned:
+		dto.AssigneeUserID = "0"
+	default:
+		dto.AssigneeUserID = req.Assignee
 	}
+	return rs.getReportCasePage(ctx, dto)
+}
 
-	resp := make([]*schema.GetReportListPageResp, 0)
+func (rs *ReportService) getReportCasePage(ctx context.Context, dto *schema.GetReportCasePageDTO) (
+	pageModel *pager.PageModel, err error) {
+	cases, total, err := rs.reportRepo.GetReportCasePage(ctx, dto)
+	if err != nil {
+		return nil, err
+	}
+	objectIDs := make([]string, 0, len(cases))
+	for _, c := range cases {
+		objectIDs = append(objectIDs, c.ObjectID)
+	}
+	reports := make([]*entity.Report, 0)
+	if len(objectIDs) > 0 {
+		reports, err = rs.reportRepo.GetPendingReportsByObjectIDs(ctx, objectIDs)
+		if err != nil {
+			return nil, err
+		}
+	}
+	caseReports := make(map[string][]*entity.Report)
+	userIDs := make([]string, 0)
 	for _, report := range reports {
-		info, err := rs.objectInfoService.GetUnreviewedRevisionInfo(ctx, report.ObjectID)
+		caseReports[report.ObjectID] = append(caseReports[report.ObjectID], report)
+		userIDs = append(userIDs, report.UserID, report.ReportedUserID)
+	}
+
+	infoMapping := make(map[string]*schema.UnreviewedRevisionInfoInfo)
+	for _, c := range cases {
+		info, err := rs.objectInfoService.GetUnreviewedRevisionInfo(ctx, c.ObjectID)
 		if err != nil {
 			log.Errorf("GetUnreviewedRevisionInfo failed, err: %v", err)
 			continue
 		}
+		infoMapping[c.ObjectID] = info
+		userIDs = append(userIDs, info.ObjectCreatorUserID, c.AssigneeUserID)
+	}
+	userMapping, err := rs.commonUser.BatchUserBasicInfoByID(ctx, userIDs)
+	if err != nil {
+		log.Errorf("get user basic info failed: %v", err)
+	}
+
+	lang := handler.GetLangByCtx(ctx)
+	sla := rs.reportSLA()
+	now := time.Now()
+	resp := make([]*schema.GetReportListPageResp, 0)
+	for _, c := range cases {
+		info, ok := infoMapping[c.ObjectID]
+		if !ok || len(caseReports[c.ObjectID]) == 0 {
+			continue
+		}
+		// reports are sorted by id, the first one is the oldest and the last one is the latest
+		flags := caseReports[c.ObjectID]
+		first, latest := flags[0], flags[len(flags)-1]
 
 		r := &schema.GetReportListPageResp{
-			FlagID:           report.ID,
+			FlagID:           latest.ID,
 			CreatedAt:        info.CreatedAt,
 			ObjectID:         info.ObjectID,
 			ObjectType:       info.ObjectType,
@@ -154,46 +251,67 @@ func (rs *ReportService) GetUnreviewedReportPostPage(ctx context.Context, req *s
 			AnswerCount:      info.AnswerCount,
 			AnswerAccepted:   info.AnswerAccepted,
 			Tags:             info.Tags,
-			SubmitAt:         report.CreatedAt.Unix(),
+			SubmitAt:         latest.CreatedAt.Unix(),
 			ObjectStatus:     info.Status,
 			ObjectShowStatus: info.ShowStatus,
-			ReasonContent:    report.Content,
-		}
-
-		// get user info
-		userInfo, exists, e := rs.commonUser.GetUserBasicInfoByID(ctx, info.ObjectCreatorUserID)
-		if e != nil {
-			log.Errorf("user not found by id: %s, err: %v", info.ObjectCreatorUserID, e)
+			ReasonContent:    latest.Content,
+			Reason:           rs.getReasonItem(ctx, latest.ReportType, lang),
+			ReportCount:      len(flags),
+			Flags:            make([]*schema.ReportCaseFlag, 0, len(flags)),
+			FirstReportedAt:  first.CreatedAt.Unix(),
+			AgeSeconds:       int64(now.Sub(first.CreatedAt).Seconds()),
+			SLADeadline:      first.CreatedAt.Add(sla).Unix(),
+			Overdue:          now.After(first.CreatedAt.Add(sla)),
 		}
-		if exists {
-			_ = copier.Copy(&r.AuthorUserInfo, userInfo)
+		if author, ok := userMapping[info.ObjectCreatorUserID]; ok {
+			r.AuthorUserInfo = *author
 		}
-
-		// get submitter info
-		submitter, exists, e := rs.commonUser.GetUserBasicInfoByID(ctx, report.ReportedUserID)
-		if e != nil {
-			log.Errorf("user not found by id: %s, err: %v", info.ObjectCreatorUserID, e)
+		if submitter, ok := userMapping[latest.UserID]; ok {
+			r.SubmitterUser = *submitter
 		}
-		if exists {
-			_ = copier.Copy(&r.SubmitterUser, submitter)
+		if assignee, ok := userMapping[c.AssigneeUserID]; ok {
+			r.AssigneeUser = assignee
 		}
-
-		if report.ReportType > 0 {
-			r.Reason = &schema.ReasonItem{ReasonType: report.ReportType}
-			cf, err := rs.configService.GetConfigByID(ctx, report.ReportType)
-			if err != nil {
-				log.Error(err)
-			} else {
-				_ = json.Unmarshal([]byte(cf.Value), r.Reason)
-				r.Reason.Translate(cf.Key, lang)
+		for _, report := range flags {
+			flag := &schema.ReportCaseFlag{
+				FlagID:        report.ID,
+				SubmitAt:      report.CreatedAt.Unix(),
+				Reason:        rs.getReasonItem(ctx, report.ReportType, lang),
+				ReasonContent: report.Content,
 			}
+			if submitter, ok := userMapping[report.UserID]; ok {
+				flag.SubmitterUser = *submitter
+			}
+			r.Flags = append(r.Flags, flag)
 		}
 		resp = append(resp, r)
 	}
 	return pager.NewPageModel(total, resp), nil
 }
 
-// ReviewReport review report
+func (rs *ReportService) getReasonItem(ctx context.Context, reportType int, lang i18n.Language) *schema.ReasonItem {
+	if reportType <= 0 {
+		return nil
+	}
+	item := &schema.ReasonItem{ReasonType: reportType}
+	cf, err := rs.configService.GetConfigByID(ctx, reportType)
+	if err != nil {
+		log.Error(err)
+		return item
+	}
+	_ = json.Unmarshal([]byte(cf.Value), item)
+	item.Translate(cf.Key, lang)
+	return item
+}
+
+func (rs *ReportService) reportSLA() time.Duration {
+	if rs.serviceConfig != nil && rs.serviceConfig.ReportSLAHours > 0 {
+		return time.Duration(rs.serviceConfig.ReportSLAHours) * time.Hour
+	}
+	return defaultReportSLA
+}
+
+// ReviewReport review report, all the pending reports of the same object are resolved together
 func (rs *ReportService) ReviewReport(ctx context.Context, req *schema.ReviewReportReq) (err error) {
 	report, exist, err := rs.reportRepo.GetByID(ctx, req.FlagID)
 	if err != nil {
@@ -209,12 +327,90 @@ func (rs *ReportService) ReviewReport(ctx context.Context, req *schema.ReviewRep
 
 	// ignore this report
 	if req.OperationType == constant.ReportOperationIgnoreReport {
-		return rs.reportRepo.UpdateStatus(ctx, report.ID, entity.ReportStatusIgnore)
+		_, err = rs.resolveReportCase(ctx, report, entity.ReportStatusIgnore, req.UserID, req.OperationType)
+		return err
+	}
+
+	if req.OperationType != constant.ReportOperationApproveReport {
+		if err = rs.reportHandle.UpdateReportedObject(ctx, report, req); err != nil {
+			return
+		}
 	}
+	_, err = rs.resolveReportCase(ctx, report, entity.ReportStatusCompleted, req.UserID, req.OperationType)
+	return err
+}
 
-	if err = rs.reportHandle.UpdateReportedObject(ctx, report, req); err != nil {
-		return
+// BulkReviewReportCase approve or ignore the report cases without changing the posts
+func (rs *ReportService) BulkReviewReportCase(ctx context.Context, req *schema.BulkReviewReportCaseReq) (
+	resp *schema.BulkReviewReportCaseResp, err error) {
+	status := entity.ReportStatusCompleted
+	if req.OperationType == constant.ReportOperationIgnoreReport {
+		status = entity.ReportStatusIgnore
+	}
+	reports, err := rs.reportRepo.GetPendingReportsByObjectIDs(ctx, req.ObjectIDs)
+	if err != nil {
+		return nil, err
+	}
+	latestReports := make(map[string]*entity.Report)
+	for _, report := range reports {
+		latestReports[report.ObjectID] = report
 	}
 
-	return rs.reportRepo.UpdateStatus(ctx, report.ID, entity.ReportStatusCompleted)
+	resp = &schema.BulkReviewReportCaseResp{}
+	for _, report := range latestReports {
+		handled, err := rs.resolveReportCase(ctx, report, status, req.UserID, req.OperationType)
+		if err != nil {
+			return nil, err
+		}
+		if handled {
+			resp.HandledCount++
+		}
+	}
+	return resp, nil
+}
+
+// AssignReportCase assign the pending report case to an admin or moderator
+func (rs *ReportService) AssignReportCase(ctx context.Context, req *schema.AssignReportCaseReq) (err error) {
+	assigneeUserID := "0"
+	if len(req.AssigneeUserID) > 0 {
+		roleID, err := rs.userRoleRelService.GetUserRole(ctx, req.AssigneeUserID)
+		if err != nil {
+			return err
+		}
+		if roleID != role.RoleAdminID && roleID != role.RoleModeratorID {
+			return errors.BadRequest(reason.ReportAssigneeInvalid)
+		}
+		assigneeUserID = req.AssigneeUserID
+	}
+
+	pendingReports, err := rs.reportRepo.GetPendingReportsByObjectIDs(ctx, []string{req.ObjectID})
+	if err != nil {
+		return err
+	}
+	if len(pendingReports) == 0 {
+		return errors.NotFound(reason.ReportNotFound)
+	}
+	return rs.reportRepo.UpdatePendingAssignee(ctx, req.ObjectID, assigneeUserID)
+}
+
+// resolveReportCase resolve all the pending reports of the reported object,
+// handled is false if the case has been resolved by others
+func (rs *ReportService) resolveReportCase(ctx context.Context, report *entity.Report, status int,
+	operatorID, operationType string) (handled bool, err error) {
+	affected, err := rs.reportRepo.UpdatePendingStatus(ctx, report.ObjectID, status, operatorID)
+	if err != nil || affected == 0 {
+		return false, err
+	}
+	event := constant.WebhookEventReportHandled
+	if status == entity.ReportStatusIgnore {
+		event = constant.WebhookEventReportIgnored
+	}
+	rs.webhookQueueService.Send(ctx, &schema.WebhookEventMsg{
+		Event:         event,
+		ObjectID:      report.ObjectID,
+		UserID:        report.ReportedUserID,
+		TriggerUserID: operatorID,
+		ExtraInfo:     map[string]string{"report_id": report.ID, "operation_type": operationType},
+	})
+	return true, nil
 }
@@ -0,0 +1,301 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package report
+
+import (
+	"context"
+	"sort"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/apache/incubator-answer/internal/base/constant"
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/apache/incubator-answer/internal/service/config"
+	"github.com/apache/incubator-answer/internal/service/mock"
+	"github.com/apache/incubator-answer/internal/service/object_info"
+	"github.com/apache/incubator-answer/internal/service/role"
+	usercommon "github.com/apache/incubator-answer/internal/service/user_common"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	testCommentID      = "10070000000000001"
+	testOtherCommentID = "10070000000000002"
+)
+
+// testReportEnv keeps the reports and the webhook events of the mocked repositories in memory
+type testReportEnv struct {
+	reports []*entity.Report
+	events  []constant.ActivityTypeKey
+}
+
+func (e *testReportEnv) addReport(ctx context.Context, report *entity.Report) error {
+	report.ID = strconv.Itoa(len(e.reports) + 1)
+	report.CreatedAt = time.Now().Add(time.Duration(len(e.reports)) * time.Second)
+	e.reports = append(e.reports, report)
+	return nil
+}
+
+func (e *testReportEnv) getByID(ctx context.Context, id string) (*entity.Report, bool, error) {
+	for _, report := range e.reports {
+		if report.ID == id {
+			return report, true, nil
+		}
+	}
+	return nil, false, nil
+}
+
+func (e *testReportEnv) getReportCasePage(ctx context.Context, query *schema.GetReportCasePageDTO) (
+	cases []*entity.ReportCaseStat, total int64, err error) {
+	caseMapping := make(map[string]*entity.ReportCaseStat)
+	for _, report := range e.reports {
+		if report.Status != entity.ReportStatusPending {
+			continue
+		}
+		if len(query.AssigneeUserID) > 0 && report.AssigneeUserID != query.AssigneeUserID {
+			continue
+		}
+		c, ok := caseMapping[report.ObjectID]
+		if !ok {
+			c = &entity.ReportCaseStat{ObjectID: report.ObjectID, FirstReportID: report.ID,
+				AssigneeUserID: report.AssigneeUserID}
+			caseMapping[report.ObjectID] = c
+			cases = append(cases, c)
+		}
+		c.ReportCount++
+		c.LastReportID = report.ID
+	}
+	return cases, int64(len(cases)), nil
+}
+
+func (e *testReportEnv) getPendingReportsByObjectIDs(ctx context.Context, objectIDs []string) (
+	reports []*entity.Report, err error) {
+	for _, report := range e.reports {
+		for _, objectID := range objectIDs {
+			if report.ObjectID == objectID && report.Status == entity.ReportStatusPending {
+				reports = append(reports, report)
+			}
+		}
+	}
+	sort.Slice(reports, func(i, j int) bool { return reports[i].ID < reports[j].ID })
+	return reports, nil
+}
+
+func (e *testReportEnv) updatePendingAssignee(ctx context.Context, objectID, assigneeUserID string) error {
+	for _, report := range e.reports {
+		if report.ObjectID == objectID && report.Status == entity.ReportStatusPending {
+			report.AssigneeUserID = assigneeUserID
+		}
+	}
+	return nil
+}
+
+func (e *testReportEnv) updatePendingStatus(ctx context.Context, objectID string, status int, handledUserID string) (
+	affected int64, err error) {
+	for _, report := range e.reports {
+		if report.ObjectID == objectID && report.Status == entity.ReportStatusPending {
+			report.Status = status
+			report.HandledUserID = handledUserID
+			affected++
+		}
+	}
+	return affected, nil
+}
+
+func newTestReportService(t *testing.T) (*ReportService, *testReportEnv) {
+	ctl := gomock.NewController(t)
+	env := &testReportEnv{}
+
+	reportRepo := mock.NewMockReportRepo(ctl)
+	reportRepo.EXPECT().AddReport(gomock.Any(), gomock.Any()).DoAndReturn(env.addReport).AnyTimes()
+	reportRepo.EXPECT().GetByID(gomock.Any(), gomock.Any()).DoAndReturn(env.getByID).AnyTimes()
+	reportRepo.EXPECT().GetReportCasePage(gomock.Any(), gomock.Any()).DoAndReturn(env.getReportCasePage).AnyTimes()
+	reportRepo.EXPECT().GetPendingReportsByObjectIDs(gomock.Any(), gomock.Any()).
+		DoAndReturn(env.getPendingReportsByObjectIDs).AnyTimes()
+	reportRepo.EXPECT().UpdatePendingAssignee(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(env.updatePendingAssignee).AnyTimes()
+	reportRepo.EXPECT().UpdatePendingStatus(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(env.updatePendingStatus).AnyTimes()
+
+	getComment := func(ctx context.Context, commentID string) (*entity.Comment, bool, error) {
+		return &entity.Comment{ID: commentID, UserID: "9", Status: entity.CommentStatusAvailable}, true, nil
+	}
+	commentRepo := mock.NewMockCommentCommonRepo(ctl)
+	commentRepo.EXPECT().GetComment(gomock.Any(), gomock.Any()).DoAndReturn(getComment).AnyTimes()
+	commentRepo.EXPECT().GetCommentWithoutStatus(gomock.Any(), gomock.Any()).DoAndReturn(getComment).AnyTimes()
+
+	configRepo := mock.NewMockConfigRepo(ctl)
+	configRepo.EXPECT().GetConfigByID(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, id int) (*entity.Config, error) {
+			return &entity.Config{ID: id, Key: "reason.spam", Value: `{"name":"spam"}`}, nil
+		}).AnyTimes()
+
+	userRepo := mock.NewMockUserRepo(ctl)
+	userRepo.EXPECT().BatchGetByID(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, ids []string) ([]*entity.User, error) {
+			users := make([]*entity.User, 0)
+			for _, id := range ids {
+				if len(id) > 0 && id != "0" {
+					users = append(users, &entity.User{ID: id, Username: "user" + id})
+				}
+			}
+			return users, nil
+		}).AnyTimes()
+
+	siteInfoService := mock.NewMockSiteInfoCommonService(ctl)
+	siteInfoService.EXPECT().FormatListAvatar(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, userList []*entity.User) map[string]*schema.AvatarInfo {
+			avatarMapping := make(map[string]*schema.AvatarInfo)
+			for _, user := range userList {
+				avatarMapping[user.ID] = &schema.AvatarInfo{}
+			}
+			return avatarMapping
+		}).AnyTimes()
+
+	roles := map[string]int{"1": role.RoleAdminID, "2": role.RoleModeratorID, "3": role.RoleUserID}
+	userRoleRelRepo := mock.NewMockUserRoleRelRepo(ctl)
+	userRoleRelRepo.EXPECT().GetUserRoleRel(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, userID string) (*entity.UserRoleRel, bool, error) {
+			roleID, ok := roles[userID]
+			return &entity.UserRoleRel{UserID: userID, RoleID: roleID}, ok, nil
+		}).AnyTimes()
+
+	webhookQueue := mock.NewMockWebhookQueueService(ctl)
+	webhookQueue.EXPECT().Send(gomock.Any(), gomock.Any()).Do(
+		func(ctx context.Context, msg *schema.WebhookEventMsg) {
+			env.events = append(env.events, msg.Event)
+		}).AnyTimes()
+
+	userRoleRelService := role.NewUserRoleRelService(userRoleRelRepo, nil)
+	rs := NewReportService(
+		reportRepo,
+		object_info.NewObjService(nil, nil, commentRepo, nil, nil),
+		usercommon.NewUserCommon(userRepo, userRoleRelService, nil, siteInfoService),
+		nil, nil, nil, nil,
+		config.NewConfigService(configRepo),
+		webhookQueue,
+		userRoleRelService,
+		nil,
+	)
+	return rs, env
+}
+
+func TestReportService_GroupFlagsIntoCase(t *testing.T) {
+	ctx := context.TODO()
+	rs, env := newTestReportService(t)
+
+	assert.NoError(t, rs.AddReport(ctx, &schema.AddReportReq{ObjectID: testCommentID, ReportType: 1, UserID: "4"}))
+	assert.NoError(t, rs.AssignReportCase(ctx, &schema.AssignReportCaseReq{ObjectID: testCommentID, AssigneeUserID: "2"}))
+	assert.NoError(t, rs.AddReport(ctx, &schema.AddReportReq{ObjectID: testCommentID, ReportType: 1, UserID: "5"}))
+	assert.NoError(t, rs.AddReport(ctx, &schema.AddReportReq{ObjectID: testOtherCommentID, ReportType: 1, UserID: "4"}))
+
+	// the new flag joins the pending case and keeps its assignee
+	assert.Equal(t, "2", env.reports[1].AssigneeUserID)
+	assert.Equal(t, "9", env.reports[1].ReportedUserID)
+
+	pageModel, err := rs.GetReportCasePage(ctx, &schema.GetReportCasePageReq{Page: 1, PageSize: 10})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), pageModel.Count)
+	cases := pageModel.List.([]*schema.GetReportListPageResp)
+	assert.Len(t, cases, 2)
+	assert.Equal(t, testCommentID, cases[0].ObjectID)
+	assert.Equal(t, 2, cases[0].ReportCount)
+	assert.Len(t, cases[0].Flags, 2)
+	assert.Equal(t, "2", cases[0].FlagID)
+	assert.Equal(t, env.reports[0].CreatedAt.Unix(), cases[0].FirstReportedAt)
+	assert.Equal(t, "user2", cases[0].AssigneeUser.Username)
+	// the case submitter is the reporter of the latest flag, not the reported user
+	assert.Equal(t, "user5", cases[0].SubmitterUser.Username)
+	assert.Equal(t, "user9", cases[0].AuthorUserInfo.Username)
+	assert.Equal(t, 1, cases[1].ReportCount)
+	assert.Nil(t, cases[1].AssigneeUser)
+}
+
+func TestReportService_AssignReportCase(t *testing.T) {
+	ctx := context.TODO()
+	rs, env := newTestReportService(t)
+	assert.NoError(t, rs.AddReport(ctx, &schema.AddReportReq{ObjectID: testCommentID, ReportType: 1, UserID: "4"}))
+	assert.NoError(t, rs.AddReport(ctx, &schema.AddReportReq{ObjectID: testCommentID, ReportType: 1, UserID: "5"}))
+
+	// only admins and moderators can be assigned
+	err := rs.AssignReportCase(ctx, &schema.AssignReportCaseReq{ObjectID: testCommentID, AssigneeUserID: "3"})
+	assert.Error(t, err)
+	err = rs.AssignReportCase(ctx, &schema.AssignReportCaseReq{ObjectID: testOtherCommentID, AssigneeUserID: "1"})
+	assert.Error(t, err)
+
+	assert.NoError(t, rs.AssignReportCase(ctx, &schema.AssignReportCaseReq{ObjectID: testCommentID, AssigneeUserID: "1"}))
+	for _, report := range env.reports {
+		assert.Equal(t, "1", report.AssigneeUserID)
+	}
+	pageModel, err := rs.GetReportCasePage(ctx, &schema.GetReportCasePageReq{Assignee: schema.ReportCaseAssigneeMe, UserID: "1"})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), pageModel.Count)
+
+	// unassign
+	assert.NoError(t, rs.AssignReportCase(ctx, &schema.AssignReportCaseReq{ObjectID: testCommentID}))
+	for _, report := range env.reports {
+		assert.Equal(t, "0", report.AssigneeUserID)
+	}
+	pageModel, err = rs.GetReportCasePage(ctx, &schema.GetReportCasePageReq{Assignee: schema.ReportCaseAssigneeUnassigned})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), pageModel.Count)
+}
+
+func TestReportService_BulkReviewReportCase(t *testing.T) {
+	ctx := context.TODO()
+	rs, env := newTestReportService(t)
+	assert.NoError(t, rs.AddReport(ctx, &schema.AddReportReq{ObjectID: testCommentID, ReportType: 1, UserID: "4"}))
+	assert.NoError(t, rs.AddReport(ctx, &schema.AddReportReq{ObjectID: testCommentID, ReportType: 1, UserID: "5"}))
+	assert.NoError(t, rs.AddReport(ctx, &schema.AddReportReq{ObjectID: testOtherCommentID, ReportType: 1, UserID: "4"}))
+
+	// the case of the other comment has been handled by others
+	assert.NoError(t, rs.ReviewReport(ctx, &schema.ReviewReportReq{
+		FlagID: "3", OperationType: constant.ReportOperationApproveReport, UserID: "2"}))
+	assert.Equal(t, entity.ReportStatusCompleted, env.reports[2].Status)
+
+	resp, err := rs.BulkReviewReportCase(ctx, &schema.BulkReviewReportCaseReq{
+		ObjectIDs:     []string{testCommentID, testOtherCommentID},
+		OperationType: constant.ReportOperationIgnoreReport,
+		UserID:        "1",
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, resp.HandledCount)
+	assert.Equal(t, entity.ReportStatusIgnore, env.reports[0].Status)
+	assert.Equal(t, entity.ReportStatusIgnore, env.reports[1].Status)
+	assert.Equal(t, "1", env.reports[1].HandledUserID)
+	assert.Equal(t, entity.ReportStatusCompleted, env.reports[2].Status)
+	assert.Equal(t, "2", env.reports[2].HandledUserID)
+
+	// all handled, nothing to do
+	resp, err = rs.BulkReviewReportCase(ctx, &schema.BulkReviewReportCaseReq{
+		ObjectIDs:     []string{testCommentID},
+		OperationType: constant.ReportOperationApproveReport,
+		UserID:        "1",
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, 0, resp.HandledCount)
+	assert.Equal(t, []constant.ActivityTypeKey{
+		constant.WebhookEventReportCreated, constant.WebhookEventReportCreated, constant.WebhookEventReportCreated,
+		constant.WebhookEventReportHandled, constant.WebhookEventReportIgnored,
+	}, env.events)
+}
@@ -21,17 +21,23 @@ package report_common
 
 import (
 	"context"
+	"time"
 
 	"github.com/apache/incubator-answer/internal/entity"
 	"github.com/apache/incubator-answer/internal/schema"
 )
 
+//go:generate mockgen -source=./report_common.go -destination=../mock/report_repo_mock.go -package=mock
+
 // ReportRepo report repository
 type ReportRepo interface {
 	AddReport(ctx context.Context, report *entity.Report) (err error)
-	GetReportListPage(ctx context.Context, query *schema.GetReportListPageDTO) (
-		reports []*entity.Report, total int64, err error)
 	GetByID(ctx context.Context, id string) (report *entity.Report, exist bool, err error)
-	UpdateStatus(ctx context.Context, id string, status int) (err error)
+	GetReportCasePage(ctx context.Context, query *schema.GetReportCasePageDTO) (
+		cases []*entity.ReportCaseStat, total int64, err error)
+	GetPendingReportsByObjectIDs(ctx context.Context, objectIDs []string) (reports []*entity.Report, err error)
+	UpdatePendingAssignee(ctx context.Context, objectID, assigneeUserID string) (err error)
+	UpdatePendingStatus(ctx context.Context, objectID string, status int, handledUserID string) (affected int64, err error)
+	GetHandledReports(ctx context.Context, startTime time.Time) (reports []*entity.Report, err error)
 	GetReportCount(ctx context.Context) (count int64, err error)
 }
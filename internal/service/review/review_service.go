@@ -35,6 +35,7 @@ import (
 	"github.com/apache/incubator-answer/internal/service/siteinfo_common"
 	tagcommon "github.com/apache/incubator-answer/internal/service/tag_common"
 	usercommon "github.com/apache/incubator-answer/internal/service/user_common"
+	"github.com/apache/incubator-answer/internal/service/webhook_queue"
 	"github.com/apache/incubator-answer/pkg/htmltext"
 	"github.com/apache/incubator-answer/pkg/token"
 	"github.com/apache/incubator-answer/pkg/uid"
@@ -67,6 +68,7 @@ type ReviewService struct {
 	externalNotificationQueueService notice_queue.ExternalNotificationQueueService
 	notificationQueueService         notice_queue.NotificationQueueService
 	siteInfoService                  siteinfo_common.SiteInfoCommonService
+	webhookQueueService              webhook_queue.WebhookQueueService
 }
 
 // NewReviewService new review service
@@ -83,6 +85,7 @@ func NewReviewService(
 	questionCommon *questioncommon.QuestionCommon,
 	notificationQueueService notice_queue.NotificationQueueService,
 	siteInfoService siteinfo_common.SiteInfoCommonService,
+	webhookQueueService webhook_queue.WebhookQueueService,
 ) *ReviewService {
 	return &ReviewService{
 		reviewRepo:                       reviewRepo,
@@ -97,6 +100,7 @@ func NewReviewService(
 		questionCommon:                   questionCommon,
 		notificationQueueService:         notificationQueueService,
 		siteInfoService:                  siteInfoService,
+		webhookQueueService:              webhookQueueService,
 	}
 }
 
@@ -205,6 +209,12 @@ func (cs *ReviewService) callPluginToReview(ctx context.Context, userID, objectI
 		if err := cs.reviewRepo.AddReview(ctx, r); err != nil {
 			log.Errorf("add review failed, err: %v", err)
 		}
+		cs.webhookQueueService.Send(ctx, &schema.WebhookEventMsg{
+			Event:     constant.WebhookEventReviewPending,
+			ObjectID:  objectID,
+			UserID:    userID,
+			ExtraInfo: map[string]string{"submitter": r.Submitter, "reason": r.Reason},
+		})
 	}
 	return reviewStatus
 }
@@ -226,12 +236,23 @@ func (cs *ReviewService) UpdateReview(ctx context.Context, req *schema.UpdateRev
 		return err
 	}
 
+	event := constant.WebhookEventReviewApproved
 	if req.IsApprove() {
 		err = cs.reviewRepo.UpdateReviewStatus(ctx, req.ReviewID, req.UserID, entity.ReviewStatusApproved)
 	} else {
+		event = constant.WebhookEventReviewRejected
 		err = cs.reviewRepo.UpdateReviewStatus(ctx, req.ReviewID, req.UserID, entity.ReviewStatusRejected)
 	}
-	return
+	if err != nil {
+		return err
+	}
+	cs.webhookQueueService.Send(ctx, &schema.WebhookEventMsg{
+		Event:         event,
+		ObjectID:      review.ObjectID,
+		UserID:        review.UserID,
+		TriggerUserID: req.UserID,
+	})
+	return nil
 }
 
 // update object status
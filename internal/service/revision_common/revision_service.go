@@ -23,6 +23,7 @@ import (
 	"context"
 
 	"github.com/apache/incubator-answer/internal/base/reason"
+	"github.com/apache/incubator-answer/internal/service/attachment"
 	"github.com/apache/incubator-answer/internal/service/revision"
 	usercommon "github.com/apache/incubator-answer/internal/service/user_common"
 	"github.com/apache/incubator-answer/pkg/uid"
@@ -36,16 +37,19 @@ import (
 
 // RevisionService user service
 type RevisionService struct {
-	revisionRepo revision.RevisionRepo
-	userRepo     usercommon.UserRepo
+	revisionRepo      revision.RevisionRepo
+	userRepo          usercommon.UserRepo
+	attachmentService *attachment.AttachmentService
 }
 
 func NewRevisionService(revisionRepo revision.RevisionRepo,
 	userRepo usercommon.UserRepo,
+	attachmentService *attachment.AttachmentService,
 ) *RevisionService {
 	return &RevisionService{
-		revisionRepo: revisionRepo,
-		userRepo:     userRepo,
+		revisionRepo:      revisionRepo,
+		userRepo:          userRepo,
+		attachmentService: attachmentService,
 	}
 }
 
@@ -70,6 +74,7 @@ func (rs *RevisionService) AddRevision(ctx context.Context, req *schema.AddRevis
 	if err != nil {
 		return "", err
 	}
+	rs.attachmentService.LinkAttachments(ctx, req.ObjectID, req.Content)
 	return rev.ID, nil
 }
 
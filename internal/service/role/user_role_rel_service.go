@@ -25,6 +25,8 @@ import (
 	"github.com/apache/incubator-answer/internal/entity"
 )
 
+//go:generate mockgen -source=./user_role_rel_service.go -destination=../mock/user_role_rel_repo_mock.go -package=mock
+
 // UserRoleRelRepo userRoleRel repository
 type UserRoleRelRepo interface {
 	SaveUserRoleRel(ctx context.Context, userID string, roleID int) (err error)
@@ -126,16 +126,17 @@ func (sp *SearchParser) parseTags(ctx context.Context, query *string) (tags [][]
 		if err != nil || !exists {
 			continue
 		}
-		tagGroup = append(tagGroup, tag.ID)
+		// the tag covers its synonyms and all the descendants of the main tag
+		mainTagID := tag.ID
 		if tag.MainTagID > 0 {
-			tagGroup = append(tagGroup, fmt.Sprintf("%d", tag.MainTagID))
+			mainTagID = fmt.Sprintf("%d", tag.MainTagID)
 		}
-		synIDs, err := sp.tagCommonService.GetTagIDsByMainTagID(ctx, tag.ID)
-		if err != nil || !exists {
+		tagIDs, err := sp.tagCommonService.GetTagIDsWithDescendants(ctx, mainTagID)
+		if err != nil {
 			continue
 		}
 		tagGroup = append(tagGroup, tag.ID)
-		tagGroup = append(tagGroup, synIDs...)
+		tagGroup = append(tagGroup, tagIDs...)
 		tagGroup = converter.UniqueArray(tagGroup)
 		tags = append(tags, tagGroup)
 	}
@@ -0,0 +1,319 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+package search_sync
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/apache/incubator-answer/internal/base/constant"
+	"github.com/apache/incubator-answer/internal/base/reason"
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/apache/incubator-answer/plugin"
+	"github.com/segmentfault/pacman/errors"
+	"github.com/segmentfault/pacman/log"
+)
+
+const defaultPageSize = 100
+
+// SearchSyncRepo read the contents that need to be synced to search plugin
+type SearchSyncRepo interface {
+	// CountQuestions count questions updated after since, zero since means all
+	CountQuestions(ctx context.Context, since time.Time) (count int64, err error)
+	CountAnswers(ctx context.Context, since time.Time) (count int64, err error)
+	GetQuestionsPageSince(ctx context.Context, since time.Time, page, pageSize int) (
+		questionList []*plugin.SearchContent, err error)
+	GetAnswersPageSince(ctx context.Context, since time.Time, page, pageSize int) (
+		answerList []*plugin.SearchContent, err error)
+}
+
+// SearchSyncCheckpointRepo search sync checkpoint repository
+type SearchSyncCheckpointRepo interface {
+	GetCheckpoint(ctx context.Context, pluginSlugName string) (
+		checkpoint *entity.SearchSyncCheckpoint, exist bool, err error)
+	SaveCheckpoint(ctx context.Context, checkpoint *entity.SearchSyncCheckpoint) (err error)
+}
+
+// SearchSyncService rebuild the index of the active search plugin
+type SearchSyncService struct {
+	searchSyncRepo SearchSyncRepo
+	checkpointRepo SearchSyncCheckpointRepo
+	lock           sync.Mutex
+	progress       *schema.SearchReindexProgress
+}
+
+// NewSearchSyncService new search sync service
+func NewSearchSyncService(
+	searchSyncRepo SearchSyncRepo,
+	checkpointRepo SearchSyncCheckpointRepo,
+) *SearchSyncService {
+	return &SearchSyncService{
+		searchSyncRepo: searchSyncRepo,
+		checkpointRepo: checkpointRepo,
+	}
+}
+
+// StartReindex start rebuilding index in background, dry run is done synchronously
+func (ss *SearchSyncService) StartReindex(ctx context.Context, req *schema.SearchReindexReq) (
+	resp *schema.SearchReindexProgress, err error) {
+	if req.DryRun {
+		return ss.Reindex(ctx, req, nil)
+	}
+	if progress := ss.GetProgress(); progress != nil && progress.Running {
+		return nil, errors.BadRequest(reason.SearchReindexIsRunning)
+	}
+	if _, err = getSearchPlugin(); err != nil {
+		return nil, err
+	}
+	go func() {
+		if _, err := ss.Reindex(context.Background(), req, nil); err != nil {
+			log.Errorf("rebuild search index failed: %v", err)
+		}
+	}()
+	return &schema.SearchReindexProgress{Running: true}, nil
+}
+
+// GetReindexStatus get the progress of the running rebuild, or the checkpoint of the last one
+func (ss *SearchSyncService) GetReindexStatus(ctx context.Context) (resp *schema.SearchReindexProgress, err error) {
+	if progress := ss.GetProgress(); progress != nil {
+		return progress, nil
+	}
+	search, err := getSearchPlugin()
+	if err != nil {
+		return nil, err
+	}
+	checkpoint, exist, err := ss.checkpointRepo.GetCheckpoint(ctx, search.Info().SlugName)
+	if err != nil {
+		return nil, err
+	}
+	resp = &schema.SearchReindexProgress{PluginSlugName: search.Info().SlugName}
+	if exist {
+		fillProgress(resp, checkpoint)
+	}
+	return resp, nil
+}
+
+// GetProgress get a copy of the progress of current process
+func (ss *SearchSyncService) GetProgress() *schema.SearchReindexProgress {
+	ss.lock.Lock()
+	defer ss.lock.Unlock()
+	if ss.progress == nil {
+		return nil
+	}
+	progress := *ss.progress
+	return &progress
+}
+
+// Reindex sync all questions and answers to the active search plugin. The checkpoint is saved after every page,
+// so an unfinished run can be resumed. The report will be called after every page if it is not nil.
+func (ss *SearchSyncService) Reindex(ctx context.Context, req *schema.SearchReindexReq,
+	report func(progress *schema.SearchReindexProgress)) (resp *schema.SearchReindexProgress, err error) {
+	search, err := getSearchPlugin()
+	if err != nil {
+		return nil, err
+	}
+	pageSize := req.PageSize
+	if pageSize <= 0 {
+		pageSize = defaultPageSize
+	}
+
+	checkpoint, err := ss.prepareCheckpoint(ctx, search.Info().SlugName, req)
+	if err != nil {
+		return nil, err
+	}
+	progress := &schema.SearchReindexProgress{DryRun: req.DryRun, Resumed: checkpoint.Page > 0 ||
+		checkpoint.ObjectType == constant.AnswerObjectType}
+	fillProgress(progress, checkpoint)
+	progress.QuestionTotal, err = ss.searchSyncRepo.CountQuestions(ctx, checkpoint.Since)
+	if err != nil {
+		return nil, err
+	}
+	progress.AnswerTotal, err = ss.searchSyncRepo.CountAnswers(ctx, checkpoint.Since)
+	if err != nil {
+		return nil, err
+	}
+	if req.DryRun {
+		return progress, nil
+	}
+
+	if !ss.start(progress) {
+		return nil, errors.BadRequest(reason.SearchReindexIsRunning)
+	}
+	defer ss.finish()
+
+	err = ss.sync(ctx, search, checkpoint, pageSize, func() {
+		fillProgress(progress, checkpoint)
+		ss.setProgress(progress)
+		if report != nil {
+			report(progress)
+		}
+	})
+	if err != nil {
+		progress.Error = err.Error()
+		ss.setProgress(progress)
+		return progress, err
+	}
+
+	checkpoint.FinishedAt = time.Now()
+	checkpoint.LastSyncedAt = checkpoint.StartedAt
+	if err = ss.checkpointRepo.SaveCheckpoint(ctx, checkpoint); err != nil {
+		return progress, err
+	}
+	fillProgress(progress, checkpoint)
+	progress.Running = false
+	ss.setProgress(progress)
+	return progress, nil
+}
+
+// prepareCheckpoint resume the unfinished run in the same mode, or start a new one
+func (ss *SearchSyncService) prepareCheckpoint(ctx context.Context, pluginSlugName string,
+	req *schema.SearchReindexReq) (checkpoint *entity.SearchSyncCheckpoint, err error) {
+	mode := entity.SearchSyncModeFull
+	if req.Incremental {
+		mode = entity.SearchSyncModeIncremental
+	}
+	checkpoint, exist, err := ss.checkpointRepo.GetCheckpoint(ctx, pluginSlugName)
+	if err != nil {
+		return nil, err
+	}
+	if !exist {
+		checkpoint = &entity.SearchSyncCheckpoint{PluginSlugName: pluginSlugName}
+	}
+	unfinished := exist && !checkpoint.StartedAt.IsZero() && checkpoint.FinishedAt.IsZero()
+	if unfinished && checkpoint.Mode == mode && !req.Restart {
+		return checkpoint, nil
+	}
+
+	checkpoint.Mode = mode
+	checkpoint.ObjectType = constant.QuestionObjectType
+	checkpoint.Page = 0
+	checkpoint.Synced = 0
+	checkpoint.Since = time.Time{}
+	if mode == entity.SearchSyncModeIncremental {
+		checkpoint.Since = checkpoint.LastSyncedAt
+	}
+	checkpoint.StartedAt = time.Now()
+	checkpoint.FinishedAt = time.Time{}
+	return checkpoint, nil
+}
+
+func (ss *SearchSyncService) sync(ctx context.Context, search plugin.Search,
+	checkpoint *entity.SearchSyncCheckpoint, pageSize int, report func()) (err error) {
+	for {
+		page := checkpoint.Page + 1
+		var contents []*plugin.SearchContent
+		if checkpoint.ObjectType == constant.QuestionObjectType {
+			contents, err = ss.searchSyncRepo.GetQuestionsPageSince(ctx, checkpoint.Since, page, pageSize)
+		} else {
+			contents, err = ss.searchSyncRepo.GetAnswersPageSince(ctx, checkpoint.Since, page, pageSize)
+		}
+		if err != nil {
+			return err
+		}
+		if len(contents) == 0 {
+			if checkpoint.ObjectType == constant.AnswerObjectType {
+				return nil
+			}
+			checkpoint.ObjectType = constant.AnswerObjectType
+			checkpoint.Page = 0
+			continue
+		}
+
+		for _, content := range contents {
+			if content.Status == plugin.SearchContentStatusDeleted {
+				err = search.DeleteContent(ctx, content.ObjectID)
+			} else {
+				err = search.UpdateContent(ctx, content)
+			}
+			if err != nil {
+				return err
+			}
+		}
+		checkpoint.Page = page
+		checkpoint.Synced += int64(len(contents))
+		if err = ss.checkpointRepo.SaveCheckpoint(ctx, checkpoint); err != nil {
+			return err
+		}
+		report()
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+		}
+	}
+}
+
+func (ss *SearchSyncService) start(progress *schema.SearchReindexProgress) bool {
+	ss.lock.Lock()
+	defer ss.lock.Unlock()
+	if ss.progress != nil && ss.progress.Running {
+		return false
+	}
+	progress.Running = true
+	p := *progress
+	ss.progress = &p
+	return true
+}
+
+func (ss *SearchSyncService) finish() {
+	ss.lock.Lock()
+	defer ss.lock.Unlock()
+	if ss.progress != nil {
+		ss.progress.Running = false
+	}
+}
+
+func (ss *SearchSyncService) setProgress(progress *schema.SearchReindexProgress) {
+	ss.lock.Lock()
+	defer ss.lock.Unlock()
+	p := *progress
+	ss.progress = &p
+}
+
+func getSearchPlugin() (search plugin.Search, err error) {
+	_ = plugin.CallSearch(func(fn plugin.Search) error {
+		search = fn
+		return nil
+	})
+	if search == nil {
+		return nil, errors.BadRequest(reason.SearchPluginNotFound)
+	}
+	return search, nil
+}
+
+func fillProgress(progress *schema.SearchReindexProgress, checkpoint *entity.SearchSyncCheckpoint) {
+	progress.PluginSlugName = checkpoint.PluginSlugName
+	progress.Mode = checkpoint.Mode
+	progress.ObjectType = checkpoint.ObjectType
+	progress.Page = checkpoint.Page
+	progress.Synced = checkpoint.Synced
+	progress.Since, progress.StartedAt, progress.FinishedAt = 0, 0, 0
+	if !checkpoint.Since.IsZero() {
+		progress.Since = checkpoint.Since.Unix()
+	}
+	if !checkpoint.StartedAt.IsZero() {
+		progress.StartedAt = checkpoint.StartedAt.Unix()
+	}
+	if !checkpoint.FinishedAt.IsZero() {
+		progress.FinishedAt = checkpoint.FinishedAt.Unix()
+	}
+}
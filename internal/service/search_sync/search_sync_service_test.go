@@ -0,0 +1,156 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package search_sync
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/apache/incubator-answer/plugin"
+	"github.com/stretchr/testify/assert"
+)
+
+type mockSearch struct {
+	updated map[string]bool
+	deleted map[string]bool
+	// failAt fail when the content is updated, used to interrupt the reindex
+	failAt string
+}
+
+func (m *mockSearch) Info() plugin.Info {
+	return plugin.Info{SlugName: "mock_search"}
+}
+func (m *mockSearch) Description() plugin.SearchDesc                            { return plugin.SearchDesc{} }
+func (m *mockSearch) RegisterSyncer(ctx context.Context, _ plugin.SearchSyncer) {}
+func (m *mockSearch) SearchContents(ctx context.Context, cond *plugin.SearchBasicCond) (
+	[]plugin.SearchResult, int64, error) {
+	return nil, 0, nil
+}
+func (m *mockSearch) SearchQuestions(ctx context.Context, cond *plugin.SearchBasicCond) (
+	[]plugin.SearchResult, int64, error) {
+	return nil, 0, nil
+}
+func (m *mockSearch) SearchAnswers(ctx context.Context, cond *plugin.SearchBasicCond) (
+	[]plugin.SearchResult, int64, error) {
+	return nil, 0, nil
+}
+func (m *mockSearch) UpdateContent(ctx context.Context, content *plugin.SearchContent) error {
+	if content.ObjectID == m.failAt {
+		return fmt.Errorf("engine is down")
+	}
+	m.updated[content.ObjectID] = true
+	return nil
+}
+func (m *mockSearch) DeleteContent(ctx context.Context, objectID string) error {
+	m.deleted[objectID] = true
+	return nil
+}
+
+type mockSearchSyncRepo struct {
+	questions, answers []*plugin.SearchContent
+}
+
+func (m *mockSearchSyncRepo) CountQuestions(ctx context.Context, since time.Time) (int64, error) {
+	return int64(len(m.questions)), nil
+}
+func (m *mockSearchSyncRepo) CountAnswers(ctx context.Context, since time.Time) (int64, error) {
+	return int64(len(m.answers)), nil
+}
+func (m *mockSearchSyncRepo) GetQuestionsPageSince(ctx context.Context, since time.Time, page, pageSize int) (
+	[]*plugin.SearchContent, error) {
+	return paginate(m.questions, page, pageSize), nil
+}
+func (m *mockSearchSyncRepo) GetAnswersPageSince(ctx context.Context, since time.Time, page, pageSize int) (
+	[]*plugin.SearchContent, error) {
+	return paginate(m.answers, page, pageSize), nil
+}
+
+func paginate(contents []*plugin.SearchContent, page, pageSize int) []*plugin.SearchContent {
+	start := (page - 1) * pageSize
+	if start >= len(contents) {
+		return nil
+	}
+	end := start + pageSize
+	if end > len(contents) {
+		end = len(contents)
+	}
+	return contents[start:end]
+}
+
+type mockCheckpointRepo struct {
+	checkpoint *entity.SearchSyncCheckpoint
+}
+
+func (m *mockCheckpointRepo) GetCheckpoint(ctx context.Context, pluginSlugName string) (
+	*entity.SearchSyncCheckpoint, bool, error) {
+	if m.checkpoint == nil {
+		return nil, false, nil
+	}
+	checkpoint := *m.checkpoint
+	return &checkpoint, true, nil
+}
+func (m *mockCheckpointRepo) SaveCheckpoint(ctx context.Context, checkpoint *entity.SearchSyncCheckpoint) error {
+	checkpoint.ID = 1
+	saved := *checkpoint
+	m.checkpoint = &saved
+	return nil
+}
+
+func TestReindexResume(t *testing.T) {
+	search := &mockSearch{updated: map[string]bool{}, deleted: map[string]bool{}, failAt: "a2"}
+	plugin.Register(search)
+	plugin.StatusManager.Enable(search.Info().SlugName, true)
+
+	syncRepo := &mockSearchSyncRepo{
+		questions: []*plugin.SearchContent{{ObjectID: "q1"}, {ObjectID: "q2"},
+			{ObjectID: "q3", Status: plugin.SearchContentStatusDeleted}},
+		answers: []*plugin.SearchContent{{ObjectID: "a1"}, {ObjectID: "a2"}},
+	}
+	checkpointRepo := &mockCheckpointRepo{}
+	ss := NewSearchSyncService(syncRepo, checkpointRepo)
+	ctx := context.Background()
+
+	resp, err := ss.Reindex(ctx, &schema.SearchReindexReq{DryRun: true}, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), resp.QuestionTotal)
+	assert.Equal(t, int64(2), resp.AnswerTotal)
+	assert.Nil(t, checkpointRepo.checkpoint)
+
+	_, err = ss.Reindex(ctx, &schema.SearchReindexReq{PageSize: 1}, nil)
+	assert.Error(t, err)
+	assert.Equal(t, "answer", checkpointRepo.checkpoint.ObjectType)
+	assert.Equal(t, 1, checkpointRepo.checkpoint.Page)
+	assert.True(t, checkpointRepo.checkpoint.FinishedAt.IsZero())
+	assert.True(t, search.deleted["q3"])
+
+	search.failAt = ""
+	search.updated = map[string]bool{}
+	resp, err = ss.Reindex(ctx, &schema.SearchReindexReq{PageSize: 1}, nil)
+	assert.NoError(t, err)
+	assert.True(t, resp.Resumed)
+	assert.Equal(t, map[string]bool{"a2": true}, search.updated)
+	assert.Equal(t, int64(5), resp.Synced)
+	assert.False(t, checkpointRepo.checkpoint.FinishedAt.IsZero())
+	assert.Equal(t, checkpointRepo.checkpoint.StartedAt, checkpointRepo.checkpoint.LastSyncedAt)
+}
@@ -19,6 +19,89 @@
 
 package service_config
 
+import "github.com/apache/incubator-answer/pkg/storage"
+
 type ServiceConfig struct {
-	UploadPath string `json:"upload_path" mapstructure:"upload_path" yaml:"upload_path"`
+	UploadPath  string             `json:"upload_path" mapstructure:"upload_path" yaml:"upload_path"`
+	InboundMail *InboundMailConfig `json:"inbound_mail" mapstructure:"inbound_mail" yaml:"inbound_mail,omitempty"`
+	// ReportSLAHours the flags should be handled in these hours, default is 24
+	ReportSLAHours int `json:"report_sla_hours" mapstructure:"report_sla_hours" yaml:"report_sla_hours,omitempty"`
+	// Storage where the uploaded files are stored, default is the upload path on local disk
+	Storage *storage.Config `json:"storage" mapstructure:"storage" yaml:"storage,omitempty"`
+	// Image the processing of the uploaded post images, they are stored as-is if it is empty
+	Image *ImageConfig `json:"image" mapstructure:"image" yaml:"image,omitempty"`
+	// OrphanUploadGraceHours the uploaded files not referenced by any content are deleted after these hours,
+	// 0 means never
+	OrphanUploadGraceHours int `json:"orphan_upload_grace_hours" mapstructure:"orphan_upload_grace_hours" yaml:"orphan_upload_grace_hours,omitempty"`
+	// EmailDeliveryRetentionDays the sent, bounced and failed email delivery logs are deleted after these days,
+	// default is 30
+	EmailDeliveryRetentionDays int `json:"email_delivery_retention_days" mapstructure:"email_delivery_retention_days" yaml:"email_delivery_retention_days,omitempty"`
+}
+
+// ImageConfig the responsive variants and WebP copies of the uploaded post images
+type ImageConfig struct {
+	// Variants whether to generate the thumbnail, medium and full variants
+	Variants bool `json:"variants" mapstructure:"variants" yaml:"variants"`
+	// ThumbnailWidth default is 320
+	ThumbnailWidth int `json:"thumbnail_width" mapstructure:"thumbnail_width" yaml:"thumbnail_width,omitempty"`
+	// MediumWidth default is 800
+	MediumWidth int `json:"medium_width" mapstructure:"medium_width" yaml:"medium_width,omitempty"`
+	// FullWidth the wider images are scaled down to it, default is 1920
+	FullWidth int `json:"full_width" mapstructure:"full_width" yaml:"full_width,omitempty"`
+	// WebP whether to add the WebP copies, they are only kept when they are smaller than the images
+	WebP bool `json:"webp" mapstructure:"webp" yaml:"webp"`
+}
+
+// Enabled whether the post images are processed
+func (c *ImageConfig) Enabled() bool {
+	return c != nil && (c.Variants || c.WebP)
+}
+
+// GetThumbnailWidth get thumbnail width
+func (c *ImageConfig) GetThumbnailWidth() int {
+	if c.ThumbnailWidth <= 0 {
+		return 320
+	}
+	return c.ThumbnailWidth
+}
+
+// GetMediumWidth get medium width
+func (c *ImageConfig) GetMediumWidth() int {
+	if c.MediumWidth <= 0 {
+		return 800
+	}
+	return c.MediumWidth
+}
+
+// GetFullWidth get full width
+func (c *ImageConfig) GetFullWidth() int {
+	if c.FullWidth <= 0 {
+		return 1920
+	}
+	return c.FullWidth
+}
+
+// InboundMailConfig reply-by-email config, it is disabled when the reply address or secret is empty
+type InboundMailConfig struct {
+	// ReplyAddress the notification emails are replied to "local+token@domain" of this address
+	ReplyAddress string `json:"reply_address" mapstructure:"reply_address" yaml:"reply_address"`
+	// Secret signs the reply token, the MTA pipe must send it as bearer token to the inbound mail endpoint
+	Secret string `json:"secret" mapstructure:"secret" yaml:"secret"`
+	// Maildir the maildir polled for the inbound mails, empty means not polling
+	Maildir string `json:"maildir" mapstructure:"maildir" yaml:"maildir,omitempty"`
+	// MaxMessageSize the inbound mail larger than it in MB is rejected, default is 10
+	MaxMessageSize int `json:"max_message_size" mapstructure:"max_message_size" yaml:"max_message_size,omitempty"`
+}
+
+// Enabled whether the reply-by-email is enabled
+func (c *InboundMailConfig) Enabled() bool {
+	return c != nil && len(c.ReplyAddress) > 0 && len(c.Secret) > 0
+}
+
+// MaxMessageBytes the max size of the inbound mail in bytes
+func (c *InboundMailConfig) MaxMessageBytes() int64 {
+	if c == nil || c.MaxMessageSize <= 0 {
+		return 10 * 1024 * 1024
+	}
+	return int64(c.MaxMessageSize) * 1024 * 1024
 }
@@ -205,6 +205,7 @@ func (s *SiteInfoService) SaveSiteWrite(ctx context.Context, req *schema.SiteWri
 		return errData, err
 	}
 
+	req.FormatAttachmentTypes()
 	content, _ := json.Marshal(req)
 	data := &entity.SiteInfo{
 		Type:    constant.SiteTypeWrite,
@@ -0,0 +1,248 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package tag
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+
+	"github.com/apache/incubator-answer/internal/base/constant"
+	"github.com/apache/incubator-answer/internal/base/reason"
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/apache/incubator-answer/pkg/converter"
+	"github.com/segmentfault/pacman/errors"
+)
+
+// formatTagHierarchy set the ancestors, children and group of the tag
+func (ts *TagService) formatTagHierarchy(ctx context.Context, tagInfo *entity.Tag, resp *schema.GetTagResp) (err error) {
+	ancestors, err := ts.tagCommonService.GetTagAncestors(ctx, tagInfo)
+	if err != nil {
+		return err
+	}
+	children, err := ts.tagCommonService.GetTagChildren(ctx, tagInfo.ID)
+	if err != nil {
+		return err
+	}
+	resp.Ancestors = make([]*schema.TagTreeNode, 0, len(ancestors))
+	for _, tag := range ancestors {
+		resp.Ancestors = append(resp.Ancestors, newTagTreeNode(tag))
+	}
+	resp.Children = make([]*schema.TagTreeNode, 0, len(children))
+	for _, tag := range children {
+		resp.Children = append(resp.Children, newTagTreeNode(tag))
+	}
+
+	if tagInfo.TagGroupID > 0 {
+		tagGroup, exist, err := ts.tagGroupRepo.GetTagGroup(ctx, tagInfo.TagGroupID)
+		if err != nil {
+			return err
+		}
+		if exist {
+			resp.TagGroup = newTagGroupResp(tagGroup)
+		}
+	}
+	return nil
+}
+
+// GetTagTree get the tag trees grouped by tag group
+func (ts *TagService) GetTagTree(ctx context.Context) (resp *schema.GetTagTreeResp, err error) {
+	tags, err := ts.tagRepo.GetHierarchyTagList(ctx)
+	if err != nil {
+		return nil, err
+	}
+	tagGroups, err := ts.tagGroupRepo.GetTagGroupList(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	resp = &schema.GetTagTreeResp{
+		Groups:    make([]*schema.TagGroupTree, 0, len(tagGroups)),
+		Ungrouped: make([]*schema.TagTreeNode, 0),
+	}
+	groupMapping := make(map[int64]*schema.TagGroupTree)
+	for _, tagGroup := range tagGroups {
+		groupTree := &schema.TagGroupTree{TagGroupResp: *newTagGroupResp(tagGroup), Tags: make([]*schema.TagTreeNode, 0)}
+		groupMapping[tagGroup.ID] = groupTree
+		resp.Groups = append(resp.Groups, groupTree)
+	}
+
+	nodeMapping := make(map[string]*schema.TagTreeNode)
+	for _, tag := range tags {
+		nodeMapping[tag.ID] = newTagTreeNode(tag)
+	}
+	for _, tag := range tags {
+		node := nodeMapping[tag.ID]
+		if parent, ok := nodeMapping[converter.IntToString(tag.ParentTagID)]; ok && tag.ParentTagID > 0 {
+			parent.Children = append(parent.Children, node)
+			continue
+		}
+		// the root tag places the whole tree in its group
+		if groupTree, ok := groupMapping[tag.TagGroupID]; ok {
+			groupTree.Tags = append(groupTree.Tags, node)
+		} else {
+			resp.Ungrouped = append(resp.Ungrouped, node)
+		}
+	}
+	return resp, nil
+}
+
+// UpdateTagStructure move the tag to another parent or group, the change is recorded as a tag revision
+func (ts *TagService) UpdateTagStructure(ctx context.Context, req *schema.UpdateTagStructureReq) (err error) {
+	tagInfo, exist, err := ts.tagCommonService.GetTagByID(ctx, req.TagID)
+	if err != nil {
+		return err
+	}
+	if !exist {
+		return errors.BadRequest(reason.TagNotFound)
+	}
+	if tagInfo.MainTagID > 0 {
+		return errors.BadRequest(reason.TagParentInvalid)
+	}
+
+	var parentTagID int64
+	if len(req.ParentTagID) > 0 {
+		parentTagID, err = ts.tagCommonService.CheckTagParent(ctx, tagInfo, req.ParentTagID)
+		if err != nil {
+			return err
+		}
+	}
+	if req.TagGroupID > 0 {
+		_, exist, err := ts.tagGroupRepo.GetTagGroup(ctx, req.TagGroupID)
+		if err != nil {
+			return err
+		}
+		if !exist {
+			return errors.BadRequest(reason.TagGroupNotFound)
+		}
+	}
+	if tagInfo.ParentTagID == parentTagID && tagInfo.TagGroupID == req.TagGroupID {
+		return nil
+	}
+
+	if err = ts.tagRepo.UpdateTagStructure(ctx, tagInfo.ID, parentTagID, req.TagGroupID); err != nil {
+		return err
+	}
+	tagInfo.ParentTagID = parentTagID
+	tagInfo.TagGroupID = req.TagGroupID
+
+	revisionDTO := &schema.AddRevisionDTO{
+		UserID:   req.UserID,
+		ObjectID: tagInfo.ID,
+		Title:    tagInfo.SlugName,
+		Log:      req.EditSummary,
+		Status:   entity.RevisionReviewPassStatus,
+	}
+	tagInfoJson, _ := json.Marshal(tagInfo)
+	revisionDTO.Content = string(tagInfoJson)
+	revisionID, err := ts.revisionService.AddRevision(ctx, revisionDTO, true)
+	if err != nil {
+		return err
+	}
+	ts.activityQueueService.Send(ctx, &schema.ActivityMsg{
+		UserID:           req.UserID,
+		ObjectID:         tagInfo.ID,
+		OriginalObjectID: tagInfo.ID,
+		ActivityTypeKey:  constant.ActTagEdited,
+		RevisionID:       revisionID,
+	})
+	return nil
+}
+
+// GetTagGroupList get all tag groups
+func (ts *TagService) GetTagGroupList(ctx context.Context) (resp []*schema.TagGroupResp, err error) {
+	tagGroups, err := ts.tagGroupRepo.GetTagGroupList(ctx)
+	if err != nil {
+		return nil, err
+	}
+	resp = make([]*schema.TagGroupResp, 0, len(tagGroups))
+	for _, tagGroup := range tagGroups {
+		resp = append(resp, newTagGroupResp(tagGroup))
+	}
+	return resp, nil
+}
+
+// AddTagGroup add tag group
+func (ts *TagService) AddTagGroup(ctx context.Context, req *schema.AddTagGroupReq) (resp *schema.TagGroupResp, err error) {
+	name := strings.TrimSpace(req.Name)
+	_, exist, err := ts.tagGroupRepo.GetTagGroupByName(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	if exist {
+		return nil, errors.BadRequest(reason.TagGroupAlreadyExist)
+	}
+	tagGroup := &entity.TagGroup{
+		Name:        name,
+		Description: req.Description,
+		SortOrder:   req.SortOrder,
+	}
+	if err = ts.tagGroupRepo.AddTagGroup(ctx, tagGroup); err != nil {
+		return nil, err
+	}
+	return newTagGroupResp(tagGroup), nil
+}
+
+// UpdateTagGroup update tag group
+func (ts *TagService) UpdateTagGroup(ctx context.Context, req *schema.UpdateTagGroupReq) (err error) {
+	tagGroup, exist, err := ts.tagGroupRepo.GetTagGroup(ctx, req.TagGroupID)
+	if err != nil {
+		return err
+	}
+	if !exist {
+		return errors.BadRequest(reason.TagGroupNotFound)
+	}
+	name := strings.TrimSpace(req.Name)
+	sameName, exist, err := ts.tagGroupRepo.GetTagGroupByName(ctx, name)
+	if err != nil {
+		return err
+	}
+	if exist && sameName.ID != tagGroup.ID {
+		return errors.BadRequest(reason.TagGroupAlreadyExist)
+	}
+	tagGroup.Name = name
+	tagGroup.Description = req.Description
+	tagGroup.SortOrder = req.SortOrder
+	return ts.tagGroupRepo.UpdateTagGroup(ctx, tagGroup)
+}
+
+// RemoveTagGroup remove tag group, the tags in this group are kept
+func (ts *TagService) RemoveTagGroup(ctx context.Context, req *schema.RemoveTagGroupReq) (err error) {
+	return ts.tagGroupRepo.RemoveTagGroup(ctx, req.TagGroupID)
+}
+
+func newTagTreeNode(tag *entity.Tag) *schema.TagTreeNode {
+	return &schema.TagTreeNode{
+		TagID:         tag.ID,
+		SlugName:      tag.SlugName,
+		DisplayName:   tag.DisplayName,
+		QuestionCount: tag.QuestionCount,
+	}
+}
+
+func newTagGroupResp(tagGroup *entity.TagGroup) *schema.TagGroupResp {
+	return &schema.TagGroupResp{
+		TagGroupID:  tagGroup.ID,
+		Name:        tagGroup.Name,
+		Description: tagGroup.Description,
+		SortOrder:   tagGroup.SortOrder,
+	}
+}
@@ -0,0 +1,215 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package tag
+
+import (
+	"context"
+	"strconv"
+	"testing"
+
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/apache/incubator-answer/internal/service/activity_queue"
+	"github.com/apache/incubator-answer/internal/service/attachment"
+	"github.com/apache/incubator-answer/internal/service/revision"
+	"github.com/apache/incubator-answer/internal/service/revision_common"
+	"github.com/apache/incubator-answer/internal/service/siteinfo_common"
+	tagcommonser "github.com/apache/incubator-answer/internal/service/tag_common"
+	"github.com/stretchr/testify/assert"
+)
+
+func testTagID(n int64) string {
+	return strconv.FormatInt(10030000000000000+n, 10)
+}
+
+// mockTagStore the tags shared by the mocked tag repos
+type mockTagStore struct {
+	tags map[string]*entity.Tag
+}
+
+func (m *mockTagStore) add(n, parent int64) {
+	tag := &entity.Tag{ID: testTagID(n), SlugName: "tag-" + strconv.FormatInt(n, 10)}
+	if parent > 0 {
+		tag.ParentTagID, _ = strconv.ParseInt(testTagID(parent), 10, 64)
+	}
+	m.tags[tag.ID] = tag
+}
+
+type mockTagCommonRepo struct {
+	tagcommonser.TagCommonRepo
+	store *mockTagStore
+}
+
+func (m *mockTagCommonRepo) GetTagByID(ctx context.Context, tagID string, includeDeleted bool) (
+	*entity.Tag, bool, error) {
+	tag, ok := m.store.tags[tagID]
+	if !ok {
+		return nil, false, nil
+	}
+	copied := *tag
+	return &copied, true, nil
+}
+
+type mockTagRepo struct {
+	tagcommonser.TagRepo
+	store *mockTagStore
+}
+
+func (m *mockTagRepo) GetTagListByParentIDs(ctx context.Context, parentTagIDs []string) ([]*entity.Tag, error) {
+	tagList := make([]*entity.Tag, 0)
+	for _, tag := range m.store.tags {
+		for _, parentID := range parentTagIDs {
+			if strconv.FormatInt(tag.ParentTagID, 10) == parentID {
+				tagList = append(tagList, tag)
+			}
+		}
+	}
+	return tagList, nil
+}
+
+func (m *mockTagRepo) UpdateTagStructure(ctx context.Context, tagID string, parentTagID, tagGroupID int64) error {
+	m.store.tags[tagID].ParentTagID = parentTagID
+	m.store.tags[tagID].TagGroupID = tagGroupID
+	return nil
+}
+
+type mockTagGroupRepo struct {
+	tagcommonser.TagGroupRepo
+}
+
+func (m *mockTagGroupRepo) GetTagGroup(ctx context.Context, tagGroupID int64) (*entity.TagGroup, bool, error) {
+	if tagGroupID != 1 {
+		return nil, false, nil
+	}
+	return &entity.TagGroup{ID: 1, Name: "language"}, true, nil
+}
+
+type mockSiteInfoService struct {
+	siteinfo_common.SiteInfoCommonService
+}
+
+func (m *mockSiteInfoService) GetSiteWrite(ctx context.Context) (*schema.SiteWriteResp, error) {
+	return &schema.SiteWriteResp{}, nil
+}
+
+type mockRevisionRepo struct {
+	revision.RevisionRepo
+	revisions []*entity.Revision
+}
+
+func (m *mockRevisionRepo) AddRevision(ctx context.Context, revision *entity.Revision, autoUpdateRevisionID bool) error {
+	revision.ID = strconv.Itoa(len(m.revisions) + 1)
+	m.revisions = append(m.revisions, revision)
+	return nil
+}
+
+type mockActivityQueueService struct {
+	activity_queue.ActivityQueueService
+}
+
+func (m *mockActivityQueueService) Send(ctx context.Context, msg *schema.ActivityMsg) {}
+
+func newTestTagService(store *mockTagStore) (*TagService, *mockRevisionRepo) {
+	tagRepo := &mockTagRepo{store: store}
+	revisionRepo := &mockRevisionRepo{}
+	revisionService := revision_common.NewRevisionService(revisionRepo, nil, attachment.NewAttachmentService(nil))
+	activityQueueService := &mockActivityQueueService{}
+	tagCommonService := tagcommonser.NewTagCommonService(&mockTagCommonRepo{store: store}, nil, tagRepo,
+		revisionService, &mockSiteInfoService{}, activityQueueService)
+	return NewTagService(tagRepo, tagCommonService, revisionService, nil, &mockSiteInfoService{},
+		activityQueueService, &mockTagGroupRepo{}), revisionRepo
+}
+
+func TestTagService_UpdateTagStructureCycle(t *testing.T) {
+	ctx := context.TODO()
+	store := &mockTagStore{tags: make(map[string]*entity.Tag)}
+	// 1 -> 2 -> 3
+	store.add(1, 0)
+	store.add(2, 1)
+	store.add(3, 2)
+	ts, revisionRepo := newTestTagService(store)
+
+	err := ts.UpdateTagStructure(ctx, &schema.UpdateTagStructureReq{TagID: testTagID(1), ParentTagID: testTagID(1)})
+	assert.Error(t, err)
+	err = ts.UpdateTagStructure(ctx, &schema.UpdateTagStructureReq{TagID: testTagID(1), ParentTagID: testTagID(3)})
+	assert.Error(t, err)
+	err = ts.UpdateTagStructure(ctx, &schema.UpdateTagStructureReq{TagID: testTagID(2), ParentTagID: testTagID(3)})
+	assert.Error(t, err)
+	assert.Empty(t, revisionRepo.revisions)
+
+	// move the descendant to the root
+	err = ts.UpdateTagStructure(ctx, &schema.UpdateTagStructureReq{TagID: testTagID(3)})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(0), store.tags[testTagID(3)].ParentTagID)
+	assert.Len(t, revisionRepo.revisions, 1)
+}
+
+func TestTagService_UpdateTagStructureDepth(t *testing.T) {
+	ctx := context.TODO()
+	store := &mockTagStore{tags: make(map[string]*entity.Tag)}
+	// the chain 1 -> 2 -> ... -> 9
+	for i := int64(1); i < tagcommonser.MaxTagTreeDepth; i++ {
+		store.add(i, i-1)
+	}
+	// the subtree 20 -> 21
+	store.add(20, 0)
+	store.add(21, 20)
+	store.add(30, 0)
+	ts, _ := newTestTagService(store)
+
+	// the whole subtree is counted, not only the moved tag
+	err := ts.UpdateTagStructure(ctx, &schema.UpdateTagStructureReq{TagID: testTagID(20), ParentTagID: testTagID(9)})
+	assert.Error(t, err)
+	err = ts.UpdateTagStructure(ctx, &schema.UpdateTagStructureReq{TagID: testTagID(20), ParentTagID: testTagID(8)})
+	assert.NoError(t, err)
+
+	err = ts.UpdateTagStructure(ctx, &schema.UpdateTagStructureReq{TagID: testTagID(30), ParentTagID: testTagID(21)})
+	assert.Error(t, err)
+	err = ts.UpdateTagStructure(ctx, &schema.UpdateTagStructureReq{TagID: testTagID(30), ParentTagID: testTagID(9)})
+	assert.NoError(t, err)
+}
+
+func TestTagService_UpdateTagStructureRegroup(t *testing.T) {
+	ctx := context.TODO()
+	store := &mockTagStore{tags: make(map[string]*entity.Tag)}
+	store.add(1, 0)
+	store.add(2, 1)
+	ts, revisionRepo := newTestTagService(store)
+
+	err := ts.UpdateTagStructure(ctx, &schema.UpdateTagStructureReq{TagID: testTagID(1), TagGroupID: 2})
+	assert.Error(t, err)
+
+	err = ts.UpdateTagStructure(ctx, &schema.UpdateTagStructureReq{TagID: testTagID(1), TagGroupID: 1})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), store.tags[testTagID(1)].TagGroupID)
+	assert.Len(t, revisionRepo.revisions, 1)
+
+	// no change, no revision
+	err = ts.UpdateTagStructure(ctx, &schema.UpdateTagStructureReq{TagID: testTagID(1), TagGroupID: 1})
+	assert.NoError(t, err)
+	assert.Len(t, revisionRepo.revisions, 1)
+
+	// move the child to the root of another group
+	err = ts.UpdateTagStructure(ctx, &schema.UpdateTagStructureReq{TagID: testTagID(2), TagGroupID: 1})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(0), store.tags[testTagID(2)].ParentTagID)
+	assert.Equal(t, int64(1), store.tags[testTagID(2)].TagGroupID)
+	assert.Len(t, revisionRepo.revisions, 2)
+}
@@ -51,6 +51,7 @@ type TagService struct {
 	followCommon         activity_common.FollowRepo
 	siteInfoService      siteinfo_common.SiteInfoCommonService
 	activityQueueService activity_queue.ActivityQueueService
+	tagGroupRepo         tagcommonser.TagGroupRepo
 }
 
 // NewTagService new tag service
@@ -61,6 +62,7 @@ func NewTagService(
 	followCommon activity_common.FollowRepo,
 	siteInfoService siteinfo_common.SiteInfoCommonService,
 	activityQueueService activity_queue.ActivityQueueService,
+	tagGroupRepo tagcommonser.TagGroupRepo,
 ) *TagService {
 	return &TagService{
 		tagRepo:              tagRepo,
@@ -69,6 +71,7 @@ func NewTagService(
 		followCommon:         followCommon,
 		siteInfoService:      siteInfoService,
 		activityQueueService: activityQueueService,
+		tagGroupRepo:         tagGroupRepo,
 	}
 }
 
@@ -92,6 +95,15 @@ func (ts *TagService) RemoveTag(ctx context.Context, req *schema.RemoveTagReq) (
 		return errors.BadRequest(reason.TagIsUsedCannotDelete)
 	}
 
+	// the tag which has child tags cannot be deleted
+	children, err := ts.tagCommonService.GetTagChildren(ctx, req.TagID)
+	if err != nil {
+		return err
+	}
+	if len(children) > 0 {
+		return errors.BadRequest(reason.TagIsUsedCannotDelete)
+	}
+
 	// tagRelRepo
 	err = ts.tagRepo.RemoveTag(ctx, req.TagID)
 	if err != nil {
@@ -188,6 +200,9 @@ func (ts *TagService) GetTagInfo(ctx context.Context, req *schema.GetTagInfoReq)
 	resp.Status = entity.TagStatusDisplayMapping[tagInfo.Status]
 	resp.MemberActions = permission.GetTagPermission(ctx, tagInfo.Status, req.CanEdit, req.CanDelete, req.CanRecover)
 	resp.GetExcerpt()
+	if err = ts.formatTagHierarchy(ctx, tagInfo, resp); err != nil {
+		return nil, err
+	}
 	return resp, nil
 }
 
@@ -39,6 +39,7 @@ import (
 	"github.com/segmentfault/pacman/log"
 )
 
+//go:generate mockgen -source=./tag_common.go -destination=../mock/tag_common_repo_mock.go -package=mock
 type TagCommonRepo interface {
 	AddTagList(ctx context.Context, tagList []*entity.Tag) (err error)
 	GetTagListByIDs(ctx context.Context, ids []string) (tagList []*entity.Tag, err error)
@@ -62,6 +63,19 @@ type TagRepo interface {
 	GetTagSynonymCount(ctx context.Context, tagID string) (count int64, err error)
 	GetIDsByMainTagId(ctx context.Context, mainTagID string) (tagIDs []string, err error)
 	GetTagList(ctx context.Context, tag *entity.Tag) (tagList []*entity.Tag, err error)
+	GetIDsByMainTagIDs(ctx context.Context, mainTagIDs []string) (tagIDs []string, err error)
+	GetTagListByParentIDs(ctx context.Context, parentTagIDs []string) (tagList []*entity.Tag, err error)
+	GetHierarchyTagList(ctx context.Context) (tagList []*entity.Tag, err error)
+	UpdateTagStructure(ctx context.Context, tagID string, parentTagID, tagGroupID int64) (err error)
+}
+
+type TagGroupRepo interface {
+	AddTagGroup(ctx context.Context, tagGroup *entity.TagGroup) (err error)
+	UpdateTagGroup(ctx context.Context, tagGroup *entity.TagGroup) (err error)
+	RemoveTagGroup(ctx context.Context, tagGroupID int64) (err error)
+	GetTagGroup(ctx context.Context, tagGroupID int64) (tagGroup *entity.TagGroup, exist bool, err error)
+	GetTagGroupByName(ctx context.Context, name string) (tagGroup *entity.TagGroup, exist bool, err error)
+	GetTagGroupList(ctx context.Context) (tagGroups []*entity.TagGroup, err error)
 }
 
 type TagRelRepo interface {
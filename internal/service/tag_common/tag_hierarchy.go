@@ -0,0 +1,177 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package tag_common
+
+import (
+	"context"
+
+	"github.com/apache/incubator-answer/internal/base/reason"
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/pkg/converter"
+	"github.com/segmentfault/pacman/errors"
+)
+
+// MaxTagTreeDepth the max depth of the tag tree
+const MaxTagTreeDepth = 10
+
+// GetTagIDsWithDescendants get the ids of the tag, all its descendants and their synonyms
+func (ts *TagCommonService) GetTagIDsWithDescendants(ctx context.Context, tagID string) (tagIDs []string, err error) {
+	visited := map[string]bool{tagID: true}
+	tagIDs = []string{tagID}
+	current := []string{tagID}
+	for depth := 0; depth < MaxTagTreeDepth && len(current) > 0; depth++ {
+		children, err := ts.tagRepo.GetTagListByParentIDs(ctx, current)
+		if err != nil {
+			return nil, err
+		}
+		current = make([]string, 0)
+		for _, child := range children {
+			if visited[child.ID] {
+				continue
+			}
+			visited[child.ID] = true
+			tagIDs = append(tagIDs, child.ID)
+			current = append(current, child.ID)
+		}
+	}
+	synonymIDs, err := ts.tagRepo.GetIDsByMainTagIDs(ctx, tagIDs)
+	if err != nil {
+		return nil, err
+	}
+	return converter.UniqueArray(append(tagIDs, synonymIDs...)), nil
+}
+
+// GetTagIDsWithAncestors get the ids of the tags and all their ancestors
+func (ts *TagCommonService) GetTagIDsWithAncestors(ctx context.Context, tagIDs []string) (result []string, err error) {
+	visited := make(map[string]bool)
+	for _, tagID := range tagIDs {
+		visited[tagID] = true
+	}
+	result = append(result, tagIDs...)
+	current := tagIDs
+	for depth := 0; depth < MaxTagTreeDepth && len(current) > 0; depth++ {
+		tags, err := ts.tagCommonRepo.GetTagListByIDs(ctx, current)
+		if err != nil {
+			return nil, err
+		}
+		current = make([]string, 0)
+		for _, tag := range tags {
+			if tag.ParentTagID == 0 {
+				continue
+			}
+			parentID := converter.IntToString(tag.ParentTagID)
+			if visited[parentID] {
+				continue
+			}
+			visited[parentID] = true
+			result = append(result, parentID)
+			current = append(current, parentID)
+		}
+	}
+	return result, nil
+}
+
+// GetTagAncestors get the ancestors of the tag, ordered from the root to the parent
+func (ts *TagCommonService) GetTagAncestors(ctx context.Context, tag *entity.Tag) (ancestors []*entity.Tag, err error) {
+	ancestors = make([]*entity.Tag, 0)
+	visited := map[string]bool{tag.ID: true}
+	parentID := tag.ParentTagID
+	for depth := 0; parentID > 0 && depth < MaxTagTreeDepth; depth++ {
+		parent, exist, err := ts.GetTagByID(ctx, converter.IntToString(parentID))
+		if err != nil {
+			return nil, err
+		}
+		if !exist || visited[parent.ID] {
+			break
+		}
+		visited[parent.ID] = true
+		ancestors = append([]*entity.Tag{parent}, ancestors...)
+		parentID = parent.ParentTagID
+	}
+	return ancestors, nil
+}
+
+// GetTagChildren get the child tags of the tag
+func (ts *TagCommonService) GetTagChildren(ctx context.Context, tagID string) (children []*entity.Tag, err error) {
+	children, err = ts.tagRepo.GetTagListByParentIDs(ctx, []string{tagID})
+	if err != nil {
+		return nil, err
+	}
+	ts.TagsFormatRecommendAndReserved(ctx, children)
+	return children, nil
+}
+
+// GetTagSubtreeHeight get the number of levels of the tag and its descendants, 1 if the tag has no children
+func (ts *TagCommonService) GetTagSubtreeHeight(ctx context.Context, tagID string) (height int, err error) {
+	visited := map[string]bool{tagID: true}
+	current := []string{tagID}
+	for len(current) > 0 && height <= MaxTagTreeDepth {
+		height++
+		children, err := ts.tagRepo.GetTagListByParentIDs(ctx, current)
+		if err != nil {
+			return 0, err
+		}
+		current = make([]string, 0)
+		for _, child := range children {
+			if visited[child.ID] {
+				continue
+			}
+			visited[child.ID] = true
+			current = append(current, child.ID)
+		}
+	}
+	return height, nil
+}
+
+// CheckTagParent check the tag can be moved under the parent tag, it returns the id of the parent tag.
+// The parent can not be a synonym or a descendant of the tag, and the moved subtree must not exceed the max depth.
+func (ts *TagCommonService) CheckTagParent(ctx context.Context, tagInfo *entity.Tag, parentTagID string) (
+	parentID int64, err error) {
+	if parentTagID == tagInfo.ID {
+		return 0, errors.BadRequest(reason.TagParentInvalid)
+	}
+	parentTag, exist, err := ts.GetTagByID(ctx, parentTagID)
+	if err != nil {
+		return 0, err
+	}
+	if !exist {
+		return 0, errors.BadRequest(reason.TagNotFound)
+	}
+	if parentTag.MainTagID > 0 {
+		return 0, errors.BadRequest(reason.TagParentInvalid)
+	}
+	ancestors, err := ts.GetTagAncestors(ctx, parentTag)
+	if err != nil {
+		return 0, err
+	}
+	for _, ancestor := range ancestors {
+		if ancestor.ID == tagInfo.ID {
+			return 0, errors.BadRequest(reason.TagParentInvalid)
+		}
+	}
+	height, err := ts.GetTagSubtreeHeight(ctx, tagInfo.ID)
+	if err != nil {
+		return 0, err
+	}
+	if len(ancestors)+1+height > MaxTagTreeDepth {
+		return 0, errors.BadRequest(reason.TagParentInvalid)
+	}
+	return converter.StringToInt64(parentTag.ID), nil
+}
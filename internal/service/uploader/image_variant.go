@@ -0,0 +1,153 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package uploader
+
+import (
+	"bytes"
+	"image"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/apache/incubator-answer/pkg/imagevariant"
+	"github.com/apache/incubator-answer/pkg/webp"
+	"github.com/disintegration/imaging"
+	"github.com/segmentfault/pacman/log"
+)
+
+// maxVariantPixels the larger images are stored as-is, decoding them costs too much memory
+const maxVariantPixels = 50_000_000
+
+// supportedVariantFileExtMapping the gif is not processed, the animation would be lost
+var supportedVariantFileExtMapping = map[string]imaging.Format{
+	".jpg":  imaging.JPEG,
+	".jpeg": imaging.JPEG,
+	".png":  imaging.PNG,
+}
+
+// generateImageVariants resizes the post image to the configured variants and adds their WebP copies.
+// The image is renamed to record the variants, the variants are saved beside it. The first returned file
+// is the image, it is the original file if nothing is generated.
+func (us *uploaderService) generateImageVariants(filePath string) (files []string, err error) {
+	conf := us.serviceConfig.Image
+	ext := strings.ToLower(filepath.Ext(filePath))
+	format, ok := supportedVariantFileExtMapping[ext]
+	if !conf.Enabled() || !ok {
+		return []string{filePath}, nil
+	}
+	original, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, err
+	}
+	imageConfig, _, err := image.DecodeConfig(bytes.NewReader(original))
+	if err != nil {
+		return nil, err
+	}
+	if imageConfig.Width*imageConfig.Height > maxVariantPixels {
+		return []string{filePath}, nil
+	}
+	full, err := imaging.Decode(bytes.NewReader(original))
+	if err != nil {
+		return nil, err
+	}
+
+	v := &imagevariant.Variants{Full: full.Bounds().Dx()}
+	if conf.Variants {
+		if v.Full > conf.GetFullWidth() {
+			full = imaging.Resize(full, conf.GetFullWidth(), 0, imaging.Lanczos)
+			v.Full = full.Bounds().Dx()
+			original = nil
+		}
+		if conf.GetThumbnailWidth() < v.Full {
+			v.Thumbnail = conf.GetThumbnailWidth()
+		}
+		if conf.GetMediumWidth() < v.Full && conf.GetMediumWidth() > v.Thumbnail {
+			v.Medium = conf.GetMediumWidth()
+		}
+	}
+	images := make(map[int]image.Image)
+	for _, width := range v.Widths() {
+		if width == v.Full {
+			images[width] = full
+		} else {
+			images[width] = imaging.Resize(full, width, 0, imaging.Lanczos)
+		}
+	}
+
+	// the contents are keyed by the suffix of file name, the name is only known after WebP is checked
+	contents := make(map[string][]byte)
+	for width, img := range images {
+		if width == v.Full && original != nil {
+			contents[v.VariantFileName("", width, ext)] = original
+			continue
+		}
+		buf := &bytes.Buffer{}
+		if err = imaging.Encode(buf, img, format); err != nil {
+			return nil, err
+		}
+		contents[v.VariantFileName("", width, ext)] = buf.Bytes()
+	}
+	if conf.WebP {
+		if err = addWebPContents(v, images, contents, len(contents[v.VariantFileName("", v.Full, ext)])); err != nil {
+			log.Warnf("encode webp of %s failed: %v", filePath, err)
+		}
+	}
+	if len(contents) == 1 && original != nil {
+		return []string{filePath}, nil
+	}
+
+	id := strings.TrimSuffix(filepath.Base(filePath), filepath.Ext(filePath))
+	name := strings.TrimSuffix(v.FileName(id, ext), ext)
+	dir := filepath.Dir(filePath)
+	files = []string{filepath.Join(dir, name+ext)}
+	for suffix, content := range contents {
+		file := filepath.Join(dir, name+suffix)
+		if err = os.WriteFile(file, content, 0644); err != nil {
+			return nil, err
+		}
+		if file != files[0] {
+			files = append(files, file)
+		}
+	}
+	_ = os.Remove(filePath)
+	return files, nil
+}
+
+// addWebPContents adds the WebP copies if the full WebP is smaller than the full image,
+// the lossless WebP of photos is usually larger than jpeg
+func addWebPContents(v *imagevariant.Variants, images map[int]image.Image, contents map[string][]byte,
+	fullSize int) error {
+	webpContents := make(map[string][]byte)
+	for _, width := range append([]int{v.Full}, v.Widths()[:len(images)-1]...) {
+		buf := &bytes.Buffer{}
+		if err := webp.Encode(buf, images[width]); err != nil {
+			return err
+		}
+		if width == v.Full && buf.Len() >= fullSize {
+			return nil
+		}
+		webpContents[v.VariantFileName("", width, ".webp")] = buf.Bytes()
+	}
+	v.WebP = true
+	for name, content := range webpContents {
+		contents[name] = content
+	}
+	return nil
+}
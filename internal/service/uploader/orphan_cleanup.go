@@ -0,0 +1,167 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package uploader
+
+import (
+	"context"
+	"path"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/apache/incubator-answer/pkg/storage"
+	"github.com/segmentfault/pacman/log"
+)
+
+var (
+	// orphanSubPaths the files in these sub paths are referenced by the urls in contents,
+	// the attachments are not included because they are tracked by their records
+	orphanSubPaths = []string{avatarSubPath, avatarThumbSubPath, postSubPath, brandingSubPath}
+	// uploadedFileIDRegexp the id of file in the url, the variants of image and the avatar thumbnails share the id
+	uploadedFileIDRegexp = regexp.MustCompile(`(?:post|avatar|branding)/([1-9A-HJ-NP-Za-km-z]+)`)
+	fileIDRegexp         = regexp.MustCompile(`^[1-9A-HJ-NP-Za-km-z]+$`)
+)
+
+// orphanAttachmentPageSize the unlinked attachments read in every page when cleaning
+const orphanAttachmentPageSize = 100
+
+type orphanUpload struct {
+	storage storage.Storage
+	key     string
+}
+
+// CleanOrphanUploadsCron deletes the uploaded files that are not referenced by any question, answer, comment,
+// tag, revision, user or site setting and the attachments that are not used in any question or answer
+// after the grace period. It does nothing if the grace period is not configured.
+func (us *uploaderService) CleanOrphanUploadsCron(ctx context.Context) {
+	if us.serviceConfig.OrphanUploadGraceHours <= 0 {
+		return
+	}
+	deadline := time.Now().Add(-time.Duration(us.serviceConfig.OrphanUploadGraceHours) * time.Hour)
+
+	// the files are kept on local disk if they failed to be saved to the remote storage
+	storages := []storage.Storage{us.storage}
+	if us.storage.Type() != storage.TypeLocal {
+		storages = append(storages, storage.NewLocalStorage(us.serviceConfig.UploadPath))
+	}
+	us.cleanOrphanUploads(ctx, storages, deadline)
+	us.cleanUnlinkedAttachments(ctx, storages, deadline)
+}
+
+func (us *uploaderService) cleanOrphanUploads(ctx context.Context, storages []storage.Storage, deadline time.Time) {
+	candidates := make(map[string][]*orphanUpload)
+	for _, s := range storages {
+		for _, subPath := range orphanSubPaths {
+			err := s.List(ctx, subPath+"/", func(obj *storage.Object) error {
+				if obj.ModTime.After(deadline) {
+					return nil
+				}
+				if id := uploadedFileID(obj.Key); len(id) > 0 {
+					candidates[id] = append(candidates[id], &orphanUpload{storage: s, key: obj.Key})
+				}
+				return nil
+			})
+			if err != nil {
+				log.Errorf("list %s of %s storage failed: %v", subPath, s.Type(), err)
+				return
+			}
+		}
+	}
+	if len(candidates) == 0 {
+		return
+	}
+
+	// the contents are scanned after listing, so the files referenced during listing are kept
+	err := us.uploadedURLRepo.ScanContents(ctx, func(content string) {
+		for _, matches := range uploadedFileIDRegexp.FindAllStringSubmatch(content, -1) {
+			delete(candidates, matches[1])
+		}
+	})
+	if err != nil {
+		log.Errorf("scan the uploaded urls in contents failed: %v", err)
+		return
+	}
+	deleted := 0
+	for _, uploads := range candidates {
+		for _, upload := range uploads {
+			if err := upload.storage.Delete(ctx, upload.key); err != nil {
+				log.Errorf("delete orphan upload %s from %s storage failed: %v", upload.key, upload.storage.Type(), err)
+				continue
+			}
+			deleted++
+		}
+	}
+	log.Infof("deleted %d orphan uploads older than %d hours", deleted, us.serviceConfig.OrphanUploadGraceHours)
+}
+
+// cleanUnlinkedAttachments deletes the attachments whose object id is still 0 after the grace period,
+// the record is removed before the file, so the attachment linked during cleaning is never broken
+func (us *uploaderService) cleanUnlinkedAttachments(ctx context.Context, storages []storage.Storage,
+	deadline time.Time) {
+	deleted := 0
+	for lastID := int64(0); ; {
+		attachments, err := us.attachmentService.GetUnlinkedAttachments(ctx, deadline, lastID, orphanAttachmentPageSize)
+		if err != nil {
+			log.Errorf("get unlinked attachments failed: %v", err)
+			return
+		}
+		for _, attachment := range attachments {
+			lastID = attachment.ID
+			removed, err := us.attachmentService.RemoveUnlinkedAttachment(ctx, attachment.ID)
+			if err != nil {
+				log.Errorf("remove unlinked attachment %s failed: %v", attachment.FileID, err)
+				continue
+			}
+			if !removed {
+				continue
+			}
+			for _, s := range storages {
+				if err := s.Delete(ctx, attachment.FilePath); err != nil {
+					log.Errorf("delete unlinked attachment %s from %s storage failed: %v", attachment.FilePath, s.Type(), err)
+				}
+			}
+			deleted++
+		}
+		if len(attachments) < orphanAttachmentPageSize {
+			break
+		}
+	}
+	log.Infof("deleted %d unlinked attachments older than %d hours", deleted, us.serviceConfig.OrphanUploadGraceHours)
+}
+
+// uploadedFileID the id of uploaded file, the files not named by us have no id and are never deleted.
+// eg: post/<id>.png, post/<id>_320_800_1920_webp@320.webp, avatar_thumb/100_100@<id>.jpg
+func uploadedFileID(key string) string {
+	name := path.Base(key)
+	if strings.HasPrefix(key, avatarThumbSubPath+"/") {
+		index := strings.Index(name, "@")
+		if index < 0 {
+			return ""
+		}
+		name = name[index+1:]
+	}
+	if index := strings.IndexAny(name, "_@."); index >= 0 {
+		name = name[:index]
+	}
+	if !fileIDRegexp.MatchString(name) {
+		return ""
+	}
+	return name
+}
@@ -0,0 +1,159 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package uploader
+
+import (
+	"context"
+	"fmt"
+	"mime"
+	"path"
+	"strings"
+
+	"github.com/apache/incubator-answer/internal/base/reason"
+	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/apache/incubator-answer/internal/service/service_config"
+	"github.com/apache/incubator-answer/internal/service/siteinfo_common"
+	"github.com/apache/incubator-answer/pkg/storage"
+	"github.com/segmentfault/pacman/errors"
+)
+
+//go:generate mockgen -source=./storage_migrate.go -destination=../mock/uploaded_url_repo_mock.go -package=mock
+
+// UploadedURLRepo the urls of uploaded files in contents
+type UploadedURLRepo interface {
+	CountURLPrefix(ctx context.Context, urlPrefix string) (count int64, err error)
+	ReplaceURLPrefix(ctx context.Context, oldURLPrefix, newURLPrefix string) (affected int64, err error)
+	ScanContents(ctx context.Context, fn func(content string)) (err error)
+}
+
+// StorageMigrateService migrate the uploaded files between storages
+type StorageMigrateService struct {
+	serviceConfig   *service_config.ServiceConfig
+	siteInfoService siteinfo_common.SiteInfoCommonService
+	uploadedURLRepo UploadedURLRepo
+}
+
+// NewStorageMigrateService new storage migrate service
+func NewStorageMigrateService(
+	serviceConfig *service_config.ServiceConfig,
+	siteInfoService siteinfo_common.SiteInfoCommonService,
+	uploadedURLRepo UploadedURLRepo,
+) *StorageMigrateService {
+	return &StorageMigrateService{
+		serviceConfig:   serviceConfig,
+		siteInfoService: siteInfoService,
+		uploadedURLRepo: uploadedURLRepo,
+	}
+}
+
+// Migrate copies all uploaded files from the source storage to the target storage, then rewrites their urls
+// in contents. The files already in the target storage are skipped, so it can be run again after a failure.
+// The report is called after every file is checked.
+func (ss *StorageMigrateService) Migrate(ctx context.Context, req *schema.StorageMigrateReq,
+	report func(obj *storage.Object, copied bool)) (resp *schema.StorageMigrateResp, err error) {
+	if req.From == req.To {
+		return nil, errors.BadRequest(reason.RequestFormatError).WithMsg("the source and target storage are the same")
+	}
+	from, err := storage.New(ss.serviceConfig.Storage, req.From, ss.serviceConfig.UploadPath)
+	if err != nil {
+		return nil, errors.BadRequest(reason.RequestFormatError).WithError(err).WithMsg(err.Error())
+	}
+	to, err := storage.New(ss.serviceConfig.Storage, req.To, ss.serviceConfig.UploadPath)
+	if err != nil {
+		return nil, errors.BadRequest(reason.RequestFormatError).WithError(err).WithMsg(err.Error())
+	}
+	siteGeneral, err := ss.siteInfoService.GetSiteGeneral(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	resp = &schema.StorageMigrateResp{
+		OldURLPrefix: req.FromURLPrefix,
+		NewURLPrefix: req.ToURLPrefix,
+	}
+	if len(resp.OldURLPrefix) == 0 {
+		resp.OldURLPrefix = ss.urlPrefix(req.From, siteGeneral.SiteUrl)
+	}
+	if len(resp.NewURLPrefix) == 0 {
+		resp.NewURLPrefix = ss.urlPrefix(req.To, siteGeneral.SiteUrl)
+	}
+	resp.OldURLPrefix = strings.TrimSuffix(resp.OldURLPrefix, "/") + "/"
+	resp.NewURLPrefix = strings.TrimSuffix(resp.NewURLPrefix, "/") + "/"
+
+	err = from.List(ctx, "", func(obj *storage.Object) error {
+		// the thumbnails of avatar are generated again on demand
+		if strings.HasPrefix(obj.Key, avatarThumbSubPath+"/") {
+			return nil
+		}
+		resp.Files++
+		if target, err := to.Stat(ctx, obj.Key); err == nil && target.Size == obj.Size {
+			resp.Skipped++
+			report(obj, false)
+			return nil
+		}
+		if !req.DryRun {
+			if err := copyObject(ctx, from, to, obj); err != nil {
+				return err
+			}
+		}
+		resp.Copied++
+		report(obj, true)
+		return nil
+	})
+	if err != nil {
+		return nil, errors.InternalServer(reason.UnknownError).WithError(err).WithStack()
+	}
+
+	if resp.OldURLPrefix == resp.NewURLPrefix {
+		return resp, nil
+	}
+	if req.DryRun {
+		resp.Rewritten, err = ss.uploadedURLRepo.CountURLPrefix(ctx, resp.OldURLPrefix)
+	} else {
+		resp.Rewritten, err = ss.uploadedURLRepo.ReplaceURLPrefix(ctx, resp.OldURLPrefix, resp.NewURLPrefix)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// urlPrefix the url prefix of files in the storage, the files are served by the site if it is not the configured
+// storage or the url prefix is not configured
+func (ss *StorageMigrateService) urlPrefix(storageType, siteURL string) string {
+	if storageType == ss.serviceConfig.Storage.GetType() {
+		if urlPrefix := ss.serviceConfig.Storage.GetURLPrefix(); len(urlPrefix) > 0 {
+			return urlPrefix
+		}
+	}
+	return siteURL + "/uploads"
+}
+
+func copyObject(ctx context.Context, from, to storage.Storage, obj *storage.Object) error {
+	reader, err := from.Get(ctx, obj.Key)
+	if err != nil {
+		return fmt.Errorf("read %s failed: %w", obj.Key, err)
+	}
+	defer reader.Close()
+	if err = to.Put(ctx, obj.Key, reader, obj.Size, mime.TypeByExtension(path.Ext(obj.Key))); err != nil {
+		return fmt.Errorf("write %s failed: %w", obj.Key, err)
+	}
+	return nil
+}
@@ -21,8 +21,10 @@ package uploader
 
 import (
 	"bytes"
+	"context"
 	"fmt"
 	"io"
+	"mime"
 	"mime/multipart"
 	"net/http"
 	"os"
@@ -31,14 +33,18 @@ import (
 	"strings"
 
 	"github.com/apache/incubator-answer/internal/base/reason"
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/service/attachment"
 	"github.com/apache/incubator-answer/internal/service/service_config"
 	"github.com/apache/incubator-answer/internal/service/siteinfo_common"
 	"github.com/apache/incubator-answer/pkg/checker"
 	"github.com/apache/incubator-answer/pkg/dir"
+	"github.com/apache/incubator-answer/pkg/storage"
 	"github.com/apache/incubator-answer/pkg/uid"
 	"github.com/apache/incubator-answer/plugin"
 	"github.com/disintegration/imaging"
 	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
 	exifremove "github.com/scottleedavis/go-exif-remove"
 	"github.com/segmentfault/pacman/errors"
 	"github.com/segmentfault/pacman/log"
@@ -49,6 +55,7 @@ const (
 	avatarThumbSubPath = "avatar_thumb"
 	postSubPath        = "post"
 	brandingSubPath    = "branding"
+	attachmentSubPath  = "attachment"
 )
 
 var (
@@ -57,6 +64,7 @@ var (
 		avatarThumbSubPath,
 		postSubPath,
 		brandingSubPath,
+		attachmentSubPath,
 	}
 	supportedThumbFileExtMapping = map[string]imaging.Format{
 		".jpg":  imaging.JPEG,
@@ -71,26 +79,42 @@ type UploaderService interface {
 	UploadPostFile(ctx *gin.Context) (url string, err error)
 	UploadBrandingFile(ctx *gin.Context) (url string, err error)
 	AvatarThumbFile(ctx *gin.Context, fileName string, size int) (url string, err error)
+	UploadPostAttachment(ctx *gin.Context, userID string) (url string, err error)
+	DownloadAttachment(ctx context.Context, fileID string) (attachment *entity.Attachment, reader io.ReadCloser, err error)
+	OpenUploadedFile(ctx context.Context, fileSubPath string) (reader io.ReadCloser, err error)
+	CleanOrphanUploadsCron(ctx context.Context)
 }
 
 // uploaderService uploader service
 type uploaderService struct {
-	serviceConfig   *service_config.ServiceConfig
-	siteInfoService siteinfo_common.SiteInfoCommonService
+	serviceConfig     *service_config.ServiceConfig
+	siteInfoService   siteinfo_common.SiteInfoCommonService
+	attachmentService *attachment.AttachmentService
+	uploadedURLRepo   UploadedURLRepo
+	storage           storage.Storage
 }
 
 // NewUploaderService new upload service
 func NewUploaderService(serviceConfig *service_config.ServiceConfig,
-	siteInfoService siteinfo_common.SiteInfoCommonService) UploaderService {
+	siteInfoService siteinfo_common.SiteInfoCommonService,
+	attachmentService *attachment.AttachmentService,
+	uploadedURLRepo UploadedURLRepo) UploaderService {
 	for _, subPath := range subPathList {
 		err := dir.CreateDirIfNotExist(filepath.Join(serviceConfig.UploadPath, subPath))
 		if err != nil {
 			panic(err)
 		}
 	}
+	fileStorage, err := storage.New(serviceConfig.Storage, serviceConfig.Storage.GetType(), serviceConfig.UploadPath)
+	if err != nil {
+		panic(err)
+	}
 	return &uploaderService{
-		serviceConfig:   serviceConfig,
-		siteInfoService: siteInfoService,
+		serviceConfig:     serviceConfig,
+		siteInfoService:   siteInfoService,
+		attachmentService: attachmentService,
+		uploadedURLRepo:   uploadedURLRepo,
+		storage:           fileStorage,
 	}
 }
 
@@ -137,8 +161,12 @@ func (us *uploaderService) AvatarThumbFile(ctx *gin.Context, fileName string, si
 	if err == nil {
 		return thumbFilePath, nil
 	}
-	filePath := fmt.Sprintf("%s/%s/%s", us.serviceConfig.UploadPath, avatarSubPath, fileName)
-	avatarFile, err = os.ReadFile(filePath)
+	originalFile, err := us.OpenUploadedFile(ctx, path.Join(avatarSubPath, fileName))
+	if err != nil {
+		return "", err
+	}
+	avatarFile, err = io.ReadAll(originalFile)
+	_ = originalFile.Close()
 	if err != nil {
 		return "", errors.InternalServer(reason.UnknownError).WithError(err).WithStack()
 	}
@@ -227,6 +255,85 @@ func (us *uploaderService) UploadBrandingFile(ctx *gin.Context) (
 	return us.uploadFile(ctx, fileHeader, avatarFilePath)
 }
 
+// UploadPostAttachment upload the file attached to post, the file type and size must be allowed by the site write config
+func (us *uploaderService) UploadPostAttachment(ctx *gin.Context, userID string) (url string, err error) {
+	siteWrite, err := us.siteInfoService.GetSiteWrite(ctx)
+	if err != nil {
+		return "", err
+	}
+	if len(siteWrite.AttachmentTypes) == 0 {
+		return "", errors.BadRequest(reason.UploadFileUnsupportedFileFormat)
+	}
+
+	// max size
+	ctx.Request.Body = http.MaxBytesReader(ctx.Writer, ctx.Request.Body, int64(siteWrite.MaxAttachmentSize())*1024*1024)
+	file, fileHeader, err := ctx.Request.FormFile("file")
+	if err != nil {
+		return "", errors.BadRequest(reason.RequestFormatError).WithError(err)
+	}
+	file.Close()
+	fileExt := strings.ToLower(path.Ext(fileHeader.Filename))
+	attachmentType := siteWrite.GetAttachmentType(fileExt)
+	if attachmentType == nil {
+		return "", errors.BadRequest(reason.UploadFileUnsupportedFileFormat)
+	}
+	if fileHeader.Size > int64(attachmentType.MaxSize)*1024*1024 {
+		return "", errors.BadRequest(reason.UploadFileTooLarge)
+	}
+
+	siteGeneral, err := us.siteInfoService.GetSiteGeneral(ctx)
+	if err != nil {
+		return "", err
+	}
+	fileID := strings.ReplaceAll(uuid.NewString(), "-", "")
+	fileSubPath := path.Join(attachmentSubPath, fileID+fileExt)
+	filePath := path.Join(us.serviceConfig.UploadPath, fileSubPath)
+	if err := ctx.SaveUploadedFile(fileHeader, filePath); err != nil {
+		return "", errors.InternalServer(reason.UnknownError).WithError(err).WithStack()
+	}
+	mimeType, ok := checker.SniffAttachmentFile(filePath)
+	if !ok {
+		log.Warnf("attachment %s is rejected, the content is %s", fileHeader.Filename, mimeType)
+		_ = os.Remove(filePath)
+		return "", errors.BadRequest(reason.UploadFileUnsupportedFileFormat)
+	}
+
+	fileName := []rune(path.Base(strings.ReplaceAll(fileHeader.Filename, "\\", "/")))
+	if len(fileName) > 255 {
+		fileName = fileName[len(fileName)-255:]
+	}
+	err = us.attachmentService.AddAttachment(ctx, &entity.Attachment{
+		FileID:   fileID,
+		UserID:   userID,
+		ObjectID: "0",
+		FileName: string(fileName),
+		FilePath: fileSubPath,
+		MimeType: mimeType,
+		FileSize: fileHeader.Size,
+	})
+	if err != nil {
+		_ = os.Remove(filePath)
+		return "", err
+	}
+	_ = us.saveToStorage(ctx, siteGeneral.SiteUrl, fileSubPath, filePath)
+	return fmt.Sprintf("%s/attachments/%s", siteGeneral.SiteUrl, fileID), nil
+}
+
+// DownloadAttachment open the attachment file and count the download
+func (us *uploaderService) DownloadAttachment(ctx context.Context, fileID string) (
+	attachment *entity.Attachment, reader io.ReadCloser, err error) {
+	attachment, err = us.attachmentService.GetAttachment(ctx, fileID)
+	if err != nil {
+		return nil, nil, err
+	}
+	reader, err = us.OpenUploadedFile(ctx, attachment.FilePath)
+	if err != nil {
+		return nil, nil, err
+	}
+	us.attachmentService.IncreaseDownloadCount(ctx, attachment)
+	return attachment, reader, nil
+}
+
 func (us *uploaderService) uploadFile(ctx *gin.Context, file *multipart.FileHeader, fileSubPath string) (
 	url string, err error) {
 	siteGeneral, err := us.siteInfoService.GetSiteGeneral(ctx)
@@ -252,8 +359,76 @@ func (us *uploaderService) uploadFile(ctx *gin.Context, file *multipart.FileHead
 		log.Error(err)
 	}
 
-	url = fmt.Sprintf("%s/uploads/%s", siteGeneral.SiteUrl, fileSubPath)
-	return url, nil
+	if path.Dir(fileSubPath) == postSubPath {
+		files, err := us.generateImageVariants(filePath)
+		if err != nil {
+			log.Errorf("generate variants of %s failed, keep it as-is: %v", fileSubPath, err)
+		} else {
+			for _, file := range files[1:] {
+				_ = us.saveToStorage(ctx, siteGeneral.SiteUrl, path.Join(postSubPath, filepath.Base(file)), file)
+			}
+			filePath, fileSubPath = files[0], path.Join(postSubPath, filepath.Base(files[0]))
+		}
+	}
+	return us.saveToStorage(ctx, siteGeneral.SiteUrl, fileSubPath, filePath), nil
+}
+
+// saveToStorage moves the checked local file to the configured storage and returns the url of file.
+// If the storage is not available, the file is kept on local disk and served by the site.
+func (us *uploaderService) saveToStorage(ctx context.Context, siteURL, fileSubPath, localFilePath string) (url string) {
+	localURL := fmt.Sprintf("%s/uploads/%s", siteURL, fileSubPath)
+	if us.storage.Type() != storage.TypeLocal {
+		if err := us.putToStorage(ctx, fileSubPath, localFilePath); err != nil {
+			log.Errorf("save file %s to %s storage failed, keep it on local disk: %v", fileSubPath, us.storage.Type(), err)
+			return localURL
+		}
+		if err := os.Remove(localFilePath); err != nil {
+			log.Warnf("remove local file %s failed: %v", localFilePath, err)
+		}
+	}
+	if urlPrefix := us.serviceConfig.Storage.GetURLPrefix(); len(urlPrefix) > 0 {
+		return urlPrefix + "/" + fileSubPath
+	}
+	return localURL
+}
+
+func (us *uploaderService) putToStorage(ctx context.Context, fileSubPath, localFilePath string) error {
+	file, err := os.Open(localFilePath)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	info, err := file.Stat()
+	if err != nil {
+		return err
+	}
+	return us.storage.Put(ctx, fileSubPath, file, info.Size(), mime.TypeByExtension(path.Ext(fileSubPath)))
+}
+
+// OpenUploadedFile opens the uploaded file on local disk, or in the storage if it is not on local disk
+func (us *uploaderService) OpenUploadedFile(ctx context.Context, fileSubPath string) (reader io.ReadCloser, err error) {
+	key, err := storage.CleanKey(fileSubPath)
+	if err != nil {
+		return nil, errors.NotFound(reason.ObjectNotFound)
+	}
+	file, err := os.Open(filepath.Join(us.serviceConfig.UploadPath, filepath.FromSlash(key)))
+	if err == nil {
+		return file, nil
+	}
+	if !os.IsNotExist(err) {
+		return nil, errors.InternalServer(reason.UnknownError).WithError(err).WithStack()
+	}
+	if us.storage.Type() == storage.TypeLocal {
+		return nil, errors.NotFound(reason.ObjectNotFound)
+	}
+	reader, err = us.storage.Get(ctx, key)
+	if err == storage.ErrNotExist {
+		return nil, errors.NotFound(reason.ObjectNotFound)
+	}
+	if err != nil {
+		return nil, errors.InternalServer(reason.UnknownError).WithError(err).WithStack()
+	}
+	return reader, nil
 }
 
 func (us *uploaderService) tryToUploadByPlugin(ctx *gin.Context, source plugin.UploadSource) (
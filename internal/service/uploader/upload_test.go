@@ -0,0 +1,222 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package uploader
+
+import (
+	"context"
+	"image"
+	"image/color"
+	"image/png"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/service/attachment"
+	"github.com/apache/incubator-answer/internal/service/mock"
+	"github.com/apache/incubator-answer/internal/service/service_config"
+	"github.com/apache/incubator-answer/pkg/storage"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testUploadEnv keeps the contents and the attachments of the mocked repositories in memory
+type testUploadEnv struct {
+	contents    []string
+	attachments []*entity.Attachment
+}
+
+func (e *testUploadEnv) getUnlinkedAttachments(ctx context.Context, createdBefore time.Time, lastID int64,
+	limit int) ([]*entity.Attachment, error) {
+	attachments := make([]*entity.Attachment, 0)
+	for _, a := range e.attachments {
+		if a.ObjectID == "0" && a.CreatedAt.Before(createdBefore) && a.ID > lastID && len(attachments) < limit {
+			attachments = append(attachments, a)
+		}
+	}
+	return attachments, nil
+}
+
+func (e *testUploadEnv) removeUnlinkedAttachment(ctx context.Context, id int64) (bool, error) {
+	for i, a := range e.attachments {
+		if a.ID == id && a.ObjectID == "0" {
+			e.attachments = append(e.attachments[:i], e.attachments[i+1:]...)
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func newTestUploaderService(t *testing.T, conf *service_config.ServiceConfig, env *testUploadEnv) *uploaderService {
+	conf.UploadPath = t.TempDir()
+	for _, subPath := range subPathList {
+		require.NoError(t, os.MkdirAll(filepath.Join(conf.UploadPath, subPath), 0755))
+	}
+	ctl := gomock.NewController(t)
+
+	uploadedURLRepo := mock.NewMockUploadedURLRepo(ctl)
+	uploadedURLRepo.EXPECT().ScanContents(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, fn func(content string)) error {
+			for _, content := range env.contents {
+				fn(content)
+			}
+			return nil
+		}).AnyTimes()
+
+	attachmentRepo := mock.NewMockAttachmentRepo(ctl)
+	attachmentRepo.EXPECT().GetUnlinkedAttachments(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(env.getUnlinkedAttachments).AnyTimes()
+	attachmentRepo.EXPECT().RemoveUnlinkedAttachment(gomock.Any(), gomock.Any()).
+		DoAndReturn(env.removeUnlinkedAttachment).AnyTimes()
+
+	return &uploaderService{
+		serviceConfig:     conf,
+		uploadedURLRepo:   uploadedURLRepo,
+		attachmentService: attachment.NewAttachmentService(attachmentRepo),
+		storage:           storage.NewLocalStorage(conf.UploadPath),
+	}
+}
+
+func listUploadedFiles(t *testing.T, root string) (files []string) {
+	err := filepath.Walk(root, func(p string, info os.FileInfo, err error) error {
+		if err == nil && !info.IsDir() {
+			rel, _ := filepath.Rel(root, p)
+			files = append(files, filepath.ToSlash(rel))
+		}
+		return err
+	})
+	require.NoError(t, err)
+	sort.Strings(files)
+	return files
+}
+
+func TestUploadedFileID(t *testing.T) {
+	assert.Equal(t, "5Ab9x", uploadedFileID("post/5Ab9x.png"))
+	assert.Equal(t, "5Ab9x", uploadedFileID("post/5Ab9x_320_800_1920_webp@320.webp"))
+	assert.Equal(t, "5Ab9x", uploadedFileID("avatar_thumb/100_100@5Ab9x.jpg"))
+	assert.Equal(t, "", uploadedFileID("avatar_thumb/5Ab9x.jpg"))
+	assert.Equal(t, "", uploadedFileID("post/.gitkeep"))
+	assert.Equal(t, "", uploadedFileID("post/my-image.png"))
+}
+
+func TestCleanOrphanUploadsCron(t *testing.T) {
+	env := &testUploadEnv{contents: []string{
+		`<img src="http://localhost/uploads/post/Used1_320_800_1000.png">`,
+		`{"type":"custom","custom":"http://cdn.example.com/avatar/Used2.jpg"}`,
+	}}
+	us := newTestUploaderService(t, &service_config.ServiceConfig{OrphanUploadGraceHours: 24}, env)
+	old := time.Now().Add(-48 * time.Hour)
+	for _, file := range []string{
+		"post/Used1_320_800_1000.png", "post/Used1_320_800_1000@320.png",
+		"avatar/Used2.jpg", "avatar_thumb/100_100@Used2.jpg",
+		"post/Gone1.png", "avatar_thumb/100_100@Gone2.jpg", "branding/Gone3.png",
+		"post/New1.png", "post/not-ours.png", "attachment/0123456789abcdef0123456789abcdef.pdf",
+	} {
+		p := filepath.Join(us.serviceConfig.UploadPath, filepath.FromSlash(file))
+		require.NoError(t, os.WriteFile(p, []byte("x"), 0644))
+		if file != "post/New1.png" {
+			require.NoError(t, os.Chtimes(p, old, old))
+		}
+	}
+
+	// unlinked attachments are tracked by their records, the files without records are never deleted
+	for i, file := range []struct {
+		path      string
+		objectID  string
+		createdAt time.Time
+	}{
+		{"attachment/11111111111111111111111111111111.pdf", "0", old},
+		{"attachment/22222222222222222222222222222222.pdf", "10010000000000001", old},
+		{"attachment/33333333333333333333333333333333.pdf", "0", time.Now()},
+	} {
+		p := filepath.Join(us.serviceConfig.UploadPath, filepath.FromSlash(file.path))
+		require.NoError(t, os.WriteFile(p, []byte("x"), 0644))
+		env.attachments = append(env.attachments, &entity.Attachment{
+			ID: int64(i + 1), ObjectID: file.objectID, FilePath: file.path, CreatedAt: file.createdAt})
+	}
+
+	us.CleanOrphanUploadsCron(context.Background())
+	assert.Len(t, env.attachments, 2)
+	assert.Equal(t, []string{
+		"attachment/0123456789abcdef0123456789abcdef.pdf",
+		"attachment/22222222222222222222222222222222.pdf",
+		"attachment/33333333333333333333333333333333.pdf",
+		"avatar/Used2.jpg",
+		"avatar_thumb/100_100@Used2.jpg",
+		"post/New1.png",
+		"post/Used1_320_800_1000.png",
+		"post/Used1_320_800_1000@320.png",
+		"post/not-ours.png",
+	}, listUploadedFiles(t, us.serviceConfig.UploadPath))
+
+	// disabled
+	us.serviceConfig.OrphanUploadGraceHours = 0
+	env.contents = nil
+	us.CleanOrphanUploadsCron(context.Background())
+	assert.Len(t, listUploadedFiles(t, us.serviceConfig.UploadPath), 9)
+}
+
+func TestGenerateImageVariants(t *testing.T) {
+	us := newTestUploaderService(t, &service_config.ServiceConfig{Image: &service_config.ImageConfig{
+		Variants: true, ThumbnailWidth: 100, MediumWidth: 300, FullWidth: 500, WebP: true,
+	}}, &testUploadEnv{})
+	img := image.NewNRGBA(image.Rect(0, 0, 800, 200))
+	for y := 0; y < 200; y++ {
+		for x := 0; x < 800; x++ {
+			img.SetNRGBA(x, y, color.NRGBA{R: uint8(x / 8), G: uint8(y), B: 100, A: 0xff})
+		}
+	}
+	filePath := filepath.Join(us.serviceConfig.UploadPath, "post", "Pic1.png")
+	file, err := os.Create(filePath)
+	require.NoError(t, err)
+	require.NoError(t, png.Encode(file, img))
+	require.NoError(t, file.Close())
+
+	files, err := us.generateImageVariants(filePath)
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(us.serviceConfig.UploadPath, "post", "Pic1_100_300_500_webp.png"), files[0])
+	assert.Equal(t, []string{
+		"post/Pic1_100_300_500_webp.png",
+		"post/Pic1_100_300_500_webp.webp",
+		"post/Pic1_100_300_500_webp@100.png",
+		"post/Pic1_100_300_500_webp@100.webp",
+		"post/Pic1_100_300_500_webp@300.png",
+		"post/Pic1_100_300_500_webp@300.webp",
+	}, listUploadedFiles(t, us.serviceConfig.UploadPath))
+
+	full, err := os.Open(files[0])
+	require.NoError(t, err)
+	defer full.Close()
+	fullConfig, _, err := image.DecodeConfig(full)
+	require.NoError(t, err)
+	assert.Equal(t, 500, fullConfig.Width)
+	assert.Equal(t, 125, fullConfig.Height)
+
+	// the small image is stored as-is
+	filePath = filepath.Join(us.serviceConfig.UploadPath, "post", "Pic2.jpg")
+	require.NoError(t, os.WriteFile(filePath, []byte("not an image"), 0644))
+	us.serviceConfig.Image = nil
+	files, err = us.generateImageVariants(filePath)
+	require.NoError(t, err)
+	assert.Equal(t, []string{filePath}, files)
+}
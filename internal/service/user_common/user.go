@@ -39,6 +39,7 @@ import (
 	"github.com/segmentfault/pacman/log"
 )
 
+//go:generate mockgen -source=./user.go -destination=../mock/user_repo_mock.go -package=mock
 type UserRepo interface {
 	AddUser(ctx context.Context, user *entity.User) (err error)
 	IncreaseAnswerCount(ctx context.Context, userID string, amount int) (err error)
@@ -0,0 +1,366 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package user_data
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/apache/incubator-answer/internal/base/constant"
+	"github.com/apache/incubator-answer/internal/base/reason"
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/apache/incubator-answer/internal/service/activity_type"
+	"github.com/apache/incubator-answer/internal/service/auth"
+	"github.com/apache/incubator-answer/internal/service/config"
+	usercommon "github.com/apache/incubator-answer/internal/service/user_common"
+	"github.com/apache/incubator-answer/pkg/converter"
+	"github.com/segmentfault/pacman/errors"
+	"github.com/segmentfault/pacman/log"
+)
+
+// voteActivityTypes the activity types of voting by user
+var voteActivityTypes = []string{
+	activity_type.QuestionVoteUp,
+	activity_type.QuestionVoteDown,
+	activity_type.AnswerVoteUp,
+	activity_type.AnswerVoteDown,
+	activity_type.CommentVoteUp,
+}
+
+// UserDataRepo user data repository
+type UserDataRepo interface {
+	GetUserQuestions(ctx context.Context, userID string) (questions []*entity.Question, err error)
+	GetUserAnswers(ctx context.Context, userID string) (answers []*entity.Answer, err error)
+	GetUserComments(ctx context.Context, userID string) (comments []*entity.Comment, err error)
+	GetUserActivities(ctx context.Context, userID string, activityTypes []int) (activities []*entity.Activity, err error)
+	GetUserCollections(ctx context.Context, userID string) (collections []*entity.Collection, err error)
+	GetUserNotifications(ctx context.Context, userID string) (notifications []*entity.Notification, err error)
+	GetUserExternalLogins(ctx context.Context, userID string) (logins []*entity.UserExternalLogin, err error)
+	EraseUser(ctx context.Context, user *entity.User, auditLog *entity.AuditLog) (err error)
+}
+
+// UserDataService export and erase the personal data of user
+type UserDataService struct {
+	userDataRepo  UserDataRepo
+	userRepo      usercommon.UserRepo
+	configService *config.ConfigService
+	authService   *auth.AuthService
+}
+
+// NewUserDataService new user data service
+func NewUserDataService(
+	userDataRepo UserDataRepo,
+	userRepo usercommon.UserRepo,
+	configService *config.ConfigService,
+	authService *auth.AuthService,
+) *UserDataService {
+	return &UserDataService{
+		userDataRepo:  userDataRepo,
+		userRepo:      userRepo,
+		configService: configService,
+		authService:   authService,
+	}
+}
+
+// ExportUserData build an archive of the profile, posts, comments, votes, collections,
+// notifications and external logins of user
+func (us *UserDataService) ExportUserData(ctx context.Context, userID string) (
+	fileName string, content []byte, err error) {
+	userInfo, exist, err := us.userRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		return "", nil, err
+	}
+	if !exist {
+		return "", nil, errors.BadRequest(reason.UserNotFound)
+	}
+
+	files := make(map[string]any)
+	files["profile.json"] = &schema.UserDataProfile{
+		ID:            userInfo.ID,
+		Username:      userInfo.Username,
+		DisplayName:   userInfo.DisplayName,
+		EMail:         userInfo.EMail,
+		Mobile:        userInfo.Mobile,
+		Avatar:        userInfo.Avatar,
+		Bio:           userInfo.Bio,
+		Website:       userInfo.Website,
+		Location:      userInfo.Location,
+		IPInfo:        userInfo.IPInfo,
+		Language:      userInfo.Language,
+		Rank:          userInfo.Rank,
+		Status:        constant.ConvertUserStatus(userInfo.Status, userInfo.MailStatus),
+		CreatedAt:     unixTime(userInfo.CreatedAt),
+		LastLoginDate: unixTime(userInfo.LastLoginDate),
+	}
+	if files["questions.json"], err = us.getQuestions(ctx, userID); err != nil {
+		return "", nil, err
+	}
+	if files["answers.json"], err = us.getAnswers(ctx, userID); err != nil {
+		return "", nil, err
+	}
+	if files["comments.json"], err = us.getComments(ctx, userID); err != nil {
+		return "", nil, err
+	}
+	if files["votes.json"], err = us.getVotes(ctx, userID); err != nil {
+		return "", nil, err
+	}
+	if files["collections.json"], err = us.getCollections(ctx, userID); err != nil {
+		return "", nil, err
+	}
+	if files["notifications.json"], err = us.getNotifications(ctx, userID); err != nil {
+		return "", nil, err
+	}
+	if files["external_logins.json"], err = us.getExternalLogins(ctx, userID); err != nil {
+		return "", nil, err
+	}
+
+	content, err = writeUserDataArchive(files)
+	if err != nil {
+		return "", nil, errors.InternalServer(reason.UnknownError).WithError(err).WithStack()
+	}
+	fileName = fmt.Sprintf("answer_user_data_%s_%s.tar.gz", userInfo.ID, time.Now().Format("20060102150405"))
+	return fileName, content, nil
+}
+
+func (us *UserDataService) getQuestions(ctx context.Context, userID string) (resp []*schema.UserDataPost, err error) {
+	questions, err := us.userDataRepo.GetUserQuestions(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	resp = make([]*schema.UserDataPost, 0, len(questions))
+	for _, q := range questions {
+		resp = append(resp, &schema.UserDataPost{
+			ID:        q.ID,
+			Title:     q.Title,
+			Content:   q.OriginalText,
+			Status:    q.Status,
+			VoteCount: q.VoteCount,
+			CreatedAt: unixTime(q.CreatedAt),
+			UpdatedAt: unixTime(q.UpdatedAt),
+		})
+	}
+	return resp, nil
+}
+
+func (us *UserDataService) getAnswers(ctx context.Context, userID string) (resp []*schema.UserDataPost, err error) {
+	answers, err := us.userDataRepo.GetUserAnswers(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	resp = make([]*schema.UserDataPost, 0, len(answers))
+	for _, a := range answers {
+		resp = append(resp, &schema.UserDataPost{
+			ID:         a.ID,
+			QuestionID: a.QuestionID,
+			Content:    a.OriginalText,
+			Status:     a.Status,
+			VoteCount:  a.VoteCount,
+			CreatedAt:  unixTime(a.CreatedAt),
+			UpdatedAt:  unixTime(a.UpdatedAt),
+		})
+	}
+	return resp, nil
+}
+
+func (us *UserDataService) getComments(ctx context.Context, userID string) (resp []*schema.UserDataComment, err error) {
+	comments, err := us.userDataRepo.GetUserComments(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	resp = make([]*schema.UserDataComment, 0, len(comments))
+	for _, c := range comments {
+		resp = append(resp, &schema.UserDataComment{
+			ID:         c.ID,
+			ObjectID:   c.ObjectID,
+			QuestionID: c.QuestionID,
+			Content:    c.OriginalText,
+			Status:     c.Status,
+			CreatedAt:  unixTime(c.CreatedAt),
+		})
+	}
+	return resp, nil
+}
+
+func (us *UserDataService) getVotes(ctx context.Context, userID string) (resp []*schema.UserDataVote, err error) {
+	activityTypes := make([]int, 0, len(voteActivityTypes))
+	activityTypeKeys := make(map[int]string, len(voteActivityTypes))
+	for _, key := range voteActivityTypes {
+		id, err := us.configService.GetIDByKey(ctx, key)
+		if err != nil {
+			return nil, err
+		}
+		activityTypes = append(activityTypes, id)
+		activityTypeKeys[id] = key
+	}
+	activities, err := us.userDataRepo.GetUserActivities(ctx, userID, activityTypes)
+	if err != nil {
+		return nil, err
+	}
+	resp = make([]*schema.UserDataVote, 0, len(activities))
+	for _, act := range activities {
+		resp = append(resp, &schema.UserDataVote{
+			ObjectID:  act.ObjectID,
+			Type:      activityTypeKeys[act.ActivityType],
+			Cancelled: act.Cancelled == entity.ActivityCancelled,
+			CreatedAt: unixTime(act.CreatedAt),
+		})
+	}
+	return resp, nil
+}
+
+func (us *UserDataService) getCollections(ctx context.Context, userID string) (
+	resp []*schema.UserDataCollection, err error) {
+	collections, err := us.userDataRepo.GetUserCollections(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	resp = make([]*schema.UserDataCollection, 0, len(collections))
+	for _, c := range collections {
+		resp = append(resp, &schema.UserDataCollection{ObjectID: c.ObjectID, CreatedAt: unixTime(c.CreatedAt)})
+	}
+	return resp, nil
+}
+
+func (us *UserDataService) getNotifications(ctx context.Context, userID string) (
+	resp []*schema.UserDataNotification, err error) {
+	notifications, err := us.userDataRepo.GetUserNotifications(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	resp = make([]*schema.UserDataNotification, 0, len(notifications))
+	for _, n := range notifications {
+		resp = append(resp, &schema.UserDataNotification{
+			ObjectID:  n.ObjectID,
+			Type:      n.Type,
+			Content:   n.Content,
+			IsRead:    n.IsRead == schema.NotificationRead,
+			CreatedAt: unixTime(n.CreatedAt),
+		})
+	}
+	return resp, nil
+}
+
+func (us *UserDataService) getExternalLogins(ctx context.Context, userID string) (
+	resp []*schema.UserDataExternalLogin, err error) {
+	logins, err := us.userDataRepo.GetUserExternalLogins(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	resp = make([]*schema.UserDataExternalLogin, 0, len(logins))
+	for _, l := range logins {
+		resp = append(resp, &schema.UserDataExternalLogin{
+			Provider:   l.Provider,
+			ExternalID: l.ExternalID,
+			MetaInfo:   l.MetaInfo,
+			CreatedAt:  unixTime(l.CreatedAt),
+		})
+	}
+	return resp, nil
+}
+
+// unixTime convert time to unix timestamp, the zero time is 0
+func unixTime(t time.Time) int64 {
+	if t.IsZero() {
+		return 0
+	}
+	return t.Unix()
+}
+
+// writeUserDataArchive write the files as json to a tar.gz archive in memory
+func writeUserDataArchive(files map[string]any) ([]byte, error) {
+	buf := &bytes.Buffer{}
+	gzipWriter := gzip.NewWriter(buf)
+	tarWriter := tar.NewWriter(gzipWriter)
+	names := make([]string, 0, len(files))
+	for name := range files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		content, err := json.MarshalIndent(files[name], "", "  ")
+		if err != nil {
+			return nil, err
+		}
+		header := &tar.Header{Name: name, Mode: 0o644, Size: int64(len(content)), ModTime: time.Now()}
+		if err = tarWriter.WriteHeader(header); err != nil {
+			return nil, err
+		}
+		if _, err = tarWriter.Write(content); err != nil {
+			return nil, err
+		}
+	}
+	if err := tarWriter.Close(); err != nil {
+		return nil, err
+	}
+	if err := gzipWriter.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// EraseUser erase the personal data of user. The posts of user are kept, but the authorship is anonymized
+// as the deleted user, the email, mobile, ip info and profile are cleared and the user can not login any more.
+func (us *UserDataService) EraseUser(ctx context.Context, req *schema.EraseUserReq) (err error) {
+	if req.UserID == req.LoginUserID {
+		return errors.BadRequest(reason.AdminCannotModifySelfStatus)
+	}
+	userInfo, exist, err := us.userRepo.GetByUserID(ctx, req.UserID)
+	if err != nil {
+		return err
+	}
+	if !exist {
+		return errors.BadRequest(reason.UserNotFound)
+	}
+
+	anonymousName := "user" + converter.DeleteUserDisplay(userInfo.ID)
+	username := anonymousName
+	if other, exist, err := us.userRepo.GetByUsername(ctx, username); err != nil {
+		return err
+	} else if exist && other.ID != userInfo.ID {
+		username = fmt.Sprintf("%s_%s", anonymousName, userInfo.ID)
+	}
+
+	erased := &entity.User{
+		ID:          userInfo.ID,
+		Username:    username,
+		DisplayName: anonymousName,
+		Status:      entity.UserStatusDeleted,
+		DeletedAt:   time.Now(),
+	}
+	auditLog := &entity.AuditLog{
+		OperatorID: req.LoginUserID,
+		Action:     entity.AuditActionUserErase,
+		ObjectID:   userInfo.ID,
+		Detail:     "erased username, display name, email, mobile, ip info, password, profile, external logins, notifications and email delivery logs",
+	}
+	if err = us.userDataRepo.EraseUser(ctx, erased, auditLog); err != nil {
+		return err
+	}
+	us.authService.RemoveUserAllTokens(ctx, userInfo.ID)
+	log.Infof("user %s is erased by %s", userInfo.ID, req.LoginUserID)
+	return nil
+}
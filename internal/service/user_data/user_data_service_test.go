@@ -0,0 +1,64 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package user_data
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUnixTime(t *testing.T) {
+	assert.Equal(t, int64(0), unixTime(time.Time{}))
+	assert.Equal(t, int64(1704164645), unixTime(time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)))
+}
+
+func TestWriteUserDataArchive(t *testing.T) {
+	content, err := writeUserDataArchive(map[string]any{
+		"votes.json":   []string{},
+		"profile.json": map[string]string{"username": "alice"},
+	})
+	assert.NoError(t, err)
+
+	gzipReader, err := gzip.NewReader(bytes.NewReader(content))
+	assert.NoError(t, err)
+	tarReader := tar.NewReader(gzipReader)
+	names := make([]string, 0)
+	files := make(map[string]string)
+	for {
+		header, err := tarReader.Next()
+		if err == io.EOF {
+			break
+		}
+		assert.NoError(t, err)
+		body, err := io.ReadAll(tarReader)
+		assert.NoError(t, err)
+		names = append(names, header.Name)
+		files[header.Name] = string(body)
+	}
+	assert.Equal(t, []string{"profile.json", "votes.json"}, names)
+	assert.Contains(t, files["profile.json"], `"username": "alice"`)
+	assert.Equal(t, "[]", files["votes.json"])
+}
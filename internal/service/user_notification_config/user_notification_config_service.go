@@ -38,6 +38,12 @@ type UserNotificationConfigRepo interface {
 		[]*entity.UserNotificationConfig, error)
 }
 
+// NotificationMuteRepo the questions muted by the user, muted questions send no email notifications
+type NotificationMuteRepo interface {
+	AddMute(ctx context.Context, userID, objectID string) (err error)
+	IsMuted(ctx context.Context, userID, objectID string) (muted bool, err error)
+}
+
 type UserNotificationConfigService struct {
 	userRepo                   usercommon.UserRepo
 	userNotificationConfigRepo UserNotificationConfigRepo
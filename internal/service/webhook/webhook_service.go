@@ -0,0 +1,451 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/apache/incubator-answer/internal/base/constant"
+	"github.com/apache/incubator-answer/internal/base/pager"
+	"github.com/apache/incubator-answer/internal/base/reason"
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/apache/incubator-answer/internal/service/job_queue"
+	"github.com/apache/incubator-answer/internal/service/object_info"
+	"github.com/apache/incubator-answer/internal/service/siteinfo_common"
+	"github.com/apache/incubator-answer/internal/service/webhook_queue"
+	"github.com/apache/incubator-answer/pkg/display"
+	"github.com/apache/incubator-answer/pkg/token"
+	"github.com/apache/incubator-answer/pkg/uid"
+	"github.com/segmentfault/pacman/errors"
+	"github.com/segmentfault/pacman/log"
+)
+
+const (
+	// WebhookQueueName the name of the persisted queue which delivers webhooks
+	WebhookQueueName = "webhook"
+
+	deliveryTimeout     = 10 * time.Second
+	maxResponseBodySize = 4096
+
+	HeaderEvent     = "X-Answer-Event"
+	HeaderDelivery  = "X-Answer-Delivery"
+	HeaderSignature = "X-Answer-Signature"
+)
+
+// WebhookRepo webhook repository
+type WebhookRepo interface {
+	AddWebhook(ctx context.Context, webhook *entity.Webhook) (err error)
+	UpdateWebhook(ctx context.Context, webhook *entity.Webhook) (err error)
+	RemoveWebhook(ctx context.Context, webhookID int64) (err error)
+	GetWebhook(ctx context.Context, webhookID int64) (webhook *entity.Webhook, exist bool, err error)
+	GetWebhookList(ctx context.Context) (webhooks []*entity.Webhook, err error)
+	GetEnabledWebhookList(ctx context.Context) (webhooks []*entity.Webhook, err error)
+	AddDelivery(ctx context.Context, delivery *entity.WebhookDelivery) (err error)
+	UpdateDelivery(ctx context.Context, delivery *entity.WebhookDelivery) (err error)
+	GetDelivery(ctx context.Context, deliveryID int64) (delivery *entity.WebhookDelivery, exist bool, err error)
+	GetDeliveryPage(ctx context.Context, page, pageSize int, webhookID int64) (
+		deliveries []*entity.WebhookDelivery, total int64, err error)
+}
+
+// WebhookService webhook service
+type WebhookService struct {
+	webhookRepo       WebhookRepo
+	jobQueueService   *job_queue.JobQueueService
+	objectInfoService *object_info.ObjService
+	siteInfoService   siteinfo_common.SiteInfoCommonService
+	httpClient        *http.Client
+}
+
+// NewWebhookService new webhook service
+func NewWebhookService(
+	webhookRepo WebhookRepo,
+	jobQueueService *job_queue.JobQueueService,
+	webhookQueueService webhook_queue.WebhookQueueService,
+	objectInfoService *object_info.ObjService,
+	siteInfoService siteinfo_common.SiteInfoCommonService,
+) *WebhookService {
+	ws := &WebhookService{
+		webhookRepo:       webhookRepo,
+		jobQueueService:   jobQueueService,
+		objectInfoService: objectInfoService,
+		siteInfoService:   siteInfoService,
+		httpClient:        &http.Client{Timeout: deliveryTimeout},
+	}
+	webhookQueueService.RegisterHandler(ws.handleEvent)
+	jobQueueService.RegisterHandler(WebhookQueueName, ws.handleDeliveryJob)
+	return ws
+}
+
+// handleEvent fan out the event to all enabled webhooks subscribed to it. The errors are only logged,
+// returning them would retry the whole event and create duplicated deliveries.
+func (ws *WebhookService) handleEvent(ctx context.Context, msg *schema.WebhookEventMsg) error {
+	ws.dispatch(ctx, msg)
+	return nil
+}
+
+func (ws *WebhookService) dispatch(ctx context.Context, msg *schema.WebhookEventMsg) {
+	webhooks, err := ws.webhookRepo.GetEnabledWebhookList(ctx)
+	if err != nil {
+		log.Error(err)
+		return
+	}
+	subscribers := make([]*entity.Webhook, 0)
+	for _, webhook := range webhooks {
+		if ws.isSubscribed(webhook, msg.Event) {
+			subscribers = append(subscribers, webhook)
+		}
+	}
+	if len(subscribers) == 0 {
+		return
+	}
+
+	payload, err := json.Marshal(ws.buildPayload(ctx, msg))
+	if err != nil {
+		log.Error(err)
+		return
+	}
+	for _, webhook := range subscribers {
+		delivery := &entity.WebhookDelivery{
+			WebhookID: webhook.ID,
+			Event:     string(msg.Event),
+			Payload:   string(payload),
+			Status:    entity.WebhookDeliveryStatusPending,
+		}
+		if err = ws.webhookRepo.AddDelivery(ctx, delivery); err != nil {
+			log.Error(err)
+			continue
+		}
+		err = ws.jobQueueService.Enqueue(ctx, WebhookQueueName, &schema.WebhookDeliveryJob{DeliveryID: delivery.ID})
+		if err != nil {
+			log.Error(err)
+		}
+	}
+}
+
+func (ws *WebhookService) isSubscribed(webhook *entity.Webhook, event constant.ActivityTypeKey) bool {
+	for _, e := range decodeEvents(webhook.Events) {
+		if e == string(event) {
+			return true
+		}
+	}
+	return false
+}
+
+func (ws *WebhookService) buildPayload(ctx context.Context, msg *schema.WebhookEventMsg) *schema.WebhookPayload {
+	payload := &schema.WebhookPayload{
+		Event:         string(msg.Event),
+		Timestamp:     time.Now().Unix(),
+		UserID:        msg.UserID,
+		TriggerUserID: msg.TriggerUserID,
+		ExtraInfo:     msg.ExtraInfo,
+	}
+	if len(msg.ObjectID) == 0 {
+		return payload
+	}
+	objInfo, err := ws.objectInfoService.GetInfo(ctx, uid.DeShortID(msg.ObjectID))
+	if err != nil || objInfo == nil {
+		log.Warnf("get webhook event object %s failed: %v", msg.ObjectID, err)
+		payload.Object = &schema.WebhookPayloadObject{ObjectID: msg.ObjectID}
+		return payload
+	}
+	payload.Object = &schema.WebhookPayloadObject{
+		ObjectID:      objInfo.ObjectID,
+		ObjectType:    objInfo.ObjectType,
+		Title:         objInfo.Title,
+		QuestionID:    objInfo.QuestionID,
+		AnswerID:      objInfo.AnswerID,
+		CommentID:     objInfo.CommentID,
+		TagID:         objInfo.TagID,
+		CreatorUserID: objInfo.ObjectCreatorUserID,
+	}
+	payload.Object.URL = ws.objectURL(ctx, objInfo)
+	return payload
+}
+
+func (ws *WebhookService) objectURL(ctx context.Context, objInfo *schema.SimpleObjectInfo) string {
+	if len(objInfo.QuestionID) == 0 {
+		return ""
+	}
+	general, err := ws.siteInfoService.GetSiteGeneral(ctx)
+	if err != nil {
+		return ""
+	}
+	seo, err := ws.siteInfoService.GetSiteSeo(ctx)
+	if err != nil {
+		return ""
+	}
+	switch objInfo.ObjectType {
+	case constant.AnswerObjectType:
+		return display.AnswerURL(seo.Permalink, general.SiteUrl, objInfo.QuestionID, objInfo.Title, objInfo.AnswerID)
+	case constant.CommentObjectType:
+		return display.CommentURL(seo.Permalink, general.SiteUrl,
+			objInfo.QuestionID, objInfo.Title, objInfo.AnswerID, objInfo.CommentID)
+	default:
+		return display.QuestionURL(seo.Permalink, general.SiteUrl, objInfo.QuestionID, objInfo.Title)
+	}
+}
+
+func (ws *WebhookService) handleDeliveryJob(ctx context.Context, payload []byte) error {
+	job := &schema.WebhookDeliveryJob{}
+	if err := json.Unmarshal(payload, job); err != nil {
+		return job_queue.Permanent(err)
+	}
+	delivery, exist, err := ws.webhookRepo.GetDelivery(ctx, job.DeliveryID)
+	if err != nil {
+		return err
+	}
+	if !exist {
+		return nil
+	}
+	webhook, exist, err := ws.webhookRepo.GetWebhook(ctx, delivery.WebhookID)
+	if err != nil {
+		return err
+	}
+	// the webhook has been removed or disabled after the event happened
+	if !exist || webhook.Status != entity.WebhookStatusEnabled {
+		return nil
+	}
+	return ws.deliver(ctx, webhook, delivery)
+}
+
+// deliver send the delivery to the webhook and record the result, returns error if it should be retried
+func (ws *WebhookService) deliver(ctx context.Context, webhook *entity.Webhook, delivery *entity.WebhookDelivery) error {
+	deliveryErr := ws.post(ctx, webhook, delivery)
+	delivery.Attempts++
+	if deliveryErr != nil {
+		delivery.Status = entity.WebhookDeliveryStatusFailed
+		delivery.Error = deliveryErr.Error()
+	} else {
+		delivery.Status = entity.WebhookDeliveryStatusSuccess
+		delivery.Error = ""
+	}
+	if err := ws.webhookRepo.UpdateDelivery(ctx, delivery); err != nil {
+		log.Error(err)
+	}
+	return deliveryErr
+}
+
+func (ws *WebhookService) post(ctx context.Context, webhook *entity.Webhook, delivery *entity.WebhookDelivery) error {
+	body := []byte(delivery.Payload)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhook.URL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "Answer-Webhook")
+	req.Header.Set(HeaderEvent, delivery.Event)
+	req.Header.Set(HeaderDelivery, strconv.FormatInt(delivery.ID, 10))
+	req.Header.Set(HeaderSignature, Sign(webhook.Secret, body))
+
+	start := time.Now()
+	resp, err := ws.httpClient.Do(req)
+	delivery.Duration = time.Since(start).Milliseconds()
+	if err != nil {
+		delivery.ResponseCode = 0
+		delivery.ResponseBody = ""
+		return err
+	}
+	defer resp.Body.Close()
+	respBody, _ := io.ReadAll(io.LimitReader(resp.Body, maxResponseBodySize))
+	delivery.ResponseCode = resp.StatusCode
+	delivery.ResponseBody = string(respBody)
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook responded with status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// Sign returns the value of the signature header, it is the hex encoded HMAC-SHA256 of body
+func Sign(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// AddWebhook add webhook
+func (ws *WebhookService) AddWebhook(ctx context.Context, req *schema.AddWebhookReq) (
+	resp *schema.GetWebhookResp, err error) {
+	webhook := &entity.Webhook{
+		Name:   req.Name,
+		URL:    req.URL,
+		Secret: req.Secret,
+		Events: encodeEvents(req.Events),
+		Status: entity.WebhookStatusEnabled,
+	}
+	if len(webhook.Secret) == 0 {
+		webhook.Secret = token.GenerateToken()
+	}
+	if err = ws.webhookRepo.AddWebhook(ctx, webhook); err != nil {
+		return nil, err
+	}
+	return formatWebhook(webhook), nil
+}
+
+// UpdateWebhook update webhook, it can be disabled by setting enabled to false
+func (ws *WebhookService) UpdateWebhook(ctx context.Context, req *schema.UpdateWebhookReq) (err error) {
+	webhook, exist, err := ws.webhookRepo.GetWebhook(ctx, req.WebhookID)
+	if err != nil {
+		return err
+	}
+	if !exist {
+		return errors.BadRequest(reason.WebhookNotFound)
+	}
+	webhook.Name = req.Name
+	webhook.URL = req.URL
+	if len(req.Secret) > 0 {
+		webhook.Secret = req.Secret
+	}
+	webhook.Events = encodeEvents(req.Events)
+	if req.Enabled {
+		webhook.Status = entity.WebhookStatusEnabled
+	} else {
+		webhook.Status = entity.WebhookStatusDisabled
+	}
+	return ws.webhookRepo.UpdateWebhook(ctx, webhook)
+}
+
+// RemoveWebhook remove webhook
+func (ws *WebhookService) RemoveWebhook(ctx context.Context, req *schema.RemoveWebhookReq) (err error) {
+	return ws.webhookRepo.RemoveWebhook(ctx, req.WebhookID)
+}
+
+// GetWebhookList get all webhooks
+func (ws *WebhookService) GetWebhookList(ctx context.Context) (resp []*schema.GetWebhookResp, err error) {
+	webhooks, err := ws.webhookRepo.GetWebhookList(ctx)
+	if err != nil {
+		return nil, err
+	}
+	resp = make([]*schema.GetWebhookResp, 0, len(webhooks))
+	for _, webhook := range webhooks {
+		resp = append(resp, formatWebhook(webhook))
+	}
+	return resp, nil
+}
+
+// TestWebhook send a ping event to the webhook right now and return the delivery result
+func (ws *WebhookService) TestWebhook(ctx context.Context, req *schema.TestWebhookReq) (
+	resp *schema.GetWebhookDeliveryResp, err error) {
+	webhook, exist, err := ws.webhookRepo.GetWebhook(ctx, req.WebhookID)
+	if err != nil {
+		return nil, err
+	}
+	if !exist {
+		return nil, errors.BadRequest(reason.WebhookNotFound)
+	}
+	payload, err := json.Marshal(&schema.WebhookPayload{
+		Event:     string(constant.WebhookEventPing),
+		Timestamp: time.Now().Unix(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	delivery := &entity.WebhookDelivery{
+		WebhookID: webhook.ID,
+		Event:     string(constant.WebhookEventPing),
+		Payload:   string(payload),
+		Status:    entity.WebhookDeliveryStatusPending,
+	}
+	if err = ws.webhookRepo.AddDelivery(ctx, delivery); err != nil {
+		return nil, err
+	}
+	// the result of test is returned to the admin directly, so it will not be retried
+	_ = ws.deliver(ctx, webhook, delivery)
+	return formatDelivery(delivery), nil
+}
+
+// GetDeliveryPage get webhook delivery logs
+func (ws *WebhookService) GetDeliveryPage(ctx context.Context, req *schema.GetWebhookDeliveryPageReq) (
+	pageModel *pager.PageModel, err error) {
+	deliveries, total, err := ws.webhookRepo.GetDeliveryPage(ctx, req.Page, req.PageSize, req.WebhookID)
+	if err != nil {
+		return nil, err
+	}
+	resp := make([]*schema.GetWebhookDeliveryResp, 0, len(deliveries))
+	for _, delivery := range deliveries {
+		resp = append(resp, formatDelivery(delivery))
+	}
+	return pager.NewPageModel(total, resp), nil
+}
+
+// GetEvents get all events that webhook can subscribe
+func (ws *WebhookService) GetEvents() (resp []string) {
+	resp = make([]string, 0, len(constant.WebhookEvents))
+	for _, event := range constant.WebhookEvents {
+		resp = append(resp, string(event))
+	}
+	return resp
+}
+
+func formatWebhook(webhook *entity.Webhook) *schema.GetWebhookResp {
+	return &schema.GetWebhookResp{
+		WebhookID: webhook.ID,
+		Name:      webhook.Name,
+		URL:       webhook.URL,
+		Secret:    webhook.Secret,
+		Events:    decodeEvents(webhook.Events),
+		Enabled:   webhook.Status == entity.WebhookStatusEnabled,
+		CreatedAt: webhook.CreatedAt.Unix(),
+		UpdatedAt: webhook.UpdatedAt.Unix(),
+	}
+}
+
+func formatDelivery(delivery *entity.WebhookDelivery) *schema.GetWebhookDeliveryResp {
+	return &schema.GetWebhookDeliveryResp{
+		DeliveryID:   delivery.ID,
+		WebhookID:    delivery.WebhookID,
+		Event:        delivery.Event,
+		Payload:      delivery.Payload,
+		Status:       entity.WebhookDeliveryStatus[delivery.Status],
+		Attempts:     delivery.Attempts,
+		ResponseCode: delivery.ResponseCode,
+		ResponseBody: delivery.ResponseBody,
+		Error:        delivery.Error,
+		Duration:     delivery.Duration,
+		CreatedAt:    delivery.CreatedAt.Unix(),
+		UpdatedAt:    delivery.UpdatedAt.Unix(),
+	}
+}
+
+func encodeEvents(events []string) string {
+	data, _ := json.Marshal(events)
+	return string(data)
+}
+
+func decodeEvents(data string) (events []string) {
+	events = make([]string, 0)
+	if len(data) == 0 {
+		return events
+	}
+	if err := json.Unmarshal([]byte(data), &events); err != nil {
+		log.Warnf("decode webhook events failed: %v", err)
+	}
+	return events
+}
@@ -0,0 +1,65 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package webhook_queue
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/apache/incubator-answer/internal/service/job_queue"
+	"github.com/segmentfault/pacman/log"
+)
+
+// WebhookEventQueueName the name of the persisted queue
+const WebhookEventQueueName = "webhook_event"
+
+//go:generate mockgen -source=./webhook_queue.go -destination=../mock/webhook_queue_service_mock.go -package=mock
+type WebhookQueueService interface {
+	Send(ctx context.Context, msg *schema.WebhookEventMsg)
+	RegisterHandler(handler func(ctx context.Context, msg *schema.WebhookEventMsg) error)
+}
+
+type webhookQueueService struct {
+	jobQueueService *job_queue.JobQueueService
+}
+
+func (ns *webhookQueueService) Send(ctx context.Context, msg *schema.WebhookEventMsg) {
+	if err := ns.jobQueueService.Enqueue(ctx, WebhookEventQueueName, msg); err != nil {
+		log.Errorf("send webhook event failed: %v", err)
+	}
+}
+
+func (ns *webhookQueueService) RegisterHandler(
+	handler func(ctx context.Context, msg *schema.WebhookEventMsg) error) {
+	ns.jobQueueService.RegisterHandler(WebhookEventQueueName, func(ctx context.Context, payload []byte) error {
+		msg := &schema.WebhookEventMsg{}
+		if err := json.Unmarshal(payload, msg); err != nil {
+			return job_queue.Permanent(err)
+		}
+		log.Debugf("received webhook event %+v", msg)
+		return handler(ctx, msg)
+	})
+}
+
+// NewWebhookQueueService create a new webhook event queue service
+func NewWebhookQueueService(jobQueueService *job_queue.JobQueueService) WebhookQueueService {
+	return &webhookQueueService{jobQueueService: jobQueueService}
+}
@@ -30,6 +30,7 @@ import (
 	"path/filepath"
 	"strings"
 
+	"github.com/gabriel-vasile/mimetype"
 	"github.com/segmentfault/pacman/log"
 	"golang.org/x/image/webp"
 )
@@ -38,6 +39,15 @@ const (
 	maxImageSize = 8192 * 8192
 )
 
+// blockedAttachmentMimeTypes the files can be rendered or executed directly, they are never accepted as attachment
+var blockedAttachmentMimeTypes = []string{
+	"text/html",
+	"image/svg+xml",
+	"application/vnd.microsoft.portable-executable",
+	"application/x-elf",
+	"application/x-mach-binary",
+}
+
 // IsSupportedImageFile currently answers support image type is
 // `image/jpeg, image/jpg, image/png, image/gif, image/webp`
 func IsSupportedImageFile(localFilePath string) bool {
@@ -66,6 +76,29 @@ func IsSupportedImageFile(localFilePath string) bool {
 	return true
 }
 
+// SniffAttachmentFile detects the mime type of attachment by its content. The file is supported if it is plain text
+// or its content matches the extension, e.g. a zip archive can't be renamed to .pdf.
+func SniffAttachmentFile(localFilePath string) (mimeType string, ok bool) {
+	detected, err := mimetype.DetectFile(localFilePath)
+	if err != nil {
+		log.Errorf("detect file type error: %v", err)
+		return "", false
+	}
+	ext := strings.ToLower(filepath.Ext(localFilePath))
+	matched := false
+	for m := detected; m != nil; m = m.Parent() {
+		for _, blocked := range blockedAttachmentMimeTypes {
+			if m.Is(blocked) {
+				return detected.String(), false
+			}
+		}
+		if m.Extension() == ext || m.Is("text/plain") {
+			matched = true
+		}
+	}
+	return detected.String(), matched
+}
+
 func decodeAndCheckImageFile(localFilePath string, checker func(io.Reader) error) bool {
 	file, err := os.Open(localFilePath)
 	if err != nil {
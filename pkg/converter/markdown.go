@@ -23,6 +23,7 @@ import (
 	"bytes"
 	"regexp"
 
+	"github.com/apache/incubator-answer/pkg/imagevariant"
 	"github.com/asaskevich/govalidator"
 	"github.com/microcosm-cc/bluemonday"
 	"github.com/segmentfault/pacman/log"
@@ -61,6 +62,8 @@ func Markdown2HTML(source string) string {
 	filter.AllowElements("kbd")
 	filter.AllowAttrs("title").Matching(regexp.MustCompile(`^[\p{L}\p{N}\s\-_',\[\]!\./\\\(\)]*$|^@embed?$`)).Globally()
 	html = filter.Sanitize(html)
+	// the srcset is generated by us, it is added after sanitizing
+	html = imagevariant.RewriteSrcset(html)
 	return html
 }
 
@@ -0,0 +1,236 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package diff
+
+import (
+	"strings"
+	"unicode"
+)
+
+// OpType describes how a piece of text changed between two versions
+type OpType string
+
+const (
+	OpEqual  OpType = "equal"
+	OpInsert OpType = "insert"
+	OpDelete OpType = "delete"
+)
+
+// maxCompareCells limits the size of the LCS table, larger inputs are reported as a full replacement
+const maxCompareCells = 4 << 20
+
+// Segment is a run of words with the same change type
+type Segment struct {
+	Type OpType `json:"type"`
+	Text string `json:"text"`
+}
+
+// Line is a single line of a line diff. OldNumber and NewNumber are 1-based and 0 when the line
+// does not exist on that side. Words is only set for changed lines that could be paired with a
+// line on the other side.
+type Line struct {
+	Type      OpType    `json:"type"`
+	OldNumber int       `json:"old_number"`
+	NewNumber int       `json:"new_number"`
+	Text      string    `json:"text"`
+	Words     []Segment `json:"words,omitempty"`
+}
+
+// Lines returns the line diff from a to b, changed lines are refined with a word diff
+func Lines(a, b string) []Line {
+	oldLines, newLines := splitLines(a), splitLines(b)
+	lines := make([]Line, 0, len(oldLines)+len(newLines))
+	oldNumber, newNumber := 0, 0
+	var deleted, inserted []Line
+	flush := func() {
+		for i := 0; i < len(deleted) && i < len(inserted); i++ {
+			words := Words(deleted[i].Text, inserted[i].Text)
+			deleted[i].Words = pickSegments(words, OpDelete)
+			inserted[i].Words = pickSegments(words, OpInsert)
+		}
+		lines = append(lines, deleted...)
+		lines = append(lines, inserted...)
+		deleted, inserted = nil, nil
+	}
+	for _, op := range compare(oldLines, newLines) {
+		switch op.typ {
+		case OpEqual:
+			flush()
+			oldNumber++
+			newNumber++
+			lines = append(lines, Line{Type: OpEqual, OldNumber: oldNumber, NewNumber: newNumber, Text: op.text})
+		case OpDelete:
+			oldNumber++
+			deleted = append(deleted, Line{Type: OpDelete, OldNumber: oldNumber, Text: op.text})
+		case OpInsert:
+			newNumber++
+			inserted = append(inserted, Line{Type: OpInsert, NewNumber: newNumber, Text: op.text})
+		}
+	}
+	flush()
+	return lines
+}
+
+// Words returns the word diff from a to b, whitespace and punctuation are kept as separate tokens
+func Words(a, b string) []Segment {
+	segments := make([]Segment, 0)
+	for _, op := range compare(splitWords(a), splitWords(b)) {
+		if n := len(segments); n > 0 && segments[n-1].Type == op.typ {
+			segments[n-1].Text += op.text
+			continue
+		}
+		segments = append(segments, Segment{Type: op.typ, Text: op.text})
+	}
+	return segments
+}
+
+// Changed reports whether the diff contains any insert or delete
+func Changed(lines []Line) bool {
+	for _, line := range lines {
+		if line.Type != OpEqual {
+			return true
+		}
+	}
+	return false
+}
+
+type op struct {
+	typ  OpType
+	text string
+}
+
+// compare returns the edit script from a to b based on the longest common subsequence
+func compare(a, b []string) []op {
+	ops := make([]op, 0, len(a)+len(b))
+	prefix := 0
+	for prefix < len(a) && prefix < len(b) && a[prefix] == b[prefix] {
+		ops = append(ops, op{typ: OpEqual, text: a[prefix]})
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(a)-prefix && suffix < len(b)-prefix &&
+		a[len(a)-1-suffix] == b[len(b)-1-suffix] {
+		suffix++
+	}
+	midA, midB := a[prefix:len(a)-suffix], b[prefix:len(b)-suffix]
+
+	if len(midA)*len(midB) > maxCompareCells {
+		for _, s := range midA {
+			ops = append(ops, op{typ: OpDelete, text: s})
+		}
+		for _, s := range midB {
+			ops = append(ops, op{typ: OpInsert, text: s})
+		}
+	} else {
+		// table[i][j] is the LCS length of midA[i:] and midB[j:]
+		width := len(midB) + 1
+		table := make([]int32, (len(midA)+1)*width)
+		for i := len(midA) - 1; i >= 0; i-- {
+			for j := len(midB) - 1; j >= 0; j-- {
+				if midA[i] == midB[j] {
+					table[i*width+j] = table[(i+1)*width+j+1] + 1
+				} else if table[(i+1)*width+j] >= table[i*width+j+1] {
+					table[i*width+j] = table[(i+1)*width+j]
+				} else {
+					table[i*width+j] = table[i*width+j+1]
+				}
+			}
+		}
+		i, j := 0, 0
+		for i < len(midA) && j < len(midB) {
+			switch {
+			case midA[i] == midB[j]:
+				ops = append(ops, op{typ: OpEqual, text: midA[i]})
+				i++
+				j++
+			case table[(i+1)*width+j] >= table[i*width+j+1]:
+				ops = append(ops, op{typ: OpDelete, text: midA[i]})
+				i++
+			default:
+				ops = append(ops, op{typ: OpInsert, text: midB[j]})
+				j++
+			}
+		}
+		for ; i < len(midA); i++ {
+			ops = append(ops, op{typ: OpDelete, text: midA[i]})
+		}
+		for ; j < len(midB); j++ {
+			ops = append(ops, op{typ: OpInsert, text: midB[j]})
+		}
+	}
+
+	for _, s := range a[len(a)-suffix:] {
+		ops = append(ops, op{typ: OpEqual, text: s})
+	}
+	return ops
+}
+
+func splitLines(s string) []string {
+	if len(s) == 0 {
+		return []string{}
+	}
+	s = strings.ReplaceAll(s, "\r\n", "\n")
+	return strings.Split(strings.TrimSuffix(s, "\n"), "\n")
+}
+
+// splitWords splits s into words, whitespace runs and single symbols. CJK characters are
+// split one by one because they are not separated by spaces.
+func splitWords(s string) []string {
+	tokens := make([]string, 0)
+	runes := []rune(s)
+	for start := 0; start < len(runes); {
+		end := start + 1
+		switch r := runes[start]; {
+		case isCJK(r):
+		case unicode.IsSpace(r):
+			for end < len(runes) && unicode.IsSpace(runes[end]) {
+				end++
+			}
+		case unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_':
+			for end < len(runes) && !isCJK(runes[end]) &&
+				(unicode.IsLetter(runes[end]) || unicode.IsDigit(runes[end]) || runes[end] == '_') {
+				end++
+			}
+		}
+		tokens = append(tokens, string(runes[start:end]))
+		start = end
+	}
+	return tokens
+}
+
+func isCJK(r rune) bool {
+	return unicode.In(r, unicode.Han, unicode.Hiragana, unicode.Katakana, unicode.Hangul)
+}
+
+// pickSegments keeps the segments visible from one side of a word diff
+func pickSegments(segments []Segment, side OpType) []Segment {
+	picked := make([]Segment, 0, len(segments))
+	for _, segment := range segments {
+		if segment.Type != OpEqual && segment.Type != side {
+			continue
+		}
+		if n := len(picked); n > 0 && picked[n-1].Type == segment.Type {
+			picked[n-1].Text += segment.Text
+			continue
+		}
+		picked = append(picked, segment)
+	}
+	return picked
+}
@@ -0,0 +1,69 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package diff
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWords(t *testing.T) {
+	segments := Words("How to read a file in Go", "How to read a large file in Golang")
+	assert.Equal(t, []Segment{
+		{Type: OpEqual, Text: "How to read a "},
+		{Type: OpInsert, Text: "large "},
+		{Type: OpEqual, Text: "file in "},
+		{Type: OpDelete, Text: "Go"},
+		{Type: OpInsert, Text: "Golang"},
+	}, segments)
+
+	segments = Words("你好世界", "你好中国")
+	assert.Equal(t, []Segment{
+		{Type: OpEqual, Text: "你好"},
+		{Type: OpDelete, Text: "世界"},
+		{Type: OpInsert, Text: "中国"},
+	}, segments)
+
+	assert.Empty(t, Words("", ""))
+}
+
+func TestLines(t *testing.T) {
+	lines := Lines("first\nsecond line\nthird\n", "first\nsecond changed line\nthird\nfourth")
+	assert.Len(t, lines, 5)
+	assert.Equal(t, Line{Type: OpEqual, OldNumber: 1, NewNumber: 1, Text: "first"}, lines[0])
+	assert.Equal(t, OpDelete, lines[1].Type)
+	assert.Equal(t, 2, lines[1].OldNumber)
+	assert.Equal(t, []Segment{{Type: OpEqual, Text: "second line"}}, lines[1].Words)
+	assert.Equal(t, OpInsert, lines[2].Type)
+	assert.Equal(t, 2, lines[2].NewNumber)
+	assert.Equal(t, []Segment{
+		{Type: OpEqual, Text: "second "},
+		{Type: OpInsert, Text: "changed "},
+		{Type: OpEqual, Text: "line"},
+	}, lines[2].Words)
+	assert.Equal(t, Line{Type: OpEqual, OldNumber: 3, NewNumber: 3, Text: "third"}, lines[3])
+	assert.Equal(t, Line{Type: OpInsert, NewNumber: 4, Text: "fourth"}, lines[4])
+	assert.True(t, Changed(lines))
+
+	lines = Lines("same\r\ntext", "same\ntext\n")
+	assert.False(t, Changed(lines))
+	assert.Empty(t, Lines("", ""))
+}
@@ -0,0 +1,276 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package feed
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"encoding/xml"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const (
+	// FormatAtom Atom 1.0 format
+	FormatAtom = "atom"
+	// FormatRSS RSS 2.0 format
+	FormatRSS = "rss"
+
+	mimeAtom = "application/atom+xml"
+	mimeRSS  = "application/rss+xml"
+	// ContentTypeAtom the content type of Atom feed
+	ContentTypeAtom = mimeAtom + "; charset=utf-8"
+	// ContentTypeRSS the content type of RSS feed
+	ContentTypeRSS = mimeRSS + "; charset=utf-8"
+)
+
+// Feed syndication feed, it can be rendered as Atom or RSS
+type Feed struct {
+	// Title feed title
+	Title string
+	// Description feed description
+	Description string
+	// Link the page that the feed is about
+	Link string
+	// SelfLink the url of the feed itself
+	SelfLink string
+	// Updated the last time the feed changed, it is the newest item time if it is zero
+	Updated time.Time
+	// Items feed items, newest first
+	Items []*Item
+}
+
+// Item syndication feed item
+type Item struct {
+	// ID the permanent unique id of the item, the link is used if it is empty
+	ID     string
+	Title  string
+	Link   string
+	Author string
+	// Content html content
+	Content   string
+	Published time.Time
+	Updated   time.Time
+}
+
+// LastModified the last time the feed or its items changed
+func (f *Feed) LastModified() time.Time {
+	updated := f.Updated
+	for _, item := range f.Items {
+		if item.Updated.After(updated) {
+			updated = item.Updated
+		}
+		if item.Published.After(updated) {
+			updated = item.Published
+		}
+	}
+	return updated.UTC()
+}
+
+// Render render the feed in the format, Atom is used if the format is unknown
+func (f *Feed) Render(format string) (content []byte, contentType string, err error) {
+	if format == FormatRSS {
+		content, err = f.ToRSS()
+		return content, ContentTypeRSS, err
+	}
+	content, err = f.ToAtom()
+	return content, ContentTypeAtom, err
+}
+
+// ETag the entity tag of the rendered feed
+func ETag(content []byte) string {
+	sum := sha1.Sum(content)
+	return `"` + hex.EncodeToString(sum[:]) + `"`
+}
+
+// NotModified whether the client has the same feed by the conditional request headers.
+// If-None-Match takes precedence over If-Modified-Since as RFC 7232 requires.
+func NotModified(header http.Header, etag string, lastModified time.Time) bool {
+	if ifNoneMatch := header.Get("If-None-Match"); len(ifNoneMatch) > 0 {
+		for _, tag := range strings.Split(ifNoneMatch, ",") {
+			tag = strings.TrimPrefix(strings.TrimSpace(tag), "W/")
+			if tag == "*" || tag == etag {
+				return true
+			}
+		}
+		return false
+	}
+	if lastModified.IsZero() {
+		return false
+	}
+	since, err := http.ParseTime(header.Get("If-Modified-Since"))
+	if err != nil {
+		return false
+	}
+	return !lastModified.Truncate(time.Second).After(since)
+}
+
+type atomFeed struct {
+	XMLName  xml.Name     `xml:"http://www.w3.org/2005/Atom feed"`
+	Title    string       `xml:"title"`
+	Subtitle string       `xml:"subtitle,omitempty"`
+	ID       string       `xml:"id"`
+	Updated  string       `xml:"updated"`
+	Links    []*atomLink  `xml:"link"`
+	Entries  []*atomEntry `xml:"entry"`
+}
+
+type atomLink struct {
+	Href string `xml:"href,attr"`
+	Rel  string `xml:"rel,attr,omitempty"`
+	Type string `xml:"type,attr,omitempty"`
+}
+
+type atomEntry struct {
+	Title     string       `xml:"title"`
+	ID        string       `xml:"id"`
+	Link      *atomLink    `xml:"link"`
+	Published string       `xml:"published"`
+	Updated   string       `xml:"updated"`
+	Author    *atomAuthor  `xml:"author,omitempty"`
+	Content   *atomContent `xml:"content,omitempty"`
+}
+
+type atomAuthor struct {
+	Name string `xml:"name"`
+}
+
+type atomContent struct {
+	Type    string `xml:"type,attr"`
+	Content string `xml:",chardata"`
+}
+
+// ToAtom render the feed as Atom 1.0
+func (f *Feed) ToAtom() ([]byte, error) {
+	af := &atomFeed{
+		Title:    f.Title,
+		Subtitle: f.Description,
+		ID:       f.SelfLink,
+		Updated:  f.LastModified().Format(time.RFC3339),
+		Links: []*atomLink{
+			{Href: f.Link, Rel: "alternate", Type: "text/html"},
+			{Href: f.SelfLink, Rel: "self", Type: mimeAtom},
+		},
+		Entries: make([]*atomEntry, 0, len(f.Items)),
+	}
+	for _, item := range f.Items {
+		entry := &atomEntry{
+			Title:     item.Title,
+			ID:        item.id(),
+			Link:      &atomLink{Href: item.Link, Rel: "alternate", Type: "text/html"},
+			Published: item.Published.UTC().Format(time.RFC3339),
+			Updated:   item.updated().UTC().Format(time.RFC3339),
+		}
+		if len(item.Author) > 0 {
+			entry.Author = &atomAuthor{Name: item.Author}
+		}
+		if len(item.Content) > 0 {
+			entry.Content = &atomContent{Type: "html", Content: item.Content}
+		}
+		af.Entries = append(af.Entries, entry)
+	}
+	return marshal(af)
+}
+
+type rssFeed struct {
+	XMLName xml.Name    `xml:"rss"`
+	Version string      `xml:"version,attr"`
+	AtomNS  string      `xml:"xmlns:atom,attr"`
+	DCNS    string      `xml:"xmlns:dc,attr"`
+	Channel *rssChannel `xml:"channel"`
+}
+
+type rssChannel struct {
+	Title         string     `xml:"title"`
+	Link          string     `xml:"link"`
+	Description   string     `xml:"description"`
+	LastBuildDate string     `xml:"lastBuildDate"`
+	AtomLink      *atomLink  `xml:"atom:link"`
+	Items         []*rssItem `xml:"item"`
+}
+
+type rssItem struct {
+	Title       string   `xml:"title"`
+	Link        string   `xml:"link"`
+	GUID        *rssGUID `xml:"guid"`
+	PubDate     string   `xml:"pubDate"`
+	Creator     string   `xml:"dc:creator,omitempty"`
+	Description string   `xml:"description,omitempty"`
+}
+
+type rssGUID struct {
+	IsPermaLink bool   `xml:"isPermaLink,attr"`
+	Value       string `xml:",chardata"`
+}
+
+// ToRSS render the feed as RSS 2.0
+func (f *Feed) ToRSS() ([]byte, error) {
+	channel := &rssChannel{
+		Title:         f.Title,
+		Link:          f.Link,
+		Description:   f.Description,
+		LastBuildDate: f.LastModified().Format(time.RFC1123Z),
+		AtomLink:      &atomLink{Href: f.SelfLink, Rel: "self", Type: mimeRSS},
+		Items:         make([]*rssItem, 0, len(f.Items)),
+	}
+	if len(channel.Description) == 0 {
+		channel.Description = f.Title
+	}
+	for _, item := range f.Items {
+		id := item.id()
+		channel.Items = append(channel.Items, &rssItem{
+			Title:       item.Title,
+			Link:        item.Link,
+			GUID:        &rssGUID{IsPermaLink: id == item.Link, Value: id},
+			PubDate:     item.Published.UTC().Format(time.RFC1123Z),
+			Creator:     item.Author,
+			Description: item.Content,
+		})
+	}
+	return marshal(&rssFeed{
+		Version: "2.0",
+		AtomNS:  "http://www.w3.org/2005/Atom",
+		DCNS:    "http://purl.org/dc/elements/1.1/",
+		Channel: channel,
+	})
+}
+
+func (i *Item) id() string {
+	if len(i.ID) > 0 {
+		return i.ID
+	}
+	return i.Link
+}
+
+func (i *Item) updated() time.Time {
+	if i.Updated.After(i.Published) {
+		return i.Updated
+	}
+	return i.Published
+}
+
+func marshal(v any) ([]byte, error) {
+	content, err := xml.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte(xml.Header), content...), nil
+}
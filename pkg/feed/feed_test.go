@@ -0,0 +1,127 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package feed
+
+import (
+	"encoding/xml"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func testFeed() *Feed {
+	return &Feed{
+		Title:    "Newest questions - Answer",
+		Link:     "http://localhost/questions",
+		SelfLink: "http://localhost/questions/feed",
+		Items: []*Item{
+			{
+				Title:     "How to <b>escape</b>?",
+				Link:      "http://localhost/questions/1",
+				Author:    "alice",
+				Content:   "<p>a & b</p>",
+				Published: time.Unix(1700000000, 0),
+				Updated:   time.Unix(1700000100, 0),
+			},
+			{
+				ID:        "http://localhost/questions/2#comment-3",
+				Title:     "Comment",
+				Link:      "http://localhost/questions/2",
+				Published: time.Unix(1600000000, 0),
+			},
+		},
+	}
+}
+
+func TestFeed_LastModified(t *testing.T) {
+	assert.Equal(t, time.Unix(1700000100, 0).UTC(), testFeed().LastModified())
+	assert.True(t, (&Feed{}).LastModified().IsZero())
+}
+
+func TestFeed_ToAtom(t *testing.T) {
+	content, contentType, err := testFeed().Render(FormatAtom)
+	assert.NoError(t, err)
+	assert.Equal(t, ContentTypeAtom, contentType)
+
+	af := &atomFeed{}
+	assert.NoError(t, xml.Unmarshal(content, af))
+	assert.Equal(t, "http://localhost/questions/feed", af.ID)
+	assert.Equal(t, "2023-11-14T22:15:00Z", af.Updated)
+	assert.Len(t, af.Entries, 2)
+	assert.Equal(t, "How to <b>escape</b>?", af.Entries[0].Title)
+	assert.Equal(t, "<p>a & b</p>", af.Entries[0].Content.Content)
+	assert.Equal(t, "alice", af.Entries[0].Author.Name)
+	assert.Equal(t, "http://localhost/questions/2#comment-3", af.Entries[1].ID)
+	assert.Nil(t, af.Entries[1].Author)
+}
+
+func TestFeed_ToRSS(t *testing.T) {
+	content, contentType, err := testFeed().Render(FormatRSS)
+	assert.NoError(t, err)
+	assert.Equal(t, ContentTypeRSS, contentType)
+	assert.Contains(t, string(content), `<rss version="2.0"`)
+
+	rf := &struct {
+		Channel struct {
+			Description string `xml:"description"`
+			Items       []struct {
+				GUID struct {
+					IsPermaLink bool   `xml:"isPermaLink,attr"`
+					Value       string `xml:",chardata"`
+				} `xml:"guid"`
+				PubDate     string `xml:"pubDate"`
+				Description string `xml:"description"`
+			} `xml:"item"`
+		} `xml:"channel"`
+	}{}
+	assert.NoError(t, xml.Unmarshal(content, rf))
+	assert.Equal(t, "Newest questions - Answer", rf.Channel.Description)
+	assert.Len(t, rf.Channel.Items, 2)
+	assert.True(t, rf.Channel.Items[0].GUID.IsPermaLink)
+	assert.False(t, rf.Channel.Items[1].GUID.IsPermaLink)
+	assert.Equal(t, "Tue, 14 Nov 2023 22:13:20 +0000", rf.Channel.Items[0].PubDate)
+	assert.Equal(t, "<p>a & b</p>", rf.Channel.Items[0].Description)
+}
+
+func TestETag(t *testing.T) {
+	assert.Equal(t, ETag([]byte("a")), ETag([]byte("a")))
+	assert.NotEqual(t, ETag([]byte("a")), ETag([]byte("b")))
+}
+
+func TestNotModified(t *testing.T) {
+	etag := ETag([]byte("a"))
+	lastModified := time.Unix(1700000000, 500)
+
+	header := http.Header{}
+	assert.False(t, NotModified(header, etag, lastModified))
+
+	header.Set("If-None-Match", `"x", W/`+etag)
+	assert.True(t, NotModified(header, etag, lastModified))
+	header.Set("If-None-Match", `"x"`)
+	header.Set("If-Modified-Since", lastModified.UTC().Format(http.TimeFormat))
+	assert.False(t, NotModified(header, etag, lastModified))
+
+	header.Del("If-None-Match")
+	assert.True(t, NotModified(header, etag, lastModified))
+	assert.False(t, NotModified(header, etag, lastModified.Add(time.Second)))
+	assert.False(t, NotModified(header, etag, time.Time{}))
+}
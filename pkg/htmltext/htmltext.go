@@ -20,6 +20,7 @@
 package htmltext
 
 import (
+	"html"
 	"io"
 	"net/http"
 	"net/url"
@@ -84,6 +85,44 @@ func ClearText(html string) (text string) {
 	return
 }
 
+var (
+	plainTextBlockquoteReg = regexp.MustCompile(`(?is)<blockquote[^>]*>(.*?)</blockquote>`)
+	plainTextLinkReg       = regexp.MustCompile(`(?is)<a\s[^>]*?href=["']([^"']*)["'][^>]*>(.*?)</a>`)
+	plainTextBreakReg      = regexp.MustCompile(`(?i)<br\s*/?>|</(p|div|li|h[1-6])>`)
+	plainTextBlankLineReg  = regexp.MustCompile(`\n{3,}`)
+)
+
+// PlainText convert the HTML of an email body to a readable plain text alternative.
+// Links keep their target after the text, blockquotes are prefixed with "> ".
+func PlainText(htmlContent string) (text string) {
+	// collapse the source whitespace, only the tags decide the line breaks
+	htmlContent = strings.Join(strings.Fields(htmlContent), " ")
+	htmlContent = plainTextBlockquoteReg.ReplaceAllStringFunc(htmlContent, func(s string) string {
+		inner := PlainText(plainTextBlockquoteReg.FindStringSubmatch(s)[1])
+		lines := strings.Split(inner, "\n")
+		for i := range lines {
+			lines[i] = strings.TrimSpace("> " + lines[i])
+		}
+		return "\n" + strings.Join(lines, "\n") + "\n"
+	})
+	htmlContent = plainTextLinkReg.ReplaceAllStringFunc(htmlContent, func(s string) string {
+		matches := plainTextLinkReg.FindStringSubmatch(s)
+		href, title := matches[1], strings.TrimSpace(strip.StripTags(matches[2]))
+		if len(title) == 0 || title == href {
+			return href
+		}
+		return title + " (" + href + ")"
+	})
+	htmlContent = plainTextBreakReg.ReplaceAllString(htmlContent, "\n")
+
+	lines := strings.Split(html.UnescapeString(strip.StripTags(htmlContent)), "\n")
+	for i := range lines {
+		lines[i] = strings.TrimSpace(lines[i])
+	}
+	text = plainTextBlankLineReg.ReplaceAllString(strings.Join(lines, "\n"), "\n\n")
+	return strings.TrimSpace(text)
+}
+
 func UrlTitle(title string) (text string) {
 	title = convertChinese(title)
 	title = clearEmoji(title)
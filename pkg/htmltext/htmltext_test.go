@@ -49,6 +49,18 @@ func TestClearText(t *testing.T) {
 	assert.Equal(t, expected, clearedText)
 }
 
+func TestPlainText(t *testing.T) {
+	expected := "Title (https://example.com/q/1)\n\nuser:\n\n> first line\n> second &line\n\n--\nUnsubscribe (https://example.com/unsubscribe?code=1&a=b)"
+	text := PlainText("<a href='https://example.com/q/1'>Title</a><br><br>\n\nuser:<br>\n" +
+		"<blockquote>first line<br>second &amp;line</blockquote><br>\n\n--<br>\n" +
+		"<small><a href='https://example.com/unsubscribe?code=1&amp;a=b'>Unsubscribe</a></small>")
+	assert.Equal(t, expected, text)
+
+	expected = "https://example.com"
+	text = PlainText("<p><a href=\"https://example.com\">https://example.com</a></p>")
+	assert.Equal(t, expected, text)
+}
+
 func TestFetchExcerpt(t *testing.T) {
 	var (
 		expected,
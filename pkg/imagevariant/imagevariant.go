@@ -0,0 +1,134 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+// Package imagevariant names the responsive variants of uploaded images and renders their srcset.
+// The widths of variants are recorded in the file name "<id>_<thumbnail>_<medium>_<full>[_webp].<ext>",
+// so the srcset can be rendered from the url of image without looking up anything.
+package imagevariant
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+const webpExt = ".webp"
+
+var (
+	fileNameRegexp = regexp.MustCompile(`^([1-9A-HJ-NP-Za-km-z]+)_(\d+)_(\d+)_(\d+)(_webp)?(\.[a-z]+)$`)
+	imgTagRegexp   = regexp.MustCompile(`<img\s[^>]*>`)
+	srcAttrRegexp  = regexp.MustCompile(`\ssrc="([^"]+)"`)
+)
+
+// Variants the widths of variants, 0 means the variant is not generated because the image is not wider than it
+type Variants struct {
+	Thumbnail int
+	Medium    int
+	Full      int
+	// WebP whether every variant also has a WebP copy
+	WebP bool
+}
+
+// FileName the file name of the full image
+func (v *Variants) FileName(id, ext string) string {
+	name := fmt.Sprintf("%s_%d_%d_%d", id, v.Thumbnail, v.Medium, v.Full)
+	if v.WebP {
+		name += "_webp"
+	}
+	return name + ext
+}
+
+// Widths the widths of generated variants from small to large, the last one is the full image
+func (v *Variants) Widths() (widths []int) {
+	for _, width := range []int{v.Thumbnail, v.Medium} {
+		if width > 0 && width < v.Full && (len(widths) == 0 || width > widths[len(widths)-1]) {
+			widths = append(widths, width)
+		}
+	}
+	return append(widths, v.Full)
+}
+
+// VariantFileName the file name of the variant in width, the ext is the ext of full image or ".webp".
+// The full image is not renamed, only the ext is replaced.
+func (v *Variants) VariantFileName(fileName string, width int, ext string) string {
+	name := strings.TrimSuffix(fileName, path.Ext(fileName))
+	if width == v.Full {
+		return name + ext
+	}
+	return fmt.Sprintf("%s@%d%s", name, width, ext)
+}
+
+// Parse parses the variants from the file name of full image
+func Parse(fileName string) (id string, v *Variants, ok bool) {
+	matches := fileNameRegexp.FindStringSubmatch(fileName)
+	if matches == nil {
+		return "", nil, false
+	}
+	v = &Variants{WebP: len(matches[5]) > 0}
+	v.Thumbnail, _ = strconv.Atoi(matches[2])
+	v.Medium, _ = strconv.Atoi(matches[3])
+	v.Full, _ = strconv.Atoi(matches[4])
+	if v.Full <= 0 {
+		return "", nil, false
+	}
+	return matches[1], v, true
+}
+
+// Srcset the srcset of the image url in the ext
+func (v *Variants) Srcset(src, ext string) string {
+	dir, fileName := src[:strings.LastIndex(src, "/")+1], src[strings.LastIndex(src, "/")+1:]
+	candidates := make([]string, 0, 3)
+	for _, width := range v.Widths() {
+		candidates = append(candidates, fmt.Sprintf("%s%s %dw", dir, v.VariantFileName(fileName, width, ext), width))
+	}
+	return strings.Join(candidates, ", ")
+}
+
+// Sizes the sizes attribute, the image is never displayed wider than the full image
+func (v *Variants) Sizes() string {
+	return fmt.Sprintf("(max-width: %dpx) 100vw, %dpx", v.Full, v.Full)
+}
+
+// RewriteSrcset adds the srcset of variants to the images in html, the images with WebP copies
+// are wrapped in picture so the browsers not supporting WebP fall back to the img.
+func RewriteSrcset(html string) string {
+	return imgTagRegexp.ReplaceAllStringFunc(html, func(img string) string {
+		if strings.Contains(img, " srcset=") {
+			return img
+		}
+		matches := srcAttrRegexp.FindStringSubmatch(img)
+		if matches == nil || strings.ContainsAny(matches[1], ", ?#") {
+			return img
+		}
+		src := matches[1]
+		_, v, ok := Parse(path.Base(src))
+		if !ok || (len(v.Widths()) == 1 && !v.WebP) {
+			return img
+		}
+		attrs := fmt.Sprintf(` srcset="%s" sizes="%s"`, v.Srcset(src, path.Ext(src)), v.Sizes())
+		rewritten := strings.Replace(img, matches[0], matches[0]+attrs, 1)
+		if !v.WebP {
+			return rewritten
+		}
+		return fmt.Sprintf(`<picture><source type="image/webp" srcset="%s" sizes="%s"/>%s</picture>`,
+			v.Srcset(src, webpExt), v.Sizes(), rewritten)
+	})
+}
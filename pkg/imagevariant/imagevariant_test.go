@@ -0,0 +1,69 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package imagevariant
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParse(t *testing.T) {
+	v := &Variants{Thumbnail: 320, Medium: 800, Full: 1920, WebP: true}
+	fileName := v.FileName("5Ab9xYz", ".jpg")
+	assert.Equal(t, "5Ab9xYz_320_800_1920_webp.jpg", fileName)
+
+	id, parsed, ok := Parse(fileName)
+	assert.True(t, ok)
+	assert.Equal(t, "5Ab9xYz", id)
+	assert.Equal(t, v, parsed)
+	assert.Equal(t, []int{320, 800, 1920}, parsed.Widths())
+	assert.Equal(t, "5Ab9xYz_320_800_1920_webp@320.webp", parsed.VariantFileName(fileName, 320, ".webp"))
+	assert.Equal(t, "5Ab9xYz_320_800_1920_webp.webp", parsed.VariantFileName(fileName, 1920, ".webp"))
+
+	_, _, ok = Parse("5Ab9xYz.jpg")
+	assert.False(t, ok)
+	_, _, ok = Parse("5Ab9xYz_0_0_0.jpg")
+	assert.False(t, ok)
+	_, parsed, ok = Parse("5Ab9xYz_0_600_700.png")
+	assert.True(t, ok)
+	assert.Equal(t, []int{600, 700}, parsed.Widths())
+}
+
+func TestRewriteSrcset(t *testing.T) {
+	html := `<p><img src="http://a.com/uploads/post/abc_320_800_1000.jpg" alt="x"/></p>`
+	assert.Equal(t, `<p><img src="http://a.com/uploads/post/abc_320_800_1000.jpg"`+
+		` srcset="http://a.com/uploads/post/abc_320_800_1000@320.jpg 320w, http://a.com/uploads/post/abc_320_800_1000@800.jpg 800w, http://a.com/uploads/post/abc_320_800_1000.jpg 1000w"`+
+		` sizes="(max-width: 1000px) 100vw, 1000px" alt="x"/></p>`, RewriteSrcset(html))
+
+	html = `<img src="/uploads/post/abc_0_0_200_webp.png">`
+	assert.Equal(t, `<picture><source type="image/webp" srcset="/uploads/post/abc_0_0_200_webp.webp 200w" sizes="(max-width: 200px) 100vw, 200px"/>`+
+		`<img src="/uploads/post/abc_0_0_200_webp.png" srcset="/uploads/post/abc_0_0_200_webp.png 200w" sizes="(max-width: 200px) 100vw, 200px"></picture>`,
+		RewriteSrcset(html))
+
+	// nothing to choose from, or not a variant image
+	for _, html := range []string{
+		`<img src="/uploads/post/abc_0_0_200.png">`,
+		`<img src="/uploads/post/abc.png">`,
+		`<img src="/uploads/post/abc_320_800_1000.jpg" srcset="/a.jpg 1x">`,
+	} {
+		assert.Equal(t, html, RewriteSrcset(html))
+	}
+}
@@ -0,0 +1,328 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package storage
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	s3DefaultRegion    = "us-east-1"
+	s3EmptyPayloadHash = "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"
+	s3UnsignedPayload  = "UNSIGNED-PAYLOAD"
+	s3TimeFormat       = "20060102T150405Z"
+)
+
+// S3Config S3 compatible object storage config
+type S3Config struct {
+	// Endpoint eg: https://s3.us-east-1.amazonaws.com or http://127.0.0.1:9000
+	Endpoint  string `json:"endpoint" mapstructure:"endpoint" yaml:"endpoint"`
+	Region    string `json:"region" mapstructure:"region" yaml:"region"`
+	Bucket    string `json:"bucket" mapstructure:"bucket" yaml:"bucket"`
+	AccessKey string `json:"access_key" mapstructure:"access_key" yaml:"access_key"`
+	SecretKey string `json:"secret_key" mapstructure:"secret_key" yaml:"secret_key"`
+	// PathStyle use endpoint/bucket/key instead of bucket.endpoint/key, MinIO needs it
+	PathStyle bool `json:"path_style" mapstructure:"path_style" yaml:"path_style"`
+	// Prefix the prefix of all keys in bucket, eg: answer/
+	Prefix string `json:"prefix" mapstructure:"prefix" yaml:"prefix,omitempty"`
+}
+
+// S3Storage stores files on S3 compatible object storage, the requests are signed with AWS Signature Version 4
+type S3Storage struct {
+	conf     *S3Config
+	endpoint *url.URL
+	client   *http.Client
+	now      func() time.Time
+}
+
+// NewS3Storage new S3 compatible storage
+func NewS3Storage(conf *S3Config) (*S3Storage, error) {
+	endpoint, err := url.Parse(conf.Endpoint)
+	if err != nil || len(endpoint.Host) == 0 {
+		return nil, fmt.Errorf("storage: invalid s3 endpoint %s", conf.Endpoint)
+	}
+	if len(conf.Bucket) == 0 {
+		return nil, fmt.Errorf("storage: s3 bucket is missing")
+	}
+	if len(conf.Region) == 0 {
+		conf.Region = s3DefaultRegion
+	}
+	return &S3Storage{conf: conf, endpoint: endpoint, client: &http.Client{}, now: time.Now}, nil
+}
+
+// Type the type of storage
+func (s *S3Storage) Type() string {
+	return TypeS3
+}
+
+// Put stores the object
+func (s *S3Storage) Put(ctx context.Context, key string, reader io.Reader, size int64, contentType string) error {
+	if size < 0 {
+		content, err := io.ReadAll(reader)
+		if err != nil {
+			return err
+		}
+		reader, size = bytes.NewReader(content), int64(len(content))
+	}
+	req, err := s.newRequest(ctx, http.MethodPut, key, nil, reader)
+	if err != nil {
+		return err
+	}
+	req.ContentLength = size
+	if len(contentType) > 0 {
+		req.Header.Set("Content-Type", contentType)
+	}
+	resp, err := s.do(req, s3UnsignedPayload)
+	if err != nil {
+		return err
+	}
+	return resp.Body.Close()
+}
+
+// Get reads the object
+func (s *S3Storage) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	req, err := s.newRequest(ctx, http.MethodGet, key, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := s.do(req, s3EmptyPayloadHash)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Body, nil
+}
+
+// Stat gets the information of the object
+func (s *S3Storage) Stat(ctx context.Context, key string) (*Object, error) {
+	req, err := s.newRequest(ctx, http.MethodHead, key, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := s.do(req, s3EmptyPayloadHash)
+	if err != nil {
+		return nil, err
+	}
+	_ = resp.Body.Close()
+	key, _ = CleanKey(key)
+	modTime, _ := http.ParseTime(resp.Header.Get("Last-Modified"))
+	return &Object{Key: key, Size: resp.ContentLength, ModTime: modTime}, nil
+}
+
+// Delete deletes the object
+func (s *S3Storage) Delete(ctx context.Context, key string) error {
+	req, err := s.newRequest(ctx, http.MethodDelete, key, nil, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := s.do(req, s3EmptyPayloadHash)
+	if err == ErrNotExist {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	return resp.Body.Close()
+}
+
+type s3ListResult struct {
+	Contents []struct {
+		Key          string `xml:"Key"`
+		Size         int64  `xml:"Size"`
+		LastModified string `xml:"LastModified"`
+	} `xml:"Contents"`
+	IsTruncated           bool   `xml:"IsTruncated"`
+	NextContinuationToken string `xml:"NextContinuationToken"`
+}
+
+// List calls fn for every object whose key starts with the prefix
+func (s *S3Storage) List(ctx context.Context, prefix string, fn func(obj *Object) error) error {
+	token := ""
+	for {
+		query := url.Values{"list-type": {"2"}, "prefix": {s.conf.Prefix + prefix}}
+		if len(token) > 0 {
+			query.Set("continuation-token", token)
+		}
+		req, err := s.newRequest(ctx, http.MethodGet, "", query, nil)
+		if err != nil {
+			return err
+		}
+		resp, err := s.do(req, s3EmptyPayloadHash)
+		if err != nil {
+			return err
+		}
+		result := &s3ListResult{}
+		err = xml.NewDecoder(resp.Body).Decode(result)
+		_ = resp.Body.Close()
+		if err != nil {
+			return fmt.Errorf("storage: decode s3 list result failed: %w", err)
+		}
+		for _, content := range result.Contents {
+			modTime, _ := time.Parse(time.RFC3339, content.LastModified)
+			obj := &Object{Key: strings.TrimPrefix(content.Key, s.conf.Prefix), Size: content.Size, ModTime: modTime}
+			if err = fn(obj); err != nil {
+				return err
+			}
+		}
+		if !result.IsTruncated || len(result.NextContinuationToken) == 0 {
+			return nil
+		}
+		token = result.NextContinuationToken
+	}
+}
+
+// newRequest new request of the object, the request of bucket is created if the key is empty
+func (s *S3Storage) newRequest(ctx context.Context, method, key string, query url.Values, body io.Reader) (
+	*http.Request, error) {
+	objectPath := ""
+	if len(key) > 0 {
+		cleanKey, err := CleanKey(key)
+		if err != nil {
+			return nil, err
+		}
+		objectPath = s3Escape(s.conf.Prefix+cleanKey, false)
+	}
+	u := *s.endpoint
+	if s.conf.PathStyle {
+		u.Path = strings.TrimSuffix(u.Path, "/") + "/" + s3Escape(s.conf.Bucket, false) + "/" + objectPath
+	} else {
+		u.Host = s.conf.Bucket + "." + u.Host
+		u.Path = strings.TrimSuffix(u.Path, "/") + "/" + objectPath
+	}
+	u.RawPath = u.Path
+	u.RawQuery = s3CanonicalQuery(query)
+	return http.NewRequestWithContext(ctx, method, u.String(), body)
+}
+
+func (s *S3Storage) do(req *http.Request, payloadHash string) (*http.Response, error) {
+	s.sign(req, payloadHash, s.now())
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return resp, nil
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, ErrNotExist
+	}
+	message, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+	return nil, fmt.Errorf("storage: s3 %s %s failed: %s %s", req.Method, req.URL.Path, resp.Status, message)
+}
+
+// sign signs the request with AWS Signature Version 4
+func (s *S3Storage) sign(req *http.Request, payloadHash string, now time.Time) {
+	amzDate := now.UTC().Format(s3TimeFormat)
+	req.Header.Set("X-Amz-Date", amzDate)
+	req.Header.Set("X-Amz-Content-Sha256", payloadHash)
+
+	headers := map[string]string{"host": req.URL.Host}
+	for name, values := range req.Header {
+		name = strings.ToLower(name)
+		if strings.HasPrefix(name, "x-amz-") || name == "content-type" || name == "content-md5" || name == "range" {
+			headers[name] = strings.TrimSpace(strings.Join(values, ","))
+		}
+	}
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	canonicalHeaders := &strings.Builder{}
+	for _, name := range names {
+		canonicalHeaders.WriteString(name + ":" + headers[name] + "\n")
+	}
+	signedHeaders := strings.Join(names, ";")
+
+	canonicalURI := req.URL.EscapedPath()
+	if len(canonicalURI) == 0 {
+		canonicalURI = "/"
+	}
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		canonicalURI,
+		s3CanonicalQuery(req.URL.Query()),
+		canonicalHeaders.String(),
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+
+	date := amzDate[:8]
+	scope := date + "/" + s.conf.Region + "/s3/aws4_request"
+	stringToSign := "AWS4-HMAC-SHA256\n" + amzDate + "\n" + scope + "\n" + sha256Hex([]byte(canonicalRequest))
+	signingKey := hmacSHA256([]byte("AWS4"+s.conf.SecretKey), date)
+	for _, part := range []string{s.conf.Region, "s3", "aws4_request"} {
+		signingKey = hmacSHA256(signingKey, part)
+	}
+	signature := hex.EncodeToString(hmacSHA256(signingKey, stringToSign))
+	req.Header.Set("Authorization", fmt.Sprintf("AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		s.conf.AccessKey, scope, signedHeaders, signature))
+}
+
+// s3CanonicalQuery the query sorted by key, the keys and values are encoded as AWS requires
+func s3CanonicalQuery(query url.Values) string {
+	pairs := make([]string, 0, len(query))
+	for key, values := range query {
+		for _, value := range values {
+			pairs = append(pairs, s3Escape(key, true)+"="+s3Escape(value, true))
+		}
+	}
+	sort.Strings(pairs)
+	return strings.Join(pairs, "&")
+}
+
+// s3Escape encodes all characters except the unreserved ones of RFC 3986
+func s3Escape(s string, encodeSlash bool) string {
+	builder := &strings.Builder{}
+	for _, b := range []byte(s) {
+		switch {
+		case 'A' <= b && b <= 'Z', 'a' <= b && b <= 'z', '0' <= b && b <= '9',
+			b == '-', b == '_', b == '.', b == '~', b == '/' && !encodeSlash:
+			builder.WriteByte(b)
+		default:
+			builder.WriteString("%" + strings.ToUpper(strconv.FormatInt(int64(b)|0x100, 16)[1:]))
+		}
+	}
+	return builder.String()
+}
+
+func sha256Hex(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	h := hmac.New(sha256.New, key)
+	h.Write([]byte(data))
+	return h.Sum(nil)
+}
@@ -0,0 +1,127 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+// Package storage stores the uploaded files on local disk, S3 compatible object storage or WebDAV server.
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+	"time"
+)
+
+const (
+	// TypeLocal store files on local disk
+	TypeLocal = "local"
+	// TypeS3 store files on S3 compatible object storage
+	TypeS3 = "s3"
+	// TypeWebDAV store files on WebDAV server
+	TypeWebDAV = "webdav"
+)
+
+var (
+	// ErrNotExist the object does not exist
+	ErrNotExist = errors.New("storage: object does not exist")
+	// ErrInvalidKey the key is empty or escapes from the root
+	ErrInvalidKey = errors.New("storage: invalid object key")
+)
+
+// Object the stored object
+type Object struct {
+	// Key the slash separated path relative to the root of storage, eg: post/abc.png
+	Key     string
+	Size    int64
+	ModTime time.Time
+}
+
+// Storage stores the objects by keys
+type Storage interface {
+	// Type the type of storage
+	Type() string
+	// Put stores the object, the existing object with the same key is replaced
+	Put(ctx context.Context, key string, reader io.Reader, size int64, contentType string) error
+	// Get reads the object, ErrNotExist is returned if it does not exist
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+	// Stat gets the information of the object, ErrNotExist is returned if it does not exist
+	Stat(ctx context.Context, key string) (*Object, error)
+	// Delete deletes the object, it is not an error if the object does not exist
+	Delete(ctx context.Context, key string) error
+	// List calls fn for every object whose key starts with the prefix
+	List(ctx context.Context, prefix string, fn func(obj *Object) error) error
+}
+
+// Config storage config
+type Config struct {
+	// Type local, s3 or webdav, default is local
+	Type string `json:"type" mapstructure:"type" yaml:"type"`
+	// URLPrefix the public url prefix of the stored files, eg: https://cdn.example.com/answer.
+	// The files are served by the /uploads of site if it is empty.
+	URLPrefix string        `json:"url_prefix" mapstructure:"url_prefix" yaml:"url_prefix,omitempty"`
+	S3        *S3Config     `json:"s3" mapstructure:"s3" yaml:"s3,omitempty"`
+	WebDAV    *WebDAVConfig `json:"webdav" mapstructure:"webdav" yaml:"webdav,omitempty"`
+}
+
+// GetType the type of storage, local if it is not set
+func (c *Config) GetType() string {
+	if c == nil || len(c.Type) == 0 {
+		return TypeLocal
+	}
+	return c.Type
+}
+
+// GetURLPrefix the public url prefix of the stored files without trailing slash
+func (c *Config) GetURLPrefix() string {
+	if c == nil {
+		return ""
+	}
+	return strings.TrimSuffix(c.URLPrefix, "/")
+}
+
+// New creates the storage of the type, the local storage stores files in localPath
+func New(conf *Config, storageType, localPath string) (Storage, error) {
+	switch storageType {
+	case TypeLocal:
+		return NewLocalStorage(localPath), nil
+	case TypeS3:
+		if conf == nil || conf.S3 == nil {
+			return nil, fmt.Errorf("storage: s3 config is missing")
+		}
+		return NewS3Storage(conf.S3)
+	case TypeWebDAV:
+		if conf == nil || conf.WebDAV == nil {
+			return nil, fmt.Errorf("storage: webdav config is missing")
+		}
+		return NewWebDAVStorage(conf.WebDAV)
+	default:
+		return nil, fmt.Errorf("storage: unknown storage type %s", storageType)
+	}
+}
+
+// CleanKey cleans the key, the key must be relative and must not escape from the root
+func CleanKey(key string) (string, error) {
+	key = strings.TrimPrefix(path.Clean("/"+strings.ReplaceAll(key, "\\", "/")), "/")
+	if len(key) == 0 {
+		return "", ErrInvalidKey
+	}
+	return key, nil
+}
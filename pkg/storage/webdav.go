@@ -0,0 +1,284 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package storage
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"path"
+	"strconv"
+	"strings"
+)
+
+// WebDAVConfig WebDAV server config
+type WebDAVConfig struct {
+	// URL the root url of files, eg: https://dav.example.com/answer/
+	URL      string `json:"url" mapstructure:"url" yaml:"url"`
+	Username string `json:"username" mapstructure:"username" yaml:"username,omitempty"`
+	Password string `json:"password" mapstructure:"password" yaml:"password,omitempty"`
+}
+
+// WebDAVStorage stores files on WebDAV server
+type WebDAVStorage struct {
+	conf   *WebDAVConfig
+	root   *url.URL
+	client *http.Client
+}
+
+// NewWebDAVStorage new WebDAV storage
+func NewWebDAVStorage(conf *WebDAVConfig) (*WebDAVStorage, error) {
+	root, err := url.Parse(conf.URL)
+	if err != nil || len(root.Host) == 0 {
+		return nil, fmt.Errorf("storage: invalid webdav url %s", conf.URL)
+	}
+	root.Path = strings.TrimSuffix(root.Path, "/") + "/"
+	root.RawPath = ""
+	return &WebDAVStorage{conf: conf, root: root, client: &http.Client{}}, nil
+}
+
+// Type the type of storage
+func (s *WebDAVStorage) Type() string {
+	return TypeWebDAV
+}
+
+// Put stores the object, the missing parent collections are created
+func (s *WebDAVStorage) Put(ctx context.Context, key string, reader io.Reader, size int64, contentType string) error {
+	key, err := CleanKey(key)
+	if err != nil {
+		return err
+	}
+	if err = s.mkdirAll(ctx, path.Dir(key)); err != nil {
+		return err
+	}
+	req, err := s.newRequest(ctx, http.MethodPut, key, reader)
+	if err != nil {
+		return err
+	}
+	if size >= 0 {
+		req.ContentLength = size
+	}
+	if len(contentType) > 0 {
+		req.Header.Set("Content-Type", contentType)
+	}
+	resp, err := s.do(req)
+	if err != nil {
+		return err
+	}
+	return resp.Body.Close()
+}
+
+// Get reads the object
+func (s *WebDAVStorage) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	key, err := CleanKey(key)
+	if err != nil {
+		return nil, err
+	}
+	req, err := s.newRequest(ctx, http.MethodGet, key, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := s.do(req)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Body, nil
+}
+
+// Stat gets the information of the object
+func (s *WebDAVStorage) Stat(ctx context.Context, key string) (*Object, error) {
+	key, err := CleanKey(key)
+	if err != nil {
+		return nil, err
+	}
+	req, err := s.newRequest(ctx, http.MethodHead, key, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := s.do(req)
+	if err != nil {
+		return nil, err
+	}
+	_ = resp.Body.Close()
+	modTime, _ := http.ParseTime(resp.Header.Get("Last-Modified"))
+	return &Object{Key: key, Size: resp.ContentLength, ModTime: modTime}, nil
+}
+
+// Delete deletes the object
+func (s *WebDAVStorage) Delete(ctx context.Context, key string) error {
+	key, err := CleanKey(key)
+	if err != nil {
+		return err
+	}
+	req, err := s.newRequest(ctx, http.MethodDelete, key, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := s.do(req)
+	if err == ErrNotExist {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	return resp.Body.Close()
+}
+
+type webDAVMultiStatus struct {
+	Responses []struct {
+		Href string `xml:"href"`
+		Prop struct {
+			ResourceType struct {
+				Collection *struct{} `xml:"collection"`
+			} `xml:"resourcetype"`
+			ContentLength string `xml:"getcontentlength"`
+			LastModified  string `xml:"getlastmodified"`
+		} `xml:"propstat>prop"`
+	} `xml:"response"`
+}
+
+const webDAVPropfindBody = `<?xml version="1.0" encoding="utf-8"?>
+<d:propfind xmlns:d="DAV:"><d:prop><d:resourcetype/><d:getcontentlength/><d:getlastmodified/></d:prop></d:propfind>`
+
+// List calls fn for every object whose key starts with the prefix, the collections are walked one level at a time
+func (s *WebDAVStorage) List(ctx context.Context, prefix string, fn func(obj *Object) error) error {
+	dir := ""
+	if i := strings.LastIndex(prefix, "/"); i >= 0 {
+		dir = prefix[:i+1]
+	}
+	return s.walk(ctx, dir, prefix, fn)
+}
+
+func (s *WebDAVStorage) walk(ctx context.Context, dir, prefix string, fn func(obj *Object) error) error {
+	req, err := s.newRequest(ctx, "PROPFIND", dir, strings.NewReader(webDAVPropfindBody))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Depth", "1")
+	req.Header.Set("Content-Type", "application/xml; charset=utf-8")
+	resp, err := s.do(req)
+	if err == ErrNotExist {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	result := &webDAVMultiStatus{}
+	err = xml.NewDecoder(resp.Body).Decode(result)
+	_ = resp.Body.Close()
+	if err != nil {
+		return fmt.Errorf("storage: decode webdav multistatus failed: %w", err)
+	}
+	for _, item := range result.Responses {
+		key, ok := s.keyOfHref(item.Href)
+		if !ok || strings.TrimSuffix(key, "/") == strings.TrimSuffix(dir, "/") {
+			continue
+		}
+		if item.Prop.ResourceType.Collection != nil {
+			key = strings.TrimSuffix(key, "/") + "/"
+			if !strings.HasPrefix(key, prefix) && !strings.HasPrefix(prefix, key) {
+				continue
+			}
+			if err = s.walk(ctx, key, prefix, fn); err != nil {
+				return err
+			}
+			continue
+		}
+		if !strings.HasPrefix(key, prefix) {
+			continue
+		}
+		size, _ := strconv.ParseInt(item.Prop.ContentLength, 10, 64)
+		modTime, _ := http.ParseTime(item.Prop.LastModified)
+		if err = fn(&Object{Key: key, Size: size, ModTime: modTime}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// keyOfHref the key relative to the root of the href in multistatus
+func (s *WebDAVStorage) keyOfHref(href string) (string, bool) {
+	u, err := url.Parse(href)
+	if err != nil {
+		return "", false
+	}
+	if !strings.HasPrefix(u.Path, s.root.Path) {
+		return "", false
+	}
+	return strings.TrimPrefix(u.Path, s.root.Path), true
+}
+
+// mkdirAll creates the collection and its parents, the existing collections are skipped
+func (s *WebDAVStorage) mkdirAll(ctx context.Context, dir string) error {
+	if dir == "." || dir == "/" || len(dir) == 0 {
+		return nil
+	}
+	current := ""
+	for _, name := range strings.Split(dir, "/") {
+		current += name + "/"
+		req, err := s.newRequest(ctx, "MKCOL", current, nil)
+		if err != nil {
+			return err
+		}
+		resp, err := s.client.Do(req)
+		if err != nil {
+			return err
+		}
+		_ = resp.Body.Close()
+		// 405 Method Not Allowed means the collection already exists
+		if resp.StatusCode >= 300 && resp.StatusCode != http.StatusMethodNotAllowed {
+			return fmt.Errorf("storage: webdav MKCOL %s failed: %s", current, resp.Status)
+		}
+	}
+	return nil
+}
+
+// newRequest new request of the key, the key ends with slash is a collection
+func (s *WebDAVStorage) newRequest(ctx context.Context, method, key string, body io.Reader) (*http.Request, error) {
+	u := *s.root
+	u.Path += strings.TrimPrefix(key, "/")
+	req, err := http.NewRequestWithContext(ctx, method, u.String(), body)
+	if err != nil {
+		return nil, err
+	}
+	if len(s.conf.Username) > 0 {
+		req.SetBasicAuth(s.conf.Username, s.conf.Password)
+	}
+	return req, nil
+}
+
+func (s *WebDAVStorage) do(req *http.Request) (*http.Response, error) {
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return resp, nil
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, ErrNotExist
+	}
+	message, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+	return nil, fmt.Errorf("storage: webdav %s %s failed: %s %s", req.Method, req.URL.Path, resp.Status, message)
+}
@@ -0,0 +1,654 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+// Package webp encodes images to lossless WebP (VP8L).
+// It uses the subtract green and predictor transforms and the LZ77 backward references without color cache,
+// the encoded files are usually smaller than PNG but larger than lossy JPEG for photos.
+package webp
+
+import (
+	"container/heap"
+	"encoding/binary"
+	"errors"
+	"image"
+	"image/color"
+	"io"
+	"sort"
+)
+
+const (
+	maxDimension = 1 << 14
+
+	transformPredictor     = 0
+	transformSubtractGreen = 2
+
+	// predictorSizeBits the predictor mode is chosen for every 16x16 tile
+	predictorSizeBits = 4
+
+	maxCodeLength           = 15
+	maxCodeLengthCodeLength = 7
+	numLiteralCodes         = 256
+	numLengthCodes          = 24
+	numDistanceCodes        = 40
+
+	minMatchLength = 3
+	maxMatchLength = 4096
+	// maxDistance the distance codes larger than it have no prefix code
+	maxDistance = 1<<20 - 120
+	// numDistanceMapCodes the distance codes mapped to the neighbor pixels, see the distance map of spec
+	numDistanceMapCodes = 120
+	hashBits            = 16
+	maxChainLength      = 16
+)
+
+var codeLengthCodeOrder = [19]int{17, 18, 0, 1, 2, 3, 4, 5, 16, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15}
+
+// predictorModes the modes tried for every tile: L, T, Average2(L, T) and ClampAddSubtractFull(L, T, TL)
+var predictorModes = []int{1, 2, 7, 12}
+
+// ErrTooLarge the width or height of image is larger than 16384
+var ErrTooLarge = errors.New("webp: image is too large")
+
+// Encode writes the image to w in lossless WebP format
+func Encode(w io.Writer, img image.Image) error {
+	bounds := img.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+	if width <= 0 || height <= 0 || width > maxDimension || height > maxDimension {
+		return ErrTooLarge
+	}
+	argb, hasAlpha := toARGB(img)
+
+	bw := &bitWriter{}
+	bw.write(0x2f, 8)
+	bw.write(uint32(width-1), 14)
+	bw.write(uint32(height-1), 14)
+	bw.writeBool(hasAlpha)
+	bw.write(0, 3)
+
+	subtractGreen(argb)
+	bw.writeBool(true)
+	bw.write(transformSubtractGreen, 2)
+
+	modes := predict(argb, width, height)
+	bw.writeBool(true)
+	bw.write(transformPredictor, 2)
+	bw.write(predictorSizeBits-2, 3)
+	writeEntropyCodedImage(bw, modes, (width+1<<predictorSizeBits-1)>>predictorSizeBits, false)
+	bw.writeBool(false)
+
+	writeEntropyCodedImage(bw, argb, width, true)
+	data := bw.bytes()
+
+	padding := len(data) & 1
+	header := make([]byte, 20)
+	copy(header[0:], "RIFF")
+	binary.LittleEndian.PutUint32(header[4:], uint32(12+len(data)+padding))
+	copy(header[8:], "WEBPVP8L")
+	binary.LittleEndian.PutUint32(header[16:], uint32(len(data)))
+	if _, err := w.Write(header); err != nil {
+		return err
+	}
+	if _, err := w.Write(data); err != nil {
+		return err
+	}
+	if padding > 0 {
+		_, err := w.Write([]byte{0})
+		return err
+	}
+	return nil
+}
+
+// toARGB converts the image to ARGB pixels in row order
+func toARGB(img image.Image) (argb []uint32, hasAlpha bool) {
+	bounds := img.Bounds()
+	argb = make([]uint32, 0, bounds.Dx()*bounds.Dy())
+	nrgba, isNRGBA := img.(*image.NRGBA)
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			var c color.NRGBA
+			if isNRGBA {
+				c = nrgba.NRGBAAt(x, y)
+			} else {
+				c = color.NRGBAModel.Convert(img.At(x, y)).(color.NRGBA)
+			}
+			if c.A != 0xff {
+				hasAlpha = true
+			}
+			argb = append(argb, uint32(c.A)<<24|uint32(c.R)<<16|uint32(c.G)<<8|uint32(c.B))
+		}
+	}
+	return argb, hasAlpha
+}
+
+func subtractGreen(argb []uint32) {
+	for i, p := range argb {
+		green := (p >> 8) & 0xff
+		red := ((p >> 16) - green) & 0xff
+		blue := (p - green) & 0xff
+		argb[i] = p&0xff00ff00 | red<<16 | blue
+	}
+}
+
+// predict replaces the pixels with the residuals of the best predictor of every tile,
+// the modes of tiles are returned as the predictor sub-image
+func predict(argb []uint32, width, height int) (modes []uint32) {
+	tileSize := 1 << predictorSizeBits
+	tilesX := (width + tileSize - 1) >> predictorSizeBits
+	tilesY := (height + tileSize - 1) >> predictorSizeBits
+	modes = make([]uint32, tilesX*tilesY)
+	for ty := 0; ty < tilesY; ty++ {
+		for tx := 0; tx < tilesX; tx++ {
+			bestMode, bestCost := predictorModes[0], -1
+			for _, mode := range predictorModes {
+				cost := 0
+				for y := ty * tileSize; y < height && y < (ty+1)*tileSize; y++ {
+					for x := tx * tileSize; x < width && x < (tx+1)*tileSize; x++ {
+						cost += residualCost(sub(argb[y*width+x], predictPixel(argb, width, x, y, mode)))
+					}
+				}
+				if bestCost < 0 || cost < bestCost {
+					bestMode, bestCost = mode, cost
+				}
+			}
+			modes[ty*tilesX+tx] = 0xff000000 | uint32(bestMode)<<8
+		}
+	}
+
+	// the residuals are calculated from the original pixels, so they are written to a new slice
+	residuals := make([]uint32, len(argb))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			mode := int(modes[(y>>predictorSizeBits)*tilesX+x>>predictorSizeBits]>>8) & 0xf
+			residuals[y*width+x] = sub(argb[y*width+x], predictPixel(argb, width, x, y, mode))
+		}
+	}
+	copy(argb, residuals)
+	return modes
+}
+
+func predictPixel(argb []uint32, width, x, y, mode int) uint32 {
+	switch {
+	case x == 0 && y == 0:
+		return 0xff000000
+	case y == 0:
+		return argb[x-1]
+	case x == 0:
+		return argb[(y-1)*width]
+	}
+	left, top, topLeft := argb[y*width+x-1], argb[(y-1)*width+x], argb[(y-1)*width+x-1]
+	switch mode {
+	case 1:
+		return left
+	case 2:
+		return top
+	case 7:
+		return average2(left, top)
+	default:
+		return clampAddSubtractFull(left, top, topLeft)
+	}
+}
+
+func average2(a, b uint32) uint32 {
+	return (((a ^ b) & 0xfefefefe) >> 1) + (a & b)
+}
+
+func clampAddSubtractFull(a, b, c uint32) uint32 {
+	var result uint32
+	for shift := uint32(0); shift < 32; shift += 8 {
+		v := int((a>>shift)&0xff) + int((b>>shift)&0xff) - int((c>>shift)&0xff)
+		if v < 0 {
+			v = 0
+		} else if v > 0xff {
+			v = 0xff
+		}
+		result |= uint32(v) << shift
+	}
+	return result
+}
+
+// sub subtracts every channel of b from a, modulo 256
+func sub(a, b uint32) uint32 {
+	alphaGreen := 0x00ff00ff + (a & 0xff00ff00) - (b & 0xff00ff00)
+	redBlue := 0xff00ff00 + (a & 0x00ff00ff) - (b & 0x00ff00ff)
+	return (alphaGreen & 0xff00ff00) | (redBlue & 0x00ff00ff)
+}
+
+func residualCost(p uint32) int {
+	cost := 0
+	for shift := uint32(0); shift < 32; shift += 8 {
+		v := int((p >> shift) & 0xff)
+		if v > 128 {
+			v = 256 - v
+		}
+		cost += v
+	}
+	return cost
+}
+
+// writeEntropyCodedImage writes the pixels with one group of prefix codes and without color cache
+func writeEntropyCodedImage(bw *bitWriter, argb []uint32, width int, isMain bool) {
+	// no color cache
+	bw.writeBool(false)
+	if isMain {
+		// no meta prefix codes
+		bw.writeBool(false)
+	}
+
+	tokens := backwardReferences(argb, width)
+	histograms := [5][]int{
+		make([]int, numLiteralCodes+numLengthCodes),
+		make([]int, numLiteralCodes),
+		make([]int, numLiteralCodes),
+		make([]int, numLiteralCodes),
+		make([]int, numDistanceCodes),
+	}
+	for _, t := range tokens {
+		if t.length == 0 {
+			histograms[0][(t.pixel>>8)&0xff]++
+			histograms[1][(t.pixel>>16)&0xff]++
+			histograms[2][t.pixel&0xff]++
+			histograms[3][t.pixel>>24]++
+			continue
+		}
+		lengthCode, _, _ := prefixEncode(t.length)
+		distanceCode, _, _ := prefixEncode(t.distanceCode)
+		histograms[0][numLiteralCodes+lengthCode]++
+		histograms[4][distanceCode]++
+	}
+	var codes [5]*prefixCode
+	for i, histogram := range histograms {
+		codes[i] = writePrefixCode(bw, histogram)
+	}
+	for _, t := range tokens {
+		if t.length == 0 {
+			codes[0].write(bw, int(t.pixel>>8)&0xff)
+			codes[1].write(bw, int(t.pixel>>16)&0xff)
+			codes[2].write(bw, int(t.pixel)&0xff)
+			codes[3].write(bw, int(t.pixel>>24))
+			continue
+		}
+		lengthCode, extraBits, extraValue := prefixEncode(t.length)
+		codes[0].write(bw, numLiteralCodes+lengthCode)
+		bw.write(extraValue, extraBits)
+		distanceCode, extraBits, extraValue := prefixEncode(t.distanceCode)
+		codes[4].write(bw, distanceCode)
+		bw.write(extraValue, extraBits)
+	}
+}
+
+// pixelToken a literal pixel if the length is 0, otherwise a backward reference
+type pixelToken struct {
+	pixel        uint32
+	length       int
+	distanceCode int
+}
+
+// backwardReferences finds the LZ77 backward references greedily, the pixel above and the pixel on the left
+// are always tried because they are the most common in images
+func backwardReferences(argb []uint32, width int) (tokens []pixelToken) {
+	head := make([]int32, 1<<hashBits)
+	for i := range head {
+		head[i] = -1
+	}
+	chain := make([]int32, len(argb))
+	insert := func(i int) {
+		if i+1 < len(argb) {
+			h := hashPixels(argb[i], argb[i+1])
+			chain[i], head[h] = head[h], int32(i)
+		}
+	}
+
+	for i := 0; i < len(argb); {
+		bestLength, bestDistance := 0, 0
+		try := func(distance int) {
+			if distance <= 0 || distance > i || distance > maxDistance {
+				return
+			}
+			length := matchLength(argb, i-distance, i)
+			if length > bestLength {
+				bestLength, bestDistance = length, distance
+			}
+		}
+		try(1)
+		try(width)
+		if i+1 < len(argb) {
+			candidate := head[hashPixels(argb[i], argb[i+1])]
+			for n := 0; candidate >= 0 && n < maxChainLength && bestLength < maxMatchLength; n++ {
+				try(i - int(candidate))
+				candidate = chain[candidate]
+			}
+		}
+
+		if bestLength < minMatchLength {
+			tokens = append(tokens, pixelToken{pixel: argb[i]})
+			insert(i)
+			i++
+			continue
+		}
+		tokens = append(tokens, pixelToken{length: bestLength, distanceCode: distanceToCode(bestDistance, width)})
+		for j := i; j < i+bestLength; j++ {
+			insert(j)
+		}
+		i += bestLength
+	}
+	return tokens
+}
+
+func hashPixels(a, b uint32) uint32 {
+	return ((a * 0x9e3779b1) ^ (b * 0x85ebca6b)) >> (32 - hashBits) & (1<<hashBits - 1)
+}
+
+func matchLength(argb []uint32, from, to int) (length int) {
+	for length < maxMatchLength && to+length < len(argb) && argb[from+length] == argb[to+length] {
+		length++
+	}
+	return length
+}
+
+// distanceToCode maps the distance of the pixel above and the pixel on the left to their short codes,
+// the other distances are not mapped
+func distanceToCode(distance, width int) int {
+	switch distance {
+	case width:
+		return 1
+	case 1:
+		return 2
+	default:
+		return distance + numDistanceMapCodes
+	}
+}
+
+// prefixEncode encodes the length or distance code to the prefix symbol and the extra bits
+func prefixEncode(value int) (symbol int, extraBits uint, extraValue uint32) {
+	value--
+	if value < 4 {
+		return value, 0, 0
+	}
+	highestBit := 0
+	for v := value; v > 1; v >>= 1 {
+		highestBit++
+	}
+	secondBit := (value >> (highestBit - 1)) & 1
+	extraBits = uint(highestBit - 1)
+	return 2*highestBit + secondBit, extraBits, uint32(value) & (1<<extraBits - 1)
+}
+
+// prefixCode the canonical Huffman code, the bits of codes are reversed for the LSB first bit writer
+type prefixCode struct {
+	lengths []int
+	codes   []uint32
+}
+
+func (c *prefixCode) write(bw *bitWriter, symbol int) {
+	if c.lengths[symbol] > 0 {
+		bw.write(c.codes[symbol], uint(c.lengths[symbol]))
+	}
+}
+
+// writePrefixCode writes the prefix code of the histogram, the simple code is used if there are at most 2 symbols
+func writePrefixCode(bw *bitWriter, histogram []int) *prefixCode {
+	symbols := make([]int, 0, 2)
+	for symbol, count := range histogram {
+		if count > 0 {
+			symbols = append(symbols, symbol)
+		}
+	}
+	if len(symbols) == 0 {
+		symbols = append(symbols, 0)
+	}
+
+	if len(symbols) <= 2 && symbols[len(symbols)-1] < numLiteralCodes {
+		lengths := make([]int, len(histogram))
+		bw.writeBool(true)
+		bw.write(uint32(len(symbols)-1), 1)
+		if symbols[0] <= 1 {
+			bw.write(0, 1)
+			bw.write(uint32(symbols[0]), 1)
+		} else {
+			bw.write(1, 1)
+			bw.write(uint32(symbols[0]), 8)
+		}
+		if len(symbols) == 2 {
+			bw.write(uint32(symbols[1]), 8)
+			lengths[symbols[0]], lengths[symbols[1]] = 1, 1
+		}
+		return newPrefixCode(lengths)
+	}
+
+	lengths := huffmanCodeLengths(histogram, maxCodeLength)
+	bw.writeBool(false)
+
+	tokens := codeLengthTokens(lengths)
+	codeLengthHistogram := make([]int, len(codeLengthCodeOrder))
+	for _, token := range tokens {
+		codeLengthHistogram[token.symbol]++
+	}
+	codeLengthLengths := huffmanCodeLengths(codeLengthHistogram, maxCodeLengthCodeLength)
+	numCodes := 4
+	for i, symbol := range codeLengthCodeOrder {
+		if codeLengthLengths[symbol] > 0 && i+1 > numCodes {
+			numCodes = i + 1
+		}
+	}
+	bw.write(uint32(numCodes-4), 4)
+	for _, symbol := range codeLengthCodeOrder[:numCodes] {
+		bw.write(uint32(codeLengthLengths[symbol]), 3)
+	}
+	// all symbols of the alphabet are written
+	bw.writeBool(false)
+	codeLengthCode := newPrefixCode(codeLengthLengths)
+	for _, token := range tokens {
+		codeLengthCode.write(bw, token.symbol)
+		if token.extraBits > 0 {
+			bw.write(uint32(token.extraValue), token.extraBits)
+		}
+	}
+	return newPrefixCode(lengths)
+}
+
+type codeLengthToken struct {
+	symbol     int
+	extraBits  uint
+	extraValue int
+}
+
+// codeLengthTokens run-length encodes the code lengths, 16 repeats the previous length, 17 and 18 repeat zeros
+func codeLengthTokens(lengths []int) (tokens []codeLengthToken) {
+	for i := 0; i < len(lengths); {
+		value, run := lengths[i], 1
+		for i+run < len(lengths) && lengths[i+run] == value {
+			run++
+		}
+		i += run
+		if value == 0 {
+			for run >= 11 {
+				n := run
+				if n > 138 {
+					n = 138
+				}
+				tokens = append(tokens, codeLengthToken{symbol: 18, extraBits: 7, extraValue: n - 11})
+				run -= n
+			}
+			if run >= 3 {
+				tokens = append(tokens, codeLengthToken{symbol: 17, extraBits: 3, extraValue: run - 3})
+				run = 0
+			}
+		} else {
+			tokens = append(tokens, codeLengthToken{symbol: value})
+			run--
+			for run >= 3 {
+				n := run
+				if n > 6 {
+					n = 6
+				}
+				tokens = append(tokens, codeLengthToken{symbol: 16, extraBits: 2, extraValue: n - 3})
+				run -= n
+			}
+		}
+		for ; run > 0; run-- {
+			tokens = append(tokens, codeLengthToken{symbol: value})
+		}
+	}
+	return tokens
+}
+
+func newPrefixCode(lengths []int) *prefixCode {
+	c := &prefixCode{lengths: lengths, codes: make([]uint32, len(lengths))}
+	lengthCount := make([]int, maxCodeLength+1)
+	for _, length := range lengths {
+		lengthCount[length]++
+	}
+	lengthCount[0] = 0
+	nextCode := make([]uint32, maxCodeLength+2)
+	code := uint32(0)
+	for length := 1; length <= maxCodeLength; length++ {
+		code = (code + uint32(lengthCount[length-1])) << 1
+		nextCode[length] = code
+	}
+	for symbol, length := range lengths {
+		if length == 0 {
+			continue
+		}
+		c.codes[symbol] = reverseBits(nextCode[length], length)
+		nextCode[length]++
+	}
+	return c
+}
+
+func reverseBits(code uint32, length int) (reversed uint32) {
+	for i := 0; i < length; i++ {
+		reversed = reversed<<1 | code&1
+		code >>= 1
+	}
+	return reversed
+}
+
+// huffmanCodeLengths the lengths of Huffman code limited to maxLength. The small counts are raised until the code
+// fits, at least two symbols have codes so the code is always complete.
+func huffmanCodeLengths(histogram []int, maxLength int) []int {
+	used := make([]int, 0, len(histogram))
+	for symbol, count := range histogram {
+		if count > 0 {
+			used = append(used, symbol)
+		}
+	}
+	for len(used) < 2 {
+		// the dummy symbol makes a complete code of 1 bit
+		dummy := 0
+		for len(used) == 1 && dummy == used[0] {
+			dummy++
+		}
+		used = append(used, dummy)
+		sort.Ints(used)
+	}
+
+	lengths := make([]int, len(histogram))
+	for minCount := 1; ; minCount *= 2 {
+		nodes := make(huffmanHeap, 0, len(used))
+		for _, symbol := range used {
+			count := histogram[symbol]
+			if count < minCount {
+				count = minCount
+			}
+			nodes = append(nodes, &huffmanNode{count: count, symbol: symbol})
+		}
+		heap.Init(&nodes)
+		for nodes.Len() > 1 {
+			a, b := heap.Pop(&nodes).(*huffmanNode), heap.Pop(&nodes).(*huffmanNode)
+			heap.Push(&nodes, &huffmanNode{count: a.count + b.count, symbol: -1, left: a, right: b})
+		}
+		maxDepth := 0
+		var walk func(node *huffmanNode, depth int)
+		walk = func(node *huffmanNode, depth int) {
+			if node.left == nil {
+				lengths[node.symbol] = depth
+				if depth > maxDepth {
+					maxDepth = depth
+				}
+				return
+			}
+			walk(node.left, depth+1)
+			walk(node.right, depth+1)
+		}
+		walk(nodes[0], 0)
+		if maxDepth <= maxLength {
+			return lengths
+		}
+	}
+}
+
+type huffmanNode struct {
+	count       int
+	symbol      int
+	left, right *huffmanNode
+}
+
+type huffmanHeap []*huffmanNode
+
+func (h huffmanHeap) Len() int { return len(h) }
+func (h huffmanHeap) Less(i, j int) bool {
+	if h[i].count == h[j].count {
+		return h[i].symbol < h[j].symbol
+	}
+	return h[i].count < h[j].count
+}
+func (h huffmanHeap) Swap(i, j int)       { h[i], h[j] = h[j], h[i] }
+func (h *huffmanHeap) Push(x interface{}) { *h = append(*h, x.(*huffmanNode)) }
+func (h *huffmanHeap) Pop() interface{} {
+	old := *h
+	node := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return node
+}
+
+// bitWriter writes the bits from the least significant bit
+type bitWriter struct {
+	buf   []byte
+	acc   uint64
+	nBits uint
+}
+
+func (b *bitWriter) write(value uint32, n uint) {
+	if n == 0 {
+		return
+	}
+	b.acc |= uint64(value&(1<<n-1)) << b.nBits
+	b.nBits += n
+	for b.nBits >= 8 {
+		b.buf = append(b.buf, byte(b.acc))
+		b.acc >>= 8
+		b.nBits -= 8
+	}
+}
+
+func (b *bitWriter) writeBool(value bool) {
+	if value {
+		b.write(1, 1)
+	} else {
+		b.write(0, 1)
+	}
+}
+
+func (b *bitWriter) bytes() []byte {
+	if b.nBits > 0 {
+		b.buf = append(b.buf, byte(b.acc))
+		b.acc, b.nBits = 0, 0
+	}
+	return b.buf
+}
@@ -0,0 +1,130 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package webp
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	xwebp "golang.org/x/image/webp"
+)
+
+func roundTrip(t *testing.T, img image.Image) {
+	buf := &bytes.Buffer{}
+	require.NoError(t, Encode(buf, img))
+	decoded, err := xwebp.Decode(buf)
+	require.NoError(t, err)
+	require.Equal(t, img.Bounds().Size(), decoded.Bounds().Size())
+	for y := 0; y < img.Bounds().Dy(); y++ {
+		for x := 0; x < img.Bounds().Dx(); x++ {
+			want := color.NRGBAModel.Convert(img.At(img.Bounds().Min.X+x, img.Bounds().Min.Y+y))
+			got := color.NRGBAModel.Convert(decoded.At(decoded.Bounds().Min.X+x, decoded.Bounds().Min.Y+y))
+			if !assert.Equal(t, want, got, "pixel (%d, %d)", x, y) {
+				return
+			}
+		}
+	}
+}
+
+func TestEncode_Gradient(t *testing.T) {
+	img := image.NewNRGBA(image.Rect(0, 0, 67, 41))
+	for y := 0; y < 41; y++ {
+		for x := 0; x < 67; x++ {
+			img.SetNRGBA(x, y, color.NRGBA{R: uint8(x * 3), G: uint8(y * 5), B: uint8(x + y), A: 0xff})
+		}
+	}
+	roundTrip(t, img)
+}
+
+func TestEncode_Noise(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	img := image.NewNRGBA(image.Rect(0, 0, 50, 33))
+	for i := range img.Pix {
+		img.Pix[i] = uint8(r.Intn(256))
+	}
+	roundTrip(t, img)
+}
+
+func TestEncode_SolidAndTwoColors(t *testing.T) {
+	solid := image.NewNRGBA(image.Rect(0, 0, 1, 1))
+	solid.SetNRGBA(0, 0, color.NRGBA{R: 10, G: 20, B: 30, A: 0xff})
+	roundTrip(t, solid)
+
+	twoColors := image.NewNRGBA(image.Rect(0, 0, 20, 20))
+	for y := 0; y < 20; y++ {
+		for x := 0; x < 20; x++ {
+			if (x+y)%2 == 0 {
+				twoColors.SetNRGBA(x, y, color.NRGBA{R: 255, A: 128})
+			} else {
+				twoColors.SetNRGBA(x, y, color.NRGBA{B: 255, A: 255})
+			}
+		}
+	}
+	roundTrip(t, twoColors)
+}
+
+func TestEncode_SubImage(t *testing.T) {
+	img := image.NewRGBA(image.Rect(0, 0, 40, 40))
+	for i := range img.Pix {
+		img.Pix[i] = uint8(i * 7)
+	}
+	roundTrip(t, img.SubImage(image.Rect(5, 7, 35, 30)))
+}
+
+func TestEncode_Repeated(t *testing.T) {
+	// the screenshot like image has long matches in far distances
+	img := image.NewNRGBA(image.Rect(0, 0, 300, 500))
+	r := rand.New(rand.NewSource(2))
+	block := make([]uint8, 4*37)
+	for i := range block {
+		block[i] = uint8(r.Intn(4) * 60)
+	}
+	for i := 0; i < len(img.Pix); i += 4 {
+		copy(img.Pix[i:i+4], block[(i/4%37)*4:])
+		if i/4%1500 < 3 {
+			img.Pix[i] = uint8(r.Intn(256))
+		}
+	}
+	roundTrip(t, img)
+}
+
+func TestPrefixEncode(t *testing.T) {
+	for value := 1; value < 1<<20; value++ {
+		symbol, extraBits, extraValue := prefixEncode(value)
+		decoded := symbol + 1
+		if symbol >= 4 {
+			decoded = (2+symbol&1)<<((symbol-2)>>1) + int(extraValue) + 1
+			assert.Equal(t, uint((symbol-2)>>1), extraBits)
+		}
+		if decoded != value {
+			t.Fatalf("prefix encode %d: got %d", value, decoded)
+		}
+	}
+}
+
+func TestEncode_TooLarge(t *testing.T) {
+	err := Encode(&bytes.Buffer{}, image.NewNRGBA(image.Rect(0, 0, maxDimension+1, 1)))
+	assert.ErrorIs(t, err, ErrTooLarge)
+}
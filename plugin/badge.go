@@ -0,0 +1,76 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package plugin
+
+// BadgeLevel is the level of a badge
+type BadgeLevel string
+
+const (
+	BadgeLevelBronze BadgeLevel = "bronze"
+	BadgeLevelSilver BadgeLevel = "silver"
+	BadgeLevelGold   BadgeLevel = "gold"
+)
+
+// BadgeDefinition describes a badge provided by the plugin
+type BadgeDefinition struct {
+	// The unique name of the badge in the plugin, e.g. first_bounty
+	Name        string
+	Level       BadgeLevel
+	Title       Translator
+	Description Translator
+}
+
+// BadgeEvent is the activity that makes the badges of the user evaluated
+type BadgeEvent struct {
+	UserID string
+	// The activity type, e.g. answer.accept. It is empty when all badges are evaluated again by the back-fill job.
+	ActivityType string
+	// The object of the activity, e.g. the answer id
+	ObjectID string
+	// The statistics of the user
+	Stats BadgeUserStats
+}
+
+// BadgeUserStats contains the statistics of the user that the rules usually need
+type BadgeUserStats struct {
+	// The user's reputation
+	Rank                int
+	QuestionCount       int
+	AnswerCount         int
+	AcceptedAnswerCount int64
+	// The amount of days that the user has any activity
+	ActiveDays int64
+}
+
+type Badge interface {
+	Base
+
+	// Badges returns all badges provided by the plugin
+	Badges() []BadgeDefinition
+
+	// Evaluate returns the names of the badges the user has earned, the badges that the user already has are ignored
+	Evaluate(event *BadgeEvent) (names []string)
+}
+
+var (
+	// CallBadge is a function that calls all registered badge plugins
+	CallBadge,
+	registerBadge = MakePlugin[Badge](false)
+)
@@ -106,6 +106,10 @@ func Register(p Base) {
 	if _, ok := p.(CDN); ok {
 		registerCDN(p.(CDN))
 	}
+
+	if _, ok := p.(Badge); ok {
+		registerBadge(p.(Badge))
+	}
 }
 
 type Stack[T Base] struct {
@@ -77,6 +77,24 @@ export interface TagInfo extends TagBase {
   main_tag_slug_name?: string;
   excerpt?;
   status: string;
+  ancestors?: TagTreeNode[];
+  children?: TagTreeNode[];
+  tag_group?: TagGroup | null;
+}
+
+export interface TagTreeNode {
+  tag_id: string;
+  slug_name: string;
+  display_name: string;
+  question_count: number;
+  children?: TagTreeNode[];
+}
+
+export interface TagGroup {
+  tag_group_id: number;
+  name: string;
+  description: string;
+  sort_order: number;
 }
 export interface QuestionParams extends ImgCodeReq {
   title: string;
@@ -158,6 +176,25 @@ export interface UserInfoBase {
   role_id?: RoleId;
 }
 
+export type BadgeLevel = 'gold' | 'silver' | 'bronze';
+
+export interface UserBadge {
+  name: string;
+  level: BadgeLevel;
+  title: string;
+  description: string;
+  /** the tag of the tag badges */
+  object_id?: string;
+  object_name?: string;
+  awarded_at: number;
+}
+
+export interface UserBadgeCount {
+  gold: number;
+  silver: number;
+  bronze: number;
+}
+
 export interface UserInfoRes extends UserInfoBase {
   bio: string;
   bio_html: string;
@@ -170,6 +207,8 @@ export interface UserInfoRes extends UserInfoBase {
   language: string;
   e_mail?: string;
   have_password: boolean;
+  badge_count?: UserBadgeCount;
+  badges?: UserBadge[];
   [prop: string]: any;
 }
 
@@ -292,7 +331,8 @@ export type QuestionOrderBy =
   | 'active'
   | 'hot'
   | 'score'
-  | 'unanswered';
+  | 'unanswered'
+  | 'featured';
 
 export interface QueryQuestionsReq extends Paging {
   order: QuestionOrderBy;
@@ -43,6 +43,7 @@ export const QUESTION_ORDER_KEYS: Type.QuestionOrderBy[] = [
   'hot',
   'score',
   'unanswered',
+  'featured',
 ];
 interface Props {
   source: 'questions' | 'tag';
@@ -104,6 +105,11 @@ const QuestionList: FC<Props> = ({
                     {li.title}
                     {li.status === 2 ? ` [${t('closed')}]` : ''}
                   </NavLink>
+                  {li.bounty_amount > 0 && (
+                    <span className="badge bg-primary ms-2 align-middle">
+                      {t('bounty_amount', { amount: li.bounty_amount })}
+                    </span>
+                  )}
                 </h5>
                 <div className="d-flex flex-wrap flex-column flex-md-row align-items-md-center small mb-2 text-secondary">
                   <div className="d-flex flex-wrap me-0 me-md-3">
@@ -29,7 +29,7 @@ import { useTranslation } from 'react-i18next';
 
 import { usePageTags } from '@/hooks';
 import * as Type from '@/common/interface';
-import { FollowingTags, CustomSidebar, Icon } from '@/components';
+import { FollowingTags, CustomSidebar, Icon, Tag } from '@/components';
 import {
   useTagInfo,
   useFollow,
@@ -134,6 +134,24 @@ const Index: FC = () => {
           </div>
         ) : (
           <div className="tag-box mb-5">
+            {tagInfo.ancestors?.length > 0 || tagInfo.tag_group ? (
+              <nav className="small mb-2">
+                {tagInfo.tag_group ? (
+                  <span className="text-secondary me-1">
+                    {t('tag_group')}: {tagInfo.tag_group.name}
+                    {tagInfo.ancestors?.length > 0 ? ' /' : ''}
+                  </span>
+                ) : null}
+                {tagInfo.ancestors?.map((ancestor: Type.TagTreeNode) => (
+                  <span key={ancestor.tag_id} className="me-1">
+                    <Link to={pathFactory.tagLanding(ancestor.slug_name)}>
+                      {ancestor.display_name}
+                    </Link>
+                    {' /'}
+                  </span>
+                ))}
+              </nav>
+            ) : null}
             <h3 className="mb-3">
               <Link
                 to={pathFactory.tagLanding(tagInfo.slug_name)}
@@ -150,6 +168,22 @@ const Index: FC = () => {
               </Link>
             </p>
 
+            {tagInfo.children?.length > 0 ? (
+              <div className="mb-3">
+                <span className="text-secondary me-2">{t('child_tags')}:</span>
+                {tagInfo.children.map((child: Type.TagTreeNode) => (
+                  <Tag
+                    key={child.tag_id}
+                    className="me-1"
+                    data={{
+                      slug_name: child.slug_name,
+                      display_name: child.display_name,
+                    }}
+                  />
+                ))}
+              </div>
+            ) : null}
+
             <div className="box-ft">
               {tagInfo.is_follower ? (
                 <div>
@@ -39,6 +39,7 @@ const Achievements = ({ data, handleReadNotification }) => {
       {data.map((item) => {
         const { comment, question, answer } =
           item?.object_info?.object_map || {};
+        const isBadge = item.object_info.object_type === 'badge';
         let url = '';
         switch (item.object_info.object_type) {
           case 'question':
@@ -50,6 +51,9 @@ const Achievements = ({ data, handleReadNotification }) => {
           case 'comment':
             url = `/questions/${question}/${answer}?commentId=${comment}`;
             break;
+          case 'badge':
+            url = `/users/${item.user_info?.username}`;
+            break;
           default:
             url = '';
         }
@@ -60,11 +64,15 @@ const Achievements = ({ data, handleReadNotification }) => {
               'd-flex border-start-0 border-end-0 py-3',
               !item.is_read && 'warning',
             )}>
-            {item.rank > 0 && (
+            {/* the badge has no reputation change */}
+            {isBadge && <div className="num text-end" />}
+            {!isBadge && item.rank > 0 && (
               <div className="text-success num text-end">{`+${item.rank}`}</div>
             )}
-            {item.rank === 0 && <div className="num text-end">{item.rank}</div>}
-            {item.rank < 0 && (
+            {!isBadge && item.rank === 0 && (
+              <div className="num text-end">{item.rank}</div>
+            )}
+            {!isBadge && item.rank < 0 && (
               <div className="text-danger num text-end">{`${item.rank}`}</div>
             )}
             <div className="d-flex flex-column ms-3 flex-fill">
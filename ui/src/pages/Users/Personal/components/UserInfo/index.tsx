@@ -33,6 +33,13 @@ interface Props {
   data: UserInfoRes;
 }
 
+const BADGE_LEVELS = ['gold', 'silver', 'bronze'] as const;
+const BADGE_COLORS = {
+  gold: '#f1b600',
+  silver: '#9a9ea3',
+  bronze: '#c38b5f',
+};
+
 const Index: FC<Props> = ({ data }) => {
   const { t } = useTranslation('translation', { keyPrefix: 'personal' });
   const { agent: ucAgent } = userCenterStore();
@@ -107,12 +114,48 @@ const Index: FC<Props> = ({ data }) => {
             <strong className="fs-5">{data.answer_count || 0}</strong>
             <span className="text-secondary"> {t('x_answers')}</span>
           </div>
-          <div>
+          <div className="me-3">
             <strong className="fs-5">{data?.question_count || 0}</strong>
             <span className="text-secondary"> {t('x_questions')}</span>
           </div>
+          {data.badge_count &&
+            BADGE_LEVELS.map((level) =>
+              data.badge_count?.[level] ? (
+                <div className="me-3" key={level}>
+                  <span className="me-1" style={{ color: BADGE_COLORS[level] }}>
+                    ●
+                  </span>
+                  <strong className="fs-5">{data.badge_count[level]}</strong>
+                  <span className="text-secondary">
+                    {' '}
+                    {t(`x_${level}_badges`)}
+                  </span>
+                </div>
+              ) : null,
+            )}
         </div>
 
+        {data.badges && data.badges.length > 0 && (
+          <div className="d-flex flex-wrap mb-3">
+            {data.badges.map((badge) => (
+              <OverlayTrigger
+                key={`${badge.name}-${badge.object_id || 0}`}
+                placement="top"
+                overlay={<Tooltip>{badge.description}</Tooltip>}>
+                <span className="badge text-bg-light me-2 mb-2">
+                  <span
+                    className="me-1"
+                    style={{ color: BADGE_COLORS[badge.level] }}>
+                    ●
+                  </span>
+                  {badge.title}
+                  {badge.object_name ? ` · ${badge.object_name}` : ''}
+                </span>
+              </OverlayTrigger>
+            ))}
+          </div>
+        )}
+
         <div className="d-flex text-secondary">
           {!ucAgent?.enabled ? (
             <>
@@ -32,6 +32,7 @@ const Index: FC = () => {
   });
   const [searchParams] = useSearchParams();
   const code = searchParams.get('code');
+  const isMute = searchParams.get('type') === 'mute';
   useEffect(() => {
     if (code) {
       unsubscribe(code);
@@ -41,8 +42,12 @@ const Index: FC = () => {
     <Container className="pt-4 mt-2 mb-5">
       <Row className="justify-content-center">
         <Col lg={6}>
-          <h3 className="text-center mt-3 mb-5">{t('success_title')}</h3>
-          <p className="text-center">{t('success_desc')}</p>
+          <h3 className="text-center mt-3 mb-5">
+            {t(isMute ? 'mute_success_title' : 'success_title')}
+          </h3>
+          <p className="text-center">
+            {t(isMute ? 'mute_success_desc' : 'success_desc')}
+          </p>
           <div className="text-center">
             <Link to="/users/settings/notify">{t('link')}</Link>
           </div>